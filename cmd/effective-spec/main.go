@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+)
+
+// overlayFlags collects the values of the repeated -overlay flag
+type overlayFlags []string
+
+func (o *overlayFlags) String() string {
+	return strings.Join(*o, ",")
+}
+
+func (o *overlayFlags) Set(value string) error {
+	*o = append(*o, value)
+	return nil
+}
+
+// effective-spec prints the swagger document that the OpenAPI Terraform provider analyses after applying the given
+// overlays (JSON Patch or OpenAPI Overlay documents) in order. The document is printed in JSON to the standard output.
+// If the provider name is given, the swagger URL and the overlays are loaded from the plugin configuration of the
+// provider (the overlays given are applied after the configured ones); the swagger URL is then only needed to select
+// the document of the providers composed of several swagger documents.
+// Usage: effective-spec [-overlay <file>]... https://api.example.com/swagger.yaml
+// Usage: effective-spec -provider <provider name> [-overlay <file>]... [https://api.example.com/swagger.yaml]
+func main() {
+	var overlays overlayFlags
+	providerName := flag.String("provider", "", "name of the provider (terraform-provider-<name>) whose plugin configuration defines the swagger url and overlays")
+	flag.Var(&overlays, "overlay", "JSON Patch or OpenAPI Overlay document applied to the swagger document, can be repeated")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-overlay <file>]... <swagger url or file>\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s -provider <provider name> [-overlay <file>]... [<swagger url or file>]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 1 || (flag.NArg() == 0 && *providerName == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	swaggerURL := flag.Arg(0)
+	var document []byte
+	var err error
+	if *providerName != "" {
+		document, err = openapi.GetServiceEffectiveSwaggerDocument(*providerName, swaggerURL, overlays)
+		if err != nil {
+			log.Fatalf("[ERROR] Failed to get the effective swagger document of the provider '%s': %s", *providerName, err)
+		}
+	} else {
+		document, err = openapi.GetEffectiveSwaggerDocument(swaggerURL, overlays)
+		if err != nil {
+			log.Fatalf("[ERROR] Failed to get the effective swagger document '%s': %s", swaggerURL, err)
+		}
+	}
+	os.Stdout.Write(document)
+}
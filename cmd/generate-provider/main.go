@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+)
+
+// overlayFlags collects the values of the repeated -overlay flag
+type overlayFlags []string
+
+func (o *overlayFlags) String() string {
+	return strings.Join(*o, ",")
+}
+
+func (o *overlayFlags) Set(value string) error {
+	*o = append(*o, value)
+	return nil
+}
+
+// generate-provider generates the source code of a standalone terraform provider from a swagger document. The generated
+// provider embeds the swagger document (after applying the overlays in order) and the schemas of the provider, resources
+// and data sources. The files are written to the output directory, which is created if it does not exist.
+// Usage: generate-provider -name <provider name> [-out <dir>] [-overlay <file>]... https://api.example.com/swagger.yaml
+func main() {
+	var overlays overlayFlags
+	name := flag.String("name", "", "name of the provider (terraform-provider-<name>)")
+	out := flag.String("out", ".", "directory where the provider source code is written")
+	flag.Var(&overlays, "overlay", "JSON Patch or OpenAPI Overlay document applied to the swagger document, can be repeated")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s -name <provider name> [-out <dir>] [-overlay <file>]... <swagger url or file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || *name == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	generator := openapi.ProviderGenerator{ProviderName: *name, SwaggerURL: flag.Arg(0), Overlays: overlays}
+	files, err := generator.Generate()
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to generate the provider '%s': %s", *name, err)
+	}
+
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		log.Fatalf("[ERROR] Failed to create the output directory '%s': %s", *out, err)
+	}
+	var fileNames []string
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		path := filepath.Join(*out, fileName)
+		if err := ioutil.WriteFile(path, files[fileName], 0644); err != nil {
+			log.Fatalf("[ERROR] Failed to write the generated file '%s': %s", path, err)
+		}
+		fmt.Println(path)
+	}
+}
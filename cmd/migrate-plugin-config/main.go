@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+)
+
+// migrate-plugin-config converts an OpenAPI Terraform provider plugin configuration file from version 1 into version 2.
+// The migrated configuration is printed to the standard output unless the -w flag is provided, in which case the file
+// is overwritten.
+// Usage: migrate-plugin-config [-w] ~/.terraform.d/plugins/terraform-provider-openapi.yaml
+func main() {
+	write := flag.Bool("w", false, "write the migrated configuration to the file instead of the standard output")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-w] <plugin configuration file>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file := flag.Arg(0)
+	source, err := ioutil.ReadFile(file)
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to read the plugin configuration file '%s': %s", file, err)
+	}
+	migrated, err := openapi.MigratePluginConfigSchemaV1ToV2(source)
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to migrate the plugin configuration file '%s': %s", file, err)
+	}
+
+	if !*write {
+		os.Stdout.Write(migrated)
+		return
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to read the plugin configuration file '%s': %s", file, err)
+	}
+	if err := ioutil.WriteFile(file, migrated, info.Mode()); err != nil {
+		log.Fatalf("[ERROR] Failed to write the plugin configuration file '%s': %s", file, err)
+	}
+}
@@ -17,6 +17,7 @@ require (
 	github.com/go-openapi/loads v0.0.0-20171207192234-2a2b323bab96
 	github.com/go-openapi/spec v0.19.0
 	github.com/go-openapi/strfmt v0.0.0-20171222154016-4dd3d302e100 // indirect
+	github.com/go-openapi/swag v0.17.0
 	github.com/goadesign/goa v0.0.0-20180629224717-ed6ccb1eb93a
 	github.com/google/go-github v17.0.0+incompatible // indirect
 	github.com/google/go-querystring v1.0.0 // indirect
@@ -50,6 +51,7 @@ require (
 	golang.org/x/tools v0.0.0-20200331202046-9d5940d49312 // indirect
 	gopkg.in/mgo.v2 v2.0.0-20160818020120-3f83fa500528 // indirect
 	gopkg.in/yaml.v2 v2.2.2
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 replace git.apache.org/thrift.git => github.com/apache/thrift v0.0.0-20180902110319-2566ecd5d999
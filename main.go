@@ -38,6 +38,9 @@ func main() {
 				return provider
 			},
 		})
+
+	// Make sure the telemetry metrics still queued are submitted before the plugin exits
+	openapi.ShutdownTelemetry()
 }
 
 func getProviderName(binaryName string) (string, error) {
@@ -7,7 +7,6 @@ import (
 	"log"
 	"net/http"
 	"reflect"
-	"strconv"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -23,11 +22,11 @@ func checkHTTPStatusCode(openAPIResource SpecResource, res *http.Response, expec
 		if b != nil && len(b) > 0 {
 			resBody = string(b)
 		}
-		switch res.StatusCode {
-		case http.StatusUnauthorized:
-			return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d - Unauthorized: API access is denied due to invalid credentials (%s)", openAPIResource.getResourceName(), res.StatusCode, resBody)
-		case http.StatusNotFound:
+		switch {
+		case res.StatusCode == http.StatusNotFound:
 			return &openapierr.NotFoundError{OriginalError: fmt.Errorf("HTTP Response Status Code %d - Not Found. Could not find resource instance: %s", res.StatusCode, resBody)}
+		case res.StatusCode >= 400:
+			return newAPIError(openAPIResource, res, b)
 		default:
 			return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d not matching expected one %v (%s)", openAPIResource.getResourceName(), res.StatusCode, expectedHTTPStatusCodes, resBody)
 		}
@@ -212,27 +211,17 @@ func setResourceDataProperty(openAPIResource SpecResource, schemaDefinitionPrope
 }
 
 // setStateID sets the local resource's data ID with the newly identifier created in the POST API request. Refer to
-// r.resourceInfo.getResourceIdentifier() for more info regarding what property is selected as the identifier.
+// r.resourceInfo.getResourceIdentifier() for more info regarding what property is selected as the identifier. If the
+// resource has a composite identifier, the ID is built joining the identifier property values (see buildResourceID)
 func setStateID(openAPIres SpecResource, resourceLocalData *schema.ResourceData, payload map[string]interface{}) error {
 	resourceSchema, err := openAPIres.getResourceSchema()
 	if err != nil {
 		return err
 	}
-	identifierProperty, err := resourceSchema.getResourceIdentifier()
+	id, err := resourceSchema.buildResourceID(payload)
 	if err != nil {
 		return err
 	}
-	if payload[identifierProperty] == nil {
-		return fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s'", identifierProperty)
-	}
-
-	switch payload[identifierProperty].(type) {
-	case int:
-		resourceLocalData.SetId(strconv.Itoa(payload[identifierProperty].(int)))
-	case float64:
-		resourceLocalData.SetId(strconv.Itoa(int(payload[identifierProperty].(float64))))
-	default:
-		resourceLocalData.SetId(payload[identifierProperty].(string))
-	}
+	resourceLocalData.SetId(id)
 	return nil
 }
@@ -37,7 +37,7 @@ func TestCheckHTTPStatusCode(t *testing.T) {
 				StatusCode: http.StatusInternalServerError,
 			},
 			expectedStatusCodes: []int{http.StatusOK},
-			expectedError:       errors.New("[resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200] (some backend error)"),
+			expectedError:       errors.New("[resource='resourceName'] HTTP Response Status Code 500 - Internal Server Error (some backend error)"),
 		},
 		{
 			name: "response known with code 401 Unauthorized",
@@ -48,6 +48,15 @@ func TestCheckHTTPStatusCode(t *testing.T) {
 			expectedStatusCodes: []int{http.StatusOK},
 			expectedError:       errors.New("[resource='resourceName'] HTTP Response Status Code 401 - Unauthorized: API access is denied due to invalid credentials (unauthorized)"),
 		},
+		{
+			name: "response known with code 412 Precondition Failed",
+			response: &http.Response{
+				Body:       ioutil.NopCloser(strings.NewReader("etag mismatch")),
+				StatusCode: http.StatusPreconditionFailed,
+			},
+			expectedStatusCodes: []int{http.StatusOK},
+			expectedError:       errors.New("[resource='resourceName'] HTTP Response Status Code 412 - Precondition Failed: the resource changed outside Terraform since it was last read (ETag mismatch), refresh the state and retry (etag mismatch)"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -772,6 +781,25 @@ func TestSetStateID(t *testing.T) {
 		})
 	})
 
+	Convey("Given a resource factory configured with a schema definition that has a composite identifier", t, func() {
+		r, resourceData := testCreateResourceFactory(t)
+		r.openAPIResource = newSpecStubResource("record", "/v1/records", false, newCompositeIdentifierSchemaDefinition(""))
+		Convey("When setStateID is called with the resourceData and responsePayload", func() {
+			responsePayload := map[string]interface{}{
+				"zone":        "example.com",
+				"record_name": "www",
+				"priority":    float64(10),
+			}
+			err := setStateID(r.openAPIResource, resourceData, responsePayload)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And resourceData ID should contain the identifier values joined by the delimiter", func() {
+				So(resourceData.Id(), ShouldEqual, "example.com:www:10")
+			})
+		})
+	})
+
 	Convey("Given a resource factory configured with a schema definition that DOES not have an id property nor a property that should be used as the identifier", t, func() {
 		r, resourceData := testCreateResourceFactory(t)
 		Convey("When setStateID is called with the resourceData and responsePayload", func() {
@@ -74,7 +74,7 @@ func (d dataSourceFactory) dataSourceFiltersSchema() *schema.Schema {
 	}
 }
 
-func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error {
+func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) (err error) {
 	openAPIClient := i.(ClientOpenAPI)
 
 	if d.openAPIResource == nil {
@@ -83,6 +83,11 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 	resourceName := d.openAPIResource.getResourceName()
 
 	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
+	operationTelemetry := newTelemetryOperationRecorderDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
+	defer func() { operationTelemetry.submit(err) }()
+	openAPIClient, span := startOperationSpan(openAPIClient, TelemetryResourceOperationRead, fmt.Sprintf("data_%s", resourceName))
+	defer func() { span.end(err) }()
+	openAPIClient = withOperationDeadline(openAPIClient, data, schema.TimeoutRead)
 
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
 	if err != nil {
@@ -96,6 +101,7 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 
 	responsePayload := []map[string]interface{}{}
 	resp, err := openAPIClient.List(d.openAPIResource, &responsePayload, parentIDs...)
+	operationTelemetry.recordResponse(resp)
 	if err != nil {
 		return err
 	}
@@ -3,6 +3,7 @@ package openapi
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -444,6 +445,53 @@ func TestDataSourceRead_Fails_Because_List_Operation_Returns_Err(t *testing.T) {
 	assert.EqualError(t, err, "some error")
 }
 
+// deadlineClientOpenAPIStub is a clientOpenAPIStub that records the deadline its retries are bounded by
+type deadlineClientOpenAPIStub struct {
+	*clientOpenAPIStub
+	deadlineReceived time.Time
+}
+
+func (c *deadlineClientOpenAPIStub) withDeadline(deadline time.Time) ClientOpenAPI {
+	c.deadlineReceived = deadline
+	return c.clientOpenAPIStub
+}
+
+func TestDataSourceRead_BoundsRetriesByReadTimeout(t *testing.T) {
+	dataSourceFactory := dataSourceFactory{
+		openAPIResource: &specStubResource{
+			schemaDefinition: &specSchemaDefinition{
+				Properties: specSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+				},
+			},
+		},
+	}
+	resourceSchema, err := dataSourceFactory.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+
+	filtersInput := map[string]interface{}{
+		dataSourceFilterPropertyName: []interface{}{
+			newFilter("label", []interface{}{"someLabel"}),
+		},
+	}
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, filtersInput)
+	client := &deadlineClientOpenAPIStub{
+		clientOpenAPIStub: &clientOpenAPIStub{
+			responseListPayload: []map[string]interface{}{
+				{
+					"id":    "someID",
+					"label": "someLabel",
+				},
+			},
+		},
+	}
+	before := time.Now()
+	err = dataSourceFactory.read(resourceData, client)
+	require.NoError(t, err)
+	assert.WithinDuration(t, before.Add(resourceData.Timeout(schema.TimeoutRead)), client.deadlineReceived, time.Since(before))
+}
+
 func TestDataSourceRead_Fails_Because_Bad_Status_Code(t *testing.T) {
 	// Given
 	dataSourceFactory := dataSourceFactory{
@@ -471,7 +519,7 @@ func TestDataSourceRead_Fails_Because_Bad_Status_Code(t *testing.T) {
 	// When
 	err = dataSourceFactory.read(resourceData, client)
 	// Then
-	assert.Equal(t, errors.New("[data source='some resource'] GET  failed: [resource='some resource'] HTTP Response Status Code 400 not matching expected one [200] ()"), err)
+	assert.EqualError(t, err, "[data source='some resource'] GET  failed: [resource='some resource'] HTTP Response Status Code 400 - Bad Request")
 }
 
 func TestValidateInput(t *testing.T) {
@@ -54,7 +54,7 @@ func (d dataSourceInstanceFactory) dataSourceInstanceSchema() *schema.Schema {
 	}
 }
 
-func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}) error {
+func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}) (err error) {
 	openAPIClient := i.(ClientOpenAPI)
 
 	if d.openAPIResource == nil {
@@ -63,6 +63,11 @@ func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}
 	resourceName := d.getDataSourceInstanceName()
 
 	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
+	operationTelemetry := newTelemetryOperationRecorderDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
+	defer func() { operationTelemetry.submit(err) }()
+	openAPIClient, span := startOperationSpan(openAPIClient, TelemetryResourceOperationRead, fmt.Sprintf("data_%s", resourceName))
+	defer func() { span.end(err) }()
+	openAPIClient = withOperationDeadline(openAPIClient, data, schema.TimeoutRead)
 
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
 	if err != nil {
@@ -74,6 +79,7 @@ func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}
 	}
 	responsePayload := map[string]interface{}{}
 	resp, err := openAPIClient.Get(d.openAPIResource, id.(string), &responsePayload, parentIDs...)
+	operationTelemetry.recordResponse(resp)
 	if err != nil {
 		return err
 	}
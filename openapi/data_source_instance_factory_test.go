@@ -193,6 +193,7 @@ func TestDataSourceInstanceRead(t *testing.T) {
 
 		var telemetryHandlerResourceNameReceived string
 		var telemetryHandlerTFOperationReceived TelemetryResourceOperation
+		var telemetryHandlerOutcomeReceived *TelemetryResourceOperationOutcome
 
 		resourceSchema, err := dataSourceFactory.createTerraformDataSourceInstanceSchema()
 		require.NoError(t, err)
@@ -211,6 +212,9 @@ func TestDataSourceInstanceRead(t *testing.T) {
 					telemetryHandlerResourceNameReceived = resourceName
 					telemetryHandlerTFOperationReceived = tfOperation
 				},
+				submitResourceOperationMetricsFunc: func(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome) {
+					telemetryHandlerOutcomeReceived = &outcome
+				},
 			},
 		}
 
@@ -228,10 +232,15 @@ func TestDataSourceInstanceRead(t *testing.T) {
 			assert.Equal(t, expectedOwners[0], owners[0], tc.name)
 			assert.Equal(t, "data_resourceName_instance", telemetryHandlerResourceNameReceived)
 			assert.Equal(t, TelemetryResourceOperationRead, telemetryHandlerTFOperationReceived)
+			assert.NotNil(t, telemetryHandlerOutcomeReceived, tc.name)
+			assert.Equal(t, tc.returnHTTPCode, telemetryHandlerOutcomeReceived.StatusCode, tc.name)
+			assert.False(t, telemetryHandlerOutcomeReceived.Failed(), tc.name)
 		} else {
 			assert.Equal(t, tc.expectedError.Error(), err.Error(), tc.name)
 			assert.Equal(t, "data_resourceName_instance", telemetryHandlerResourceNameReceived)
 			assert.Equal(t, TelemetryResourceOperationRead, telemetryHandlerTFOperationReceived)
+			assert.NotNil(t, telemetryHandlerOutcomeReceived, tc.name)
+			assert.Equal(t, tc.expectedError.Error(), telemetryHandlerOutcomeReceived.Err.Error(), tc.name)
 		}
 	}
 }
@@ -5,4 +5,6 @@ const (
 	authorizationHeader = "Authorization"
 	userAgentHeader     = "User-Agent"
 	contentType         = "Content-Type"
+	acceptHeader        = "Accept"
+	cookieHeader        = "Cookie"
 )
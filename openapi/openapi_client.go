@@ -1,11 +1,19 @@
 package openapi
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
 
@@ -23,11 +31,12 @@ const (
 
 // ClientOpenAPI defines the behaviour expected to be implemented for the OpenAPI Client used in the Terraform OpenAPI Provider
 type ClientOpenAPI interface {
-	Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
-	Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, requestHeaders map[string]string, parentIDs ...string) (*http.Response, error)
+	Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, requestHeaders map[string]string, parentIDs ...string) (*http.Response, error)
 	Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
-	Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error)
+	Delete(resource SpecResource, id string, requestHeaders map[string]string, parentIDs ...string) (*http.Response, error)
 	List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	GetOperation(resource SpecResource, operationURL string, responsePayload interface{}) (*http.Response, error)
 	GetTelemetryHandler() TelemetryHandler
 }
 
@@ -40,26 +49,50 @@ type ProviderClient struct {
 	providerConfiguration       providerConfiguration
 	apiAuthenticator            specAuthenticator
 	telemetryHandler            TelemetryHandler
+	// retryPolicy defines the default retry policy applied to the API requests, operations can override it. If nil,
+	// requests are not retried
+	retryPolicy *specRetryPolicy
+	// rateLimiter limits the rate of API requests performed per host. If nil, requests are not rate limited
+	rateLimiter *hostRateLimiter
+	// requestDoer performs the API requests whose payload is not encoded as JSON (e,g: form or XML). If nil, the
+	// http.DefaultClient is used
+	requestDoer httpRequestDoer
+	// tracer creates the spans of the API requests. If nil, requests are not traced
+	tracer *tracer
+	// traceSpan is the span the API requests performed by the client are traced under; nil if the client is not
+	// performing a traced operation
+	traceSpan *traceSpan
+	// deadline bounds the retries of the API requests performed by the client, usually the timeout of the terraform
+	// operation the client is performing (see withOperationDeadline). If zero, the retries are bounded by the timeout of
+	// the resource operation defined in the OpenAPI document
+	deadline time.Time
 }
 
-// Post performs a POST request to the server API based on the resource configuration and the payload passed in
-func (o *ProviderClient) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+// httpRequestDoer defines the behaviour expected from the http client used to perform raw http requests
+type httpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Post performs a POST request to the server API based on the resource configuration and the payload passed in. The
+// requestHeaders passed in (e,g: idempotency key) will be sent as part of the request too
+func (o *ProviderClient) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, requestHeaders map[string]string, parentIDs ...string) (*http.Response, error) {
 	resourceURL, err := o.getResourceURL(resource, parentIDs)
 	if err != nil {
 		return nil, err
 	}
 	operation := resource.getResourceOperations().Post
-	return o.performRequest(httpPost, resourceURL, operation, requestPayload, responsePayload)
+	return o.performRequest(resource, httpPost, resourceURL, operation, o.getOperationTimeout(resource, httpPost), requestHeaders, requestPayload, responsePayload)
 }
 
-// Put performs a PUT request to the server API based on the resource configuration and the payload passed in
-func (o *ProviderClient) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+// Put performs a PUT request to the server API based on the resource configuration and the payload passed in. The
+// requestHeaders passed in (e,g: If-Match precondition) will be sent as part of the request too
+func (o *ProviderClient) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, requestHeaders map[string]string, parentIDs ...string) (*http.Response, error) {
 	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
 	if err != nil {
 		return nil, err
 	}
 	operation := resource.getResourceOperations().Put
-	return o.performRequest(httpPut, resourceURL, operation, requestPayload, responsePayload)
+	return o.performRequest(resource, httpPut, resourceURL, operation, o.getOperationTimeout(resource, httpPut), requestHeaders, requestPayload, responsePayload)
 }
 
 // Get performs a GET request to the server API based on the resource configuration and the resource instance id passed in
@@ -69,7 +102,7 @@ func (o *ProviderClient) Get(resource SpecResource, id string, responsePayload i
 		return nil, err
 	}
 	operation := resource.getResourceOperations().Get
-	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+	return o.performRequest(resource, httpGet, resourceURL, operation, o.getOperationTimeout(resource, httpGet), nil, nil, responsePayload)
 }
 
 // List performs a GET request to the root level endpoint of the resource (e,g: GET /v1/groups)
@@ -79,17 +112,31 @@ func (o *ProviderClient) List(resource SpecResource, responsePayload interface{}
 		return nil, err
 	}
 	operation := resource.getResourceOperations().List
-	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
+	return o.performRequest(resource, httpGet, resourceURL, operation, o.getOperationTimeout(resource, httpGet), nil, nil, responsePayload)
 }
 
-// Delete performs a DELETE request to the server API based on the resource configuration and the resource instance id passed in
-func (o *ProviderClient) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+// Delete performs a DELETE request to the server API based on the resource configuration and the resource instance id passed in.
+// The requestHeaders passed in (e,g: If-Match precondition) will be sent as part of the request too
+func (o *ProviderClient) Delete(resource SpecResource, id string, requestHeaders map[string]string, parentIDs ...string) (*http.Response, error) {
 	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
 	if err != nil {
 		return nil, err
 	}
 	operation := resource.getResourceOperations().Delete
-	return o.performRequest(httpDelete, resourceURL, operation, nil, nil)
+	return o.performRequest(resource, httpDelete, resourceURL, operation, o.getOperationTimeout(resource, httpDelete), requestHeaders, nil, nil)
+}
+
+// GetOperation performs a GET request to the long running operation URL returned by the API when the resource operation
+// is asynchronous. The request is configured with the same security schemes and headers as the resource GET operation
+// (the response envelope path does not apply to the operation payload)
+func (o *ProviderClient) GetOperation(resource SpecResource, operationURL string, responsePayload interface{}) (*http.Response, error) {
+	operation := resource.getResourceOperations().Get
+	if operation != nil {
+		getOperation := *operation
+		getOperation.responsePath = nil
+		operation = &getOperation
+	}
+	return o.performRequest(nil, httpGet, operationURL, operation, o.getOperationTimeout(resource, httpGet), nil, nil, responsePayload)
 }
 
 // GetTelemetryHandler returns the configured telemetry handler
@@ -97,8 +144,38 @@ func (o *ProviderClient) GetTelemetryHandler() TelemetryHandler {
 	return o.telemetryHandler
 }
 
-func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
-	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, operation.SecuritySchemes, o.providerConfiguration)
+// startSpan returns a copy of the client whose API requests are traced as children of a new span with the given name,
+// along with the new span. The span is a child of the client's current span if any; otherwise it starts a new trace. If
+// tracing is not configured the client is returned as is along with a nil span
+func (o *ProviderClient) startSpan(name string, attributes ...otlpAttribute) (ClientOpenAPI, *traceSpan) {
+	if o.tracer == nil {
+		return o, nil
+	}
+	span := o.traceSpan.startChild(name, traceSpanKindInternal, attributes...)
+	if span == nil {
+		span = o.tracer.startSpan(name, traceSpanKindInternal, attributes...)
+	}
+	client := *o
+	client.traceSpan = span
+	return &client, span
+}
+
+// withDeadline returns a copy of the client whose API requests are not retried past the given deadline
+func (o *ProviderClient) withDeadline(deadline time.Time) ClientOpenAPI {
+	client := *o
+	client.deadline = deadline
+	return &client
+}
+
+// performRequest performs the API request and retries it following the retry policy configured if the request fails with a
+// retryable error. The retries are bounded by the client deadline if set; otherwise by the timeout passed in. The requestHeaders passed in are added to the request
+// headers. The request payload is encoded following the media types the operation consumes (JSON by default). If the
+// response contains a body, it will be decoded into the responsePayload; non JSON responses are converted into the types
+// defined in the resource schema (if the resource is not nil)
+func (o *ProviderClient) performRequest(resource SpecResource, method httpMethodSupported, resourceURL string, operation *specResourceOperation, timeout *time.Duration, requestHeaders map[string]string, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	authSpan := o.traceSpan.startChild("auth", traceSpanKindInternal)
+	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, operation.SecurityRequirements, o.providerConfiguration, authSpan)
+	authSpan.end(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
 	}
@@ -109,28 +186,291 @@ func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL
 	}
 	log.Printf("[DEBUG] Performing %s %s", method, reqContext.url)
 
+	for headerName, headerValue := range requestHeaders {
+		reqContext.headers[headerName] = headerValue
+	}
+	o.appendCookieHeader(reqContext.headers, reqContext.cookies)
+
+	if len(operation.requestPath) > 0 && requestPayload != nil {
+		requestPayload = wrapPayload(requestPayload, operation.requestPath)
+	}
+
+	requestMediaType := selectMediaType(operation.consumes)
+	responseMediaType := selectMediaType(operation.produces)
+	if responseMediaType != mediaTypeJSON {
+		reqContext.headers[acceptHeader] = responseMediaType
+	}
+
 	userAgentHeader := version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)
 	o.appendUserAgentHeader(reqContext.headers, userAgentHeader)
 
 	o.logHeadersSafely(reqContext.headers)
 
+	deadline := o.deadline
+	if deadline.IsZero() && timeout != nil {
+		deadline = time.Now().Add(*timeout)
+	}
+	retryPolicy := o.retryPolicy
+	if retryPolicy != nil {
+		retryPolicy = retryPolicy.merge(operation.retryPolicy)
+		// requests containing an idempotency key can be safely retried as the API is able to deduplicate them
+		if retryPolicy.enabled == nil && operation.idempotencyKeyHeader != "" && reqContext.headers[operation.idempotencyKeyHeader] != "" {
+			enabled := true
+			retryPolicy.enabled = &enabled
+		}
+	}
+
+	var doRequest func() (*http.Response, error)
+	switch {
+	case (method == httpPost || method == httpPut) && requestMediaType != mediaTypeJSON:
+		body, err := encodePayload(requestMediaType, requestPayload, operation.xmlRootElement)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the request payload for %s %s as '%s': %s", method, resourceURL, requestMediaType, err)
+		}
+		reqContext.headers[contentType] = requestMediaType
+		doRequest = func() (*http.Response, error) {
+			return o.doRawRequest(method, reqContext.url, reqContext.headers, body)
+		}
+	case method == httpPost:
+		doRequest = func() (*http.Response, error) {
+			return o.httpClient.PostJson(reqContext.url, reqContext.headers, requestPayload, nil)
+		}
+	case method == httpPut:
+		doRequest = func() (*http.Response, error) {
+			return o.httpClient.PutJson(reqContext.url, reqContext.headers, requestPayload, nil)
+		}
+	case method == httpGet:
+		doRequest = func() (*http.Response, error) {
+			return o.httpClient.Get(reqContext.url, reqContext.headers, nil)
+		}
+	case method == httpDelete:
+		doRequest = func() (*http.Response, error) {
+			return o.httpClient.Delete(reqContext.url, reqContext.headers)
+		}
+	default:
+		return nil, fmt.Errorf("method '%s' not supported", method)
+	}
+	if o.traceSpan != nil {
+		doRequest = o.traceRequest(method, reqContext.url, reqContext.headers, doRequest)
+	}
+	if o.rateLimiter != nil {
+		host := reqContext.url
+		if u, err := url.Parse(reqContext.url); err == nil {
+			host = u.Host
+		}
+		performRequest := doRequest
+		doRequest = func() (*http.Response, error) {
+			o.rateLimiter.wait(host)
+			return performRequest()
+		}
+	}
+	resp, err := performRequestWithRetries(method, reqContext.url, retryPolicy, deadline, doRequest)
+	if err != nil {
+		return nil, err
+	}
+	if responsePayload != nil {
+		if err := o.unmarshalResponsePayload(resource, operation, method, reqContext.url, resp, responsePayload); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// traceRequest returns a function that performs the request as a child span of the client's current span, propagating
+// the span context to the API via the W3C traceparent header. Each attempt (e,g: retries) is traced as a separate span.
+// The URL query is not recorded as it may contain secrets (e,g: query api keys)
+func (o *ProviderClient) traceRequest(method httpMethodSupported, requestURL string, headers map[string]string, doRequest func() (*http.Response, error)) func() (*http.Response, error) {
+	spanURL := requestURL
+	if u, err := url.Parse(requestURL); err == nil {
+		u.RawQuery = ""
+		spanURL = u.String()
+	}
+	return func() (*http.Response, error) {
+		span := o.traceSpan.startChild(fmt.Sprintf("HTTP %s", method), traceSpanKindClient, otlpAttribute{key: "http.method", value: string(method)}, otlpAttribute{key: "http.url", value: spanURL})
+		headers[traceparentHeader] = span.traceparent()
+		resp, err := doRequest()
+		spanErr := err
+		if resp != nil {
+			span.setAttribute("http.status_code", int64(resp.StatusCode))
+			if spanErr == nil && resp.StatusCode >= http.StatusBadRequest {
+				spanErr = fmt.Errorf("response status code %d", resp.StatusCode)
+			}
+		}
+		span.end(spanErr)
+		return resp, err
+	}
+}
+
+// doRawRequest performs a request with the given body already encoded, which is used for payloads not encoded as JSON
+func (o *ProviderClient) doRawRequest(method httpMethodSupported, url string, headers map[string]string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(string(method), url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for headerName, headerValue := range headers {
+		req.Header.Set(headerName, headerValue)
+	}
+	var requestDoer httpRequestDoer = http.DefaultClient
+	if o.requestDoer != nil {
+		requestDoer = o.requestDoer
+	}
+	resp, err := requestDoer.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s %s %s failed. Response Error: '%s'", req.Method, req.URL, req.Proto, err)
+	}
+	return resp, nil
+}
+
+// unmarshalResponsePayload reads the response body and unmarshals it into the responsePayload. The body is decoded
+// following the response Content-Type header, falling back to the media type the operation produces if the header is
+// not present. If the operation has a response envelope path, the resource is extracted from the envelope. The response
+// body is reset after being read so callers are still able to read it afterwards. Responses with status codes other
+// than 2xx are not required to contain a valid body (e,g: error pages returned by load balancers), in which case the
+// body is left as is so the caller can handle the status code accordingly
+func (o *ProviderClient) unmarshalResponsePayload(resource SpecResource, operation *specResourceOperation, method httpMethodSupported, url string, resp *http.Response, responsePayload interface{}) error {
+	if resp == nil || resp.Body == nil {
+		return nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the response body for request = '%s %s': %s", method, url, err)
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if len(body) == 0 {
+		return nil
+	}
+	mediaType := resp.Header.Get(contentType)
+	if mediaType == "" {
+		mediaType = selectMediaType(operation.produces)
+	}
+	if !isFormMediaType(mediaType) && !isXMLMediaType(mediaType) && len(operation.responsePath) == 0 {
+		if err := json.Unmarshal(body, &responsePayload); err != nil && resp.StatusCode < http.StatusMultipleChoices {
+			return fmt.Errorf("unable to unmarshal response body ['%s'] for request = '%s %s'. Response = '%s'", err, method, url, resp.Status)
+		}
+		return nil
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil
+	}
+	if err := o.decodeResponsePayload(resource, operation, mediaType, body, responsePayload); err != nil {
+		return fmt.Errorf("unable to decode '%s' response body ['%s'] for request = '%s %s'. Response = '%s'", mediaType, err, method, url, resp.Status)
+	}
+	return nil
+}
+
+// decodeResponsePayload decodes the body into the responsePayload extracting the resource from the response envelope if
+// configured. Form and XML values are converted into the types defined in the resource schema and lists wrapped in a
+// root element are unwrapped if the responsePayload is a list
+func (o *ProviderClient) decodeResponsePayload(resource SpecResource, operation *specResourceOperation, mediaType string, body []byte, responsePayload interface{}) error {
+	payload, err := decodePayload(mediaType, body)
+	if err != nil {
+		return err
+	}
+	if len(operation.responsePath) > 0 {
+		if payload, err = unwrapPayload(payload, operation.responsePath); err != nil {
+			return err
+		}
+	}
+	if !isFormMediaType(mediaType) && !isXMLMediaType(mediaType) {
+		return o.convertPayload(payload, responsePayload)
+	}
+	if isListPayload(responsePayload) {
+		payload = toList(payload)
+	}
+	if resource != nil {
+		schemaDefinition, err := resource.getResourceSchema()
+		if err != nil {
+			return err
+		}
+		payload = coercePayload(payload, schemaDefinition)
+	}
+	return o.convertPayload(payload, responsePayload)
+}
+
+// convertPayload converts the decoded payload to JSON and back so it is unmarshalled into the responsePayload in the
+// same way as JSON responses
+func (o *ProviderClient) convertPayload(payload interface{}, responsePayload interface{}) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, responsePayload)
+}
+
+func isListPayload(responsePayload interface{}) bool {
+	t := reflect.TypeOf(responsePayload)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array)
+}
+
+// getOperationTimeout returns the timeout configured for the resource operation matching the given method; or the
+// default timeout if the resource operation does not have a specific timeout configured
+func (o *ProviderClient) getOperationTimeout(resource SpecResource, method httpMethodSupported) *time.Duration {
+	timeout := defaultTimeout
+	timeouts, err := resource.getTimeouts()
+	if err != nil || timeouts == nil {
+		return &timeout
+	}
+	var operationTimeout *time.Duration
 	switch method {
 	case httpPost:
-		return o.httpClient.PostJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
+		operationTimeout = timeouts.Post
 	case httpPut:
-		return o.httpClient.PutJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
+		operationTimeout = timeouts.Put
 	case httpGet:
-		return o.httpClient.Get(reqContext.url, reqContext.headers, &responsePayload)
+		operationTimeout = timeouts.Get
 	case httpDelete:
-		return o.httpClient.Delete(reqContext.url, reqContext.headers)
+		operationTimeout = timeouts.Delete
+	}
+	if operationTimeout != nil {
+		return operationTimeout
 	}
-	return nil, fmt.Errorf("method '%s' not supported", method)
+	return &timeout
 }
 
 func (o *ProviderClient) appendUserAgentHeader(headers map[string]string, value string) {
 	headers[userAgentHeader] = value
 }
 
+// appendCookieHeader merges the given cookies (e,g: cookie api keys) with the cookies already present in the Cookie header
+// (if any). The given cookies take preference over the existing ones with the same name. The cookie values are not logged
+// for security reasons
+func (o *ProviderClient) appendCookieHeader(headers map[string]string, cookies map[string]string) {
+	if len(cookies) == 0 {
+		return
+	}
+	cookieValues := map[string]string{}
+	var cookieNames []string
+	existingCookies := (&http.Request{Header: http.Header{cookieHeader: []string{headers[cookieHeader]}}}).Cookies()
+	for _, cookie := range existingCookies {
+		if _, exists := cookieValues[cookie.Name]; !exists {
+			cookieNames = append(cookieNames, cookie.Name)
+		}
+		cookieValues[cookie.Name] = cookie.Value
+	}
+	var newCookieNames []string
+	for cookieName := range cookies {
+		if _, exists := cookieValues[cookieName]; !exists {
+			newCookieNames = append(newCookieNames, cookieName)
+		}
+	}
+	sort.Strings(newCookieNames)
+	cookieNames = append(cookieNames, newCookieNames...)
+	var cookieHeaderValues []string
+	for _, cookieName := range cookieNames {
+		cookieValue := cookieValues[cookieName]
+		if value, exists := cookies[cookieName]; exists {
+			cookieValue = value
+		}
+		cookieHeaderValues = append(cookieHeaderValues, (&http.Cookie{Name: cookieName, Value: cookieValue}).String())
+		log.Printf("[DEBUG] Request Cookie '%s' sent", cookieName)
+	}
+	headers[cookieHeader] = strings.Join(cookieHeaderValues, "; ")
+}
+
 // logHeadersSafely logs the header names sent to the APIs but the values are redacted for security reasons in case
 // values contain secrets. However, the logging will display whether the values contained data or not so it's easier
 // to debug whether the headers sent had data.
@@ -233,6 +573,34 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 	return fmt.Sprintf("%s://%s%s", defaultScheme, host, path), nil
 }
 
+// getResourceInstancePath returns the path that identifies the resource instance relative to the resource URL. For resources
+// with a composite identifier, each ID value is escaped and substituted into the corresponding path parameter of the
+// resource instance path template (e,g: zone:www:A and {zone}/{record_name}/{type} -> zone/www/A). If the template is not
+// known, the path parameters are assumed to be named after the identifier properties
+func (o ProviderClient) getResourceInstancePath(resource SpecResource, id string) (string, error) {
+	resourceSchema, err := resource.getResourceSchema()
+	if err != nil || !resourceSchema.isCompositeIdentifier() {
+		return id, nil
+	}
+	values, err := resourceSchema.splitResourceID(id)
+	if err != nil {
+		return "", err
+	}
+	instancePath := resource.getResourceInstancePathTemplate()
+	if instancePath == "" {
+		instancePath = fmt.Sprintf("{%s}", strings.Join(resourceSchema.CompositeIdentifier, "}/{"))
+	}
+	pathParameterRegex, _ := regexp.Compile(`{[^/{}]+}`)
+	pathParameters := pathParameterRegex.FindAllString(instancePath, -1)
+	if len(pathParameters) != len(values) {
+		return "", fmt.Errorf("resource instance path '%s' path parameters %s do not match the composite identifier properties %s", instancePath, pathParameters, resourceSchema.CompositeIdentifier)
+	}
+	for idx, pathParameter := range pathParameters {
+		instancePath = strings.Replace(instancePath, pathParameter, url.PathEscape(values[idx]), 1)
+	}
+	return instancePath, nil
+}
+
 func (o ProviderClient) getResourceIDURL(resource SpecResource, parentIDs []string, id string) (string, error) {
 	if strings.Contains(id, "/") {
 		return "", fmt.Errorf("instance ID (%s) contains not supported characters (forward slashes)", id)
@@ -244,6 +612,10 @@ func (o ProviderClient) getResourceIDURL(resource SpecResource, parentIDs []stri
 	if id == "" {
 		return "", fmt.Errorf("could not build the resourceIDURL: required instance id value is missing")
 	}
+	id, err = o.getResourceInstancePath(resource, id)
+	if err != nil {
+		return "", err
+	}
 	if strings.HasSuffix(url, "/") {
 		return fmt.Sprintf("%s%s", url, id), nil
 	}
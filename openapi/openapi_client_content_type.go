@@ -0,0 +1,375 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeForm = "application/x-www-form-urlencoded"
+	mediaTypeXML  = "application/xml"
+)
+
+// defaultXMLRootElement defines the name of the root element used when encoding XML payloads if the body schema does not
+// specify an xml name
+const defaultXMLRootElement = "resource"
+
+// selectMediaType returns the media type used to encode the request payload (or expected in the response) given the
+// media types declared in the consumes (or produces) section. JSON is preferred if declared, otherwise the first form or
+// XML media type found is returned. JSON is returned if none of the media types declared is supported
+func selectMediaType(mediaTypes []string) string {
+	selected := ""
+	for _, mediaType := range mediaTypes {
+		switch {
+		case isJSONMediaType(mediaType):
+			return mediaTypeJSON
+		case selected == "" && (isFormMediaType(mediaType) || isXMLMediaType(mediaType)):
+			selected = mediaType
+		}
+	}
+	if selected == "" {
+		if len(mediaTypes) > 0 {
+			log.Printf("[WARN] none of the media types %v is supported, falling back to '%s'", mediaTypes, mediaTypeJSON)
+		}
+		return mediaTypeJSON
+	}
+	return selected
+}
+
+func parseMediaType(mediaType string) string {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return ""
+	}
+	return parsed
+}
+
+func isJSONMediaType(mediaType string) bool {
+	parsed := parseMediaType(mediaType)
+	return parsed == mediaTypeJSON || strings.HasSuffix(parsed, "+json")
+}
+
+func isFormMediaType(mediaType string) bool {
+	return parseMediaType(mediaType) == mediaTypeForm
+}
+
+func isXMLMediaType(mediaType string) bool {
+	parsed := parseMediaType(mediaType)
+	return parsed == mediaTypeXML || parsed == "text/xml" || strings.HasSuffix(parsed, "+xml")
+}
+
+// encodePayload encodes the payload following the given media type. XML payloads are wrapped in the given root element
+func encodePayload(mediaType string, payload interface{}, xmlRootElement string) ([]byte, error) {
+	if payload == nil {
+		return nil, nil
+	}
+	switch {
+	case isFormMediaType(mediaType):
+		return encodeFormPayload(payload)
+	case isXMLMediaType(mediaType):
+		return encodeXMLPayload(xmlRootElement, payload)
+	}
+	return json.Marshal(payload)
+}
+
+// decodePayload decodes the body following the given media type. Form and XML values are decoded as strings; see
+// coercePayload to convert them into the types defined in the resource schema
+func decodePayload(mediaType string, body []byte) (interface{}, error) {
+	switch {
+	case isFormMediaType(mediaType):
+		return decodeFormPayload(body)
+	case isXMLMediaType(mediaType):
+		return decodeXMLPayload(body)
+	}
+	var payload interface{}
+	err := json.Unmarshal(body, &payload)
+	return payload, err
+}
+
+// encodeFormPayload encodes the payload as application/x-www-form-urlencoded. Nested objects are encoded using brackets
+// (e,g: address[city]=Dublin) and arrays are encoded repeating the key (e,g: tags=a&tags=b)
+func encodeFormPayload(payload interface{}) ([]byte, error) {
+	object, ok := payload.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("form payloads must be objects, got '%T'", payload)
+	}
+	values := url.Values{}
+	if err := addFormValues(values, "", object); err != nil {
+		return nil, err
+	}
+	return []byte(values.Encode()), nil
+}
+
+func addFormValues(values url.Values, key string, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		for name, item := range v {
+			itemKey := name
+			if key != "" {
+				itemKey = fmt.Sprintf("%s[%s]", key, name)
+			}
+			if err := addFormValues(values, itemKey, item); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if _, isObject := item.(map[string]interface{}); isObject {
+				return fmt.Errorf("form payloads do not support arrays of objects (property '%s')", key)
+			}
+			if err := addFormValues(values, key, item); err != nil {
+				return err
+			}
+		}
+	default:
+		values.Add(key, formatScalarValue(v))
+	}
+	return nil
+}
+
+func formatScalarValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// decodeFormPayload decodes an application/x-www-form-urlencoded body. Keys using brackets are decoded as nested objects
+// and repeated keys as arrays
+func decodeFormPayload(body []byte) (interface{}, error) {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]interface{}{}
+	for key, items := range values {
+		var value interface{} = items[0]
+		if len(items) > 1 {
+			list := make([]interface{}, 0, len(items))
+			for _, item := range items {
+				list = append(list, item)
+			}
+			value = list
+		}
+		path := strings.Split(strings.Replace(key, "]", "", -1), "[")
+		object := payload
+		for _, name := range path[:len(path)-1] {
+			nested, ok := object[name].(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+				object[name] = nested
+			}
+			object = nested
+		}
+		object[path[len(path)-1]] = value
+	}
+	return payload, nil
+}
+
+// encodeXMLPayload encodes the payload as XML wrapped in the given root element. Object properties are encoded as child
+// elements (sorted by name) and arrays repeat the element for each item
+func encodeXMLPayload(rootElement string, payload interface{}) ([]byte, error) {
+	if rootElement == "" {
+		rootElement = defaultXMLRootElement
+	}
+	var buf bytes.Buffer
+	encoder := xml.NewEncoder(&buf)
+	if err := encodeXMLElement(encoder, rootElement, payload); err != nil {
+		return nil, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeXMLElement(encoder *xml.Encoder, name string, value interface{}) error {
+	if items, isArray := value.([]interface{}); isArray {
+		for _, item := range items {
+			if err := encodeXMLElement(encoder, name, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for childName := range v {
+			names = append(names, childName)
+		}
+		sort.Strings(names)
+		for _, childName := range names {
+			if err := encodeXMLElement(encoder, childName, v[childName]); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := encoder.EncodeToken(xml.CharData(formatScalarValue(v))); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// decodeXMLPayload decodes an XML body into the payload model. The root element is discarded, elements containing child
+// elements are decoded as objects, repeated elements as arrays and the rest as strings. Attributes are ignored
+func decodeXMLPayload(body []byte) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("XML payload does not contain any element")
+			}
+			return nil, err
+		}
+		if _, isStart := token.(xml.StartElement); isStart {
+			return decodeXMLElement(decoder)
+		}
+	}
+}
+
+func decodeXMLElement(decoder *xml.Decoder) (interface{}, error) {
+	var text strings.Builder
+	var children map[string]interface{}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if children == nil {
+				children = map[string]interface{}{}
+			}
+			name := t.Name.Local
+			switch existing := children[name].(type) {
+			case nil:
+				children[name] = child
+			case []interface{}:
+				children[name] = append(existing, child)
+			default:
+				children[name] = []interface{}{existing, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if children != nil {
+				return children, nil
+			}
+			return strings.TrimSpace(text.String()), nil
+		}
+	}
+}
+
+// coercePayload converts the values of the given decoded payload (e,g: XML or form where every value is a string) into
+// the types defined in the schema definition, so the payload follows the same model as JSON payloads. Values that can
+// not be converted are left as is
+func coercePayload(value interface{}, schemaDefinition *specSchemaDefinition) interface{} {
+	if schemaDefinition == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, propertyValue := range v {
+			property, err := schemaDefinition.getProperty(name)
+			if err != nil {
+				continue
+			}
+			v[name] = coercePropertyValue(property, propertyValue)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = coercePayload(item, schemaDefinition)
+		}
+	}
+	return value
+}
+
+func coercePropertyValue(property *specSchemaDefinitionProperty, value interface{}) interface{} {
+	switch property.Type {
+	case typeObject:
+		return coercePayload(value, property.SpecSchemaDefinition)
+	case typeList:
+		items := toList(value)
+		for i, item := range items {
+			if property.ArrayItemsType == typeObject {
+				items[i] = coercePayload(item, property.SpecSchemaDefinition)
+			} else {
+				items[i] = coerceScalarValue(property.ArrayItemsType, item)
+			}
+		}
+		return items
+	}
+	return coerceScalarValue(property.Type, value)
+}
+
+// toList returns the given value as a list. Single values are wrapped in a list and objects with only one property
+// containing the items are unwrapped (e,g: <tags><tag>a</tag><tag>b</tag></tags> is decoded as {"tag": ["a", "b"]})
+func toList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		if len(v) == 1 {
+			for _, items := range v {
+				if list, isList := items.([]interface{}); isList {
+					return list
+				}
+				return []interface{}{items}
+			}
+		}
+	case nil:
+		return []interface{}{}
+	case string:
+		if v == "" {
+			return []interface{}{}
+		}
+	}
+	return []interface{}{value}
+}
+
+func coerceScalarValue(propertyType schemaDefinitionPropertyType, value interface{}) interface{} {
+	s, isString := value.(string)
+	if !isString {
+		return value
+	}
+	switch propertyType {
+	case typeInt, typeFloat:
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case typeBool:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return value
+}
@@ -0,0 +1,269 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectMediaType(t *testing.T) {
+	testCases := []struct {
+		name              string
+		mediaTypes        []string
+		expectedMediaType string
+	}{
+		{name: "no media types declared", mediaTypes: nil, expectedMediaType: mediaTypeJSON},
+		{name: "JSON is preferred over the rest", mediaTypes: []string{"application/xml", "application/json"}, expectedMediaType: mediaTypeJSON},
+		{name: "JSON vendor media type", mediaTypes: []string{"application/vnd.api+json"}, expectedMediaType: mediaTypeJSON},
+		{name: "form media type", mediaTypes: []string{"application/x-www-form-urlencoded"}, expectedMediaType: mediaTypeForm},
+		{name: "first supported media type is returned", mediaTypes: []string{"text/plain", "text/xml", "application/x-www-form-urlencoded"}, expectedMediaType: "text/xml"},
+		{name: "media types not supported", mediaTypes: []string{"text/plain"}, expectedMediaType: mediaTypeJSON},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedMediaType, selectMediaType(tc.mediaTypes), tc.name)
+	}
+}
+
+func TestEncodeFormPayload(t *testing.T) {
+	Convey("Given a payload containing primitives, nested objects and arrays of primitives", t, func() {
+		payload := map[string]interface{}{
+			"name":    "my cdn",
+			"port":    8080,
+			"ratio":   1.5,
+			"enabled": true,
+			"tags":    []interface{}{"a", "b"},
+			"address": map[string]interface{}{"city": "Dublin"},
+		}
+		Convey("When encodeFormPayload is called", func() {
+			body, err := encodeFormPayload(payload)
+			Convey("Then the body returned should be the expected form encoded payload", func() {
+				So(err, ShouldBeNil)
+				So(string(body), ShouldEqual, "address%5Bcity%5D=Dublin&enabled=true&name=my+cdn&port=8080&ratio=1.5&tags=a&tags=b")
+			})
+		})
+	})
+	Convey("Given a payload containing an array of objects", t, func() {
+		payload := map[string]interface{}{"items": []interface{}{map[string]interface{}{"name": "a"}}}
+		Convey("When encodeFormPayload is called", func() {
+			_, err := encodeFormPayload(payload)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "form payloads do not support arrays of objects (property 'items')")
+			})
+		})
+	})
+}
+
+func TestDecodeFormPayload(t *testing.T) {
+	Convey("Given a form encoded body with nested objects and repeated keys", t, func() {
+		body := []byte("name=my+cdn&tags=a&tags=b&address%5Bcity%5D=Dublin")
+		Convey("When decodeFormPayload is called", func() {
+			payload, err := decodeFormPayload(body)
+			Convey("Then the payload returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldResemble, map[string]interface{}{
+					"name":    "my cdn",
+					"tags":    []interface{}{"a", "b"},
+					"address": map[string]interface{}{"city": "Dublin"},
+				})
+			})
+		})
+	})
+}
+
+func TestEncodeXMLPayload(t *testing.T) {
+	Convey("Given a payload containing primitives, nested objects and arrays", t, func() {
+		payload := map[string]interface{}{
+			"name":    "a<b",
+			"port":    8080,
+			"tags":    []interface{}{"a", "b"},
+			"address": map[string]interface{}{"city": "Dublin"},
+		}
+		Convey("When encodeXMLPayload is called with a root element", func() {
+			body, err := encodeXMLPayload("cdn", payload)
+			Convey("Then the body returned should be the expected XML", func() {
+				So(err, ShouldBeNil)
+				So(string(body), ShouldEqual, "<cdn><address><city>Dublin</city></address><name>a&lt;b</name><port>8080</port><tags>a</tags><tags>b</tags></cdn>")
+			})
+		})
+		Convey("When encodeXMLPayload is called without a root element", func() {
+			body, err := encodeXMLPayload("", map[string]interface{}{"name": "cdn"})
+			Convey("Then the body returned should be wrapped in the default root element", func() {
+				So(err, ShouldBeNil)
+				So(string(body), ShouldEqual, "<resource><name>cdn</name></resource>")
+			})
+		})
+	})
+}
+
+func TestDecodeXMLPayload(t *testing.T) {
+	Convey("Given an XML body with nested elements, repeated elements and attributes", t, func() {
+		body := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<cdn id="ignored">
+  <name>my cdn</name>
+  <tags><tag>a</tag><tag>b</tag></tags>
+  <address><city>Dublin</city></address>
+  <empty/>
+</cdn>`)
+		Convey("When decodeXMLPayload is called", func() {
+			payload, err := decodeXMLPayload(body)
+			Convey("Then the payload returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldResemble, map[string]interface{}{
+					"name":    "my cdn",
+					"tags":    map[string]interface{}{"tag": []interface{}{"a", "b"}},
+					"address": map[string]interface{}{"city": "Dublin"},
+					"empty":   "",
+				})
+			})
+		})
+	})
+	Convey("Given a body that is not XML", t, func() {
+		Convey("When decodeXMLPayload is called", func() {
+			_, err := decodeXMLPayload([]byte("not xml"))
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "XML payload does not contain any element")
+			})
+		})
+	})
+}
+
+func TestCoercePayload(t *testing.T) {
+	Convey("Given a schema definition and a decoded payload where all values are strings", t, func() {
+		schemaDefinition := &specSchemaDefinition{
+			Properties: specSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+				newIntSchemaDefinitionPropertyWithDefaults("port", "", true, false, nil),
+				newNumberSchemaDefinitionPropertyWithDefaults("ratio", "", true, false, nil),
+				newBoolSchemaDefinitionPropertyWithDefaults("enabled", "", true, false, nil),
+				newListSchemaDefinitionPropertyWithDefaults("ports", "", true, false, false, nil, typeInt, nil),
+				newListSchemaDefinitionPropertyWithDefaults("tags", "", true, false, false, nil, typeString, nil),
+			},
+		}
+		payload := map[string]interface{}{
+			"name":    "123",
+			"port":    "8080",
+			"ratio":   "1.5",
+			"enabled": "true",
+			"ports":   map[string]interface{}{"port": []interface{}{"80", "443"}},
+			"tags":    "single",
+			"other":   "value",
+		}
+		Convey("When coercePayload is called", func() {
+			coerced := coercePayload(payload, schemaDefinition)
+			Convey("Then the values should be converted into the types defined in the schema", func() {
+				So(coerced, ShouldResemble, map[string]interface{}{
+					"name":    "123",
+					"port":    float64(8080),
+					"ratio":   1.5,
+					"enabled": true,
+					"ports":   []interface{}{float64(80), float64(443)},
+					"tags":    []interface{}{"single"},
+					"other":   "value",
+				})
+			})
+		})
+	})
+}
+
+func TestPerformRequestWithNonJSONPayloads(t *testing.T) {
+	Convey("Given an API that consumes form payloads and produces XML responses", t, func() {
+		var contentTypeReceived, acceptReceived, bodyReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentTypeReceived = r.Header.Get(contentType)
+			acceptReceived = r.Header.Get(acceptHeader)
+			b, _ := ioutil.ReadAll(r.Body)
+			bodyReceived = string(b)
+			w.Header().Set(contentType, "application/xml; charset=utf-8")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`<cdn><id>1234</id><port>8080</port></cdn>`))
+		}))
+		defer api.Close()
+		resource := newSpecStubResource("cdn", "/v1/cdns", false, &specSchemaDefinition{
+			Properties: specSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("id", "", true, true, nil),
+				newIntSchemaDefinitionPropertyWithDefaults("port", "", true, false, nil),
+			},
+		})
+		providerClient := &ProviderClient{
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			requestDoer:      &http.Client{},
+			apiAuthenticator: newStubAuthenticator("Authorization", "Bearer secret", nil),
+		}
+		operation := &specResourceOperation{consumes: []string{mediaTypeForm}, produces: []string{mediaTypeXML}}
+		Convey("When performRequest is called with a POST request", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.performRequest(resource, httpPost, api.URL, operation, nil, nil, map[string]interface{}{"port": 8080}, &responsePayload)
+			Convey("Then the request should be form encoded", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+				So(contentTypeReceived, ShouldEqual, mediaTypeForm)
+				So(bodyReceived, ShouldEqual, "port=8080")
+			})
+			Convey("And the request should accept XML responses", func() {
+				So(acceptReceived, ShouldEqual, mediaTypeXML)
+			})
+			Convey("And the XML response should be decoded into the payload following the resource schema types", func() {
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "1234", "port": float64(8080)})
+			})
+		})
+	})
+	Convey("Given an API that returns a list of resources in XML", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(contentType, "text/xml")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`<cdns><cdn><id>1</id></cdn><cdn><id>2</id></cdn></cdns>`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newStubAuthenticator("Authorization", "Bearer secret", nil),
+		}
+		Convey("When performRequest is called with a list response payload", func() {
+			responsePayload := []map[string]interface{}{}
+			_, err := providerClient.performRequest(nil, httpGet, api.URL, &specResourceOperation{produces: []string{"text/xml"}}, nil, nil, nil, &responsePayload)
+			Convey("Then the items wrapped in the root element should be decoded into the list", func() {
+				So(err, ShouldBeNil)
+				So(responsePayload, ShouldResemble, []map[string]interface{}{{"id": "1"}, {"id": "2"}})
+			})
+		})
+	})
+	Convey("Given an API that wraps request and response payloads in an envelope", t, func() {
+		var bodyReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			bodyReceived = string(b)
+			w.Header().Set(contentType, mediaTypeJSON)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {"id": "1234", "name": "my cdn"}, "meta": {"version": 1}}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newStubAuthenticator("Authorization", "Bearer secret", nil),
+		}
+		operation := &specResourceOperation{requestPath: []string{"data"}, responsePath: []string{"data"}}
+		Convey("When performRequest is called with a POST request", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.performRequest(nil, httpPost, api.URL, operation, nil, nil, map[string]interface{}{"name": "my cdn"}, &responsePayload)
+			Convey("Then the request payload should be wrapped in the envelope", func() {
+				So(err, ShouldBeNil)
+				So(bodyReceived, ShouldEqual, `{"data":{"name":"my cdn"}}`)
+			})
+			Convey("And the response payload should be unwrapped", func() {
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "1234", "name": "my cdn"})
+			})
+		})
+		Convey("When performRequest is called with an operation whose response path does not exist in the response", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.performRequest(nil, httpPost, api.URL, &specResourceOperation{responsePath: []string{"result"}}, nil, nil, map[string]interface{}{}, &responsePayload)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "unable to decode 'application/json' response body ['payload is missing the property 'result' (path '/result')'] for request = 'POST "+api.URL+"'. Response = '201 Created'")
+			})
+		})
+	})
+}
@@ -0,0 +1,236 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
+)
+
+const problemJSONContentType = "application/problem+json"
+
+// maxErrorBodyLength defines the max number of characters of a response body included in an error when the body can not
+// be parsed
+const maxErrorBodyLength = 512
+
+// errorMessagePropertyNames contains the names of the properties commonly used by APIs to return the error message in
+// order of preference
+var errorMessagePropertyNames = []string{"message", "error", "error_description", "detail", "title"}
+
+// requestIDHeaders contains the response headers commonly used by APIs to return the ID of the request
+var requestIDHeaders = []string{"X-Request-Id", "Request-Id", "X-Correlation-Id"}
+
+// newAPIError returns the typed openapi error corresponding to the given error response. The error message is extracted
+// from the response body following the x-terraform-error-message-path configured for the operation (if any), the
+// application/problem+json format (RFC 7807) or the common error payloads, e,g: {"message": "..."}
+func newAPIError(openAPIResource SpecResource, res *http.Response, body []byte) openapierr.Error {
+	apiError := &openapierr.APIError{
+		ResourceName: openAPIResource.getResourceName(),
+		StatusCode:   res.StatusCode,
+		RequestID:    getRequestID(res),
+	}
+	if res.Request != nil && res.Request.URL != nil {
+		requestURL := *res.Request.URL
+		requestURL.RawQuery = ""
+		apiError.Method = res.Request.Method
+		apiError.URL = requestURL.String()
+	}
+	apiError.Message, apiError.Details = parseErrorResponseBody(body, res.Header.Get(contentType), getErrorMessagePath(openAPIResource, res))
+	switch res.StatusCode {
+	case http.StatusUnauthorized:
+		apiError.Hint = "API access is denied due to invalid credentials"
+	case http.StatusPreconditionFailed:
+		apiError.Hint = "the resource changed outside Terraform since it was last read (ETag mismatch), refresh the state and retry"
+	case http.StatusTooManyRequests:
+		apiError.Hint = "the API rate limit was exceeded"
+	}
+	return openapierr.NewAPIError(apiError)
+}
+
+// getRequestID returns the ID of the request returned by the API in the response headers; empty if not returned
+func getRequestID(res *http.Response) string {
+	for _, header := range requestIDHeaders {
+		if requestID := res.Header.Get(header); requestID != "" {
+			return requestID
+		}
+	}
+	return ""
+}
+
+// getErrorMessagePath returns the x-terraform-error-message-path configured for the operation the request was performed
+// for; empty if not configured or the operation can not be determined
+func getErrorMessagePath(openAPIResource SpecResource, res *http.Response) string {
+	if res.Request == nil {
+		return ""
+	}
+	operations := openAPIResource.getResourceOperations()
+	var candidates []*specResourceOperation
+	switch res.Request.Method {
+	case http.MethodPost:
+		candidates = []*specResourceOperation{operations.Post}
+	case http.MethodGet:
+		candidates = []*specResourceOperation{operations.Get, operations.List}
+	case http.MethodPut:
+		candidates = []*specResourceOperation{operations.Put}
+	case http.MethodDelete:
+		candidates = []*specResourceOperation{operations.Delete}
+	}
+	for _, operation := range candidates {
+		if messagePath := operation.getErrorMessagePath(res.StatusCode); messagePath != "" {
+			return messagePath
+		}
+	}
+	return ""
+}
+
+// parseErrorResponseBody returns a concise error message and the per-field details contained in the error response body.
+// If the body is not JSON or does not contain a known error message, the body is returned as the message (truncated if
+// too long)
+func parseErrorResponseBody(body []byte, contentType, messagePath string) (string, []string) {
+	rawBody := strings.TrimSpace(string(body))
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return truncateErrorBody(rawBody), nil
+	}
+	object, isObject := payload.(map[string]interface{})
+	if !isObject {
+		return truncateErrorBody(rawBody), nil
+	}
+	message := ""
+	if messagePath != "" {
+		message = getErrorMessageFromValue(getValueAtPath(object, messagePath))
+	}
+	if message == "" && isProblemJSON(contentType) {
+		message = getProblemJSONMessage(object)
+	}
+	if message == "" {
+		for _, key := range errorMessagePropertyNames {
+			if message = getErrorMessageFromValue(object[key]); message != "" {
+				break
+			}
+		}
+	}
+	details := getErrorDetails(object)
+	if message == "" && len(details) == 0 {
+		message = truncateErrorBody(rawBody)
+	}
+	return message, details
+}
+
+func isProblemJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == problemJSONContentType
+}
+
+// getProblemJSONMessage returns the message of an application/problem+json payload, e,g: "Validation Failed: the name is
+// already taken"
+func getProblemJSONMessage(problem map[string]interface{}) string {
+	title := getErrorMessageFromValue(problem["title"])
+	detail := getErrorMessageFromValue(problem["detail"])
+	switch {
+	case title != "" && detail != "":
+		return fmt.Sprintf("%s: %s", title, detail)
+	case detail != "":
+		return detail
+	}
+	return title
+}
+
+// getErrorDetails returns the per-field validation errors contained in the payload. The following formats are supported:
+// - application/problem+json invalid params: {"invalid-params": [{"name": "age", "reason": "must be positive"}]}
+// - list of errors: {"errors": [{"field": "age", "message": "must be positive"}]} or {"errors": ["age must be positive"]}
+// - map of errors: {"errors": {"age": "must be positive"}} or {"errors": {"age": ["must be positive"]}}
+func getErrorDetails(payload map[string]interface{}) []string {
+	var details []string
+	for _, key := range []string{"invalid-params", "invalid_params", "errors", "details"} {
+		switch errs := payload[key].(type) {
+		case []interface{}:
+			for _, e := range errs {
+				if detail := getErrorDetail(e); detail != "" {
+					details = append(details, detail)
+				}
+			}
+		case map[string]interface{}:
+			fields := make([]string, 0, len(errs))
+			for field := range errs {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				if reason := getErrorMessageFromValue(errs[field]); reason != "" {
+					details = append(details, fmt.Sprintf("%s: %s", field, reason))
+				}
+			}
+		}
+		if len(details) > 0 {
+			return details
+		}
+	}
+	return nil
+}
+
+func getErrorDetail(value interface{}) string {
+	object, isObject := value.(map[string]interface{})
+	if !isObject {
+		return getErrorMessageFromValue(value)
+	}
+	var field, reason string
+	for _, key := range []string{"name", "field", "property", "path", "pointer"} {
+		if field = getErrorMessageFromValue(object[key]); field != "" {
+			break
+		}
+	}
+	for _, key := range []string{"reason", "message", "detail", "description", "error"} {
+		if reason = getErrorMessageFromValue(object[key]); reason != "" {
+			break
+		}
+	}
+	if field != "" && reason != "" {
+		return fmt.Sprintf("%s: %s", field, reason)
+	}
+	return reason
+}
+
+// getErrorMessageFromValue returns the error message contained in the given value: strings are returned as is, objects
+// containing a message property return the message and lists of messages are joined
+func getErrorMessageFromValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]interface{}:
+		return getErrorMessageFromValue(v["message"])
+	case []interface{}:
+		var messages []string
+		for _, item := range v {
+			if message := getErrorMessageFromValue(item); message != "" {
+				messages = append(messages, message)
+			}
+		}
+		return strings.Join(messages, ", ")
+	}
+	return ""
+}
+
+// getValueAtPath returns the value at the given dot separated path (e,g: error.message); nil if the path does not exist
+func getValueAtPath(payload map[string]interface{}, path string) interface{} {
+	var value interface{} = payload
+	for _, key := range strings.Split(path, ".") {
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+func truncateErrorBody(body string) string {
+	if len(body) > maxErrorBodyLength {
+		return body[:maxErrorBodyLength] + "..."
+	}
+	return body
+}
@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewAPIError(t *testing.T) {
+	Convey("Given a resource and a 409 response containing a request id header for a request with query parameters", t, func() {
+		openAPIResource := &specStubResource{name: "cdn"}
+		requestURL, _ := url.Parse("https://api.server.com/v1/cdns?force=true")
+		res := &http.Response{
+			StatusCode: http.StatusConflict,
+			Header:     http.Header{"X-Request-Id": []string{"req-123"}},
+			Request:    &http.Request{Method: http.MethodPost, URL: requestURL},
+		}
+		Convey("When newAPIError is called with a body containing a message", func() {
+			err := newAPIError(openAPIResource, res, []byte(`{"message": "cdn already exists"}`))
+			Convey("Then the error returned should be a ConflictError", func() {
+				So(err, ShouldHaveSameTypeAs, &openapierr.ConflictError{})
+				So(err.Code(), ShouldEqual, openapierr.Conflict)
+			})
+			Convey("And the error should carry the request information", func() {
+				apiErr := err.(*openapierr.ConflictError)
+				So(apiErr.StatusCode, ShouldEqual, http.StatusConflict)
+				So(apiErr.Method, ShouldEqual, http.MethodPost)
+				So(apiErr.URL, ShouldEqual, "https://api.server.com/v1/cdns")
+				So(apiErr.RequestID, ShouldEqual, "req-123")
+			})
+			Convey("And the error message should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='cdn'] HTTP Response Status Code 409 - Conflict (cdn already exists) [request: POST https://api.server.com/v1/cdns, request id: req-123]")
+			})
+		})
+	})
+	Convey("Given a resource and a 412 response", t, func() {
+		openAPIResource := &specStubResource{name: "cdn"}
+		res := &http.Response{StatusCode: http.StatusPreconditionFailed}
+		Convey("When newAPIError is called", func() {
+			err := newAPIError(openAPIResource, res, nil)
+			Convey("Then the error returned should be a PreconditionFailedError containing the ETag mismatch hint", func() {
+				So(err, ShouldHaveSameTypeAs, &openapierr.PreconditionFailedError{})
+				So(err.Code(), ShouldEqual, openapierr.PreconditionFailed)
+				So(err.Error(), ShouldEqual, "[resource='cdn'] HTTP Response Status Code 412 - Precondition Failed: the resource changed outside Terraform since it was last read (ETag mismatch), refresh the state and retry")
+			})
+		})
+	})
+	Convey("Given a resource with an operation that configures the error message path and a 422 response", t, func() {
+		openAPIResource := &specStubResource{
+			name: "cdn",
+			resourcePutOperation: &specResourceOperation{
+				errorMessagePath: "error.summary",
+			},
+		}
+		requestURL, _ := url.Parse("https://api.server.com/v1/cdns/42")
+		res := &http.Response{
+			StatusCode: http.StatusUnprocessableEntity,
+			Request:    &http.Request{Method: http.MethodPut, URL: requestURL},
+		}
+		Convey("When newAPIError is called with a body containing the message at the configured path and field errors", func() {
+			err := newAPIError(openAPIResource, res, []byte(`{"error": {"summary": "invalid cdn"}, "errors": {"label": "is required", "ips": ["must not be empty"]}}`))
+			Convey("Then the error returned should be a ValidationError containing the message and per-field details", func() {
+				So(err, ShouldHaveSameTypeAs, &openapierr.ValidationError{})
+				So(err.Error(), ShouldEqual, "[resource='cdn'] HTTP Response Status Code 422 - Unprocessable Entity (invalid cdn: ips: must not be empty; label: is required) [request: PUT https://api.server.com/v1/cdns/42]")
+			})
+		})
+	})
+	Convey("Given a resource and responses with different status codes", t, func() {
+		openAPIResource := &specStubResource{name: "cdn"}
+		testCases := []struct {
+			statusCode   int
+			expectedCode string
+		}{
+			{http.StatusBadRequest, openapierr.Validation},
+			{http.StatusUnauthorized, openapierr.Unauthorized},
+			{http.StatusForbidden, openapierr.Forbidden},
+			{http.StatusConflict, openapierr.Conflict},
+			{http.StatusPreconditionFailed, openapierr.PreconditionFailed},
+			{http.StatusUnprocessableEntity, openapierr.Validation},
+			{http.StatusTooManyRequests, openapierr.RateLimited},
+			{http.StatusTeapot, openapierr.ClientError},
+			{http.StatusInternalServerError, openapierr.ServerError},
+			{http.StatusServiceUnavailable, openapierr.ServerError},
+		}
+		Convey("When newAPIError is called", func() {
+			Convey("Then the code of the error returned should match the status code", func() {
+				for _, tc := range testCases {
+					err := newAPIError(openAPIResource, &http.Response{StatusCode: tc.statusCode}, nil)
+					So(err.Code(), ShouldEqual, tc.expectedCode)
+				}
+			})
+		})
+	})
+}
+
+func TestGetErrorMessagePath(t *testing.T) {
+	Convey("Given a resource with a list operation configuring the error message path for 400 responses", t, func() {
+		openAPIResource := &specStubResource{
+			name: "cdn",
+			resourceListOperation: &specResourceOperation{
+				errorMessagePath: "defaultMessage",
+				responses: specResponses{
+					http.StatusBadRequest: &specResponse{errorMessagePath: "badRequestMessage"},
+				},
+			},
+		}
+		Convey("When getErrorMessagePath is called with a 400 response for a GET request", func() {
+			messagePath := getErrorMessagePath(openAPIResource, &http.Response{StatusCode: http.StatusBadRequest, Request: &http.Request{Method: http.MethodGet}})
+			Convey("Then the message path returned should be the one configured for the response", func() {
+				So(messagePath, ShouldEqual, "badRequestMessage")
+			})
+		})
+		Convey("When getErrorMessagePath is called with a 500 response for a GET request", func() {
+			messagePath := getErrorMessagePath(openAPIResource, &http.Response{StatusCode: http.StatusInternalServerError, Request: &http.Request{Method: http.MethodGet}})
+			Convey("Then the message path returned should be the operation's default one", func() {
+				So(messagePath, ShouldEqual, "defaultMessage")
+			})
+		})
+		Convey("When getErrorMessagePath is called with a response for a DELETE request", func() {
+			messagePath := getErrorMessagePath(openAPIResource, &http.Response{StatusCode: http.StatusBadRequest, Request: &http.Request{Method: http.MethodDelete}})
+			Convey("Then the message path returned should be empty", func() {
+				So(messagePath, ShouldBeEmpty)
+			})
+		})
+		Convey("When getErrorMessagePath is called with a response that does not contain the request", func() {
+			messagePath := getErrorMessagePath(openAPIResource, &http.Response{StatusCode: http.StatusBadRequest})
+			Convey("Then the message path returned should be empty", func() {
+				So(messagePath, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestParseErrorResponseBody(t *testing.T) {
+	testCases := []struct {
+		name            string
+		body            string
+		contentType     string
+		messagePath     string
+		expectedMessage string
+		expectedDetails []string
+	}{
+		{
+			name:            "problem+json payload with invalid params",
+			body:            `{"type": "https://example.com/validation", "title": "Validation Failed", "detail": "the request is not valid", "invalid-params": [{"name": "age", "reason": "must be a positive integer"}]}`,
+			contentType:     "application/problem+json; charset=utf-8",
+			expectedMessage: "Validation Failed: the request is not valid",
+			expectedDetails: []string{"age: must be a positive integer"},
+		},
+		{
+			name:            "payload with the message in the configured path",
+			body:            `{"fault": {"description": "quota exceeded"}, "message": "ignored"}`,
+			messagePath:     "fault.description",
+			expectedMessage: "quota exceeded",
+		},
+		{
+			name:            "payload with the configured path missing falls back to the common keys",
+			body:            `{"message": "something went wrong"}`,
+			messagePath:     "fault.description",
+			expectedMessage: "something went wrong",
+		},
+		{
+			name:            "payload with an error object",
+			body:            `{"error": {"code": "InvalidName", "message": "the name is not valid"}}`,
+			expectedMessage: "the name is not valid",
+		},
+		{
+			name:            "payload with a list of errors",
+			body:            `{"errors": [{"field": "name", "message": "is required"}, "label is too long"]}`,
+			expectedDetails: []string{"name: is required", "label is too long"},
+		},
+		{
+			name:            "payload that is not json",
+			body:            "  some backend error\n",
+			expectedMessage: "some backend error",
+		},
+		{
+			name:            "json payload that does not contain any known error key",
+			body:            `{"status": "bad"}`,
+			expectedMessage: `{"status": "bad"}`,
+		},
+		{
+			name:            "empty payload",
+			body:            "",
+			expectedMessage: "",
+		},
+		{
+			name:            "payload that is too long",
+			body:            strings.Repeat("a", maxErrorBodyLength+10),
+			expectedMessage: strings.Repeat("a", maxErrorBodyLength) + "...",
+		},
+	}
+	for _, tc := range testCases {
+		Convey("Given an error response body: "+tc.name, t, func() {
+			Convey("When parseErrorResponseBody is called", func() {
+				message, details := parseErrorResponseBody([]byte(tc.body), tc.contentType, tc.messagePath)
+				Convey("Then the message and details returned should be the expected ones", func() {
+					So(message, ShouldEqual, tc.expectedMessage)
+					So(details, ShouldResemble, tc.expectedDetails)
+				})
+			})
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// hostRateLimiter limits the rate of API requests performed against each host using a token bucket per host
+type hostRateLimiter struct {
+	requestsPerSecond float64
+	burst             int
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// newHostRateLimiter returns a rate limiter configured with the given rate limit configuration; nil is returned if the
+// rate limit is not configured
+func newHostRateLimiter(rateLimit *RateLimitConfig) *hostRateLimiter {
+	if rateLimit.isEmpty() {
+		return nil
+	}
+	burst := rateLimit.Burst
+	if burst <= 0 {
+		burst = int(math.Ceil(rateLimit.RequestsPerSecond))
+	}
+	return &hostRateLimiter{
+		requestsPerSecond: rateLimit.RequestsPerSecond,
+		burst:             burst,
+		buckets:           map[string]*tokenBucket{},
+	}
+}
+
+// wait blocks until a request can be performed against the given host
+func (h *hostRateLimiter) wait(host string) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	bucket, exists := h.buckets[host]
+	if !exists {
+		bucket = newTokenBucket(h.requestsPerSecond, h.burst)
+		h.buckets[host] = bucket
+	}
+	h.mu.Unlock()
+	if wait := bucket.reserve(time.Now()); wait > 0 {
+		log.Printf("[DEBUG] rate limit reached for host '%s', waiting %s before performing the request", host, wait)
+		time.Sleep(wait)
+	}
+}
+
+// tokenBucket implements the token bucket algorithm. The bucket starts full with burst tokens and is refilled at rate
+// tokens per second
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller must wait before the token is available. The
+// tokens count may go negative, which queues subsequent callers behind the ones already waiting
+func (t *tokenBucket) reserve(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.last.IsZero() {
+		elapsed := now.Sub(t.last).Seconds()
+		if elapsed > 0 {
+			t.tokens = math.Min(t.burst, t.tokens+elapsed*t.rate)
+		}
+	}
+	if now.After(t.last) {
+		t.last = now
+	}
+	t.tokens--
+	if t.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-t.tokens / t.rate * float64(time.Second))
+}
@@ -0,0 +1,70 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewHostRateLimiter(t *testing.T) {
+	Convey("Given a rate limit configuration that is not configured", t, func() {
+		var rateLimit *RateLimitConfig
+		Convey("When newHostRateLimiter is called", func() {
+			rateLimiter := newHostRateLimiter(rateLimit)
+			Convey("Then the rate limiter returned should be nil", func() {
+				So(rateLimiter, ShouldBeNil)
+			})
+			Convey("And calling wait on the nil rate limiter should not block", func() {
+				rateLimiter.wait("www.host.com")
+			})
+		})
+	})
+	Convey("Given a rate limit configuration without burst", t, func() {
+		rateLimit := &RateLimitConfig{RequestsPerSecond: 2.5}
+		Convey("When newHostRateLimiter is called", func() {
+			rateLimiter := newHostRateLimiter(rateLimit)
+			Convey("Then the burst should default to the ceiling of the requests per second", func() {
+				So(rateLimiter.burst, ShouldEqual, 3)
+			})
+		})
+	})
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	Convey("Given a token bucket with a rate of 10 requests per second and burst 2", t, func() {
+		bucket := newTokenBucket(10, 2)
+		now := time.Now()
+		Convey("When reserve is called within the burst", func() {
+			first := bucket.reserve(now)
+			second := bucket.reserve(now)
+			Convey("Then the requests should not wait", func() {
+				So(first, ShouldEqual, 0)
+				So(second, ShouldEqual, 0)
+			})
+			Convey("And when reserve is called exceeding the burst the request should wait for the next token", func() {
+				So(bucket.reserve(now), ShouldEqual, 100*time.Millisecond)
+				So(bucket.reserve(now), ShouldEqual, 200*time.Millisecond)
+			})
+			Convey("And when reserve is called after the bucket has been refilled the request should not wait", func() {
+				So(bucket.reserve(now.Add(time.Second)), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestHostRateLimiterWait(t *testing.T) {
+	Convey("Given a host rate limiter with burst 1", t, func() {
+		rateLimiter := newHostRateLimiter(&RateLimitConfig{RequestsPerSecond: 1, Burst: 1})
+		Convey("When wait is called for different hosts", func() {
+			start := time.Now()
+			rateLimiter.wait("api.rst1.domain.com")
+			rateLimiter.wait("api.dub1.domain.com")
+			Convey("Then the hosts should be limited independently and the calls should not block", func() {
+				So(time.Since(start), ShouldBeLessThan, 500*time.Millisecond)
+				So(rateLimiter.buckets, ShouldContainKey, "api.rst1.domain.com")
+				So(rateLimiter.buckets, ShouldContainKey, "api.dub1.domain.com")
+			})
+		})
+	})
+}
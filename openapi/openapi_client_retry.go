@@ -0,0 +1,188 @@
+package openapi
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const retryAfterHeader = "Retry-After"
+
+var defaultRetryMaxAttempts = 3
+var defaultRetryInitialInterval = time.Duration(1 * time.Second)
+var defaultRetryMaxInterval = time.Duration(30 * time.Second)
+
+// specRetryPolicy defines how API requests that fail due to network errors or retryable status codes are retried
+type specRetryPolicy struct {
+	// maxAttempts defines the max number of attempts including the first request
+	maxAttempts int
+	// initialInterval defines the wait time before the first retry, the wait time doubles on each subsequent retry
+	initialInterval time.Duration
+	// maxInterval caps the wait time between retries
+	maxInterval time.Duration
+	// enabled overrides the default behaviour where only idempotent methods are retried. If nil, idempotent methods
+	// are retried; if true, the request is retried regardless of the method; if false retries are disabled
+	enabled *bool
+}
+
+func newDefaultRetryPolicy() *specRetryPolicy {
+	return &specRetryPolicy{
+		maxAttempts:     defaultRetryMaxAttempts,
+		initialInterval: defaultRetryInitialInterval,
+		maxInterval:     defaultRetryMaxInterval,
+	}
+}
+
+// merge returns a new retry policy where the values populated in the override policy take preference over the ones
+// in r
+func (r *specRetryPolicy) merge(override *specRetryPolicy) *specRetryPolicy {
+	merged := &specRetryPolicy{}
+	if r != nil {
+		*merged = *r
+	}
+	if override == nil {
+		return merged
+	}
+	if override.maxAttempts > 0 {
+		merged.maxAttempts = override.maxAttempts
+	}
+	if override.initialInterval > 0 {
+		merged.initialInterval = override.initialInterval
+	}
+	if override.maxInterval > 0 {
+		merged.maxInterval = override.maxInterval
+	}
+	if override.enabled != nil {
+		merged.enabled = override.enabled
+	}
+	return merged
+}
+
+// isRetryEnabled returns true if requests performed with the given method should be retried
+func (r *specRetryPolicy) isRetryEnabled(method httpMethodSupported) bool {
+	if r == nil || r.maxAttempts <= 1 {
+		return false
+	}
+	if r.enabled != nil {
+		return *r.enabled
+	}
+	return isIdempotentMethod(method)
+}
+
+// backoff returns the wait time before the given retry attempt (starting at 1) using exponential backoff with jitter
+func (r *specRetryPolicy) backoff(attempt int) time.Duration {
+	interval := r.initialInterval
+	for i := 1; i < attempt && (r.maxInterval <= 0 || interval < r.maxInterval); i++ {
+		interval *= 2
+	}
+	if r.maxInterval > 0 && interval > r.maxInterval {
+		interval = r.maxInterval
+	}
+	if interval <= 0 {
+		return 0
+	}
+	// equal jitter: half of the interval is kept and the other half is randomised to avoid retry storms
+	half := interval / 2
+	return half + time.Duration(rand.Int63n(int64(interval-half)+1))
+}
+
+func isIdempotentMethod(method httpMethodSupported) bool {
+	switch method {
+	case httpGet, httpPut, httpDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryableResponse returns true if the request should be retried given the response and error returned by the http
+// client. Network errors, 429 Too Many Requests and 5xx responses (except 501 Not Implemented) are considered retryable
+func isRetryableResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if resp == nil {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// getRetryAfter returns the wait time requested by the server in the Retry-After header. The header value can be
+// either a number of seconds or an HTTP date. Zero is returned if the header is not present or not valid
+func getRetryAfter(resp *http.Response, now time.Time) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	value := resp.Header.Get(retryAfterHeader)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// performRequestWithRetries calls doRequest and retries it following the retry policy as long as the request fails with
+// a retryable response and the next attempt can be performed before the deadline
+func performRequestWithRetries(method httpMethodSupported, url string, policy *specRetryPolicy, deadline time.Time, doRequest func() (*http.Response, error)) (*http.Response, error) {
+	attempt := 1
+	for {
+		resp, err := doRequest()
+		if !policy.isRetryEnabled(method) || attempt >= policy.maxAttempts || !isRetryableResponse(resp, err) {
+			return resp, err
+		}
+		wait := getRetryAfter(resp, time.Now())
+		if wait == 0 {
+			wait = policy.backoff(attempt)
+		}
+		if !deadline.IsZero() && time.Now().Add(wait).After(deadline) {
+			log.Printf("[WARN] not retrying %s %s as the next attempt would be performed after the operation timeout", method, url)
+			return resp, err
+		}
+		if err != nil {
+			log.Printf("[WARN] %s %s failed (attempt %d/%d): %s. Retrying in %s", method, url, attempt, policy.maxAttempts, err, wait)
+		} else {
+			log.Printf("[WARN] %s %s returned status code %d (attempt %d/%d). Retrying in %s", method, url, resp.StatusCode, attempt, policy.maxAttempts, wait)
+			discardResponseBody(resp)
+		}
+		time.Sleep(wait)
+		attempt++
+	}
+}
+
+// deadlineClientOpenAPI is implemented by the ClientOpenAPI implementations whose retries can be bounded by a deadline
+type deadlineClientOpenAPI interface {
+	// withDeadline returns a client whose API requests are not retried past the given deadline
+	withDeadline(deadline time.Time) ClientOpenAPI
+}
+
+// withOperationDeadline returns a client whose API requests are not retried past the terraform timeout of the given
+// operation (e,g: schema.TimeoutCreate) which starts now; or the client as is if it does not support deadlines
+func withOperationDeadline(providerClient ClientOpenAPI, data *schema.ResourceData, timeoutKey string) ClientOpenAPI {
+	if deadlineClient, ok := providerClient.(deadlineClientOpenAPI); ok {
+		return deadlineClient.withDeadline(time.Now().Add(data.Timeout(timeoutKey)))
+	}
+	return providerClient
+}
+
+// discardResponseBody drains and closes the response body so the underlying connection can be reused
+func discardResponseBody(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,337 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpecRetryPolicyMerge(t *testing.T) {
+	Convey("Given a default retry policy and an operation override", t, func() {
+		enabled := true
+		defaultPolicy := newDefaultRetryPolicy()
+		override := &specRetryPolicy{maxAttempts: 5, enabled: &enabled}
+		Convey("When merge is called", func() {
+			merged := defaultPolicy.merge(override)
+			Convey("Then the values from the override should take preference and the rest should be kept", func() {
+				So(merged.maxAttempts, ShouldEqual, 5)
+				So(*merged.enabled, ShouldBeTrue)
+				So(merged.initialInterval, ShouldEqual, defaultRetryInitialInterval)
+				So(merged.maxInterval, ShouldEqual, defaultRetryMaxInterval)
+			})
+			Convey("And the default policy should not be mutated", func() {
+				So(defaultPolicy.maxAttempts, ShouldEqual, defaultRetryMaxAttempts)
+				So(defaultPolicy.enabled, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestSpecRetryPolicyIsRetryEnabled(t *testing.T) {
+	enabled := true
+	disabled := false
+	testCases := []struct {
+		name           string
+		policy         *specRetryPolicy
+		method         httpMethodSupported
+		expectedResult bool
+	}{
+		{name: "nil policy", policy: nil, method: httpGet, expectedResult: false},
+		{name: "single attempt", policy: &specRetryPolicy{maxAttempts: 1}, method: httpGet, expectedResult: false},
+		{name: "idempotent GET", policy: &specRetryPolicy{maxAttempts: 3}, method: httpGet, expectedResult: true},
+		{name: "idempotent PUT", policy: &specRetryPolicy{maxAttempts: 3}, method: httpPut, expectedResult: true},
+		{name: "idempotent DELETE", policy: &specRetryPolicy{maxAttempts: 3}, method: httpDelete, expectedResult: true},
+		{name: "non idempotent POST", policy: &specRetryPolicy{maxAttempts: 3}, method: httpPost, expectedResult: false},
+		{name: "POST with retries enabled", policy: &specRetryPolicy{maxAttempts: 3, enabled: &enabled}, method: httpPost, expectedResult: true},
+		{name: "GET with retries disabled", policy: &specRetryPolicy{maxAttempts: 3, enabled: &disabled}, method: httpGet, expectedResult: false},
+	}
+	Convey("Given a list of retry policies and methods", t, func() {
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When isRetryEnabled is called for the '%s' case", tc.name), func() {
+				Convey("Then the result should be the expected one", func() {
+					So(tc.policy.isRetryEnabled(tc.method), ShouldEqual, tc.expectedResult)
+				})
+			})
+		}
+	})
+}
+
+func TestSpecRetryPolicyBackoff(t *testing.T) {
+	Convey("Given a retry policy", t, func() {
+		policy := &specRetryPolicy{maxAttempts: 10, initialInterval: 1 * time.Second, maxInterval: 5 * time.Second}
+		Convey("When backoff is called for the first retry", func() {
+			wait := policy.backoff(1)
+			Convey("Then the wait time should be within the jitter range of the initial interval", func() {
+				So(wait, ShouldBeBetweenOrEqual, 500*time.Millisecond, 1*time.Second)
+			})
+		})
+		Convey("When backoff is called for the third retry", func() {
+			wait := policy.backoff(3)
+			Convey("Then the wait time should be within the jitter range of the exponential interval", func() {
+				So(wait, ShouldBeBetweenOrEqual, 2*time.Second, 4*time.Second)
+			})
+		})
+		Convey("When backoff is called for a retry that exceeds the max interval", func() {
+			wait := policy.backoff(8)
+			Convey("Then the wait time should be capped by the max interval", func() {
+				So(wait, ShouldBeBetweenOrEqual, 2500*time.Millisecond, 5*time.Second)
+			})
+		})
+	})
+}
+
+func TestIsRetryableResponse(t *testing.T) {
+	Convey("Given the responses returned by the http client", t, func() {
+		Convey("Then network errors should be retryable", func() {
+			So(isRetryableResponse(nil, errors.New("connection refused")), ShouldBeTrue)
+		})
+		Convey("And 429 and 5xx responses should be retryable", func() {
+			So(isRetryableResponse(&http.Response{StatusCode: http.StatusTooManyRequests}, nil), ShouldBeTrue)
+			So(isRetryableResponse(&http.Response{StatusCode: http.StatusBadGateway}, nil), ShouldBeTrue)
+			So(isRetryableResponse(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil), ShouldBeTrue)
+		})
+		Convey("And 501 and non 5xx responses should not be retryable", func() {
+			So(isRetryableResponse(&http.Response{StatusCode: http.StatusNotImplemented}, nil), ShouldBeFalse)
+			So(isRetryableResponse(&http.Response{StatusCode: http.StatusNotFound}, nil), ShouldBeFalse)
+			So(isRetryableResponse(&http.Response{StatusCode: http.StatusOK}, nil), ShouldBeFalse)
+		})
+	})
+}
+
+func TestGetRetryAfter(t *testing.T) {
+	Convey("Given a time reference", t, func() {
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		Convey("When getRetryAfter is called with a response containing the Retry-After header in seconds", func() {
+			resp := &http.Response{Header: http.Header{retryAfterHeader: []string{"7"}}}
+			Convey("Then the wait time should be the expected one", func() {
+				So(getRetryAfter(resp, now), ShouldEqual, 7*time.Second)
+			})
+		})
+		Convey("When getRetryAfter is called with a response containing the Retry-After header as an HTTP date", func() {
+			resp := &http.Response{Header: http.Header{retryAfterHeader: []string{now.Add(2 * time.Minute).Format(http.TimeFormat)}}}
+			Convey("Then the wait time should be the expected one", func() {
+				So(getRetryAfter(resp, now), ShouldEqual, 2*time.Minute)
+			})
+		})
+		Convey("When getRetryAfter is called with a response containing an invalid Retry-After header", func() {
+			resp := &http.Response{Header: http.Header{retryAfterHeader: []string{"soon"}}}
+			Convey("Then the wait time should be zero", func() {
+				So(getRetryAfter(resp, now), ShouldEqual, 0)
+			})
+		})
+		Convey("When getRetryAfter is called with a response without the Retry-After header", func() {
+			resp := &http.Response{Header: http.Header{}}
+			Convey("Then the wait time should be zero", func() {
+				So(getRetryAfter(resp, now), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestPerformRequestWithRetries(t *testing.T) {
+	policy := &specRetryPolicy{maxAttempts: 3, initialInterval: time.Millisecond, maxInterval: 5 * time.Millisecond}
+	Convey("Given a request that fails with a retryable status code and then succeeds", t, func() {
+		attempts := 0
+		doRequest := func() (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}
+		Convey("When performRequestWithRetries is called for an idempotent method", func() {
+			resp, err := performRequestWithRetries(httpGet, "http://host.com/resource", policy, time.Time{}, doRequest)
+			Convey("Then the request should have been retried and the successful response returned", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(attempts, ShouldEqual, 2)
+			})
+		})
+		Convey("When performRequestWithRetries is called for a non idempotent method", func() {
+			resp, err := performRequestWithRetries(httpPost, "http://host.com/resource", policy, time.Time{}, doRequest)
+			Convey("Then the request should not have been retried", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusBadGateway)
+				So(attempts, ShouldEqual, 1)
+			})
+		})
+	})
+	Convey("Given a request that always fails with a network error", t, func() {
+		attempts := 0
+		doRequest := func() (*http.Response, error) {
+			attempts++
+			return nil, errors.New("connection reset by peer")
+		}
+		Convey("When performRequestWithRetries is called", func() {
+			_, err := performRequestWithRetries(httpDelete, "http://host.com/resource/1234", policy, time.Time{}, doRequest)
+			Convey("Then the request should have been attempted the max number of attempts and the last error returned", func() {
+				So(err.Error(), ShouldEqual, "connection reset by peer")
+				So(attempts, ShouldEqual, policy.maxAttempts)
+			})
+		})
+	})
+	Convey("Given a request that fails with a Retry-After header exceeding the deadline", t, func() {
+		attempts := 0
+		doRequest := func() (*http.Response, error) {
+			attempts++
+			return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{retryAfterHeader: []string{"60"}}}, nil
+		}
+		Convey("When performRequestWithRetries is called", func() {
+			resp, err := performRequestWithRetries(httpGet, "http://host.com/resource", policy, time.Now().Add(1*time.Second), doRequest)
+			Convey("Then the request should not be retried and the last response should be returned", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusTooManyRequests)
+				So(attempts, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestProviderClientPerformRequestWithRetries(t *testing.T) {
+	Convey("Given an API that returns a 503 with a non JSON body on the first request and then a 200", t, func() {
+		attempts := 0
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.Header().Set(retryAfterHeader, "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte("<html>Service Unavailable</html>"))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id":"1234"}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newStubAuthenticator("Authorization", "Bearer secret", nil),
+			retryPolicy:      &specRetryPolicy{maxAttempts: 3, initialInterval: time.Millisecond, maxInterval: 5 * time.Millisecond},
+		}
+		Convey("When performRequest is called", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.performRequest(nil, httpGet, api.URL, &specResourceOperation{}, nil, nil, nil, &responsePayload)
+			Convey("Then the request should have been retried and the response payload populated", func() {
+				So(err, ShouldBeNil)
+				So(attempts, ShouldEqual, 2)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(responsePayload["id"], ShouldEqual, "1234")
+			})
+		})
+		Convey("When performRequest is called with an operation that disables retries", func() {
+			disabled := false
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.performRequest(nil, httpGet, api.URL, &specResourceOperation{retryPolicy: &specRetryPolicy{enabled: &disabled}}, nil, nil, nil, &responsePayload)
+			Convey("Then the request should not have been retried and the non JSON error body should not fail the request", func() {
+				So(err, ShouldBeNil)
+				So(attempts, ShouldEqual, 1)
+				So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+			})
+		})
+	})
+	Convey("Given an API that returns a 502 on the first POST request and then a 201", t, func() {
+		attempts := 0
+		var idempotencyKeysReceived []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			idempotencyKeysReceived = append(idempotencyKeysReceived, r.Header.Get("Idempotency-Key"))
+			if attempts == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"1234"}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newStubAuthenticator("Authorization", "Bearer secret", nil),
+			retryPolicy:      &specRetryPolicy{maxAttempts: 3, initialInterval: time.Millisecond, maxInterval: 5 * time.Millisecond},
+		}
+		Convey("When performRequest is called with an operation configured with an idempotency key header and the idempotency key", func() {
+			responsePayload := map[string]interface{}{}
+			operation := &specResourceOperation{idempotencyKeyHeader: "Idempotency-Key"}
+			resp, err := providerClient.performRequest(nil, httpPost, api.URL, operation, nil, map[string]string{"Idempotency-Key": "some-key"}, nil, &responsePayload)
+			Convey("Then the POST request should have been retried sending the same idempotency key", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+				So(idempotencyKeysReceived, ShouldResemble, []string{"some-key", "some-key"})
+			})
+		})
+		Convey("When performRequest is called with an operation that is not configured with an idempotency key header", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.performRequest(nil, httpPost, api.URL, &specResourceOperation{}, nil, nil, nil, &responsePayload)
+			Convey("Then the POST request should not have been retried", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusBadGateway)
+				So(attempts, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestProviderClientPerformRequestWithDeadline(t *testing.T) {
+	Convey("Given an API that always returns a 503 and a client configured to retry the requests", t, func() {
+		attempts := 0
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newStubAuthenticator("Authorization", "Bearer secret", nil),
+			retryPolicy:      &specRetryPolicy{maxAttempts: 3, initialInterval: 50 * time.Millisecond, maxInterval: 50 * time.Millisecond},
+		}
+		operationTimeout := 10 * time.Minute
+		Convey("When performRequest is called with a client without deadline", func() {
+			resp, err := providerClient.performRequest(nil, httpGet, api.URL, &specResourceOperation{}, &operationTimeout, nil, nil, nil)
+			Convey("Then the request should have been retried up to the max number of attempts", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+				So(attempts, ShouldEqual, 3)
+			})
+		})
+		Convey("When performRequest is called with a client whose deadline expires before the next attempt", func() {
+			client := providerClient.withDeadline(time.Now().Add(10 * time.Millisecond)).(*ProviderClient)
+			resp, err := client.performRequest(nil, httpGet, api.URL, &specResourceOperation{}, &operationTimeout, nil, nil, nil)
+			Convey("Then the request should not have been retried even though the operation timeout was not reached", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+				So(attempts, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestWithOperationDeadline(t *testing.T) {
+	createTimeout := 5 * time.Minute
+	data := (&schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &createTimeout}}).Data(nil)
+	Convey("Given a ProviderClient and the resource data of a resource configured with a create timeout", t, func() {
+		providerClient := &ProviderClient{}
+		Convey("When withOperationDeadline is called for the create operation", func() {
+			before := time.Now()
+			client := withOperationDeadline(providerClient, data, schema.TimeoutCreate)
+			Convey("Then the client returned should be bounded by the create timeout", func() {
+				deadline := client.(*ProviderClient).deadline
+				So(deadline, ShouldHappenOnOrBetween, before.Add(createTimeout), time.Now().Add(createTimeout))
+				So(providerClient.deadline.IsZero(), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a client that does not support deadlines", t, func() {
+		providerClient := &clientOpenAPIStub{}
+		Convey("When withOperationDeadline is called", func() {
+			client := withOperationDeadline(providerClient, data, schema.TimeoutCreate)
+			Convey("Then the client should be returned as is", func() {
+				So(client, ShouldEqual, providerClient)
+			})
+		})
+	})
+}
@@ -14,16 +14,27 @@ type clientOpenAPIStub struct {
 	returnHTTPCode      int
 	idReceived          string
 	parentIDsReceived   []string
-	telemetryHandler    TelemetryHandler
+	headersReceived     map[string]string
+	responseHeaders     http.Header
+	// operationResponsePayloads contains the payloads returned by subsequent GetOperation calls; the last one is returned
+	// once all of them have been consumed
+	operationResponsePayloads []map[string]interface{}
+	operationURLReceived      string
+	telemetryHandler          TelemetryHandler
 
-	funcPut func() (*http.Response, error)
+	funcPost func() (*http.Response, error)
+	funcPut  func() (*http.Response, error)
 }
 
-func (c *clientOpenAPIStub) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+func (c *clientOpenAPIStub) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, requestHeaders map[string]string, parentIDs ...string) (*http.Response, error) {
+	if c.funcPost != nil {
+		return c.funcPost()
+	}
 	if c.error != nil {
 		return nil, c.error
 	}
 	c.parentIDsReceived = parentIDs
+	c.headersReceived = requestHeaders
 	switch p := responsePayload.(type) {
 	case *map[string]interface{}:
 		*p = c.responsePayload
@@ -33,7 +44,7 @@ func (c *clientOpenAPIStub) Post(resource SpecResource, requestPayload interface
 	return c.generateStubResponse(http.StatusCreated), nil
 }
 
-func (c *clientOpenAPIStub) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+func (c *clientOpenAPIStub) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, requestHeaders map[string]string, parentIDs ...string) (*http.Response, error) {
 	if c.funcPut != nil {
 		return c.funcPut()
 	}
@@ -42,6 +53,7 @@ func (c *clientOpenAPIStub) Put(resource SpecResource, id string, requestPayload
 	}
 	c.idReceived = id
 	c.parentIDsReceived = parentIDs
+	c.headersReceived = requestHeaders
 	switch p := responsePayload.(type) {
 	case *map[string]interface{}:
 		*p = c.responsePayload
@@ -82,16 +94,38 @@ func (c *clientOpenAPIStub) List(resource SpecResource, responsePayload interfac
 	return c.generateStubResponse(http.StatusOK), nil
 }
 
-func (c *clientOpenAPIStub) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+func (c *clientOpenAPIStub) Delete(resource SpecResource, id string, requestHeaders map[string]string, parentIDs ...string) (*http.Response, error) {
 	if c.error != nil {
 		return nil, c.error
 	}
 	c.idReceived = id
 	c.parentIDsReceived = parentIDs
+	c.headersReceived = requestHeaders
 	delete(c.responsePayload, id)
 	return c.generateStubResponse(http.StatusNoContent), nil
 }
 
+func (c *clientOpenAPIStub) GetOperation(resource SpecResource, operationURL string, responsePayload interface{}) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.operationURLReceived = operationURL
+	var operationPayload map[string]interface{}
+	if len(c.operationResponsePayloads) > 0 {
+		operationPayload = c.operationResponsePayloads[0]
+		if len(c.operationResponsePayloads) > 1 {
+			c.operationResponsePayloads = c.operationResponsePayloads[1:]
+		}
+	}
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = operationPayload
+	default:
+		panic("unexpected type")
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
 func (c *clientOpenAPIStub) GetTelemetryHandler() TelemetryHandler {
 	return c.telemetryHandler
 }
@@ -99,6 +133,7 @@ func (c *clientOpenAPIStub) GetTelemetryHandler() TelemetryHandler {
 func (c *clientOpenAPIStub) generateStubResponse(defaultHTTPCode int) *http.Response {
 	return &http.Response{
 		StatusCode: c.returnCode(defaultHTTPCode),
+		Header:     c.responseHeaders,
 		Body:       ioutil.NopCloser(strings.NewReader("")),
 	}
 }
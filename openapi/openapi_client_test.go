@@ -56,8 +56,8 @@ func TestAppendOperationHeaders(t *testing.T) {
 						IsRequired:    false,
 					},
 				},
-				responses:       specResponses{},
-				SecuritySchemes: SpecSecuritySchemes{},
+				responses:            specResponses{},
+				SecurityRequirements: SpecSecurityRequirements{},
 			}
 			headersMap := map[string]string{
 				"someHeaderAlreadyPresent": "someValue",
@@ -97,8 +97,8 @@ func TestAppendOperationHeaders(t *testing.T) {
 						IsRequired: true,
 					},
 				},
-				responses:       specResponses{},
-				SecuritySchemes: SpecSecuritySchemes{},
+				responses:            specResponses{},
+				SecurityRequirements: SpecSecurityRequirements{},
 			}
 			headersMap := map[string]string{}
 			err := providerClient.appendOperationHeaders(resourcePostOperation.HeaderParameters, headersMap)
@@ -144,6 +144,33 @@ func TestAppendUserAgentHeader(t *testing.T) {
 	})
 }
 
+func TestAppendCookieHeader(t *testing.T) {
+	Convey("Given a providerClient", t, func() {
+		providerClient := &ProviderClient{}
+		Convey("When appendCookieHeader is called with a header map without a Cookie header and some cookies", func() {
+			headers := map[string]string{}
+			providerClient.appendCookieHeader(headers, map[string]string{"session_id": "secret", "csrf_token": "token"})
+			Convey("Then the Cookie header should contain the cookies sorted by name", func() {
+				So(headers[cookieHeader], ShouldEqual, "csrf_token=token; session_id=secret")
+			})
+		})
+		Convey("When appendCookieHeader is called with a header map with an existing Cookie header and some cookies", func() {
+			headers := map[string]string{cookieHeader: "locale=en; session_id=old"}
+			providerClient.appendCookieHeader(headers, map[string]string{"session_id": "secret"})
+			Convey("Then the Cookie header should contain the existing cookies merged with the new ones", func() {
+				So(headers[cookieHeader], ShouldEqual, "locale=en; session_id=secret")
+			})
+		})
+		Convey("When appendCookieHeader is called with no cookies", func() {
+			headers := map[string]string{}
+			providerClient.appendCookieHeader(headers, nil)
+			Convey("Then the Cookie header should not be added", func() {
+				So(headers, ShouldNotContainKey, cookieHeader)
+			})
+		})
+	})
+}
+
 func TestGetResourceIDURL(t *testing.T) {
 	Convey("Given a providerClient", t, func() {
 		providerClient := &ProviderClient{
@@ -256,6 +283,46 @@ func TestGetResourceIDURL(t *testing.T) {
 				So(err.Error(), ShouldEqual, "could not build the resourceIDURL: required instance id value is missing")
 			})
 		})
+
+		Convey("When getResourceIDURL is called with a specResource with a composite identifier and a composite ID", func() {
+			r := newSpecStubResource("record", "/v1/records", false, newCompositeIdentifierSchemaDefinition(""))
+			resourceURL, err := providerClient.getResourceIDURL(r, []string{}, "example.com:www:10")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then resourceURL should contain the identifier values as consecutive path parameters", func() {
+				So(resourceURL, ShouldEqual, "http://wwww.host.com/api/v1/records/example.com/www/10")
+			})
+		})
+
+		Convey("When getResourceIDURL is called with a specResource with a composite identifier, an instance path template and a composite ID", func() {
+			r := newSpecStubResource("record", "/v1/records", false, newCompositeIdentifierSchemaDefinition(""))
+			r.instancePathTemplate = "{zone}/records/{name}/{priority}"
+			resourceURL, err := providerClient.getResourceIDURL(r, []string{}, "example.com:my www:10")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then resourceURL should contain the escaped identifier values in their path parameters", func() {
+				So(resourceURL, ShouldEqual, "http://wwww.host.com/api/v1/records/example.com/records/my%20www/10")
+			})
+		})
+
+		Convey("When getResourceIDURL is called with a specResource with a composite identifier and an instance path template not matching the identifier", func() {
+			r := newSpecStubResource("record", "/v1/records", false, newCompositeIdentifierSchemaDefinition(""))
+			r.instancePathTemplate = "{zone}/{name}"
+			_, err := providerClient.getResourceIDURL(r, []string{}, "example.com:www:10")
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource instance path '{zone}/{name}' path parameters [{zone} {name}] do not match the composite identifier properties [zone record_name priority]")
+			})
+		})
+
+		Convey("When getResourceIDURL is called with a specResource with a composite identifier and an ID missing some values", func() {
+			r := newSpecStubResource("record", "/v1/records", false, newCompositeIdentifierSchemaDefinition(""))
+			_, err := providerClient.getResourceIDURL(r, []string{}, "example.com:www")
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource ID 'example.com:www' does not match the composite identifier format 'zone:record_name:priority'")
+			})
+		})
 	})
 }
 
@@ -351,9 +418,9 @@ func TestGetResourceURL(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: expectedPath,
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
@@ -413,9 +480,9 @@ func TestGetResourceURL(t *testing.T) {
 				path: expectedPath,
 				host: expectedHost,
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
@@ -486,9 +553,9 @@ func TestGetResourceURL(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: expectedPath,
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
@@ -520,9 +587,9 @@ func TestGetResourceURL(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: expectedPath,
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
@@ -554,9 +621,9 @@ func TestGetResourceURL(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: expectedPath,
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
@@ -588,9 +655,9 @@ func TestGetResourceURL(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: expectedPath,
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
@@ -622,9 +689,9 @@ func TestGetResourceURL(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: expectedPath,
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
@@ -661,9 +728,9 @@ func TestGetResourceURL(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: expectedPath,
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
@@ -700,9 +767,9 @@ func TestGetResourceURL(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: expectedPath,
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
@@ -852,11 +919,21 @@ func TestPerformRequest(t *testing.T) {
 			providerConfiguration: providerConfiguration,
 			apiAuthenticator:      apiAuthenticator,
 		}
+		Convey("When performRequest GET method is called with an auth context containing cookies and a request Cookie header", func() {
+			apiAuthenticator.authContext.cookies = map[string]string{"session_id": "secret"}
+			_, err := providerClient.performRequest(nil, "GET", "http://wwww.host.com/api/v1/resource", &specResourceOperation{}, nil, map[string]string{cookieHeader: "locale=en"}, nil, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then client should have received the auth cookies merged with the request cookies", func() {
+				So(httpClient.Headers[cookieHeader], ShouldEqual, "locale=en; session_id=secret")
+			})
+		})
 		Convey("When performRequest POST method is called with a resourceURL, a requestPayload, an empty responsePayload, and header parameters", func() {
 			resourcePostOperation := &specResourceOperation{
-				HeaderParameters: SpecHeaderParameters{headerParameter},
-				responses:        specResponses{},
-				SecuritySchemes:  SpecSecuritySchemes{},
+				HeaderParameters:     SpecHeaderParameters{headerParameter},
+				responses:            specResponses{},
+				SecurityRequirements: SpecSecurityRequirements{},
 			}
 			expectedReqPayloadProperty1 := "property1"
 			expectedReqPayloadProperty1Value := "someValue"
@@ -871,7 +948,7 @@ func TestPerformRequest(t *testing.T) {
 			expectedPath := "/v1/resource"
 			resourceURL := fmt.Sprintf("%s://%s%s%s", expectedProtocol, expectedHost, expectedBasePath, expectedPath)
 
-			_, err := providerClient.performRequest("POST", resourceURL, resourcePostOperation, requestPayload, responsePayload)
+			_, err := providerClient.performRequest(nil, "POST", resourceURL, resourcePostOperation, nil, nil, requestPayload, responsePayload)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -897,11 +974,11 @@ func TestPerformRequest(t *testing.T) {
 		})
 		Convey("When performRequest with a method that is not supported", func() {
 			resourcePostOperation := &specResourceOperation{
-				HeaderParameters: SpecHeaderParameters{},
-				responses:        specResponses{},
-				SecuritySchemes:  SpecSecuritySchemes{},
+				HeaderParameters:     SpecHeaderParameters{},
+				responses:            specResponses{},
+				SecurityRequirements: SpecSecurityRequirements{},
 			}
-			_, err := providerClient.performRequest("NotSupportedMethod", "", resourcePostOperation, nil, nil)
+			_, err := providerClient.performRequest(nil, "NotSupportedMethod", "", resourcePostOperation, nil, nil, nil, nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -917,10 +994,10 @@ func TestPerformRequest(t *testing.T) {
 						IsRequired: true,
 					},
 				},
-				responses:       specResponses{},
-				SecuritySchemes: SpecSecuritySchemes{},
+				responses:            specResponses{},
+				SecurityRequirements: SpecSecurityRequirements{},
 			}
-			_, err := providerClient.performRequest("POST", "http://host.com/resource", resourcePostOperation, nil, nil)
+			_, err := providerClient.performRequest(nil, "POST", "http://host.com/resource", resourcePostOperation, nil, nil, nil, nil)
 			Convey("Then the error message returned should be", func() {
 				So(err.Error(), ShouldEqual, "failed to configure the API request for POST http://host.com/resource: required header 'some_not_configured_header' is missing the value. Please make sure the property 'some_not_configured_header' is configured with a value in the provider's terraform configuration")
 			})
@@ -933,7 +1010,7 @@ func TestPerformRequest(t *testing.T) {
 					err:         fmt.Errorf("some error with prep auth"),
 				},
 			}
-			_, err := providerClient.performRequest("POST", "", &specResourceOperation{}, nil, nil)
+			_, err := providerClient.performRequest(nil, "POST", "", &specResourceOperation{}, nil, nil, nil, nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -976,9 +1053,9 @@ func TestProviderClientPost(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: "/v1/resource",
 				resourcePostOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{headerParameter},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{headerParameter},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			expectedReqPayloadProperty1 := "property1"
@@ -991,7 +1068,7 @@ func TestProviderClientPost(t *testing.T) {
 			}
 			responsePayload := map[string]interface{}{}
 
-			_, err := providerClient.Post(specStubResource, requestPayload, responsePayload)
+			_, err := providerClient.Post(specStubResource, requestPayload, responsePayload, nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -1063,7 +1140,7 @@ func TestProviderClientPost(t *testing.T) {
 			}
 			responsePayload := map[string]interface{}{}
 
-			_, err := providerClient.Post(specv2Resource, requestPayload, responsePayload, "parentID")
+			_, err := providerClient.Post(specv2Resource, requestPayload, responsePayload, nil, "parentID")
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -1109,9 +1186,9 @@ func TestProviderClientPut(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: "/v1/resource",
 				resourcePutOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{headerParameter},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{headerParameter},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			expectedReqPayloadProperty1 := "property1"
@@ -1121,7 +1198,7 @@ func TestProviderClientPut(t *testing.T) {
 			}
 			responsePayload := map[string]interface{}{}
 			expectedID := "1234"
-			_, err := providerClient.Put(specStubResource, expectedID, requestPayload, responsePayload)
+			_, err := providerClient.Put(specStubResource, expectedID, requestPayload, responsePayload, nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -1197,7 +1274,7 @@ func TestProviderClientPut(t *testing.T) {
 			responsePayload := map[string]interface{}{}
 			expectedID := "1234"
 			parentIDs := []string{"parentID"}
-			_, err := providerClient.Put(specv2Resource, expectedID, requestPayload, responsePayload, parentIDs...)
+			_, err := providerClient.Put(specv2Resource, expectedID, requestPayload, responsePayload, nil, parentIDs...)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -1242,9 +1319,9 @@ func TestProviderClientGet(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: "/v1/resource",
 				resourceGetOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{headerParameter},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{headerParameter},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 
@@ -1360,9 +1437,9 @@ func TestProviderClientList(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: "/v1/resource",
 				resourceListOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{headerParameter},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{headerParameter},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 
@@ -1482,13 +1559,13 @@ func TestProviderClientDelete(t *testing.T) {
 			specStubResource := &specStubResource{
 				path: "/v1/resource",
 				resourceDeleteOperation: &specResourceOperation{
-					HeaderParameters: SpecHeaderParameters{headerParameter},
-					responses:        specResponses{},
-					SecuritySchemes:  SpecSecuritySchemes{},
+					HeaderParameters:     SpecHeaderParameters{headerParameter},
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
 				},
 			}
 			expectedID := "1234"
-			_, err := providerClient.Delete(specStubResource, expectedID)
+			_, err := providerClient.Delete(specStubResource, expectedID, nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -1554,7 +1631,7 @@ func TestProviderClientDelete(t *testing.T) {
 			}
 			parentIDs := []string{"parentID"}
 			expectedID := "1234"
-			_, err := providerClient.Delete(specv2Resource, expectedID, parentIDs...)
+			_, err := providerClient.Delete(specv2Resource, expectedID, nil, parentIDs...)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -1572,6 +1649,48 @@ func TestProviderClientDelete(t *testing.T) {
 	})
 }
 
+func TestProviderClientGetOperation(t *testing.T) {
+	Convey("Given a providerClient set up with stub client that returns an operation payload", t, func() {
+		httpClient := &http_goclient.HttpClientStub{
+			Response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"state":"running"}`)),
+			},
+		}
+		expectedHeader := "Authentication"
+		expectedHeaderValue := "Bearer secret!"
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration("wwww.host.com", "/api", "http"),
+			httpClient:                  httpClient,
+			providerConfiguration:       providerConfiguration{},
+			apiAuthenticator:            newStubAuthenticator(expectedHeader, expectedHeaderValue, nil),
+		}
+		Convey("When providerClient GetOperation method is called with the operation URL", func() {
+			specStubResource := &specStubResource{
+				path: "/v1/resource",
+				resourceGetOperation: &specResourceOperation{
+					responses:            specResponses{},
+					SecurityRequirements: SpecSecurityRequirements{},
+				},
+			}
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.GetOperation(specStubResource, "http://wwww.host.com/api/v1/operations/1234", &responsePayload)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then client should have received the operation URL", func() {
+				So(httpClient.URL, ShouldEqual, "http://wwww.host.com/api/v1/operations/1234")
+			})
+			Convey("And then client should have received the resource GET operation authentication header", func() {
+				So(httpClient.Headers[expectedHeader], ShouldEqual, expectedHeaderValue)
+			})
+			Convey("And the response payload should contain the operation returned by the API", func() {
+				So(responsePayload["state"], ShouldEqual, "running")
+			})
+		})
+	})
+}
+
 func TestProviderClientGetTelemetryHandler(t *testing.T) {
 	Convey("Given a providerClient set up with a telemetry handler", t, func() {
 		telemetryHandler := &telemetryHandlerTimeoutSupport{}
@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// traceparentHeader is the W3C Trace Context header used to propagate the span context to the APIs (https://www.w3.org/TR/trace-context/)
+const traceparentHeader = "traceparent"
+
+// traceSpanKind describes the relationship between the span, its parents, and its children (same values as the OTLP SpanKind)
+type traceSpanKind int
+
+const (
+	// traceSpanKindInternal represents an internal operation (e,g: the terraform CRUD call or a polling iteration)
+	traceSpanKindInternal traceSpanKind = 1
+	// traceSpanKindClient represents an outgoing HTTP request
+	traceSpanKindClient traceSpanKind = 3
+)
+
+// traceExporter defines the behaviour expected to be implemented by the destinations the spans are exported to
+type traceExporter interface {
+	export(serviceName string, spans []*traceSpan) error
+}
+
+// tracers holds the tracers created during the plugin execution so the spans still being exported are flushed on shutdown
+var tracers = struct {
+	sync.Mutex
+	tracers []*tracer
+}{}
+
+func shutdownTracers(timeout time.Duration) {
+	tracers.Lock()
+	registeredTracers := tracers.tracers
+	tracers.tracers = nil
+	tracers.Unlock()
+
+	deadline := time.Now().Add(timeout)
+	for _, t := range registeredTracers {
+		t.shutdown(time.Until(deadline))
+	}
+}
+
+// tracer creates the spans and exports them once the root span of the trace (the terraform CRUD call) finishes. The spans
+// are exported in the background so the resource operations are not blocked by the exporter
+type tracer struct {
+	serviceName string
+	exporter    traceExporter
+
+	mutex sync.Mutex
+	// finished holds the spans that finished keyed by trace ID, waiting for the root span to finish
+	finished map[string][]*traceSpan
+	exports  sync.WaitGroup
+}
+
+// newTracer returns a tracer exporting the spans with the given exporter. The tracer is registered so the spans still being
+// exported are flushed when ShutdownTelemetry is called
+func newTracer(providerName string, exporter traceExporter) *tracer {
+	t := &tracer{
+		serviceName: fmt.Sprintf("terraform-provider-%s", providerName),
+		exporter:    exporter,
+		finished:    map[string][]*traceSpan{},
+	}
+	tracers.Lock()
+	tracers.tracers = append(tracers.tracers, t)
+	tracers.Unlock()
+	return t
+}
+
+// startSpan starts a root span of a new trace
+func (t *tracer) startSpan(name string, kind traceSpanKind, attributes ...otlpAttribute) *traceSpan {
+	return &traceSpan{
+		tracer:     t,
+		traceID:    newTraceID(16),
+		spanID:     newTraceID(8),
+		name:       name,
+		kind:       kind,
+		attributes: attributes,
+		startTime:  time.Now(),
+	}
+}
+
+// finish keeps the given span until the root span of the trace finishes, at which point all the spans of the trace are exported
+func (t *tracer) finish(span *traceSpan) {
+	traceID := hex.EncodeToString(span.traceID)
+	t.mutex.Lock()
+	t.finished[traceID] = append(t.finished[traceID], span)
+	if !span.isRoot() {
+		t.mutex.Unlock()
+		return
+	}
+	spans := t.finished[traceID]
+	delete(t.finished, traceID)
+	t.mutex.Unlock()
+
+	t.exports.Add(1)
+	go func() {
+		defer t.exports.Done()
+		if err := t.exporter.export(t.serviceName, spans); err != nil {
+			log.Printf("[WARN] failed to export trace '%s' spans: %s", traceID, err)
+		}
+	}()
+}
+
+// shutdown waits at most the given timeout for the spans being exported
+func (t *tracer) shutdown(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		t.exports.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Printf("[WARN] trace spans were not exported within the expected time %s", timeout)
+	}
+}
+
+// traceSpan represents a timed operation of a trace. All the methods are safe to call on a nil span (which is what the
+// provider works with when tracing is not configured) so callers do not need to check whether tracing is enabled
+type traceSpan struct {
+	tracer       *tracer
+	traceID      []byte
+	spanID       []byte
+	parentSpanID []byte
+	name         string
+	kind         traceSpanKind
+	attributes   []otlpAttribute
+	startTime    time.Time
+	endTime      time.Time
+	err          error
+}
+
+// startChild starts a new span whose parent is s; nil is returned if s is nil
+func (s *traceSpan) startChild(name string, kind traceSpanKind, attributes ...otlpAttribute) *traceSpan {
+	if s == nil {
+		return nil
+	}
+	return &traceSpan{
+		tracer:       s.tracer,
+		traceID:      s.traceID,
+		spanID:       newTraceID(8),
+		parentSpanID: s.spanID,
+		name:         name,
+		kind:         kind,
+		attributes:   attributes,
+		startTime:    time.Now(),
+	}
+}
+
+// setAttribute adds the given attribute to the span
+func (s *traceSpan) setAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.attributes = append(s.attributes, otlpAttribute{key: key, value: value})
+}
+
+// traceparent returns the W3C traceparent header value identifying the span (always sampled); empty if s is nil
+func (s *traceSpan) traceparent() string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(s.traceID), hex.EncodeToString(s.spanID))
+}
+
+// end finishes the span recording the given error (if any) as the span status
+func (s *traceSpan) end(err error) {
+	if s == nil {
+		return
+	}
+	s.endTime = time.Now()
+	s.err = err
+	s.tracer.finish(s)
+}
+
+func (s *traceSpan) isRoot() bool {
+	return len(s.parentSpanID) == 0
+}
+
+// newTraceID returns a random ID of the given size in bytes
+func newTraceID(size int) []byte {
+	id := make([]byte, size)
+	if _, err := rand.Read(id); err != nil {
+		log.Printf("[WARN] failed to generate a random trace ID: %s", err)
+	}
+	return id
+}
+
+// tracingClientOpenAPI is implemented by the ClientOpenAPI implementations that support tracing
+type tracingClientOpenAPI interface {
+	// startSpan returns a client whose API requests are traced as children of a new span with the given name, along
+	// with the new span
+	startSpan(name string, attributes ...otlpAttribute) (ClientOpenAPI, *traceSpan)
+}
+
+// startClientSpan starts a new span with the given name if the client supports tracing; otherwise the client is returned as
+// is along with a nil span
+func startClientSpan(providerClient ClientOpenAPI, name string, attributes ...otlpAttribute) (ClientOpenAPI, *traceSpan) {
+	if tracingClient, ok := providerClient.(tracingClientOpenAPI); ok {
+		return tracingClient.startSpan(name, attributes...)
+	}
+	return providerClient, nil
+}
+
+// startOperationSpan starts the span of the given terraform operation, which is the parent of the spans of the API
+// requests and polling iterations performed during the operation
+func startOperationSpan(providerClient ClientOpenAPI, tfOperation TelemetryResourceOperation, resourceName string) (ClientOpenAPI, *traceSpan) {
+	return startClientSpan(providerClient, fmt.Sprintf("%s %s", tfOperation, resourceName),
+		otlpAttribute{key: "terraform.operation", value: string(tfOperation)},
+		otlpAttribute{key: "terraform.resource_name", value: resourceName})
+}
@@ -0,0 +1,123 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+)
+
+const otlpTracesPath = "/v1/traces"
+
+// traceExporterOTLP exports the spans to an OpenTelemetry collector via OTLP (HTTP/protobuf)
+type traceExporterOTLP struct {
+	endpoint string
+	headers  map[string]string
+}
+
+func (o traceExporterOTLP) getTracesURL() string {
+	endpoint := strings.TrimSuffix(o.endpoint, "/")
+	if strings.HasSuffix(endpoint, otlpTracesPath) {
+		return endpoint
+	}
+	return endpoint + otlpTracesPath
+}
+
+func (o traceExporterOTLP) export(serviceName string, spans []*traceSpan) error {
+	resourceAttributes := []otlpAttribute{{key: "service.name", value: serviceName}, {key: "service.version", value: version.Version}}
+	body := encodeOTLPExportTraceServiceRequest(resourceAttributes, otlpInstrumentationScope, version.Version, spans)
+
+	tracesURL := o.getTracesURL()
+	log.Printf("[DEBUG] otlp spans to be exported to %s (%d spans)", tracesURL, len(spans))
+	req, err := http.NewRequest(http.MethodPost, tracesURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(contentType, otlpProtobufMediaType)
+	req.Header.Set(userAgentHeader, version.BuildUserAgent(runtime.GOOS, runtime.GOARCH))
+	for headerName, headerValue := range o.headers {
+		req.Header.Set(headerName, headerValue)
+	}
+	c := http.Client{Timeout: telemetryTimeout * time.Second}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("request POST %s failed. Response Error: '%s'", tracesURL, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("response returned from POST '%s' returned a non expected status code %d", tracesURL, resp.StatusCode)
+	}
+	return nil
+}
+
+// traceSpanJSON is the representation of the spans written by the traceExporterFile
+type traceSpanJSON struct {
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	ServiceName  string                 `json:"service_name"`
+	Name         string                 `json:"name"`
+	StartTime    time.Time              `json:"start_time"`
+	EndTime      time.Time              `json:"end_time"`
+	DurationMs   float64                `json:"duration_ms"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+// traceExporterFile exports the spans to a local file, appending one JSON object per span and line
+type traceExporterFile struct {
+	path  string
+	mutex sync.Mutex
+}
+
+func (f *traceExporterFile) export(serviceName string, spans []*traceSpan) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, span := range spans {
+		spanJSON := traceSpanJSON{
+			TraceID:     hex.EncodeToString(span.traceID),
+			SpanID:      hex.EncodeToString(span.spanID),
+			ServiceName: serviceName,
+			Name:        span.name,
+			StartTime:   span.startTime,
+			EndTime:     span.endTime,
+			DurationMs:  float64(span.endTime.Sub(span.startTime)) / float64(time.Millisecond),
+		}
+		if !span.isRoot() {
+			spanJSON.ParentSpanID = hex.EncodeToString(span.parentSpanID)
+		}
+		if len(span.attributes) > 0 {
+			spanJSON.Attributes = map[string]interface{}{}
+			for _, attribute := range span.attributes {
+				spanJSON.Attributes[attribute.key] = attribute.value
+			}
+		}
+		if span.err != nil {
+			spanJSON.Error = span.err.Error()
+		}
+		if err := encoder.Encode(spanJSON); err != nil {
+			return err
+		}
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open the tracing file '%s': %s", f.path, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write the spans to the tracing file '%s': %s", f.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTraceSpans() (*traceSpan, *traceSpan) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	root := &traceSpan{
+		traceID:    []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		spanID:     []byte{1, 1, 1, 1, 1, 1, 1, 1},
+		name:       "create cdn_v1",
+		kind:       traceSpanKindInternal,
+		attributes: []otlpAttribute{{key: "terraform.operation", value: "create"}},
+		startTime:  start,
+		endTime:    start.Add(2 * time.Second),
+	}
+	child := &traceSpan{
+		traceID:      root.traceID,
+		spanID:       []byte{2, 2, 2, 2, 2, 2, 2, 2},
+		parentSpanID: root.spanID,
+		name:         "HTTP POST",
+		kind:         traceSpanKindClient,
+		attributes:   []otlpAttribute{{key: "http.status_code", value: int64(500)}},
+		startTime:    start.Add(time.Second),
+		endTime:      start.Add(1500 * time.Millisecond),
+		err:          errors.New("response status code 500"),
+	}
+	return root, child
+}
+
+func TestTraceExporterFileExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracing")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "traces.json")
+
+	root, child := newTestTraceSpans()
+	exporter := &traceExporterFile{path: path}
+	require.NoError(t, exporter.export("terraform-provider-cdn", []*traceSpan{child, root}))
+	require.NoError(t, exporter.export("terraform-provider-cdn", []*traceSpan{root}))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var spans []traceSpanJSON
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var span traceSpanJSON
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+		spans = append(spans, span)
+	}
+	require.Len(t, spans, 3, "spans should be appended to the file one per line")
+	assert.Equal(t, traceSpanJSON{
+		TraceID:      "0102030405060708090a0b0c0d0e0f10",
+		SpanID:       "0202020202020202",
+		ParentSpanID: "0101010101010101",
+		ServiceName:  "terraform-provider-cdn",
+		Name:         "HTTP POST",
+		StartTime:    child.startTime,
+		EndTime:      child.endTime,
+		DurationMs:   500,
+		Attributes:   map[string]interface{}{"http.status_code": float64(500)},
+		Error:        "response status code 500",
+	}, spans[0])
+	assert.Equal(t, "", spans[1].ParentSpanID)
+	assert.Equal(t, float64(2000), spans[1].DurationMs)
+	assert.Equal(t, map[string]interface{}{"terraform.operation": "create"}, spans[1].Attributes)
+}
+
+func TestTraceExporterFileExportError(t *testing.T) {
+	exporter := &traceExporterFile{path: "/non-existing-dir/traces.json"}
+	root, _ := newTestTraceSpans()
+	err := exporter.export("terraform-provider-cdn", []*traceSpan{root})
+	assert.Contains(t, err.Error(), "failed to open the tracing file '/non-existing-dir/traces.json'")
+}
+
+func TestTraceExporterOTLPExport(t *testing.T) {
+	var body []byte
+	var path string
+	var headers http.Header
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path = req.URL.Path
+		headers = req.Header
+		body, _ = ioutil.ReadAll(req.Body)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	root, child := newTestTraceSpans()
+	exporter := traceExporterOTLP{endpoint: api.URL, headers: map[string]string{"Authorization": "Bearer token"}}
+	require.NoError(t, exporter.export("terraform-provider-cdn", []*traceSpan{child, root}))
+	assert.Equal(t, otlpTracesPath, path)
+	assert.Equal(t, otlpProtobufMediaType, headers.Get(contentType))
+	assert.Equal(t, "Bearer token", headers.Get("Authorization"))
+
+	request, err := decodeProtobufTestMessage(body)
+	require.NoError(t, err)
+	resourceSpans, err := decodeProtobufTestMessage(request[1][0].bytes)
+	require.NoError(t, err)
+	scopeSpans, err := decodeProtobufTestMessage(resourceSpans[2][0].bytes)
+	require.NoError(t, err)
+	require.Len(t, scopeSpans[2], 2)
+
+	span, err := decodeProtobufTestMessage(scopeSpans[2][0].bytes)
+	require.NoError(t, err)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", hex.EncodeToString(span[1][0].bytes))
+	assert.Equal(t, "0202020202020202", hex.EncodeToString(span[2][0].bytes))
+	assert.Equal(t, "0101010101010101", hex.EncodeToString(span[4][0].bytes))
+	assert.Equal(t, "HTTP POST", string(span[5][0].bytes))
+	assert.Equal(t, uint64(traceSpanKindClient), span[6][0].varint)
+	assert.Equal(t, uint64(child.startTime.UnixNano()), span[7][0].fixed64)
+	assert.Equal(t, uint64(child.endTime.UnixNano()), span[8][0].fixed64)
+	require.Len(t, span[9], 1)
+	status, err := decodeProtobufTestMessage(span[15][0].bytes)
+	require.NoError(t, err)
+	assert.Equal(t, "response status code 500", string(status[2][0].bytes))
+	assert.Equal(t, uint64(otlpStatusCodeError), status[3][0].varint)
+
+	rootSpan, err := decodeProtobufTestMessage(scopeSpans[2][1].bytes)
+	require.NoError(t, err)
+	assert.NotContains(t, rootSpan, 4, "root spans should not contain a parent span id")
+	rootStatus, err := decodeProtobufTestMessage(rootSpan[15][0].bytes)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(otlpStatusCodeOk), rootStatus[3][0].varint)
+}
+
+func TestTraceExporterOTLPExportFailure(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+	}))
+	defer api.Close()
+
+	root, _ := newTestTraceSpans()
+	exporter := traceExporterOTLP{endpoint: api.URL + "/v1/traces/"}
+	err := exporter.export("terraform-provider-cdn", []*traceSpan{root})
+	assert.EqualError(t, err, "response returned from POST '"+api.URL+"/v1/traces' returned a non expected status code 400")
+}
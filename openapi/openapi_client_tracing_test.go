@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// traceExporterStub is a trace exporter that records the spans exported
+type traceExporterStub struct {
+	mutex       sync.Mutex
+	serviceName string
+	exports     [][]*traceSpan
+	err         error
+}
+
+func (e *traceExporterStub) export(serviceName string, spans []*traceSpan) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.serviceName = serviceName
+	e.exports = append(e.exports, spans)
+	return e.err
+}
+
+func (e *traceExporterStub) getExports() [][]*traceSpan {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.exports
+}
+
+func TestTracerExportsSpansWhenRootSpanEnds(t *testing.T) {
+	exporter := &traceExporterStub{}
+	tracer := newTracer("cdn", exporter)
+
+	root := tracer.startSpan("create cdn_v1", traceSpanKindInternal)
+	child := root.startChild("HTTP POST", traceSpanKindClient, otlpAttribute{key: "http.method", value: "POST"})
+	grandChild := child.startChild("auth", traceSpanKindInternal)
+	grandChild.end(nil)
+	child.setAttribute("http.status_code", int64(201))
+	child.end(nil)
+	tracer.shutdown(time.Second)
+	assert.Empty(t, exporter.getExports(), "spans should not be exported until the root span ends")
+
+	root.end(errors.New("some error"))
+	tracer.shutdown(time.Second)
+
+	exports := exporter.getExports()
+	require.Len(t, exports, 1)
+	assert.Equal(t, "terraform-provider-cdn", exporter.serviceName)
+	assert.Equal(t, []*traceSpan{grandChild, child, root}, exports[0])
+	assert.Equal(t, root.traceID, child.traceID)
+	assert.Equal(t, root.traceID, grandChild.traceID)
+	assert.Equal(t, root.spanID, child.parentSpanID)
+	assert.Equal(t, child.spanID, grandChild.parentSpanID)
+	assert.True(t, root.isRoot())
+	assert.False(t, child.isRoot())
+	assert.EqualError(t, root.err, "some error")
+	assert.Equal(t, []otlpAttribute{{key: "http.method", value: "POST"}, {key: "http.status_code", value: int64(201)}}, child.attributes)
+	assert.Empty(t, tracer.finished)
+}
+
+func TestTraceSpanTraceparent(t *testing.T) {
+	tracer := newTracer("cdn", &traceExporterStub{})
+	span := tracer.startSpan("create cdn_v1", traceSpanKindInternal)
+	assert.Len(t, span.traceID, 16)
+	assert.Len(t, span.spanID, 8)
+	assert.Regexp(t, regexp.MustCompile("^00-[0-9a-f]{32}-[0-9a-f]{16}-01$"), span.traceparent())
+}
+
+func TestTraceSpanNil(t *testing.T) {
+	var span *traceSpan
+	assert.Nil(t, span.startChild("child", traceSpanKindInternal))
+	assert.Equal(t, "", span.traceparent())
+	assert.NotPanics(t, func() {
+		span.setAttribute("key", "value")
+		span.end(nil)
+	})
+}
+
+func TestStartClientSpan(t *testing.T) {
+	t.Run("client not supporting tracing", func(t *testing.T) {
+		client := &clientOpenAPIStub{}
+		tracedClient, span := startClientSpan(client, "create cdn_v1")
+		assert.Equal(t, client, tracedClient)
+		assert.Nil(t, span)
+	})
+	t.Run("provider client without tracing configured", func(t *testing.T) {
+		client := &ProviderClient{}
+		tracedClient, span := startOperationSpan(client, TelemetryResourceOperationCreate, "cdn_v1")
+		assert.Equal(t, client, tracedClient)
+		assert.Nil(t, span)
+	})
+	t.Run("provider client with tracing configured", func(t *testing.T) {
+		client := &ProviderClient{tracer: newTracer("cdn", &traceExporterStub{})}
+		tracedClient, span := startOperationSpan(client, TelemetryResourceOperationCreate, "cdn_v1")
+		require.NotNil(t, span)
+		assert.Equal(t, "create cdn_v1", span.name)
+		assert.True(t, span.isRoot())
+		assert.Equal(t, []otlpAttribute{{key: "terraform.operation", value: "create"}, {key: "terraform.resource_name", value: "cdn_v1"}}, span.attributes)
+		assert.Equal(t, span, tracedClient.(*ProviderClient).traceSpan)
+		assert.Nil(t, client.traceSpan, "the original client should not be modified")
+
+		pollClient, pollSpan := startClientSpan(tracedClient, "poll resource")
+		assert.Equal(t, span.spanID, pollSpan.parentSpanID)
+		assert.Equal(t, pollSpan, pollClient.(*ProviderClient).traceSpan)
+	})
+}
+
+func TestPerformRequestWithTracing(t *testing.T) {
+	exporter := &traceExporterStub{}
+	httpClient := &http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	providerClient := &ProviderClient{
+		openAPIBackendConfiguration: &specStubBackendConfiguration{},
+		httpClient:                  httpClient,
+		apiAuthenticator:            newStubAuthenticator("Authorization", "Bearer secret", nil),
+		tracer:                      newTracer("cdn", exporter),
+	}
+	tracedClient, span := providerClient.startSpan("read cdn_v1")
+
+	_, err := tracedClient.(*ProviderClient).performRequest(nil, httpGet, "http://www.host.com/v1/cdns/1234?api_key=secret", &specResourceOperation{}, nil, nil, nil, nil)
+	require.NoError(t, err)
+	span.end(nil)
+	providerClient.tracer.shutdown(time.Second)
+
+	exports := exporter.getExports()
+	require.Len(t, exports, 1)
+	require.Len(t, exports[0], 3)
+	authSpan, httpSpan := exports[0][0], exports[0][1]
+	assert.Equal(t, "auth", authSpan.name)
+	assert.Equal(t, span.spanID, authSpan.parentSpanID)
+	assert.Equal(t, "HTTP GET", httpSpan.name)
+	assert.Equal(t, traceSpanKindClient, httpSpan.kind)
+	assert.Equal(t, span.spanID, httpSpan.parentSpanID)
+	assert.Equal(t, []otlpAttribute{{key: "http.method", value: "GET"}, {key: "http.url", value: "http://www.host.com/v1/cdns/1234"}, {key: "http.status_code", value: int64(404)}}, httpSpan.attributes)
+	assert.EqualError(t, httpSpan.err, "response status code 404")
+	assert.Equal(t, httpSpan.traceparent(), httpClient.Headers[traceparentHeader])
+}
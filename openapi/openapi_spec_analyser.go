@@ -46,11 +46,18 @@ const (
 // Currently only OpenAPI v2 version is supported but this constructor is ready to handle new implementations such as v3
 // when the time comes
 func CreateSpecAnalyser(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string) (SpecAnalyser, error) {
+	return CreateSpecAnalyserWithOverlays(specAnalyserVersion, openAPIDocumentURL, nil)
+}
+
+// CreateSpecAnalyserWithOverlays is a factory method that returns the appropriate implementation of SpecAnalyser
+// depending upon the openApiSpecAnalyserVersion passed in. The overlays (JSON Patch or OpenAPI Overlay documents) are
+// applied in order to the OpenAPI document before it is analysed.
+func CreateSpecAnalyserWithOverlays(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string, overlays []string) (SpecAnalyser, error) {
 	var err error
 	var specAnalyser SpecAnalyser
 	switch specAnalyserVersion {
 	case specAnalyserV2:
-		specAnalyser, err = newSpecAnalyserV2(openAPIDocumentURL)
+		specAnalyser, err = newSpecAnalyserV2(openAPIDocumentURL, overlays)
 	default:
 		return nil, fmt.Errorf("open api spec analyser version '%s' not supported, please choose a valid SpecAnalyser implementation [%s]", specAnalyserVersion, specAnalyserV2)
 	}
@@ -0,0 +1,342 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+)
+
+// specAnalyserComposite is a SpecAnalyser that merges the resources, data sources, security definitions and headers of
+// multiple swagger docs so they can be exposed as a single provider (eg: an API split across several microservices, each
+// of them publishing its own swagger doc). The swagger docs are analysed when the composite is created so the conflicts
+// between them (name collisions, conflicting security definitions or headers) are reported at startup.
+type specAnalyserComposite struct {
+	resources            []SpecResource
+	dataSources          []SpecResource
+	security             specSecurityComposite
+	headers              SpecHeaderParameters
+	backendConfiguration SpecBackendConfiguration
+}
+
+// CreateCompositeSpecAnalyser is a factory method that returns a SpecAnalyser composed of the given swagger docs. The
+// swagger docs are analysed with the SpecAnalyser implementation matching the specAnalyserVersion passed in.
+func CreateCompositeSpecAnalyser(specAnalyserVersion SpecAnalyserVersion, swaggerSpecs []SwaggerSpecConfigV1) (SpecAnalyser, error) {
+	var specAnalysers []SpecAnalyser
+	for _, swaggerSpec := range swaggerSpecs {
+		log.Printf("[INFO] analysing swagger doc '%s' (name prefix: '%s', host: '%s')", swaggerSpec.URL, swaggerSpec.NamePrefix, swaggerSpec.Host)
+		specAnalyser, err := CreateSpecAnalyserWithOverlays(specAnalyserVersion, swaggerSpec.URL, swaggerSpec.Overlays)
+		if err != nil {
+			return nil, err
+		}
+		specAnalysers = append(specAnalysers, specAnalyser)
+	}
+	return newSpecAnalyserComposite(swaggerSpecs, specAnalysers)
+}
+
+// newSpecAnalyserComposite merges the given spec analysers, where each spec analyser corresponds to the swagger doc
+// configured in the same position of swaggerSpecs. The first swagger doc is the primary one: its backend configuration
+// (scheme, regions, rate limit) applies to the provider as a whole, and its host is the one used by default.
+func newSpecAnalyserComposite(swaggerSpecs []SwaggerSpecConfigV1, specAnalysers []SpecAnalyser) (*specAnalyserComposite, error) {
+	if len(specAnalysers) == 0 || len(specAnalysers) != len(swaggerSpecs) {
+		return nil, fmt.Errorf("composite spec analyser requires one spec analyser per swagger doc")
+	}
+	composite := &specAnalyserComposite{}
+
+	backendConfigurations, err := composite.mergeBackendConfigurations(swaggerSpecs, specAnalysers)
+	if err != nil {
+		return nil, err
+	}
+	globalSecurityRequirements, err := composite.mergeSecurity(swaggerSpecs, specAnalysers)
+	if err != nil {
+		return nil, err
+	}
+	if err := composite.mergeHeaders(swaggerSpecs, specAnalysers); err != nil {
+		return nil, err
+	}
+
+	resourceNames := map[string]string{}
+	dataSourceNames := map[string]string{}
+	for i, specAnalyser := range specAnalysers {
+		swaggerSpec := swaggerSpecs[i]
+		specResource := specResourceComposite{
+			namePrefix:           swaggerSpec.NamePrefix,
+			host:                 swaggerSpec.Host,
+			basePath:             backendConfigurations[i].getBasePath(),
+			securityRequirements: globalSecurityRequirements[i],
+		}
+		// the resources of the primary swagger doc fall back to the global host (which also supports multi-region)
+		if i > 0 {
+			if specResource.specHost, err = backendConfigurations[i].getHost(); err != nil {
+				return nil, fmt.Errorf("swagger '%s': %s", swaggerSpec.URL, err)
+			}
+		}
+
+		resources, err := specAnalyser.GetTerraformCompliantResources()
+		if err != nil {
+			return nil, fmt.Errorf("swagger '%s': %s", swaggerSpec.URL, err)
+		}
+		for _, resource := range resources {
+			r := specResource.wrap(resource)
+			if err := checkCompositeNameCollision(resourceNames, r, swaggerSpec.URL, "resource"); err != nil {
+				return nil, err
+			}
+			composite.resources = append(composite.resources, r)
+		}
+		for _, dataSource := range specAnalyser.GetTerraformCompliantDataSources() {
+			d := specResource.wrap(dataSource)
+			if err := checkCompositeNameCollision(dataSourceNames, d, swaggerSpec.URL, "data source"); err != nil {
+				return nil, err
+			}
+			composite.dataSources = append(composite.dataSources, d)
+		}
+	}
+	return composite, nil
+}
+
+// checkCompositeNameCollision returns an error if a resource with the same name has already been registered from a
+// different swagger doc. Duplicated names within the same swagger doc are handled by the provider factory as usual.
+func checkCompositeNameCollision(names map[string]string, resource SpecResource, swaggerURL, kind string) error {
+	if resource.shouldIgnoreResource() {
+		return nil
+	}
+	name := resource.getResourceName()
+	if registeredSwaggerURL, exists := names[name]; exists && registeredSwaggerURL != swaggerURL {
+		return fmt.Errorf("%s name '%s' from swagger '%s' collides with the %s exposed by swagger '%s', please configure a name_prefix for one of the swagger docs", kind, name, swaggerURL, kind, registeredSwaggerURL)
+	}
+	names[name] = swaggerURL
+	return nil
+}
+
+// mergeBackendConfigurations returns the backend configuration of each swagger doc and sets up the composite backend
+// configuration. All the swagger docs must use the same scheme and only the primary one can be multi-region.
+func (s *specAnalyserComposite) mergeBackendConfigurations(swaggerSpecs []SwaggerSpecConfigV1, specAnalysers []SpecAnalyser) ([]SpecBackendConfiguration, error) {
+	var backendConfigurations []SpecBackendConfiguration
+	var primaryScheme string
+	for i, specAnalyser := range specAnalysers {
+		backendConfiguration, err := specAnalyser.GetAPIBackendConfiguration()
+		if err != nil {
+			return nil, fmt.Errorf("swagger '%s': %s", swaggerSpecs[i].URL, err)
+		}
+		scheme, err := backendConfiguration.getHTTPScheme()
+		if err != nil {
+			return nil, fmt.Errorf("swagger '%s': %s", swaggerSpecs[i].URL, err)
+		}
+		if i == 0 {
+			primaryScheme = scheme
+		} else {
+			if scheme != primaryScheme {
+				return nil, fmt.Errorf("swagger '%s' scheme '%s' does not match the scheme '%s' of swagger '%s'", swaggerSpecs[i].URL, scheme, primaryScheme, swaggerSpecs[0].URL)
+			}
+			isMultiRegion, _, _, err := backendConfiguration.isMultiRegion()
+			if err != nil {
+				return nil, fmt.Errorf("swagger '%s': %s", swaggerSpecs[i].URL, err)
+			}
+			if isMultiRegion {
+				return nil, fmt.Errorf("swagger '%s' is multi-region, only the first swagger doc configured can be multi-region", swaggerSpecs[i].URL)
+			}
+		}
+		backendConfigurations = append(backendConfigurations, backendConfiguration)
+	}
+	s.backendConfiguration = specBackendConfigurationComposite{SpecBackendConfiguration: backendConfigurations[0]}
+	return backendConfigurations, nil
+}
+
+// mergeSecurity merges the security definitions of the swagger docs and returns the global security requirements of each
+// swagger doc. Security definitions with the same name must be identical in all the swagger docs, and different security
+// definitions can not share the same terraform configuration name.
+func (s *specAnalyserComposite) mergeSecurity(swaggerSpecs []SwaggerSpecConfigV1, specAnalysers []SpecAnalyser) ([]SpecSecurityRequirements, error) {
+	securityDefinitions := SpecSecurityDefinitions{}
+	securityDefinitionSwaggerURLs := map[string]string{}
+	var globalSecurityRequirements []SpecSecurityRequirements
+	for i, specAnalyser := range specAnalysers {
+		swaggerURL := swaggerSpecs[i].URL
+		specSecurityDefinitions, err := specAnalyser.GetSecurity().GetAPIKeySecurityDefinitions()
+		if err != nil {
+			return nil, fmt.Errorf("swagger '%s': %s", swaggerURL, err)
+		}
+		for _, securityDefinition := range *specSecurityDefinitions {
+			registered := securityDefinitions.findSecurityDefinitionFor(securityDefinition.getName())
+			if registered == nil {
+				for _, other := range securityDefinitions {
+					if other.getTerraformConfigurationName() == securityDefinition.getTerraformConfigurationName() {
+						return nil, fmt.Errorf("security definition '%s' from swagger '%s' conflicts with security definition '%s' from swagger '%s', both are configured with the provider property '%s'", securityDefinition.getName(), swaggerURL, other.getName(), securityDefinitionSwaggerURLs[other.getName()], securityDefinition.getTerraformConfigurationName())
+					}
+				}
+				securityDefinitions = append(securityDefinitions, securityDefinition)
+				securityDefinitionSwaggerURLs[securityDefinition.getName()] = swaggerURL
+				continue
+			}
+			if registered.getType() != securityDefinition.getType() ||
+				registered.getTerraformConfigurationName() != securityDefinition.getTerraformConfigurationName() ||
+				!reflect.DeepEqual(registered.getAPIKey(), securityDefinition.getAPIKey()) {
+				return nil, fmt.Errorf("security definition '%s' from swagger '%s' conflicts with the security definition with the same name from swagger '%s'", securityDefinition.getName(), swaggerURL, securityDefinitionSwaggerURLs[registered.getName()])
+			}
+		}
+		specGlobalSecurityRequirements, err := specAnalyser.GetSecurity().GetGlobalSecurityRequirements()
+		if err != nil {
+			return nil, fmt.Errorf("swagger '%s': %s", swaggerURL, err)
+		}
+		globalSecurityRequirements = append(globalSecurityRequirements, specGlobalSecurityRequirements)
+	}
+
+	// The global security requirements only apply to the provider as a whole if all the swagger docs share them; otherwise
+	// the resources are configured with the global security requirements of their own swagger doc (see specResourceComposite)
+	s.security = specSecurityComposite{securityDefinitions: securityDefinitions, globalSecurityRequirements: globalSecurityRequirements[0]}
+	for _, specGlobalSecurityRequirements := range globalSecurityRequirements[1:] {
+		if specGlobalSecurityRequirements.String() != globalSecurityRequirements[0].String() {
+			log.Printf("[INFO] swagger docs have different global security requirements, the security definitions will only be required by the resources that need them")
+			s.security.globalSecurityRequirements = SpecSecurityRequirements{}
+			break
+		}
+	}
+	return globalSecurityRequirements, nil
+}
+
+// mergeHeaders merges the headers of the swagger docs. Different headers can not share the same terraform configuration
+// name; a header is considered required if any of the swagger docs requires it.
+func (s *specAnalyserComposite) mergeHeaders(swaggerSpecs []SwaggerSpecConfigV1, specAnalysers []SpecAnalyser) error {
+	headerSwaggerURLs := map[string]string{}
+	for i, specAnalyser := range specAnalysers {
+		headers, err := specAnalyser.GetAllHeaderParameters()
+		if err != nil {
+			return fmt.Errorf("swagger '%s': %s", swaggerSpecs[i].URL, err)
+		}
+		for _, header := range headers {
+			terraformName := header.GetHeaderTerraformConfigurationName()
+			registered := false
+			for idx, h := range s.headers {
+				if h.GetHeaderTerraformConfigurationName() != terraformName {
+					continue
+				}
+				if h.Name != header.Name {
+					return fmt.Errorf("header '%s' from swagger '%s' conflicts with header '%s' from swagger '%s', both are configured with the provider property '%s'", header.Name, swaggerSpecs[i].URL, h.Name, headerSwaggerURLs[terraformName], terraformName)
+				}
+				s.headers[idx].IsRequired = h.IsRequired || header.IsRequired
+				registered = true
+			}
+			if !registered {
+				s.headers = append(s.headers, header)
+				headerSwaggerURLs[terraformName] = swaggerSpecs[i].URL
+			}
+		}
+	}
+	return nil
+}
+
+func (s *specAnalyserComposite) GetTerraformCompliantResources() ([]SpecResource, error) {
+	return s.resources, nil
+}
+
+func (s *specAnalyserComposite) GetTerraformCompliantDataSources() []SpecResource {
+	return s.dataSources
+}
+
+func (s *specAnalyserComposite) GetSecurity() SpecSecurity {
+	return s.security
+}
+
+func (s *specAnalyserComposite) GetAllHeaderParameters() (SpecHeaderParameters, error) {
+	return s.headers, nil
+}
+
+func (s *specAnalyserComposite) GetAPIBackendConfiguration() (SpecBackendConfiguration, error) {
+	return s.backendConfiguration, nil
+}
+
+// specSecurityComposite implements the SpecSecurity interface with the security merged from multiple swagger docs
+type specSecurityComposite struct {
+	securityDefinitions        SpecSecurityDefinitions
+	globalSecurityRequirements SpecSecurityRequirements
+}
+
+func (s specSecurityComposite) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
+	return &s.securityDefinitions, nil
+}
+
+func (s specSecurityComposite) GetGlobalSecurityRequirements() (SpecSecurityRequirements, error) {
+	return s.globalSecurityRequirements, nil
+}
+
+// specBackendConfigurationComposite is the backend configuration of the primary swagger doc with no base path, since the
+// base path of each swagger doc is already part of the path of their resources (see specResourceComposite)
+type specBackendConfigurationComposite struct {
+	SpecBackendConfiguration
+}
+
+func (s specBackendConfigurationComposite) getBasePath() string {
+	return ""
+}
+
+// specResourceComposite wraps a resource (or data source) exposed by one of the swagger docs of a composite spec analyser
+// applying the settings of the swagger doc it belongs to:
+// - the resource name is prefixed with the swagger name prefix (if configured)
+// - the resource path is prefixed with the swagger base path
+// - the API requests are sent to the swagger host override (if configured), otherwise to the resource host override or
+// the swagger host
+// - the operations with no security requirements are configured with the global security requirements of the swagger
+type specResourceComposite struct {
+	SpecResource
+	namePrefix string
+	// host is the host override configured for the swagger doc
+	host string
+	// specHost is the host of the swagger doc; empty for the primary swagger doc as the global host applies
+	specHost             string
+	basePath             string
+	securityRequirements SpecSecurityRequirements
+}
+
+func (s specResourceComposite) wrap(resource SpecResource) specResourceComposite {
+	s.SpecResource = resource
+	return s
+}
+
+func (s specResourceComposite) getResourceName() string {
+	if s.namePrefix == "" {
+		return s.SpecResource.getResourceName()
+	}
+	return fmt.Sprintf("%s_%s", s.namePrefix, s.SpecResource.getResourceName())
+}
+
+func (s specResourceComposite) getHost() (string, error) {
+	if s.host != "" {
+		return s.host, nil
+	}
+	resourceHost, err := s.SpecResource.getHost()
+	if err != nil || resourceHost != "" {
+		return resourceHost, err
+	}
+	return s.specHost, nil
+}
+
+func (s specResourceComposite) getResourcePath(parentIDs []string) (string, error) {
+	resourcePath, err := s.SpecResource.getResourcePath(parentIDs)
+	if err != nil {
+		return "", err
+	}
+	basePath := strings.Trim(s.basePath, "/")
+	if basePath == "" {
+		return resourcePath, nil
+	}
+	return fmt.Sprintf("/%s/%s", basePath, strings.TrimPrefix(resourcePath, "/")), nil
+}
+
+func (s specResourceComposite) getResourceOperations() specResourceOperations {
+	operations := s.SpecResource.getResourceOperations()
+	return specResourceOperations{
+		List:   s.withSecurityRequirements(operations.List),
+		Post:   s.withSecurityRequirements(operations.Post),
+		Get:    s.withSecurityRequirements(operations.Get),
+		Put:    s.withSecurityRequirements(operations.Put),
+		Delete: s.withSecurityRequirements(operations.Delete),
+	}
+}
+
+func (s specResourceComposite) withSecurityRequirements(operation *specResourceOperation) *specResourceOperation {
+	if operation == nil || len(operation.SecurityRequirements) != 0 || len(s.securityRequirements) == 0 {
+		return operation
+	}
+	o := *operation
+	o.SecurityRequirements = s.securityRequirements
+	return &o
+}
@@ -0,0 +1,200 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompositeSpecAnalyserStub(resources []SpecResource, securityDefinitions SpecSecurityDefinitions, globalSecurityRequirements SpecSecurityRequirements, headers SpecHeaderParameters, backendConfiguration SpecBackendConfiguration) *specAnalyserStub {
+	return &specAnalyserStub{
+		resources:            resources,
+		dataSources:          resources,
+		security:             &specSecurityStub{securityDefinitions: &securityDefinitions, globalSecurityRequirements: globalSecurityRequirements},
+		headers:              headers,
+		backendConfiguration: backendConfiguration,
+	}
+}
+
+func TestNewSpecAnalyserComposite(t *testing.T) {
+	apiKeyRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_auth"}}}
+	bearerRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "bearer_auth"}}}
+	operationRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_auth"}}}
+
+	users := newSpecStubResourceWithOperations("users", "/users", false, nil, &specResourceOperation{}, nil, &specResourceOperation{SecurityRequirements: operationRequirements}, nil)
+	billing := newSpecStubResourceWithOperations("invoices", "invoices", false, nil, &specResourceOperation{}, nil, nil, nil)
+	billingWithHost := newSpecStubResource("payments", "/payments", false, nil)
+	billingWithHost.host = "payments.billing-api.com"
+
+	swaggerSpecs := []SwaggerSpecConfigV1{
+		{URL: "https://users-api.com/swagger.json"},
+		{URL: "https://billing-api.com/swagger.json", NamePrefix: "billing"},
+	}
+	specAnalysers := []SpecAnalyser{
+		newCompositeSpecAnalyserStub([]SpecResource{users},
+			SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikey_auth", "Authorization")}, apiKeyRequirements,
+			SpecHeaderParameters{{Name: "X-Request-ID", IsRequired: false}},
+			newStubBackendConfiguration("users-api.com", "/api", "https")),
+		newCompositeSpecAnalyserStub([]SpecResource{billing, billingWithHost},
+			SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikey_auth", "Authorization"), newAPIKeyHeaderBearerSecurityDefinition("bearer_auth")}, bearerRequirements,
+			SpecHeaderParameters{{Name: "X-Request-ID", IsRequired: true}, {Name: "X-Billing-Account"}},
+			newStubBackendConfiguration("billing-api.com", "/v1/", "https")),
+	}
+
+	composite, err := newSpecAnalyserComposite(swaggerSpecs, specAnalysers)
+	require.NoError(t, err)
+
+	resources, err := composite.GetTerraformCompliantResources()
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+	require.Len(t, composite.GetTerraformCompliantDataSources(), 3)
+
+	testCases := []struct {
+		expectedName         string
+		expectedHost         string
+		expectedPath         string
+		expectedPostSecurity SpecSecurityRequirements
+	}{
+		{expectedName: "users", expectedHost: "", expectedPath: "/api/users", expectedPostSecurity: apiKeyRequirements},
+		{expectedName: "billing_invoices", expectedHost: "billing-api.com", expectedPath: "/v1/invoices", expectedPostSecurity: bearerRequirements},
+		{expectedName: "billing_payments", expectedHost: "payments.billing-api.com", expectedPath: "/v1/payments"},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.expectedName, func(t *testing.T) {
+			resource := resources[i]
+			assert.Equal(t, tc.expectedName, resource.getResourceName())
+			host, err := resource.getHost()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHost, host)
+			path, err := resource.getResourcePath(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPath, path)
+			if tc.expectedPostSecurity != nil {
+				assert.Equal(t, tc.expectedPostSecurity, resource.getResourceOperations().Post.SecurityRequirements)
+			}
+		})
+	}
+	assert.Equal(t, operationRequirements, resources[0].getResourceOperations().Get.SecurityRequirements, "operation security requirements should be kept")
+	assert.Empty(t, users.getResourceOperations().Post.SecurityRequirements, "the operations of the wrapped resource should not be modified")
+
+	securityDefinitions, err := composite.GetSecurity().GetAPIKeySecurityDefinitions()
+	require.NoError(t, err)
+	require.Len(t, *securityDefinitions, 2)
+	assert.Equal(t, "apikey_auth", (*securityDefinitions)[0].getName())
+	assert.Equal(t, "bearer_auth", (*securityDefinitions)[1].getName())
+	globalSecurityRequirements, err := composite.GetSecurity().GetGlobalSecurityRequirements()
+	require.NoError(t, err)
+	assert.Empty(t, globalSecurityRequirements, "the global security requirements differ between swagger docs so they should not apply to the provider")
+
+	headers, err := composite.GetAllHeaderParameters()
+	require.NoError(t, err)
+	assert.Equal(t, SpecHeaderParameters{{Name: "X-Request-ID", IsRequired: true}, {Name: "X-Billing-Account"}}, headers)
+
+	backendConfiguration, err := composite.GetAPIBackendConfiguration()
+	require.NoError(t, err)
+	host, err := backendConfiguration.getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "users-api.com", host)
+	assert.Equal(t, "", backendConfiguration.getBasePath())
+
+	providerClient := ProviderClient{openAPIBackendConfiguration: backendConfiguration}
+	for i, expectedURL := range []string{"https://users-api.com/api/users", "https://billing-api.com/v1/invoices", "https://payments.billing-api.com/v1/payments"} {
+		resourceURL, err := providerClient.getResourceURL(resources[i], nil)
+		require.NoError(t, err)
+		assert.Equal(t, expectedURL, resourceURL)
+	}
+}
+
+func TestNewSpecAnalyserCompositeSharedGlobalSecurity(t *testing.T) {
+	apiKeyRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_auth"}}}
+	securityDefinitions := SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikey_auth", "Authorization")}
+	composite, err := newSpecAnalyserComposite(
+		[]SwaggerSpecConfigV1{{URL: "https://users-api.com/swagger.json"}, {URL: "https://billing-api.com/swagger.json", Host: "billing.internal"}},
+		[]SpecAnalyser{
+			newCompositeSpecAnalyserStub([]SpecResource{newSpecStubResource("users", "/users", false, nil)}, securityDefinitions, apiKeyRequirements, nil, newStubBackendConfiguration("users-api.com", "", "https")),
+			newCompositeSpecAnalyserStub([]SpecResource{newSpecStubResource("invoices", "/invoices", false, nil)}, securityDefinitions, apiKeyRequirements, nil, newStubBackendConfiguration("billing-api.com", "", "https")),
+		})
+	require.NoError(t, err)
+	globalSecurityRequirements, err := composite.GetSecurity().GetGlobalSecurityRequirements()
+	require.NoError(t, err)
+	assert.Equal(t, apiKeyRequirements, globalSecurityRequirements)
+	host, err := composite.resources[1].getHost()
+	require.NoError(t, err)
+	assert.Equal(t, "billing.internal", host, "the swagger host override should take preference")
+}
+
+func TestNewSpecAnalyserCompositeErrors(t *testing.T) {
+	swaggerSpecs := []SwaggerSpecConfigV1{{URL: "https://users-api.com/swagger.json"}, {URL: "https://billing-api.com/swagger.json"}}
+	backendConfiguration := newStubBackendConfiguration("api.com", "", "https")
+	users := []SpecResource{newSpecStubResource("users", "/users", false, nil)}
+
+	testCases := []struct {
+		name          string
+		specAnalysers []SpecAnalyser
+		expectedError string
+	}{
+		{
+			name: "resource name collision",
+			specAnalysers: []SpecAnalyser{
+				newCompositeSpecAnalyserStub(users, nil, nil, nil, backendConfiguration),
+				newCompositeSpecAnalyserStub(users, nil, nil, nil, backendConfiguration),
+			},
+			expectedError: "resource name 'users' from swagger 'https://billing-api.com/swagger.json' collides with the resource exposed by swagger 'https://users-api.com/swagger.json', please configure a name_prefix for one of the swagger docs",
+		},
+		{
+			name: "data source name collision",
+			specAnalysers: []SpecAnalyser{
+				&specAnalyserStub{dataSources: users, security: &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}}, backendConfiguration: backendConfiguration},
+				&specAnalyserStub{dataSources: users, security: &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}}, backendConfiguration: backendConfiguration},
+			},
+			expectedError: "data source name 'users' from swagger 'https://billing-api.com/swagger.json' collides with the data source exposed by swagger 'https://users-api.com/swagger.json', please configure a name_prefix for one of the swagger docs",
+		},
+		{
+			name: "conflicting security definitions with the same name",
+			specAnalysers: []SpecAnalyser{
+				newCompositeSpecAnalyserStub(nil, SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikey_auth", "Authorization")}, nil, nil, backendConfiguration),
+				newCompositeSpecAnalyserStub(nil, SpecSecurityDefinitions{newAPIKeyQuerySecurityDefinition("apikey_auth", "token")}, nil, nil, backendConfiguration),
+			},
+			expectedError: "security definition 'apikey_auth' from swagger 'https://billing-api.com/swagger.json' conflicts with the security definition with the same name from swagger 'https://users-api.com/swagger.json'",
+		},
+		{
+			name: "security definitions with the same terraform name",
+			specAnalysers: []SpecAnalyser{
+				newCompositeSpecAnalyserStub(nil, SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikey_auth", "Authorization")}, nil, nil, backendConfiguration),
+				newCompositeSpecAnalyserStub(nil, SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikeyAuth", "Authorization")}, nil, nil, backendConfiguration),
+			},
+			expectedError: "security definition 'apikeyAuth' from swagger 'https://billing-api.com/swagger.json' conflicts with security definition 'apikey_auth' from swagger 'https://users-api.com/swagger.json', both are configured with the provider property 'apikey_auth'",
+		},
+		{
+			name: "headers with the same terraform name",
+			specAnalysers: []SpecAnalyser{
+				newCompositeSpecAnalyserStub(nil, nil, nil, SpecHeaderParameters{{Name: "X-Request-ID", TerraformName: "request_id"}}, backendConfiguration),
+				newCompositeSpecAnalyserStub(nil, nil, nil, SpecHeaderParameters{{Name: "Request-ID", TerraformName: "request_id"}}, backendConfiguration),
+			},
+			expectedError: "header 'Request-ID' from swagger 'https://billing-api.com/swagger.json' conflicts with header 'X-Request-ID' from swagger 'https://users-api.com/swagger.json', both are configured with the provider property 'request_id'",
+		},
+		{
+			name: "different schemes",
+			specAnalysers: []SpecAnalyser{
+				newCompositeSpecAnalyserStub(nil, nil, nil, nil, backendConfiguration),
+				newCompositeSpecAnalyserStub(nil, nil, nil, nil, newStubBackendConfiguration("api.com", "", "http")),
+			},
+			expectedError: "swagger 'https://billing-api.com/swagger.json' scheme 'http' does not match the scheme 'https' of swagger 'https://users-api.com/swagger.json'",
+		},
+		{
+			name: "multi-region secondary swagger",
+			specAnalysers: []SpecAnalyser{
+				newCompositeSpecAnalyserStub(nil, nil, nil, nil, backendConfiguration),
+				newCompositeSpecAnalyserStub(nil, nil, nil, nil, &specStubBackendConfiguration{host: "api.%s.com", httpScheme: "https", regions: []string{"rst1"}}),
+			},
+			expectedError: "swagger 'https://billing-api.com/swagger.json' is multi-region, only the first swagger doc configured can be multi-region",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newSpecAnalyserComposite(swaggerSpecs, tc.specAnalysers)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
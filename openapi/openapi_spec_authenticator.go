@@ -6,6 +6,7 @@ type authType byte
 const ( // iota is reset to 0
 	authTypeAPIKeyHeader authType = iota
 	authTypeAPIQuery
+	authTypeAPIKeyCookie
 )
 
 type specAuthenticator interface {
@@ -13,12 +14,17 @@ type specAuthenticator interface {
 	// any metadata that should be passed in to the request when making the http call to get a resource (e,g: new headers
 	// with authentication details like access tokens, url with a query token, etc).
 	// The following parameters describe the operationId for which the authentication is being prepared, the url of
-	// the resource, the operation security schemes and the provider config containing the actual values like tokens,
-	// special headers, etc for each security schemes
-	prepareAuth(url string, operationSecuritySchemes SpecSecuritySchemes, providerConfig providerConfiguration) (*authContext, error)
+	// the resource, the operation security requirements and the provider config containing the actual values like tokens,
+	// special headers, etc for each security schemes. The API requests performed by the authenticators (e,g: refresh
+	// token) are traced as children of the given span (nil if tracing is not enabled)
+	prepareAuth(url string, operationSecurityRequirements SpecSecurityRequirements, providerConfig providerConfiguration, span *traceSpan) (*authContext, error)
 }
 
 type authContext struct {
 	headers map[string]string
+	// cookies contains the cookies required for authentication (e,g: session api keys) keyed by cookie name
+	cookies map[string]string
 	url     string
+	// span is the span the API requests performed by the authenticators are traced under; nil if tracing is not enabled
+	span *traceSpan
 }
@@ -3,34 +3,34 @@ package openapi
 import (
 	"fmt"
 	"log"
+	"strings"
 )
 
 // apiAuth is an implementation of specAuthenticator encapsulating the general settings to be applied in case
 // an operation does not contain a security policy; otherwise the operation's security policies will be applied instead.
 type apiAuth struct {
-	globalSecuritySchemes *SpecSecuritySchemes
+	globalSecurityRequirements *SpecSecurityRequirements
 }
 
 // newAPIAuthenticator allows for the creation of a new authenticator
-func newAPIAuthenticator(globalSecuritySchemes *SpecSecuritySchemes) specAuthenticator {
+func newAPIAuthenticator(globalSecurityRequirements *SpecSecurityRequirements) specAuthenticator {
 	return apiAuth{
-		globalSecuritySchemes: globalSecuritySchemes,
+		globalSecurityRequirements: globalSecurityRequirements,
 	}
 }
 
-// Check if the operation contains any security policy. In the case where the operation contains multiple security
-// policies, the first one found in the list will be the one returned.
-// For more information about multiple api keys refer to https://swagger.io/docs/specification/authentication/api-keys/#multiple
-func (oa apiAuth) authRequired(url string, operationSecuritySchemes SpecSecuritySchemes) (bool, SpecSecuritySchemes) {
-	// TODO: check in the OpenAPI spec whether operation overrides global schemes or can complement global configuration?
-	if len(operationSecuritySchemes) != 0 {
-		log.Printf("operation security policies found for '%s' (overriding global security config if applicable). Selected the following based on order of appearance in the list %+v", url, operationSecuritySchemes)
-		return true, operationSecuritySchemes
+// Check if the operation contains any security requirement, in which case the operation's security requirements override
+// the global ones. The requirements returned are the alternatives (in order of preference) that can be used to authenticate
+// the request. For more information about multiple api keys refer to https://swagger.io/docs/specification/authentication/api-keys/#multiple
+func (oa apiAuth) authRequired(url string, operationSecurityRequirements SpecSecurityRequirements) (bool, SpecSecurityRequirements) {
+	if len(operationSecurityRequirements) != 0 {
+		log.Printf("operation security requirements found for '%s' (overriding global security config if applicable): %s", url, operationSecurityRequirements)
+		return true, operationSecurityRequirements
 	}
-	log.Printf("operation security schemes missing, falling back to global security schemes (if there's any)")
-	if oa.globalSecuritySchemes != nil && len(*oa.globalSecuritySchemes) != 0 {
-		log.Printf("the global configuration contains security schemes, selected the following based on order of appearance in the list %+v", oa.globalSecuritySchemes)
-		return true, *oa.globalSecuritySchemes
+	log.Printf("operation security requirements missing, falling back to global security requirements (if there's any)")
+	if oa.globalSecurityRequirements != nil && len(*oa.globalSecurityRequirements) != 0 {
+		log.Printf("the global configuration contains security requirements: %s", oa.globalSecurityRequirements)
+		return true, *oa.globalSecurityRequirements
 	}
 	return false, nil
 }
@@ -49,21 +49,64 @@ func (oa apiAuth) fetchRequiredAuthenticators(operationSecuritySchemes SpecSecur
 	return authenticators, nil
 }
 
-func (oa apiAuth) prepareAuth(url string, operationSecuritySchemes SpecSecuritySchemes, providerConfig providerConfiguration) (*authContext, error) {
+// fetchConfiguredAuthenticators returns the authenticators of the given security schemes if all of them are defined and
+// configured with a value in the provider configuration; otherwise an error is returned
+func (oa apiAuth) fetchConfiguredAuthenticators(securitySchemes SpecSecuritySchemes, providerConfig providerConfiguration) ([]specAPIKeyAuthenticator, error) {
+	authenticators, err := oa.fetchRequiredAuthenticators(securitySchemes, providerConfig)
+	if err != nil {
+		return nil, err
+	}
+	for _, authenticator := range authenticators {
+		if err := authenticator.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return authenticators, nil
+}
+
+// selectAuthenticators returns the authenticators of the first security requirement alternative that is fully configured
+// in the provider configuration. If none of the alternatives is satisfied, the error returned lists all of them
+func (oa apiAuth) selectAuthenticators(securityRequirements SpecSecurityRequirements, providerConfig providerConfiguration) ([]specAPIKeyAuthenticator, error) {
+	var errs []string
+	for _, securitySchemes := range securityRequirements {
+		authenticators, err := oa.fetchConfiguredAuthenticators(securitySchemes, providerConfig)
+		if err == nil {
+			log.Printf("[DEBUG] selected security requirement %s", securitySchemes)
+			return authenticators, nil
+		}
+		// if there are no alternatives the error is returned as is
+		if len(securityRequirements) == 1 {
+			return nil, err
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", securitySchemes, err))
+	}
+	return nil, fmt.Errorf("none of the security requirements (%s) are satisfied, please make sure all the security schemes of one of them are configured in the provider's terraform configuration: %s", securityRequirements, strings.Join(errs, "; "))
+}
+
+// getUnusableSecurityRequirements returns the security requirement alternatives that can not be used with the given
+// provider configuration, along with the reason why
+func (oa apiAuth) getUnusableSecurityRequirements(securityRequirements SpecSecurityRequirements, providerConfig providerConfiguration) []string {
+	var unusableSecurityRequirements []string
+	for _, securitySchemes := range securityRequirements {
+		if _, err := oa.fetchConfiguredAuthenticators(securitySchemes, providerConfig); err != nil {
+			unusableSecurityRequirements = append(unusableSecurityRequirements, fmt.Sprintf("%s: %s", securitySchemes, err))
+		}
+	}
+	return unusableSecurityRequirements
+}
+
+func (oa apiAuth) prepareAuth(url string, operationSecurityRequirements SpecSecurityRequirements, providerConfig providerConfiguration, span *traceSpan) (*authContext, error) {
 	authContext := &authContext{
 		headers: map[string]string{},
 		url:     url,
+		span:    span,
 	}
-	if required, requiredSecuritySchemes := oa.authRequired(url, operationSecuritySchemes); required {
-		authenticators, err := oa.fetchRequiredAuthenticators(requiredSecuritySchemes, providerConfig)
+	if required, requiredSecurityRequirements := oa.authRequired(url, operationSecurityRequirements); required {
+		authenticators, err := oa.selectAuthenticators(requiredSecurityRequirements, providerConfig)
 		if err != nil {
 			return authContext, err
 		}
 		for _, authenticator := range authenticators {
-			err := authenticator.validate()
-			if err != nil {
-				return authContext, err
-			}
 			if err := authenticator.prepareAuth(authContext); err != nil {
 				return authContext, err
 			}
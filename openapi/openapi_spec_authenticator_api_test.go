@@ -9,11 +9,11 @@ import (
 )
 
 func TestApiAuth(t *testing.T) {
-	Convey("Given a list of globalSecuritySchemes", t, func() {
-		globalSecuritySchemes := &SpecSecuritySchemes{}
+	Convey("Given a list of globalSecurityRequirements", t, func() {
+		globalSecurityRequirements := &SpecSecurityRequirements{}
 		Convey("When apiAuth method is constructed", func() {
 			apiAuth := &apiAuth{
-				globalSecuritySchemes: globalSecuritySchemes,
+				globalSecurityRequirements: globalSecurityRequirements,
 			}
 			Convey("Then the apiAuth should comply with specAuthenticator interface", func() {
 				var _ specAuthenticator = apiAuth
@@ -25,34 +25,36 @@ func TestApiAuth(t *testing.T) {
 func TestAuthRequired(t *testing.T) {
 	Convey("Given a provider configuration containing an 'apiKey' type security definition with name 'apikey_header_auth' and an operation that requires the 'apikey_auth' authentication", t, func() {
 		securityPolicyName := "apikey_header_auth"
-		operationSecuritySchemes := SpecSecuritySchemes{SpecSecurityScheme{Name: securityPolicyName}}
+		operationSecurityRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: securityPolicyName}}}
 		url := "https://www.host.com/v1/resource"
 		oa := apiAuth{
-			globalSecuritySchemes: &SpecSecuritySchemes{
-				SpecSecurityScheme{
-					Name: securityPolicyName,
+			globalSecurityRequirements: &SpecSecurityRequirements{
+				SpecSecuritySchemes{
+					SpecSecurityScheme{
+						Name: securityPolicyName,
+					},
 				},
 			},
 		}
 		Convey("When authRequired method is called", func() {
-			authRequired, operationSecurityPolicies := oa.authRequired(url, operationSecuritySchemes)
+			authRequired, operationSecurityPolicies := oa.authRequired(url, operationSecurityRequirements)
 			Convey("Then the value returned should be true", func() {
 				So(authRequired, ShouldBeTrue)
 			})
 			Convey("And the name of the security policy 'apikey_header_auth'", func() {
-				So(operationSecurityPolicies[0].Name, ShouldEqual, securityPolicyName)
+				So(operationSecurityPolicies[0][0].Name, ShouldEqual, securityPolicyName)
 			})
 		})
 	})
 
 	Convey("Given a provider configuration containing an 'apiKey' type security definition with name 'apikey_auth' and an operation that DOES NOT require any authentication", t, func() {
-		operationSecuritySchemes := SpecSecuritySchemes{}
+		operationSecurityRequirements := SpecSecurityRequirements{}
 		url := "https://www.host.com/v1/resource"
 		oa := apiAuth{
-			globalSecuritySchemes: &SpecSecuritySchemes{},
+			globalSecurityRequirements: &SpecSecurityRequirements{},
 		}
 		Convey("When authRequired method is called", func() {
-			authRequired, operationSecurityPolicies := oa.authRequired(url, operationSecuritySchemes)
+			authRequired, operationSecurityPolicies := oa.authRequired(url, operationSecurityRequirements)
 			Convey("Then the values returned should be false and the name of the security policy should be empty", func() {
 				So(authRequired, ShouldBeFalse)
 				So(operationSecurityPolicies, ShouldBeEmpty)
@@ -119,20 +121,20 @@ func TestFetchRequiredAuthenticators(t *testing.T) {
 
 func TestPrepareAuth(t *testing.T) {
 	testCases := []struct {
-		name                          string
-		apiAuthenticator              specAuthenticator
-		inputURL                      string
-		inputOperationSecuritySchemes SpecSecuritySchemes
-		inputProviderConfig           providerConfiguration
-		expectedHeaders               map[string]string
-		expectedURL                   string
-		expectedError                 error
+		name                               string
+		apiAuthenticator                   specAuthenticator
+		inputURL                           string
+		inputOperationSecurityRequirements SpecSecurityRequirements
+		inputProviderConfig                providerConfiguration
+		expectedHeaders                    map[string]string
+		expectedURL                        string
+		expectedError                      error
 	}{
 		{
-			name:                          "apiAuthenticator set up with no global security schemes and the operation contains a security scheme 'apikey_header_auth' of type apiKeyHeader that matches one defined in the provider configuration (which contains the value)",
-			apiAuthenticator:              newAPIAuthenticator(nil),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_header_auth"}},
+			name:                               "apiAuthenticator set up with no global security schemes and the operation contains a security scheme 'apikey_header_auth' of type apiKeyHeader that matches one defined in the provider configuration (which contains the value)",
+			apiAuthenticator:                   newAPIAuthenticator(nil),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_header_auth"}}},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"apikey_header_auth": apiKeyHeaderAuthenticator{
@@ -148,10 +150,10 @@ func TestPrepareAuth(t *testing.T) {
 			expectedError:   nil,
 		},
 		{
-			name:                          "apiAuthenticator set up with no global security schemes and the operation contains a security scheme 'apikey_query_auth' of type apiKeyQuery that matches one defined in the provider configuration (which contains the value)",
-			apiAuthenticator:              newAPIAuthenticator(nil),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_query_auth"}},
+			name:                               "apiAuthenticator set up with no global security schemes and the operation contains a security scheme 'apikey_query_auth' of type apiKeyQuery that matches one defined in the provider configuration (which contains the value)",
+			apiAuthenticator:                   newAPIAuthenticator(nil),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_query_auth"}}},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"apikey_query_auth": apiKeyQueryAuthenticator{
@@ -167,10 +169,10 @@ func TestPrepareAuth(t *testing.T) {
 			expectedError:   nil,
 		},
 		{
-			name:                          "apiAuthenticator set up with no global security schemes and the operation containing multiple mixed security schemes (apikey_header_auth and apikey_query_auth) that matches security definitions defined in the provider configuration (containing their value)",
-			apiAuthenticator:              newAPIAuthenticator(nil),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_header_auth"}, SpecSecurityScheme{Name: "apikey_query_auth"}},
+			name:                               "apiAuthenticator set up with no global security schemes and the operation containing multiple mixed security schemes (apikey_header_auth and apikey_query_auth) that matches security definitions defined in the provider configuration (containing their value)",
+			apiAuthenticator:                   newAPIAuthenticator(nil),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_header_auth"}, SpecSecurityScheme{Name: "apikey_query_auth"}}},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"apikey_header_auth": apiKeyHeaderAuthenticator{
@@ -192,10 +194,10 @@ func TestPrepareAuth(t *testing.T) {
 			expectedError:   nil,
 		},
 		{
-			name:                          "apiAuthenticator set up with no global security schemes and the operation containing multiple apiKey security schemes (api_key and app_id) that matches security definitions defined in the provider configuration (containing their value)",
-			apiAuthenticator:              newAPIAuthenticator(nil),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "app_id"}},
+			name:                               "apiAuthenticator set up with no global security schemes and the operation containing multiple apiKey security schemes (api_key and app_id) that matches security definitions defined in the provider configuration (containing their value)",
+			apiAuthenticator:                   newAPIAuthenticator(nil),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "app_id"}}},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					// provider config keys are always terraform name compliant - snake case
@@ -218,10 +220,10 @@ func TestPrepareAuth(t *testing.T) {
 			expectedError:   nil,
 		},
 		{
-			name:                          "apiAuthenticator set up with global security schemes that match security definitions defined in the provider configuration and the operation does not override the global security",
-			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{},
+			name:                               "apiAuthenticator set up with global security schemes that match security definitions defined in the provider configuration and the operation does not override the global security",
+			apiAuthenticator:                   newAPIAuthenticator(&SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}}),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"api_key": apiKeyHeaderAuthenticator{
@@ -237,10 +239,10 @@ func TestPrepareAuth(t *testing.T) {
 			expectedError:   nil,
 		},
 		{
-			name:                          "apiAuthenticator set up with global security schemes 'api_key' that match security definitions defined in the provider configuration and the operation overrides the global security schemes with (apiKeyOverride)",
-			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "apiKeyOverride"}},
+			name:                               "apiAuthenticator set up with global security schemes 'api_key' that match security definitions defined in the provider configuration and the operation overrides the global security schemes with (apiKeyOverride)",
+			apiAuthenticator:                   newAPIAuthenticator(&SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}}),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "apiKeyOverride"}}},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"api_key": apiKeyHeaderAuthenticator{
@@ -262,10 +264,10 @@ func TestPrepareAuth(t *testing.T) {
 			expectedError:   nil,
 		},
 		{
-			name:                          "apiAuthenticator set up with global security schemes 'apiKey' that are not defined in the provider configuration and the operation does not have any specific security scheme",
-			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}}),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{},
+			name:                               "apiAuthenticator set up with global security schemes 'apiKey' that are not defined in the provider configuration and the operation does not have any specific security scheme",
+			apiAuthenticator:                   newAPIAuthenticator(&SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}}}),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"api_key": apiKeyHeaderAuthenticator{
@@ -281,10 +283,10 @@ func TestPrepareAuth(t *testing.T) {
 			expectedError:   errors.New("operation's security policy '{not_defined_scheme}' is not defined, please make sure the swagger file contains a security definition named '{not_defined_scheme}' under the securityDefinitions section"),
 		},
 		{
-			name:                          "apiAuthenticator set up with no global security schemes and the operation having specific security scheme that are not defined in the provider configuration ",
-			apiAuthenticator:              newAPIAuthenticator(nil),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}},
+			name:                               "apiAuthenticator set up with no global security schemes and the operation having specific security scheme that are not defined in the provider configuration ",
+			apiAuthenticator:                   newAPIAuthenticator(nil),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}}},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"api_key": apiKeyHeaderAuthenticator{
@@ -300,10 +302,10 @@ func TestPrepareAuth(t *testing.T) {
 			expectedError:   errors.New("operation's security policy '{not_defined_scheme}' is not defined, please make sure the swagger file contains a security definition named '{not_defined_scheme}' under the securityDefinitions section"),
 		},
 		{
-			name:                          "apiAuthenticator set up with global security schemes 'api_key' that match security definitions defined in the provider configuration but it's missing the value",
-			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{},
+			name:                               "apiAuthenticator set up with global security schemes 'api_key' that match security definitions defined in the provider configuration but it's missing the value",
+			apiAuthenticator:                   newAPIAuthenticator(&SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}}),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"api_key": apiKeyHeaderAuthenticator{
@@ -320,10 +322,10 @@ func TestPrepareAuth(t *testing.T) {
 			expectedError:   errors.New("required security definition 'api_key' is missing the value. Please make sure the property 'api_key' is configured with a value in the provider's terraform configuration"),
 		},
 		{
-			name:                          "apiAuthenticator set up with no global security schemes and the operation has a security scheme that matches one security definition defined in the provider configuration but it's missing the value",
-			apiAuthenticator:              newAPIAuthenticator(nil),
-			inputURL:                      "https://www.host.com/v1/resource",
-			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}},
+			name:                               "apiAuthenticator set up with no global security schemes and the operation has a security scheme that matches one security definition defined in the provider configuration but it's missing the value",
+			apiAuthenticator:                   newAPIAuthenticator(nil),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}},
 			inputProviderConfig: providerConfiguration{
 				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
 					"api_key": apiKeyHeaderAuthenticator{
@@ -339,13 +341,113 @@ func TestPrepareAuth(t *testing.T) {
 			expectedURL:     "https://www.host.com/v1/resource",
 			expectedError:   errors.New("required security definition 'api_key' is missing the value. Please make sure the property 'api_key' is configured with a value in the provider's terraform configuration"),
 		},
+		{
+			name:             "apiAuthenticator set up with no global security schemes and the operation containing multiple security requirement alternatives where the first one is not configured in the provider configuration",
+			apiAuthenticator: newAPIAuthenticator(nil),
+			inputURL:         "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "app_id"}},
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_query_auth"}},
+			},
+			inputProviderConfig: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"api_key": apiKeyHeaderAuthenticator{
+						apiKey: apiKey{
+							name:  "X-API-KEY",
+							value: "superSecretKeyForApiKey",
+						},
+					},
+					"app_id": apiKeyHeaderAuthenticator{
+						apiKey: apiKey{
+							name:  "X-APP-ID",
+							value: "", // app_id is not configured so the first alternative can not be used
+						},
+						terraformConfigurationName: "app_id",
+					},
+					"apikey_query_auth": apiKeyQueryAuthenticator{
+						apiKey: apiKey{
+							name:  "someQueryParam",
+							value: "superSecretKeyInQuery",
+						},
+					},
+				},
+			},
+			expectedHeaders: map[string]string{},
+			expectedURL:     fmt.Sprintf("https://www.host.com/v1/resource?%s=%s", "someQueryParam", "superSecretKeyInQuery"),
+			expectedError:   nil,
+		},
+		{
+			name:                               "apiAuthenticator set up with global security requirement alternatives and none of them is satisfied by the provider configuration",
+			apiAuthenticator:                   newAPIAuthenticator(&SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}, SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}}}),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{},
+			inputProviderConfig: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"api_key": apiKeyHeaderAuthenticator{
+						apiKey: apiKey{
+							name:  "X-API-KEY",
+							value: "",
+						},
+						terraformConfigurationName: "api_key",
+					},
+				},
+			},
+			expectedHeaders: map[string]string{},
+			expectedURL:     "https://www.host.com/v1/resource",
+			expectedError:   errors.New("none of the security requirements ([api_key] OR [not_defined_scheme]) are satisfied, please make sure all the security schemes of one of them are configured in the provider's terraform configuration: [api_key]: required security definition 'api_key' is missing the value. Please make sure the property 'api_key' is configured with a value in the provider's terraform configuration; [not_defined_scheme]: operation's security policy '{not_defined_scheme}' is not defined, please make sure the swagger file contains a security definition named '{not_defined_scheme}' under the securityDefinitions section"),
+		},
+		{
+			name:                               "apiAuthenticator set up with global security requirement alternatives where one of them is empty (auth is optional) and the other one is not configured",
+			apiAuthenticator:                   newAPIAuthenticator(&SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}}, SpecSecuritySchemes{}}),
+			inputURL:                           "https://www.host.com/v1/resource",
+			inputOperationSecurityRequirements: SpecSecurityRequirements{},
+			inputProviderConfig: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{},
+			},
+			expectedHeaders: map[string]string{},
+			expectedURL:     "https://www.host.com/v1/resource",
+			expectedError:   nil,
+		},
 	}
 
 	for _, tc := range testCases {
-		authContext, err := tc.apiAuthenticator.prepareAuth(tc.inputURL, tc.inputOperationSecuritySchemes, tc.inputProviderConfig)
+		authContext, err := tc.apiAuthenticator.prepareAuth(tc.inputURL, tc.inputOperationSecurityRequirements, tc.inputProviderConfig, nil)
 		assert.Equal(t, tc.expectedError, err, tc.name)
 		assert.Equal(t, tc.expectedHeaders, authContext.headers, tc.name)
 		assert.Equal(t, tc.expectedURL, authContext.url, tc.name)
 	}
 
 }
+
+func TestGetUnusableSecurityRequirements(t *testing.T) {
+	Convey("Given a provider configuration containing the 'api_key' security definition with a value and the 'app_id' without value", t, func() {
+		providerConfig := providerConfiguration{
+			SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+				"api_key": apiKeyHeaderAuthenticator{
+					apiKey: apiKey{
+						name:  "X-API-KEY",
+						value: "superSecretKeyForApiKey",
+					},
+				},
+				"app_id": apiKeyHeaderAuthenticator{
+					apiKey: apiKey{
+						name:  "X-APP-ID",
+						value: "",
+					},
+					terraformConfigurationName: "app_id",
+				},
+			},
+		}
+		oa := apiAuth{}
+		Convey("When getUnusableSecurityRequirements method is called with security requirements alternatives", func() {
+			securityRequirements := SpecSecurityRequirements{
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}},
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "app_id"}},
+			}
+			unusableSecurityRequirements := oa.getUnusableSecurityRequirements(securityRequirements, providerConfig)
+			Convey("Then only the alternatives that can not be used should be returned along with the reason", func() {
+				So(unusableSecurityRequirements, ShouldResemble, []string{"[api_key AND app_id]: required security definition 'app_id' is missing the value. Please make sure the property 'app_id' is configured with a value in the provider's terraform configuration"})
+			})
+		})
+	})
+}
@@ -1,6 +1,6 @@
 package openapi
 
-// specAPIKeyAuthenticator defines the behaviour for api key type authenticators (e,g: header/query)
+// specAPIKeyAuthenticator defines the behaviour for api key type authenticators (e,g: header/query/cookie)
 type specAPIKeyAuthenticator interface {
 	getContext() interface{}
 	prepareAuth(*authContext) error
@@ -17,6 +17,8 @@ func createAPIKeyAuthenticator(secDef SpecSecurityDefinition, value string) spec
 		return newAPIKeyHeaderAuthenticator(secDef.getAPIKey().Name, secDef.buildValue(value), secDef.getTerraformConfigurationName())
 	case inQuery:
 		return newAPIKeyQueryAuthenticator(secDef.getAPIKey().Name, secDef.buildValue(value), secDef.getTerraformConfigurationName())
+	case inCookie:
+		return newAPIKeyCookieAuthenticator(secDef.getAPIKey().Name, secDef.buildValue(value), secDef.getTerraformConfigurationName())
 	}
 	return nil
 }
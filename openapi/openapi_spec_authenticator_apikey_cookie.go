@@ -0,0 +1,45 @@
+package openapi
+
+import "fmt"
+
+// Api Key Cookie Auth
+type apiKeyCookieAuthenticator struct {
+	terraformConfigurationName string
+	apiKey
+}
+
+func newAPIKeyCookieAuthenticator(name, value, terraformConfigurationName string) apiKeyCookieAuthenticator {
+	return apiKeyCookieAuthenticator{
+		terraformConfigurationName: terraformConfigurationName,
+		apiKey: apiKey{
+			name:  name,
+			value: value,
+		},
+	}
+}
+
+func (a apiKeyCookieAuthenticator) getContext() interface{} {
+	return a.apiKey
+}
+
+func (a apiKeyCookieAuthenticator) getType() authType {
+	return authTypeAPIKeyCookie
+}
+
+// prepareAuth adds the api key to the auth context cookies, which are merged with any other cookies into the Cookie
+// header of the request. The url remains the same
+func (a apiKeyCookieAuthenticator) prepareAuth(authContext *authContext) error {
+	apiKey := a.getContext().(apiKey)
+	if authContext.cookies == nil {
+		authContext.cookies = map[string]string{}
+	}
+	authContext.cookies[apiKey.name] = apiKey.value
+	return nil
+}
+
+func (a apiKeyCookieAuthenticator) validate() error {
+	if a.value == "" {
+		return fmt.Errorf("required security definition '%s' is missing the value. Please make sure the property '%s' is configured with a value in the provider's terraform configuration", a.terraformConfigurationName, a.terraformConfigurationName)
+	}
+	return nil
+}
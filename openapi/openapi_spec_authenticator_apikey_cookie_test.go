@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestApiKeyCookieAuthenticator(t *testing.T) {
+	Convey("Given a name and a value", t, func() {
+		name := ""
+		value := ""
+		Convey("When newAPIKeyCookieAuthenticator method is called", func() {
+			apiKeyCookieAuthenticator := newAPIKeyCookieAuthenticator(name, value, "session_auth")
+			Convey("Then the apiKeyCookieAuthenticator should comply with specAPIKeyAuthenticator interface", func() {
+				var _ specAPIKeyAuthenticator = apiKeyCookieAuthenticator
+			})
+		})
+	})
+}
+
+func TestApiKeyCookieAuthenticatorGetContext(t *testing.T) {
+	Convey("Given an apiKeyCookieAuthenticator", t, func() {
+		apiKeyCookieAuthenticator := newAPIKeyCookieAuthenticator("session_id", "value", "session_auth")
+		Convey("When getContext method is called", func() {
+			key := apiKeyCookieAuthenticator.getContext()
+			Convey("Then the key returned  should match the one the apiKeyCookieAuthenticator was set up with", func() {
+				So(key.(apiKey).name, ShouldEqual, apiKeyCookieAuthenticator.apiKey.name)
+				So(key.(apiKey).value, ShouldEqual, apiKeyCookieAuthenticator.apiKey.value)
+			})
+		})
+	})
+}
+
+func TestApiKeyCookieAuthenticatorGetType(t *testing.T) {
+	Convey("Given an apiKeyCookieAuthenticator", t, func() {
+		apiKeyCookieAuthenticator := newAPIKeyCookieAuthenticator("session_id", "value", "session_auth")
+		Convey("When getType method is called", func() {
+			authType := apiKeyCookieAuthenticator.getType()
+			Convey("Then the authType returned  should be api key cookie", func() {
+				So(authType, ShouldEqual, authTypeAPIKeyCookie)
+			})
+		})
+	})
+}
+
+func TestApiKeyCookieAuthenticatorPrepareAuth(t *testing.T) {
+	Convey("Given an apiKeyCookieAuthenticator", t, func() {
+		apiKeyCookieAuthenticator := newAPIKeyCookieAuthenticator("session_id", "value", "session_auth")
+		Convey("When prepareAuth method is called with a authContext", func() {
+			expectedURL := "http://www.backend.com"
+			ctx := &authContext{
+				headers: map[string]string{},
+				url:     expectedURL,
+			}
+			err := apiKeyCookieAuthenticator.prepareAuth(ctx)
+			Convey("Then the err returned  should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then the context cookies should contain the api key", func() {
+				So(ctx.cookies, ShouldResemble, map[string]string{"session_id": "value"})
+			})
+			Convey("And the context url and headers should remain the same", func() {
+				So(ctx.url, ShouldEqual, expectedURL)
+				So(ctx.headers, ShouldBeEmpty)
+			})
+		})
+		Convey("When prepareAuth method is called with a authContext that already contains other cookies", func() {
+			ctx := &authContext{
+				headers: map[string]string{},
+				cookies: map[string]string{"csrf_token": "token"},
+				url:     "http://www.backend.com",
+			}
+			err := apiKeyCookieAuthenticator.prepareAuth(ctx)
+			Convey("Then the err returned  should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then the context cookies should contain both cookies", func() {
+				So(ctx.cookies, ShouldResemble, map[string]string{"csrf_token": "token", "session_id": "value"})
+			})
+		})
+	})
+}
+
+func TestApiKeyCookieAuthenticatorValidate(t *testing.T) {
+	testCases := []struct {
+		name                      string
+		apiKeyCookieAuthenticator apiKeyCookieAuthenticator
+		expectedError             error
+	}{
+		{
+			name:                      "validate passes since api key value is populated",
+			apiKeyCookieAuthenticator: newAPIKeyCookieAuthenticator("session_id", "some session", "session_auth"),
+			expectedError:             nil,
+		},
+		{
+			name:                      "validate does not pass since api key value is NOT populated/empty",
+			apiKeyCookieAuthenticator: newAPIKeyCookieAuthenticator("session_id", "", "session_auth"),
+			expectedError:             errors.New("required security definition 'session_auth' is missing the value. Please make sure the property 'session_auth' is configured with a value in the provider's terraform configuration"),
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.apiKeyCookieAuthenticator.validate()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
@@ -30,6 +30,14 @@ func TestCreateAPIKeyAuthenticator(t *testing.T) {
 			expectedType:            authTypeAPIQuery,
 			expectedValidationError: nil,
 		},
+		{
+			name:                    "createAPIKeyAuthenticator is called with a valid specAPIKeyCookieSecurityDefinition and a value",
+			secDef:                  newAPIKeyCookieSecurityDefinition("cookie_auth", "session_id"),
+			value:                   "value",
+			expectedAuthType:        apiKeyCookieAuthenticator{},
+			expectedType:            authTypeAPIKeyCookie,
+			expectedValidationError: nil,
+		},
 		{
 			name:                    "createAPIKeyAuthenticator is called with a valid specAPIKeyHeaderRefreshTokenSecurityDefinition and a value",
 			secDef:                  newAPIKeyHeaderRefreshTokenSecurityDefinition("header_auth", authorizationHeader),
@@ -36,14 +36,20 @@ func (a apiRefreshTokenAuthenticator) getType() authType {
 }
 
 // prepareAuth will send a post request to the refreshTokenURL and get the access token from the response Authorization
-// header. Otherwise, it will fail.
-func (a apiRefreshTokenAuthenticator) prepareAuth(authContext *authContext) error {
+// header. Otherwise, it will fail. If tracing is enabled, the request is traced as a child of the auth context span
+func (a apiRefreshTokenAuthenticator) prepareAuth(authContext *authContext) (err error) {
 	apiKey := a.getContext().(apiKey)
 	headers := map[string]string{apiKey.name: apiKey.value}
+	span := authContext.span.startChild("HTTP POST refresh token", traceSpanKindClient, otlpAttribute{key: "http.method", value: http.MethodPost}, otlpAttribute{key: "http.url", value: a.refreshTokenURL})
+	defer func() { span.end(err) }()
+	if span != nil {
+		headers[traceparentHeader] = span.traceparent()
+	}
 	r, err := a.httpClient.PostJson(a.refreshTokenURL, headers, nil, nil)
 	if err != nil {
 		return err
 	}
+	span.setAttribute("http.status_code", int64(r.StatusCode))
 	if r.StatusCode != http.StatusOK && r.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("refresh token POST response '%s' status code '%d' not matching expected response status code [%d, %d]", a.refreshTokenURL, r.StatusCode, http.StatusOK, http.StatusNoContent)
 	}
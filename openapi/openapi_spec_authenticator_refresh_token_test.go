@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/dikhan/http_goclient"
 
@@ -40,6 +41,26 @@ func Test_ApiKeyRefreshTokenAuthenticator_Successfully_Prepares_Authorization(t
 		assert.Equal(t, accessTokenExpectedReturn, ctx.headers[authorizationHeader])
 	})
 
+	t.Run("happy path -- refresh token request is traced when the AuthContext contains a span", func(t *testing.T) {
+		exporter := &traceExporterStub{}
+		span := newTracer("cdn", exporter).startSpan("auth", traceSpanKindInternal)
+		httpClient := &http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusOK, Header: http.Header{authorizationHeader: []string{accessTokenExpectedReturn}}}}
+		refreshTokenAuthenticator := newAPIRefreshTokenAuthenticator("my_fancy_name", fakeRefreshToken, accessTokenFakeServer.URL, "my_fancy_name")
+		refreshTokenAuthenticator.httpClient = httpClient
+		ctx := &authContext{span: span}
+		err := refreshTokenAuthenticator.prepareAuth(ctx)
+
+		assert.NoError(t, err)
+		span.end(nil)
+		span.tracer.shutdown(time.Second)
+		exports := exporter.getExports()
+		assert.Len(t, exports, 1)
+		assert.Len(t, exports[0], 2)
+		refreshTokenSpan := exports[0][0]
+		assert.Equal(t, "HTTP POST refresh token", refreshTokenSpan.name)
+		assert.Equal(t, span.spanID, refreshTokenSpan.parentSpanID)
+		assert.Equal(t, refreshTokenSpan.traceparent(), httpClient.Headers[traceparentHeader])
+	})
 }
 
 func Test_ApiKeyRefreshTokenAuthenticator_Fails_To_Prepare_Authorization(t *testing.T) {
@@ -8,4 +8,6 @@ type SpecBackendConfiguration interface {
 	getHostByRegion(region string) (string, error)
 	isMultiRegion() (bool, string, []string, error)
 	getDefaultRegion([]string) (string, error)
+	// getRateLimit returns the client side rate limit configured in the OpenAPI document; nil if not configured
+	getRateLimit() (*RateLimitConfig, error)
 }
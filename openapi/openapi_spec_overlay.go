@@ -0,0 +1,584 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/swag"
+	"gopkg.in/yaml.v2"
+)
+
+// GetEffectiveSwaggerDocument returns the swagger document located at openAPIDocumentURL (either a URL or a path to a
+// file stored in the disk) after applying the given overlays in order. The document is returned as indented JSON with the
+// object keys sorted, so the output is deterministic.
+func GetEffectiveSwaggerDocument(openAPIDocumentURL string, overlays []string) ([]byte, error) {
+	document, err := loadSwaggerDocument(openAPIDocumentURL, overlays)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, document, "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteString("\n")
+	return out.Bytes(), nil
+}
+
+// GetServiceEffectiveSwaggerDocument returns the effective swagger document (see GetEffectiveSwaggerDocument) of the given
+// provider, using the swagger URL and the overlays of its plugin configuration. The additional overlays are applied after
+// the configured ones. When the service is composed of several swagger documents (swagger-urls) the swaggerURL selects the
+// document returned, otherwise it can be empty
+func GetServiceEffectiveSwaggerDocument(providerName, swaggerURL string, additionalOverlays []string) ([]byte, error) {
+	serviceConfiguration, err := getServiceConfiguration(providerName)
+	if err != nil {
+		return nil, err
+	}
+	openAPIDocumentURL, overlays, err := getServiceSwaggerDocumentOverlays(providerName, serviceConfiguration, swaggerURL)
+	if err != nil {
+		return nil, err
+	}
+	return GetEffectiveSwaggerDocument(openAPIDocumentURL, append(overlays, additionalOverlays...))
+}
+
+// getServiceSwaggerDocumentOverlays returns the URL and the overlays of the swagger document of the service configuration
+// selected by swaggerURL (empty selects the swagger-url of the service)
+func getServiceSwaggerDocumentOverlays(providerName string, serviceConfiguration ServiceConfiguration, swaggerURL string) (string, []string, error) {
+	swaggerSpecs := serviceConfiguration.GetSwaggerSpecs()
+	if len(swaggerSpecs) == 0 {
+		if swaggerURL != "" && swaggerURL != serviceConfiguration.GetSwaggerURL() {
+			return "", nil, fmt.Errorf("swagger url '%s' is not the one configured for provider '%s': %s", swaggerURL, providerName, serviceConfiguration.GetSwaggerURL())
+		}
+		return serviceConfiguration.GetSwaggerURL(), append([]string{}, serviceConfiguration.GetSwaggerOverlays()...), nil
+	}
+	var swaggerURLs []string
+	for _, swaggerSpec := range swaggerSpecs {
+		if swaggerSpec.URL == swaggerURL {
+			return swaggerSpec.URL, append([]string{}, swaggerSpec.Overlays...), nil
+		}
+		swaggerURLs = append(swaggerURLs, swaggerSpec.URL)
+	}
+	return "", nil, fmt.Errorf("provider '%s' is composed of several swagger documents, the swagger url must be one of: %s", providerName, strings.Join(swaggerURLs, ", "))
+}
+
+// loadSwaggerDocument retrieves the swagger document (JSON or YAML) and applies the overlays in order. Each overlay can
+// either be a JSON Patch document (RFC 6902) or an OpenAPI Overlay document; both formats can be written in JSON or YAML.
+// The resulting document is returned in JSON.
+func loadSwaggerDocument(openAPIDocumentURL string, overlays []string) (json.RawMessage, error) {
+	data, err := swag.LoadFromFileOrHTTP(openAPIDocumentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	document, err := parseJSONDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	for _, overlay := range overlays {
+		log.Printf("[INFO] applying overlay '%s' to the OpenAPI document '%s'", overlay, openAPIDocumentURL)
+		document, err = applyOverlay(document, overlay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply overlay '%s' to the OpenAPI document '%s' - error = %s", overlay, openAPIDocumentURL, err)
+		}
+	}
+	return json.Marshal(document)
+}
+
+// parseJSONDocument parses JSON or YAML content into its generic JSON representation: map[string]interface{},
+// []interface{}, string, json.Number, bool or nil. The numbers are kept as json.Number so they are not altered when the
+// document is encoded again.
+func parseJSONDocument(data []byte) (interface{}, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] != '{' && trimmed[0] != '[' {
+		var yamlDocument interface{}
+		if err := yaml.Unmarshal(trimmed, &yamlDocument); err != nil {
+			return nil, err
+		}
+		jsonData, err := json.Marshal(toJSONPathCompatible(yamlDocument))
+		if err != nil {
+			return nil, err
+		}
+		trimmed = jsonData
+	}
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// applyOverlay applies the overlay document located at overlayURL to the given document. JSON Patch documents are
+// identified by being a list of operations, and OpenAPI Overlay documents by containing the 'overlay' property.
+func applyOverlay(document interface{}, overlayURL string) (interface{}, error) {
+	data, err := swag.LoadFromFileOrHTTP(overlayURL)
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := parseJSONDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse overlay: %s", err)
+	}
+	switch o := overlay.(type) {
+	case []interface{}:
+		return applyJSONPatch(document, o)
+	case map[string]interface{}:
+		if _, ok := o["overlay"]; ok {
+			return applyOpenAPIOverlay(document, o)
+		}
+	}
+	return nil, fmt.Errorf("overlay is neither a JSON Patch document (list of operations) nor an OpenAPI Overlay document (missing 'overlay' property)")
+}
+
+// applyJSONPatch applies the JSON Patch (RFC 6902) operations to the document. The operations are applied in order and
+// if any of them fails the whole patch fails.
+func applyJSONPatch(document interface{}, operations []interface{}) (interface{}, error) {
+	for i, o := range operations {
+		operation, ok := o.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("JSON Patch operation %d must be an object", i)
+		}
+		op, _ := operation["op"].(string)
+		path, ok := operation["path"].(string)
+		if !ok {
+			return nil, fmt.Errorf("JSON Patch operation %d is missing the 'path' property", i)
+		}
+		value, hasValue := operation["value"]
+		from, hasFrom := operation["from"].(string)
+		var err error
+		switch op {
+		case "add", "replace", "test":
+			if !hasValue {
+				return nil, fmt.Errorf("JSON Patch operation %d (%s) is missing the 'value' property", i, op)
+			}
+		case "move", "copy":
+			if !hasFrom {
+				return nil, fmt.Errorf("JSON Patch operation %d (%s) is missing the 'from' property", i, op)
+			}
+		}
+		switch op {
+		case "add":
+			document, err = jsonPointerAdd(document, path, deepCopyJSON(value))
+		case "remove":
+			document, _, err = jsonPointerRemove(document, path)
+		case "replace":
+			if document, _, err = jsonPointerRemove(document, path); err == nil {
+				document, err = jsonPointerAdd(document, path, deepCopyJSON(value))
+			}
+		case "move":
+			if strings.HasPrefix(path, from+"/") {
+				return nil, fmt.Errorf("JSON Patch operation %d (move) can not move '%s' into one of its children", i, from)
+			}
+			var moved interface{}
+			if document, moved, err = jsonPointerRemove(document, from); err == nil {
+				document, err = jsonPointerAdd(document, path, moved)
+			}
+		case "copy":
+			var copied interface{}
+			if copied, err = jsonPointerGet(document, from); err == nil {
+				document, err = jsonPointerAdd(document, path, deepCopyJSON(copied))
+			}
+		case "test":
+			var current interface{}
+			if current, err = jsonPointerGet(document, path); err == nil && !reflect.DeepEqual(current, value) {
+				err = fmt.Errorf("value at '%s' does not match the expected value", path)
+			}
+		default:
+			return nil, fmt.Errorf("JSON Patch operation %d has an unsupported op '%s', supported ops are: add, remove, replace, move, copy, test", i, op)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("JSON Patch operation %d (%s) failed: %s", i, op, err)
+		}
+	}
+	return document, nil
+}
+
+// parseJSONPointer returns the reference tokens of the JSON Pointer (RFC 6901)
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON Pointer '%s' must start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+func jsonPointerGet(document interface{}, pointer string) (interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := document
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path '%s' does not exist", pointer)
+			}
+			current = value
+		case []interface{}:
+			index, err := jsonPointerArrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, fmt.Errorf("path '%s' does not exist: %s", pointer, err)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("path '%s' does not exist", pointer)
+		}
+	}
+	return current, nil
+}
+
+// jsonPointerAdd adds the value at the location referenced by the pointer, returning the resulting document. Objects
+// members are added or replaced, and array elements are inserted at the index ('-' appends the value to the array).
+func jsonPointerAdd(document interface{}, pointer string, value interface{}) (interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return jsonPointerUpdate(document, pointer, tokens, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			if token == "-" {
+				return append(node, value), nil
+			}
+			index, err := jsonPointerArrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+		}
+		return nil, fmt.Errorf("parent of path '%s' is not an object or array", pointer)
+	})
+}
+
+// jsonPointerRemove removes the value at the location referenced by the pointer, returning the resulting document and
+// the value removed
+func jsonPointerRemove(document interface{}, pointer string) (interface{}, interface{}, error) {
+	tokens, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("the whole document can not be removed")
+	}
+	var removed interface{}
+	document, err = jsonPointerUpdate(document, pointer, tokens, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path '%s' does not exist", pointer)
+			}
+			removed = value
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			index, err := jsonPointerArrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, fmt.Errorf("path '%s' does not exist: %s", pointer, err)
+			}
+			removed = node[index]
+			return append(node[:index:index], node[index+1:]...), nil
+		}
+		return nil, fmt.Errorf("path '%s' does not exist", pointer)
+	})
+	return document, removed, err
+}
+
+// jsonPointerUpdate walks the document down to the parent of the location referenced by the tokens and replaces the
+// parent with the result of the update function, which receives the parent and the last token
+func jsonPointerUpdate(node interface{}, pointer string, tokens []string, update func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return update(node, tokens[0])
+	}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[tokens[0]]
+		if !ok {
+			return nil, fmt.Errorf("path '%s' does not exist", pointer)
+		}
+		updated, err := jsonPointerUpdate(child, pointer, tokens[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		n[tokens[0]] = updated
+		return n, nil
+	case []interface{}:
+		index, err := jsonPointerArrayIndex(tokens[0], len(n)-1)
+		if err != nil {
+			return nil, fmt.Errorf("path '%s' does not exist: %s", pointer, err)
+		}
+		updated, err := jsonPointerUpdate(n[index], pointer, tokens[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		n[index] = updated
+		return n, nil
+	}
+	return nil, fmt.Errorf("path '%s' does not exist", pointer)
+}
+
+func jsonPointerArrayIndex(token string, maxIndex int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("'%s' is not a valid array index", token)
+	}
+	if index > maxIndex {
+		return 0, fmt.Errorf("array index %d out of bounds", index)
+	}
+	return index, nil
+}
+
+// overlayRemovedNode marks the nodes removed by an OpenAPI Overlay action until they are pruned from the document
+type overlayRemovedNode struct{}
+
+// applyOpenAPIOverlay applies the actions of the OpenAPI Overlay document in order. Each action selects the target nodes
+// with a JSONPath expression and either removes them ('remove: true') or updates them with the 'update' value: objects
+// are merged recursively with the update object, and arrays are concatenated with the update array (or the update value is
+// appended if it is not an array). The following subset of JSONPath is supported: the root ($), child members by name
+// (.name, ['name']), array elements by index ([0]) and wildcards (.*, [*]).
+func applyOpenAPIOverlay(document interface{}, overlay map[string]interface{}) (interface{}, error) {
+	actions, ok := overlay["actions"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("OpenAPI Overlay document is missing the 'actions' list")
+	}
+	for i, a := range actions {
+		action, ok := a.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("OpenAPI Overlay action %d must be an object", i)
+		}
+		target, ok := action["target"].(string)
+		if !ok {
+			return nil, fmt.Errorf("OpenAPI Overlay action %d is missing the 'target' property", i)
+		}
+		selector, err := parseJSONPath(target)
+		if err != nil {
+			return nil, fmt.Errorf("OpenAPI Overlay action %d target not valid: %s", i, err)
+		}
+		remove, _ := action["remove"].(bool)
+		update, hasUpdate := action["update"]
+		if !remove && !hasUpdate {
+			return nil, fmt.Errorf("OpenAPI Overlay action %d must contain either 'update' or 'remove: true'", i)
+		}
+
+		root := &jsonNodeRef{value: document}
+		root.set = func(v interface{}) { root.value = v }
+		nodes := selector.selectNodes(root)
+		if len(nodes) == 0 {
+			log.Printf("[WARN] OpenAPI Overlay action %d target '%s' did not match any node", i, target)
+			continue
+		}
+		for _, node := range nodes {
+			if remove {
+				if node == root {
+					return nil, fmt.Errorf("OpenAPI Overlay action %d can not remove the whole document", i)
+				}
+				node.set(overlayRemovedNode{})
+				continue
+			}
+			switch value := node.value.(type) {
+			case map[string]interface{}:
+				updateObject, ok := update.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("OpenAPI Overlay action %d update must be an object as target '%s' selects an object", i, target)
+				}
+				mergeJSONObjects(value, updateObject)
+			case []interface{}:
+				if updateArray, ok := update.([]interface{}); ok {
+					node.set(append(value, deepCopyJSON(updateArray).([]interface{})...))
+				} else {
+					node.set(append(value, deepCopyJSON(update)))
+				}
+			default:
+				return nil, fmt.Errorf("OpenAPI Overlay action %d target '%s' selects a value that is not an object or array", i, target)
+			}
+		}
+		document = pruneRemovedNodes(root.value)
+	}
+	return document, nil
+}
+
+// mergeJSONObjects merges the update object into the target object recursively; values other than objects are replaced
+func mergeJSONObjects(target, update map[string]interface{}) {
+	for key, updateValue := range update {
+		targetObject, targetIsObject := target[key].(map[string]interface{})
+		updateObject, updateIsObject := updateValue.(map[string]interface{})
+		if targetIsObject && updateIsObject {
+			mergeJSONObjects(targetObject, updateObject)
+			continue
+		}
+		target[key] = deepCopyJSON(updateValue)
+	}
+}
+
+func pruneRemovedNodes(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, value := range n {
+			if _, removed := value.(overlayRemovedNode); removed {
+				delete(n, key)
+				continue
+			}
+			n[key] = pruneRemovedNodes(value)
+		}
+	case []interface{}:
+		pruned := n[:0]
+		for _, value := range n {
+			if _, removed := value.(overlayRemovedNode); !removed {
+				pruned = append(pruned, pruneRemovedNodes(value))
+			}
+		}
+		return pruned
+	}
+	return node
+}
+
+func deepCopyJSON(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(n))
+		for key, value := range n {
+			c[key] = deepCopyJSON(value)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(n))
+		for i, value := range n {
+			c[i] = deepCopyJSON(value)
+		}
+		return c
+	}
+	return node
+}
+
+// jsonNodeRef references a node of the document along with the function that replaces it in its parent
+type jsonNodeRef struct {
+	value interface{}
+	set   func(interface{})
+}
+
+// jsonPathSegment is one of the steps of a JSONPath expression: a member name, an array index or a wildcard
+type jsonPathSegment struct {
+	name     string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+type jsonPathSelector []jsonPathSegment
+
+// parseJSONPath parses the supported subset of JSONPath: $, .name, ['name'], ["name"], [0], .* and [*]
+func parseJSONPath(expression string) (jsonPathSelector, error) {
+	if !strings.HasPrefix(expression, "$") {
+		return nil, fmt.Errorf("JSONPath '%s' must start with '$'", expression)
+	}
+	var selector jsonPathSelector
+	rest := expression[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			return nil, fmt.Errorf("JSONPath '%s' not supported: recursive descent (..) is not supported", expression)
+		case strings.HasPrefix(rest, ".*"):
+			selector = append(selector, jsonPathSegment{wildcard: true})
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("JSONPath '%s' not valid: empty member name", expression)
+			}
+			selector = append(selector, jsonPathSegment{name: rest[1 : end+1]})
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "[*]"):
+			selector = append(selector, jsonPathSegment{wildcard: true})
+			rest = rest[3:]
+		case strings.HasPrefix(rest, "['"), strings.HasPrefix(rest, `["`):
+			quote := rest[1]
+			end := strings.IndexByte(rest[2:], quote)
+			if end < 0 || !strings.HasPrefix(rest[2+end+1:], "]") {
+				return nil, fmt.Errorf("JSONPath '%s' not valid: missing closing quote or bracket", expression)
+			}
+			selector = append(selector, jsonPathSegment{name: rest[2 : 2+end]})
+			rest = rest[2+end+2:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath '%s' not valid: missing closing bracket", expression)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("JSONPath '%s' not supported: '%s' is not an array index, only names, indexes and wildcards are supported", expression, rest[:end+1])
+			}
+			selector = append(selector, jsonPathSegment{index: index, isIndex: true})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("JSONPath '%s' not valid: unexpected '%s'", expression, rest)
+		}
+	}
+	return selector, nil
+}
+
+// selectNodes returns the nodes matching the selector. The members selected by wildcards are sorted by name so the
+// result is deterministic
+func (s jsonPathSelector) selectNodes(root *jsonNodeRef) []*jsonNodeRef {
+	nodes := []*jsonNodeRef{root}
+	for _, segment := range s {
+		var selected []*jsonNodeRef
+		for _, node := range nodes {
+			switch value := node.value.(type) {
+			case map[string]interface{}:
+				var keys []string
+				if segment.wildcard {
+					for key := range value {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+				} else if _, ok := value[segment.name]; ok && !segment.isIndex {
+					keys = []string{segment.name}
+				}
+				for _, key := range keys {
+					key, object := key, value
+					selected = append(selected, &jsonNodeRef{value: object[key], set: func(v interface{}) { object[key] = v }})
+				}
+			case []interface{}:
+				var indexes []int
+				if segment.wildcard {
+					for i := range value {
+						indexes = append(indexes, i)
+					}
+				} else if segment.isIndex && segment.index < len(value) {
+					indexes = []int{segment.index}
+				}
+				for _, i := range indexes {
+					i, array := i, value
+					selected = append(selected, &jsonNodeRef{value: array[i], set: func(v interface{}) { array[i] = v }})
+				}
+			}
+		}
+		nodes = selected
+	}
+	return nodes
+}
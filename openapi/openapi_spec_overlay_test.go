@@ -0,0 +1,458 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const overlayTestSwagger = `swagger: "2.0"
+host: localhost:8443
+schemes:
+  - https
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+        - in: body
+          name: body
+          required: true
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          description: created
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: string
+      responses:
+        200:
+          description: ok
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+definitions:
+  ContentDeliveryNetwork:
+    type: object
+    required:
+      - label
+    properties:
+      uuid:
+        type: string
+        readOnly: true
+      label:
+        type: string
+      size:
+        type: integer
+        default: 10
+`
+
+func TestApplyJSONPatch(t *testing.T) {
+	document := func() interface{} {
+		d, err := parseJSONDocument([]byte(`{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]}}},"size":1}`))
+		require.NoError(t, err)
+		return d
+	}
+	testCases := []struct {
+		name          string
+		patch         string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "add member with escaped pointer",
+			patch:    `[{"op":"add","path":"/paths/~1v1~1cdns/post/x-terraform-resource-timeout","value":"30s"}]`,
+			expected: `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"],"x-terraform-resource-timeout":"30s"}}},"size":1}`,
+		},
+		{
+			name:     "add array elements",
+			patch:    `[{"op":"add","path":"/paths/~1v1~1cdns/post/tags/0","value":"first"},{"op":"add","path":"/paths/~1v1~1cdns/post/tags/-","value":"last"}]`,
+			expected: `{"paths":{"/v1/cdns":{"post":{"tags":["first","cdn","last"]}}},"size":1}`,
+		},
+		{
+			name:     "remove, replace, copy and move",
+			patch:    `[{"op":"copy","from":"/size","path":"/copied"},{"op":"replace","path":"/size","value":2},{"op":"move","from":"/paths/~1v1~1cdns/post/tags","path":"/tags"},{"op":"remove","path":"/tags/0"}]`,
+			expected: `{"copied":1,"paths":{"/v1/cdns":{"post":{}}},"size":2,"tags":[]}`,
+		},
+		{
+			name:     "successful test",
+			patch:    `[{"op":"test","path":"/size","value":1}]`,
+			expected: `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]}}},"size":1}`,
+		},
+		{
+			name:          "failed test",
+			patch:         `[{"op":"test","path":"/size","value":2}]`,
+			expectedError: "JSON Patch operation 0 (test) failed: value at '/size' does not match the expected value",
+		},
+		{
+			name:          "missing path",
+			patch:         `[{"op":"remove","path":"/missing"}]`,
+			expectedError: "JSON Patch operation 0 (remove) failed: path '/missing' does not exist",
+		},
+		{
+			name:          "array index out of bounds",
+			patch:         `[{"op":"replace","path":"/paths/~1v1~1cdns/post/tags/1","value":"other"}]`,
+			expectedError: "JSON Patch operation 0 (replace) failed: path '/paths/~1v1~1cdns/post/tags/1' does not exist: array index 1 out of bounds",
+		},
+		{
+			name:          "move into a child",
+			patch:         `[{"op":"move","from":"/paths","path":"/paths/other"}]`,
+			expectedError: "JSON Patch operation 0 (move) can not move '/paths' into one of its children",
+		},
+		{
+			name:          "unsupported op",
+			patch:         `[{"op":"merge","path":"/size"}]`,
+			expectedError: "JSON Patch operation 0 has an unsupported op 'merge', supported ops are: add, remove, replace, move, copy, test",
+		},
+		{
+			name:          "missing value",
+			patch:         `[{"op":"add","path":"/size"}]`,
+			expectedError: "JSON Patch operation 0 (add) is missing the 'value' property",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			operationsDocument, err := parseJSONDocument([]byte(tc.patch))
+			require.NoError(t, err)
+			result, err := applyJSONPatch(document(), operationsDocument.([]interface{}))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			out, err := json.Marshal(result)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(out))
+		})
+	}
+}
+
+func TestApplyOpenAPIOverlay(t *testing.T) {
+	document := `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]},"get":{}},"/v1/lbs":{"post":{"tags":["lb"]}}},"list":[{"id":1},{"id":2}]}`
+	testCases := []struct {
+		name          string
+		overlay       string
+		expected      string
+		expectedError string
+	}{
+		{
+			name: "update object selected by name",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $.paths['/v1/cdns'].post
+    update:
+      x-terraform-resource-name: cdn
+      tags: [other]`,
+			expected: `{"paths":{"/v1/cdns":{"post":{"tags":["other"],"x-terraform-resource-name":"cdn"},"get":{}},"/v1/lbs":{"post":{"tags":["lb"]}}},"list":[{"id":1},{"id":2}]}`,
+		},
+		{
+			name: "update objects selected by wildcard",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $.paths.*.post
+    update:
+      x-terraform-exclude-resource: true`,
+			expected: `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"],"x-terraform-exclude-resource":true},"get":{}},"/v1/lbs":{"post":{"tags":["lb"],"x-terraform-exclude-resource":true}}},"list":[{"id":1},{"id":2}]}`,
+		},
+		{
+			name: "update array",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $.paths["/v1/lbs"].post.tags
+    update: [network]
+  - target: $.list
+    update: {id: 3}`,
+			expected: `{"paths":{"/v1/cdns":{"post":{"tags":["cdn"]},"get":{}},"/v1/lbs":{"post":{"tags":["lb","network"]}}},"list":[{"id":1},{"id":2},{"id":3}]}`,
+		},
+		{
+			name: "remove nodes",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $.paths['/v1/cdns'].get
+    remove: true
+  - target: $.list[0]
+    remove: true
+  - target: $.paths.*.post.tags[*]
+    remove: true`,
+			expected: `{"paths":{"/v1/cdns":{"post":{"tags":[]}},"/v1/lbs":{"post":{"tags":[]}}},"list":[{"id":2}]}`,
+		},
+		{
+			name: "target not matching any node",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $.paths['/v1/missing'].post
+    update: {x-terraform-exclude-resource: true}`,
+			expected: document,
+		},
+		{
+			name: "remove the whole document",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $
+    remove: true`,
+			expectedError: "OpenAPI Overlay action 0 can not remove the whole document",
+		},
+		{
+			name: "update primitive value",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $.list[0].id
+    update: 5`,
+			expectedError: "OpenAPI Overlay action 0 target '$.list[0].id' selects a value that is not an object or array",
+		},
+		{
+			name: "unsupported JSONPath",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $..post
+    remove: true`,
+			expectedError: "OpenAPI Overlay action 0 target not valid: JSONPath '$..post' not supported: recursive descent (..) is not supported",
+		},
+		{
+			name: "JSONPath filter",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $.list[?(@.id == 1)]
+    remove: true`,
+			expectedError: "OpenAPI Overlay action 0 target not valid: JSONPath '$.list[?(@.id == 1)]' not supported: '[?(@.id == 1)]' is not an array index, only names, indexes and wildcards are supported",
+		},
+		{
+			name: "missing update and remove",
+			overlay: `overlay: 1.0.0
+actions:
+  - target: $.list`,
+			expectedError: "OpenAPI Overlay action 0 must contain either 'update' or 'remove: true'",
+		},
+		{
+			name:          "missing actions",
+			overlay:       `overlay: 1.0.0`,
+			expectedError: "OpenAPI Overlay document is missing the 'actions' list",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := parseJSONDocument([]byte(document))
+			require.NoError(t, err)
+			overlay, err := parseJSONDocument([]byte(tc.overlay))
+			require.NoError(t, err)
+			result, err := applyOpenAPIOverlay(d, overlay.(map[string]interface{}))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			out, err := json.Marshal(result)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(out))
+		})
+	}
+}
+
+func TestGetEffectiveSwaggerDocument(t *testing.T) {
+	swaggerFile := initAPISpecFile(overlayTestSwagger)
+	defer os.Remove(swaggerFile.Name())
+	jsonPatchFile := initAPISpecFile(`[{"op":"add","path":"/definitions/ContentDeliveryNetwork/properties/label/x-terraform-immutable","value":true}]`)
+	defer os.Remove(jsonPatchFile.Name())
+	overlayFile := initAPISpecFile(`overlay: 1.0.0
+info:
+  title: Terraform extensions
+  version: 1.0.0
+actions:
+  - target: $.paths['/v1/cdns'].post
+    update:
+      x-terraform-resource-timeout: 30s
+  - target: $.definitions.ContentDeliveryNetwork.properties.uuid
+    update:
+      x-terraform-id: true
+`)
+	defer os.Remove(overlayFile.Name())
+
+	document, err := GetEffectiveSwaggerDocument(swaggerFile.Name(), []string{jsonPatchFile.Name(), overlayFile.Name()})
+	require.NoError(t, err)
+	var effective map[string]interface{}
+	require.NoError(t, json.Unmarshal(document, &effective))
+	properties := effective["definitions"].(map[string]interface{})["ContentDeliveryNetwork"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, true, properties["label"].(map[string]interface{})["x-terraform-immutable"])
+	assert.Equal(t, true, properties["uuid"].(map[string]interface{})["x-terraform-id"])
+	assert.Equal(t, float64(10), properties["size"].(map[string]interface{})["default"])
+	assert.Equal(t, "30s", effective["paths"].(map[string]interface{})["/v1/cdns"].(map[string]interface{})["post"].(map[string]interface{})["x-terraform-resource-timeout"])
+
+	again, err := GetEffectiveSwaggerDocument(swaggerFile.Name(), []string{jsonPatchFile.Name(), overlayFile.Name()})
+	require.NoError(t, err)
+	assert.Equal(t, string(document), string(again), "the effective document should be deterministic")
+
+	invalidOverlayFile := initAPISpecFile(`actions: []`)
+	defer os.Remove(invalidOverlayFile.Name())
+	_, err = GetEffectiveSwaggerDocument(swaggerFile.Name(), []string{invalidOverlayFile.Name()})
+	assert.EqualError(t, err, "failed to apply overlay '"+invalidOverlayFile.Name()+"' to the OpenAPI document '"+swaggerFile.Name()+"' - error = overlay is neither a JSON Patch document (list of operations) nor an OpenAPI Overlay document (missing 'overlay' property)")
+}
+
+func TestNewSpecAnalyserV2WithOverlays(t *testing.T) {
+	swaggerFile := initAPISpecFile(overlayTestSwagger)
+	defer os.Remove(swaggerFile.Name())
+	idOverlayFile := initAPISpecFile(`[{"op":"add","path":"/definitions/ContentDeliveryNetwork/properties/uuid/x-terraform-id","value":true}]`)
+	defer os.Remove(idOverlayFile.Name())
+	excludeOverlayFile := initAPISpecFile(`overlay: 1.0.0
+actions:
+  - target: $.paths['/v1/cdns'].post
+    update:
+      x-terraform-exclude-resource: true
+`)
+	defer os.Remove(excludeOverlayFile.Name())
+
+	specAnalyser, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
+	require.NoError(t, err)
+	resources, err := specAnalyser.GetTerraformCompliantResources()
+	require.NoError(t, err)
+	assert.Empty(t, resources, "the resource schema is missing the id property")
+
+	specAnalyser, err = newSpecAnalyserV2(swaggerFile.Name(), []string{idOverlayFile.Name()})
+	require.NoError(t, err)
+	resources, err = specAnalyser.GetTerraformCompliantResources()
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.False(t, resources[0].shouldIgnoreResource())
+
+	specAnalyser, err = newSpecAnalyserV2(swaggerFile.Name(), []string{idOverlayFile.Name(), excludeOverlayFile.Name()})
+	require.NoError(t, err)
+	resources, err = specAnalyser.GetTerraformCompliantResources()
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.True(t, resources[0].shouldIgnoreResource(), "the overlay should have excluded the resource")
+}
+
+func TestNewSpecAnalyserV2WithOverlays_ExternalRefs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overlays")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	swaggerFile := filepath.Join(dir, "swagger.yaml")
+	swagger := strings.Replace(overlayTestSwagger, "#/definitions/ContentDeliveryNetwork", "definitions.yaml#/ContentDeliveryNetwork", -1)
+	require.NoError(t, ioutil.WriteFile(swaggerFile, []byte(swagger), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "definitions.yaml"), []byte(`ContentDeliveryNetwork:
+  type: object
+  properties:
+    uuid:
+      type: string
+      readOnly: true
+      x-terraform-id: true
+    label:
+      type: string
+`), 0644))
+	overlayFile := filepath.Join(dir, "overlay.yaml")
+	require.NoError(t, ioutil.WriteFile(overlayFile, []byte(`[{"op":"add","path":"/paths/~1v1~1cdns/post/x-terraform-resource-timeout","value":"30s"}]`), 0644))
+
+	specAnalyser, err := newSpecAnalyserV2(swaggerFile, []string{overlayFile})
+	require.NoError(t, err)
+	resources, err := specAnalyser.GetTerraformCompliantResources()
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	resourceSchema, err := resources[0].getResourceSchema()
+	require.NoError(t, err)
+	_, err = resourceSchema.getProperty("label")
+	assert.NoError(t, err, "the external ref should have been resolved relative to the swagger document")
+}
+
+func TestGetServiceSwaggerDocumentOverlays(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		serviceConfiguration ServiceConfiguration
+		swaggerURL           string
+		expectedURL          string
+		expectedOverlays     []string
+		expectedErr          string
+	}{
+		{
+			name:                 "service configured with a swagger url",
+			serviceConfiguration: &ServiceConfigV1{SwaggerURL: "swagger.yaml", Overlays: []string{"overlay.yaml"}},
+			expectedURL:          "swagger.yaml",
+			expectedOverlays:     []string{"overlay.yaml"},
+		},
+		{
+			name:                 "service configured with a swagger url and the same swagger url is selected",
+			serviceConfiguration: &ServiceConfigV1{SwaggerURL: "swagger.yaml", Overlays: []string{"overlay.yaml"}},
+			swaggerURL:           "swagger.yaml",
+			expectedURL:          "swagger.yaml",
+			expectedOverlays:     []string{"overlay.yaml"},
+		},
+		{
+			name:                 "service configured with a swagger url and a different swagger url is selected",
+			serviceConfiguration: &ServiceConfigV1{SwaggerURL: "swagger.yaml"},
+			swaggerURL:           "other.yaml",
+			expectedErr:          "swagger url 'other.yaml' is not the one configured for provider 'cdn': swagger.yaml",
+		},
+		{
+			name: "service configured with several swagger urls and one of them is selected",
+			serviceConfiguration: &ServiceConfigV1{SwaggerSpecs: []SwaggerSpecConfigV1{
+				{URL: "users.yaml"},
+				{URL: "billing.yaml", Overlays: []string{"billing-overlay.yaml"}},
+			}},
+			swaggerURL:       "billing.yaml",
+			expectedURL:      "billing.yaml",
+			expectedOverlays: []string{"billing-overlay.yaml"},
+		},
+		{
+			name: "service configured with several swagger urls and none of them is selected",
+			serviceConfiguration: &ServiceConfigV1{SwaggerSpecs: []SwaggerSpecConfigV1{
+				{URL: "users.yaml"},
+				{URL: "billing.yaml"},
+			}},
+			expectedErr: "provider 'cdn' is composed of several swagger documents, the swagger url must be one of: users.yaml, billing.yaml",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url, overlays, err := getServiceSwaggerDocumentOverlays("cdn", tc.serviceConfiguration, tc.swaggerURL)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedURL, url)
+			assert.Equal(t, tc.expectedOverlays, overlays)
+		})
+	}
+}
+
+func TestGetServiceEffectiveSwaggerDocument(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overlays")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	swaggerFile := filepath.Join(dir, "swagger.yaml")
+	require.NoError(t, ioutil.WriteFile(swaggerFile, []byte(overlayTestSwagger), 0644))
+	idOverlayFile := filepath.Join(dir, "id-overlay.yaml")
+	require.NoError(t, ioutil.WriteFile(idOverlayFile, []byte(`[{"op":"add","path":"/definitions/ContentDeliveryNetwork/properties/uuid/x-terraform-id","value":true}]`), 0644))
+	immutableOverlayFile := filepath.Join(dir, "immutable-overlay.yaml")
+	require.NoError(t, ioutil.WriteFile(immutableOverlayFile, []byte(`[{"op":"add","path":"/definitions/ContentDeliveryNetwork/properties/label/x-terraform-immutable","value":true}]`), 0644))
+	pluginConfigFile := filepath.Join(dir, OpenAPIPluginConfigurationFileName)
+	require.NoError(t, ioutil.WriteFile(pluginConfigFile, []byte(`version: '1'
+services:
+  cdn:
+    swagger-url: `+swaggerFile+`
+    overlays:
+      - `+idOverlayFile+`
+`), 0644))
+	pluginConfigFileEnvVar := fmt.Sprintf(otfVarPluginConfigurationFile, "cdn")
+	os.Setenv(pluginConfigFileEnvVar, pluginConfigFile)
+	defer os.Unsetenv(pluginConfigFileEnvVar)
+
+	document, err := GetServiceEffectiveSwaggerDocument("cdn", "", []string{immutableOverlayFile})
+	require.NoError(t, err)
+	expected, err := GetEffectiveSwaggerDocument(swaggerFile, []string{idOverlayFile, immutableOverlayFile})
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(document))
+
+	_, err = GetServiceEffectiveSwaggerDocument("cdn", "other.yaml", nil)
+	assert.EqualError(t, err, "swagger url 'other.yaml' is not the one configured for provider 'cdn': "+swaggerFile)
+}
@@ -9,6 +9,9 @@ type SpecResource interface {
 	getResourceName() string
 	getHost() (string, error)
 	getResourcePath(parentIDs []string) (string, error)
+	// getResourceInstancePathTemplate returns the resource instance path relative to the resource path (e,g: {id}), or
+	// an empty string if the instance path is not known
+	getResourceInstancePathTemplate() string
 	getResourceSchema() (*specSchemaDefinition, error)
 	shouldIgnoreResource() bool
 	getResourceOperations() specResourceOperations
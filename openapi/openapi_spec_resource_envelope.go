@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// parsePayloadPath parses the path where the resource is located inside a request or response envelope. The path can be
+// either a JSON pointer (e,g: /data/item) or a dotted path (e,g: data.item). Nil is returned if the path is empty
+func parsePayloadPath(path string) []string {
+	path = strings.TrimSpace(path)
+	if path == "" || path == "/" {
+		return nil
+	}
+	if strings.HasPrefix(path, "/") {
+		var segments []string
+		for _, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+			segments = append(segments, strings.Replace(strings.Replace(segment, "~1", "/", -1), "~0", "~", -1))
+		}
+		return segments
+	}
+	return strings.Split(path, ".")
+}
+
+// formatPayloadPath returns the path as a JSON pointer so it can be displayed in error messages
+func formatPayloadPath(path []string) string {
+	return "/" + strings.Join(path, "/")
+}
+
+// unwrapPayload returns the value located at the given path inside the payload envelope
+func unwrapPayload(payload interface{}, path []string) (interface{}, error) {
+	value := payload
+	for _, segment := range path {
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return nil, fmt.Errorf("payload does not contain an object at '%s'", formatPayloadPath(path))
+		}
+		value, isObject = object[segment]
+		if !isObject {
+			return nil, fmt.Errorf("payload is missing the property '%s' (path '%s')", segment, formatPayloadPath(path))
+		}
+	}
+	return value, nil
+}
+
+// wrapPayload returns the payload wrapped in an envelope so that the payload is located at the given path
+func wrapPayload(payload interface{}, path []string) interface{} {
+	wrapped := payload
+	for i := len(path) - 1; i >= 0; i-- {
+		wrapped = map[string]interface{}{path[i]: wrapped}
+	}
+	return wrapped
+}
+
+// getSchemaAtPath returns the schema of the property located at the given path inside the envelope schema
+func getSchemaAtPath(schema *spec.Schema, path []string) (*spec.Schema, error) {
+	current := schema
+	for _, segment := range path {
+		if current == nil {
+			return nil, fmt.Errorf("envelope schema is missing the property '%s' (path '%s')", segment, formatPayloadPath(path))
+		}
+		property, exists := current.Properties[segment]
+		if !exists {
+			return nil, fmt.Errorf("envelope schema is missing the property '%s' (path '%s')", segment, formatPayloadPath(path))
+		}
+		current = &property
+	}
+	return current, nil
+}
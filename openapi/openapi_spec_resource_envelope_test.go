@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePayloadPath(t *testing.T) {
+	testCases := []struct {
+		name         string
+		path         string
+		expectedPath []string
+	}{
+		{name: "empty path", path: "", expectedPath: nil},
+		{name: "root JSON pointer", path: "/", expectedPath: nil},
+		{name: "JSON pointer", path: "/data/item", expectedPath: []string{"data", "item"}},
+		{name: "JSON pointer with escaped characters", path: "/a~1b/c~0d", expectedPath: []string{"a/b", "c~d"}},
+		{name: "dotted path", path: "data.item", expectedPath: []string{"data", "item"}},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedPath, parsePayloadPath(tc.path), tc.name)
+	}
+}
+
+func TestUnwrapPayload(t *testing.T) {
+	Convey("Given a payload wrapped in an envelope", t, func() {
+		payload := map[string]interface{}{
+			"data": map[string]interface{}{"id": "1234"},
+			"meta": map[string]interface{}{"page": 1},
+		}
+		Convey("When unwrapPayload is called with the path of the resource", func() {
+			value, err := unwrapPayload(payload, []string{"data"})
+			Convey("Then the value returned should be the resource", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldResemble, map[string]interface{}{"id": "1234"})
+			})
+		})
+		Convey("When unwrapPayload is called with a path that does not exist", func() {
+			_, err := unwrapPayload(payload, []string{"result"})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "payload is missing the property 'result' (path '/result')")
+			})
+		})
+		Convey("When unwrapPayload is called with a path that goes through a value that is not an object", func() {
+			_, err := unwrapPayload(payload, []string{"data", "id", "value"})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "payload does not contain an object at '/data/id/value'")
+			})
+		})
+	})
+}
+
+func TestWrapPayload(t *testing.T) {
+	Convey("Given a payload", t, func() {
+		payload := map[string]interface{}{"name": "my cdn"}
+		Convey("When wrapPayload is called with a nested path", func() {
+			wrapped := wrapPayload(payload, []string{"data", "item"})
+			Convey("Then the payload should be wrapped in the envelope", func() {
+				So(wrapped, ShouldResemble, map[string]interface{}{"data": map[string]interface{}{"item": payload}})
+			})
+		})
+	})
+}
+
+func TestGetSchemaAtPath(t *testing.T) {
+	Convey("Given an envelope schema", t, func() {
+		inner := spec.Schema{SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{"id": {}}}}
+		envelope := &spec.Schema{SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{"data": inner}}}
+		Convey("When getSchemaAtPath is called with the path of the inner model", func() {
+			schema, err := getSchemaAtPath(envelope, []string{"data"})
+			Convey("Then the schema returned should be the inner model", func() {
+				So(err, ShouldBeNil)
+				So(schema.Properties, ShouldContainKey, "id")
+			})
+		})
+		Convey("When getSchemaAtPath is called with a path that does not exist", func() {
+			_, err := getSchemaAtPath(envelope, []string{"data", "item"})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "envelope schema is missing the property 'item' (path '/data/item')")
+			})
+		})
+	})
+}
@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultCompositeIdentifierDelimiter is the delimiter used to join the composite identifier values when the resource
+// does not specify one
+const defaultCompositeIdentifierDelimiter = ":"
+
+// isCompositeIdentifier returns true if the resource is identified by multiple properties
+func (s *specSchemaDefinition) isCompositeIdentifier() bool {
+	return s != nil && len(s.CompositeIdentifier) > 0
+}
+
+// getCompositeIdentifierDelimiter returns the delimiter used to join the composite identifier values
+func (s *specSchemaDefinition) getCompositeIdentifierDelimiter() string {
+	if s == nil || s.CompositeIdentifierDelimiter == "" {
+		return defaultCompositeIdentifierDelimiter
+	}
+	return s.CompositeIdentifierDelimiter
+}
+
+// getResourceIdentifiers returns the names of the properties that identify the resource. If the resource has a composite
+// identifier the properties are returned in the order they are expected in the resource ID; otherwise the single identifier
+// property is returned (see getResourceIdentifier)
+func (s *specSchemaDefinition) getResourceIdentifiers() ([]string, error) {
+	if !s.isCompositeIdentifier() {
+		identifierProperty, err := s.getResourceIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		return []string{identifierProperty}, nil
+	}
+	for _, propertyName := range s.CompositeIdentifier {
+		if _, err := s.getProperty(propertyName); err != nil {
+			return nil, fmt.Errorf("composite identifier property '%s' not found in the resource schema definition", propertyName)
+		}
+	}
+	return s.CompositeIdentifier, nil
+}
+
+// buildResourceID returns the resource ID based on the identifier values found in the payload. Composite identifier
+// values are joined using the composite identifier delimiter (e,g: zone:record_name:type)
+func (s *specSchemaDefinition) buildResourceID(payload map[string]interface{}) (string, error) {
+	identifierProperties, err := s.getResourceIdentifiers()
+	if err != nil {
+		return "", err
+	}
+	var values []string
+	for _, identifierProperty := range identifierProperties {
+		value, exists := payload[identifierProperty]
+		if !exists || value == nil {
+			return "", fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s'", identifierProperty)
+		}
+		formattedValue := formatIdentifierValue(value)
+		if s.isCompositeIdentifier() && strings.Contains(formattedValue, s.getCompositeIdentifierDelimiter()) {
+			return "", fmt.Errorf("identifier property '%s' value '%s' contains the composite identifier delimiter '%s'", identifierProperty, formattedValue, s.getCompositeIdentifierDelimiter())
+		}
+		values = append(values, formattedValue)
+	}
+	return strings.Join(values, s.getCompositeIdentifierDelimiter()), nil
+}
+
+// splitResourceID returns the identifier values contained in the given resource ID. For resources with a single identifier
+// the ID is returned as is
+func (s *specSchemaDefinition) splitResourceID(id string) ([]string, error) {
+	if !s.isCompositeIdentifier() {
+		return []string{id}, nil
+	}
+	values := strings.Split(id, s.getCompositeIdentifierDelimiter())
+	if len(values) != len(s.CompositeIdentifier) {
+		return nil, fmt.Errorf("resource ID '%s' does not match the composite identifier format '%s'", id, strings.Join(s.CompositeIdentifier, s.getCompositeIdentifierDelimiter()))
+	}
+	for idx, value := range values {
+		if value == "" {
+			return nil, fmt.Errorf("resource ID '%s' is missing the value for the composite identifier property '%s'", id, s.CompositeIdentifier[idx])
+		}
+	}
+	return values, nil
+}
+
+// getCompositeIdentifierValues returns the values of the composite identifier properties contained in the given resource ID
+// converted to the corresponding property types, keyed by terraform property name
+func (s *specSchemaDefinition) getCompositeIdentifierValues(id string) (map[string]interface{}, error) {
+	values, err := s.splitResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+	identifierValues := map[string]interface{}{}
+	for idx, propertyName := range s.CompositeIdentifier {
+		property, err := s.getProperty(propertyName)
+		if err != nil {
+			return nil, err
+		}
+		value, err := convertIdentifierValue(property, values[idx])
+		if err != nil {
+			return nil, err
+		}
+		identifierValues[property.getTerraformCompliantPropertyName()] = value
+	}
+	return identifierValues, nil
+}
+
+func formatIdentifierValue(value interface{}) string {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func convertIdentifierValue(property *specSchemaDefinitionProperty, value string) (interface{}, error) {
+	switch property.Type {
+	case typeInt:
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("identifier property '%s' value '%s' is not a valid integer", property.Name, value)
+		}
+		return v, nil
+	case typeFloat:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("identifier property '%s' value '%s' is not a valid number", property.Name, value)
+		}
+		return v, nil
+	case typeBool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("identifier property '%s' value '%s' is not a valid boolean", property.Name, value)
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,231 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newCompositeIdentifierSchemaDefinition(delimiter string) *specSchemaDefinition {
+	return &specSchemaDefinition{
+		Properties: specSchemaDefinitionProperties{
+			newStringSchemaDefinitionPropertyWithDefaults("zone", "", true, false, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("record_name", "", true, false, nil),
+			newIntSchemaDefinitionPropertyWithDefaults("priority", "", true, false, nil),
+		},
+		CompositeIdentifier:          []string{"zone", "record_name", "priority"},
+		CompositeIdentifierDelimiter: delimiter,
+	}
+}
+
+func TestIsCompositeIdentifier(t *testing.T) {
+	Convey("Given a schema definition with a composite identifier", t, func() {
+		s := newCompositeIdentifierSchemaDefinition("")
+		Convey("When isCompositeIdentifier is called", func() {
+			Convey("Then the result should be true", func() {
+				So(s.isCompositeIdentifier(), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a schema definition without a composite identifier", t, func() {
+		s := &specSchemaDefinition{Properties: specSchemaDefinitionProperties{idProperty}}
+		Convey("When isCompositeIdentifier is called", func() {
+			Convey("Then the result should be false", func() {
+				So(s.isCompositeIdentifier(), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a nil schema definition", t, func() {
+		var s *specSchemaDefinition
+		Convey("When isCompositeIdentifier is called", func() {
+			Convey("Then the result should be false", func() {
+				So(s.isCompositeIdentifier(), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestGetCompositeIdentifierDelimiter(t *testing.T) {
+	Convey("Given a schema definition with a composite identifier and no delimiter", t, func() {
+		s := newCompositeIdentifierSchemaDefinition("")
+		Convey("When getCompositeIdentifierDelimiter is called", func() {
+			Convey("Then the default delimiter should be returned", func() {
+				So(s.getCompositeIdentifierDelimiter(), ShouldEqual, defaultCompositeIdentifierDelimiter)
+			})
+		})
+	})
+	Convey("Given a schema definition with a composite identifier and a custom delimiter", t, func() {
+		s := newCompositeIdentifierSchemaDefinition("_")
+		Convey("When getCompositeIdentifierDelimiter is called", func() {
+			Convey("Then the custom delimiter should be returned", func() {
+				So(s.getCompositeIdentifierDelimiter(), ShouldEqual, "_")
+			})
+		})
+	})
+}
+
+func TestGetResourceIdentifiers(t *testing.T) {
+	Convey("Given a schema definition with a composite identifier", t, func() {
+		s := newCompositeIdentifierSchemaDefinition("")
+		Convey("When getResourceIdentifiers is called", func() {
+			identifiers, err := s.getResourceIdentifiers()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the identifiers should be returned in the declared order", func() {
+				So(identifiers, ShouldResemble, []string{"zone", "record_name", "priority"})
+			})
+		})
+	})
+	Convey("Given a schema definition with a composite identifier referring to a missing property", t, func() {
+		s := newCompositeIdentifierSchemaDefinition("")
+		s.CompositeIdentifier = []string{"zone", "type"}
+		Convey("When getResourceIdentifiers is called", func() {
+			_, err := s.getResourceIdentifiers()
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "composite identifier property 'type' not found in the resource schema definition")
+			})
+		})
+	})
+	Convey("Given a schema definition with a single id property", t, func() {
+		s := &specSchemaDefinition{Properties: specSchemaDefinitionProperties{idProperty}}
+		Convey("When getResourceIdentifiers is called", func() {
+			identifiers, err := s.getResourceIdentifiers()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the id property should be returned", func() {
+				So(identifiers, ShouldResemble, []string{idProperty.Name})
+			})
+		})
+	})
+}
+
+func TestBuildResourceID(t *testing.T) {
+	Convey("Given a schema definition with a composite identifier", t, func() {
+		s := newCompositeIdentifierSchemaDefinition("")
+		Convey("When buildResourceID is called with a payload containing all the identifier values", func() {
+			id, err := s.buildResourceID(map[string]interface{}{"zone": "example.com", "record_name": "www", "priority": float64(10)})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the ID should contain the values joined by the delimiter", func() {
+				So(id, ShouldEqual, "example.com:www:10")
+			})
+		})
+		Convey("When buildResourceID is called with a payload missing one of the identifier values", func() {
+			_, err := s.buildResourceID(map[string]interface{}{"zone": "example.com", "priority": float64(10)})
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "response object returned from the API is missing mandatory identifier property 'record_name'")
+			})
+		})
+		Convey("When buildResourceID is called with a payload where one of the values contains the delimiter", func() {
+			_, err := s.buildResourceID(map[string]interface{}{"zone": "example.com", "record_name": "www:api", "priority": float64(10)})
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "identifier property 'record_name' value 'www:api' contains the composite identifier delimiter ':'")
+			})
+		})
+	})
+	Convey("Given a schema definition with a single id property", t, func() {
+		s := &specSchemaDefinition{Properties: specSchemaDefinitionProperties{idProperty}}
+		Convey("When buildResourceID is called with a payload containing a numeric id", func() {
+			id, err := s.buildResourceID(map[string]interface{}{idProperty.Name: 1234})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the ID should be the id value", func() {
+				So(id, ShouldEqual, "1234")
+			})
+		})
+	})
+}
+
+func TestSplitResourceID(t *testing.T) {
+	Convey("Given a schema definition with a composite identifier and a custom delimiter", t, func() {
+		s := newCompositeIdentifierSchemaDefinition("_")
+		Convey("When splitResourceID is called with a valid ID", func() {
+			values, err := s.splitResourceID("example.com_www_10")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the values should be returned in order", func() {
+				So(values, ShouldResemble, []string{"example.com", "www", "10"})
+			})
+		})
+		Convey("When splitResourceID is called with an ID missing some of the values", func() {
+			_, err := s.splitResourceID("example.com_www")
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource ID 'example.com_www' does not match the composite identifier format 'zone_record_name_priority'")
+			})
+		})
+		Convey("When splitResourceID is called with an ID containing an empty value", func() {
+			_, err := s.splitResourceID("example.com__10")
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource ID 'example.com__10' is missing the value for the composite identifier property 'record_name'")
+			})
+		})
+	})
+	Convey("Given a schema definition without a composite identifier", t, func() {
+		s := &specSchemaDefinition{Properties: specSchemaDefinitionProperties{idProperty}}
+		Convey("When splitResourceID is called", func() {
+			values, err := s.splitResourceID("some:id")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the ID should be returned as is", func() {
+				So(values, ShouldResemble, []string{"some:id"})
+			})
+		})
+	})
+}
+
+func TestGetCompositeIdentifierValues(t *testing.T) {
+	Convey("Given a schema definition with a composite identifier", t, func() {
+		s := newCompositeIdentifierSchemaDefinition("")
+		Convey("When getCompositeIdentifierValues is called with a valid ID", func() {
+			values, err := s.getCompositeIdentifierValues("example.com:www:10")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the values should be converted to the property types", func() {
+				So(values, ShouldResemble, map[string]interface{}{"zone": "example.com", "record_name": "www", "priority": 10})
+			})
+		})
+		Convey("When getCompositeIdentifierValues is called with an ID containing a value that does not match the property type", func() {
+			_, err := s.getCompositeIdentifierValues("example.com:www:high")
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "identifier property 'priority' value 'high' is not a valid integer")
+			})
+		})
+	})
+}
+
+func TestFormatIdentifierValue(t *testing.T) {
+	Convey("Given identifier values of different types", t, func() {
+		Convey("When formatIdentifierValue is called with an integral number", func() {
+			Convey("Then the value should be formatted without decimals", func() {
+				So(formatIdentifierValue(float64(10)), ShouldEqual, "10")
+			})
+		})
+		Convey("When formatIdentifierValue is called with a non integral number", func() {
+			Convey("Then the value should be formatted without losing the decimals", func() {
+				So(formatIdentifierValue(10.5), ShouldEqual, "10.5")
+			})
+		})
+		Convey("When formatIdentifierValue is called with a large integral number", func() {
+			Convey("Then the value should not be formatted using the exponent notation", func() {
+				So(formatIdentifierValue(float64(12345678901)), ShouldEqual, "12345678901")
+			})
+		})
+		Convey("When formatIdentifierValue is called with an int", func() {
+			Convey("Then the value should be formatted as is", func() {
+				So(formatIdentifierValue(10), ShouldEqual, "10")
+			})
+		})
+		Convey("When formatIdentifierValue is called with a string", func() {
+			Convey("Then the value should be returned as is", func() {
+				So(formatIdentifierValue("www"), ShouldEqual, "www")
+			})
+		})
+	})
+}
@@ -1,5 +1,7 @@
 package openapi
 
+import "sort"
+
 type specResourceOperations struct {
 	List   *specResourceOperation
 	Post   *specResourceOperation
@@ -10,7 +12,65 @@ type specResourceOperations struct {
 
 // specResourceOperation defines a resource operation
 type specResourceOperation struct {
-	SecuritySchemes  SpecSecuritySchemes
-	HeaderParameters SpecHeaderParameters
-	responses        specResponses
+	// SecurityRequirements contains the alternative security requirements of the operation (only one of them needs to be satisfied)
+	SecurityRequirements SpecSecurityRequirements
+	HeaderParameters     SpecHeaderParameters
+	responses            specResponses
+	// retryPolicy contains the retry settings specific to the operation that override the provider's retry policy; nil
+	// if the operation does not override the retry policy
+	retryPolicy *specRetryPolicy
+	// idempotencyKeyHeader contains the name of the header used to send the idempotency key; empty if the operation does
+	// not support idempotency keys
+	idempotencyKeyHeader string
+	// ifMatchEnabled is true if the operation declares the If-Match header parameter, in which case the ETag of the resource
+	// stored in the state is sent as a precondition
+	ifMatchEnabled bool
+	// errorMessagePath contains the path to the error message in the error responses that do not define their own
+	// x-terraform-error-message-path (e,g: the default response); empty if not configured
+	errorMessagePath string
+	// consumes contains the media types the operation accepts in the request body (falling back to the global consumes);
+	// used to select the encoding of the request payload
+	consumes []string
+	// produces contains the media types the operation returns in the response body (falling back to the global produces);
+	// used to select the Accept header and the decoding of the response payload
+	produces []string
+	// xmlRootElement contains the name of the root element used when the request payload is encoded as XML
+	xmlRootElement string
+	// requestPath contains the path where the request payload is placed inside the request envelope (e,g: [data] for
+	// {"data": {...}}); nil if the request payload is not wrapped
+	requestPath []string
+	// responsePath contains the path where the resource is located inside the response envelope; nil if the response
+	// payload is not wrapped
+	responsePath []string
+}
+
+// getSuccessStatusCodes returns the 2xx status codes declared in the operation responses sorted in ascending order; the
+// given default status codes are returned if the operation does not declare any 2xx response
+func (o *specResourceOperation) getSuccessStatusCodes(defaultStatusCodes []int) []int {
+	if o == nil {
+		return defaultStatusCodes
+	}
+	var statusCodes []int
+	for statusCode := range o.responses {
+		if statusCode >= 200 && statusCode < 300 {
+			statusCodes = append(statusCodes, statusCode)
+		}
+	}
+	if len(statusCodes) == 0 {
+		return defaultStatusCodes
+	}
+	sort.Ints(statusCodes)
+	return statusCodes
+}
+
+// getErrorMessagePath returns the path to the error message in the response body for the given status code; empty if
+// not configured
+func (o *specResourceOperation) getErrorMessagePath(statusCode int) string {
+	if o == nil {
+		return ""
+	}
+	if response := o.responses.getResponse(statusCode); response != nil && response.errorMessagePath != "" {
+		return response.errorMessagePath
+	}
+	return o.errorMessagePath
 }
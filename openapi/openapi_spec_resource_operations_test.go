@@ -0,0 +1,79 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetSuccessStatusCodes(t *testing.T) {
+	defaultStatusCodes := []int{http.StatusOK, http.StatusAccepted}
+	Convey("Given a specResourceOperation that declares successful and error responses", t, func() {
+		operation := &specResourceOperation{
+			responses: specResponses{
+				http.StatusMultiStatus:         &specResponse{},
+				http.StatusCreated:             &specResponse{},
+				http.StatusBadRequest:          &specResponse{},
+				http.StatusInternalServerError: &specResponse{},
+			},
+		}
+		Convey("When getSuccessStatusCodes is called", func() {
+			statusCodes := operation.getSuccessStatusCodes(defaultStatusCodes)
+			Convey("Then the status codes returned should be the 2xx declared in the responses sorted", func() {
+				So(statusCodes, ShouldResemble, []int{http.StatusCreated, http.StatusMultiStatus})
+			})
+		})
+	})
+	Convey("Given a specResourceOperation that does not declare any successful response", t, func() {
+		operation := &specResourceOperation{
+			responses: specResponses{
+				http.StatusBadRequest: &specResponse{},
+			},
+		}
+		Convey("When getSuccessStatusCodes is called", func() {
+			statusCodes := operation.getSuccessStatusCodes(defaultStatusCodes)
+			Convey("Then the status codes returned should be the default ones", func() {
+				So(statusCodes, ShouldResemble, defaultStatusCodes)
+			})
+		})
+	})
+	Convey("Given a nil specResourceOperation", t, func() {
+		var operation *specResourceOperation
+		Convey("When getSuccessStatusCodes is called", func() {
+			statusCodes := operation.getSuccessStatusCodes(defaultStatusCodes)
+			Convey("Then the status codes returned should be the default ones", func() {
+				So(statusCodes, ShouldResemble, defaultStatusCodes)
+			})
+		})
+	})
+}
+
+func TestSpecResourceOperationGetErrorMessagePath(t *testing.T) {
+	Convey("Given a specResourceOperation with an error message path configured at the operation and a response level", t, func() {
+		operation := &specResourceOperation{
+			errorMessagePath: "message",
+			responses: specResponses{
+				http.StatusConflict: &specResponse{errorMessagePath: "error.message"},
+			},
+		}
+		Convey("When getErrorMessagePath is called with the status code of the response", func() {
+			Convey("Then the message path returned should be the one configured in the response", func() {
+				So(operation.getErrorMessagePath(http.StatusConflict), ShouldEqual, "error.message")
+			})
+		})
+		Convey("When getErrorMessagePath is called with a status code not declared", func() {
+			Convey("Then the message path returned should be the one configured in the operation", func() {
+				So(operation.getErrorMessagePath(http.StatusInternalServerError), ShouldEqual, "message")
+			})
+		})
+	})
+	Convey("Given a nil specResourceOperation", t, func() {
+		var operation *specResourceOperation
+		Convey("When getErrorMessagePath is called", func() {
+			Convey("Then the message path returned should be empty", func() {
+				So(operation.getErrorMessagePath(http.StatusConflict), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// maxPollInterval caps the wait time between polls when a backoff multiplier is configured
+var maxPollInterval = time.Duration(10 * time.Minute)
+
+// specPollPolicy defines the cadence used when polling asynchronous resources and long running operations
+type specPollPolicy struct {
+	// delay defines how long to wait before the first poll
+	delay time.Duration
+	// interval defines how long to wait between polls
+	interval time.Duration
+	// backoffMultiplier multiplies the interval after each poll (e,g: 2 doubles the wait time on each poll). Values lower
+	// than or equal to 1 keep the interval constant
+	backoffMultiplier float64
+}
+
+// merge returns a new poll policy where the values populated in the override policy take preference over the ones in p
+func (p *specPollPolicy) merge(override *specPollPolicy) *specPollPolicy {
+	merged := &specPollPolicy{}
+	if p != nil {
+		*merged = *p
+	}
+	if override == nil {
+		return merged
+	}
+	if override.delay > 0 {
+		merged.delay = override.delay
+	}
+	if override.interval > 0 {
+		merged.interval = override.interval
+	}
+	if override.backoffMultiplier > 0 {
+		merged.backoffMultiplier = override.backoffMultiplier
+	}
+	return merged
+}
+
+// getInterval returns the wait time before the given poll (starting at 1 for the poll following the first one)
+func (p *specPollPolicy) getInterval(poll int) time.Duration {
+	if p.backoffMultiplier <= 1 {
+		return p.interval
+	}
+	interval := time.Duration(float64(p.interval) * math.Pow(p.backoffMultiplier, float64(poll-1)))
+	if interval > maxPollInterval || interval <= 0 {
+		return maxPollInterval
+	}
+	return interval
+}
+
+// createStateChangeConf returns a StateChangeConf that polls following the poll policy. The waits between polls are
+// handled by the refresh func returned so the backoff multiplier can be honoured; the waits never go beyond the timeout.
+// The progress of the polling is logged at INFO level including the elapsed time and the last status seen
+func (p *specPollPolicy) createStateChangeConf(description string, pending, target []string, refresh resource.StateRefreshFunc, timeout time.Duration) *resource.StateChangeConf {
+	start := time.Now()
+	deadline := start.Add(timeout)
+	poll := 0
+	return &resource.StateChangeConf{
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			if poll > 0 {
+				wait := p.getInterval(poll)
+				if remaining := time.Until(deadline); wait > remaining {
+					wait = remaining
+				}
+				if wait > 0 {
+					time.Sleep(wait)
+				}
+			}
+			poll++
+			result, status, err := refresh()
+			if err == nil {
+				log.Printf("[INFO] %s: elapsed time %s, last status seen '%s'", description, time.Since(start).Round(time.Second), status)
+			}
+			return result, status, err
+		},
+		Timeout: timeout,
+		// the wait between polls is handled by the refresh func, a minimal poll interval makes the StateChangeConf call
+		// the refresh func right after the previous poll
+		PollInterval: time.Nanosecond,
+		Delay:        p.delay,
+	}
+}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpecPollPolicyMerge(t *testing.T) {
+	Convey("Given a poll policy and an override policy", t, func() {
+		pollPolicy := &specPollPolicy{delay: time.Second, interval: 5 * time.Second}
+		override := &specPollPolicy{interval: 30 * time.Second, backoffMultiplier: 1.5}
+		Convey("When merge is called", func() {
+			merged := pollPolicy.merge(override)
+			Convey("Then the values populated in the override should take preference", func() {
+				So(merged, ShouldResemble, &specPollPolicy{delay: time.Second, interval: 30 * time.Second, backoffMultiplier: 1.5})
+			})
+			Convey("And the original policy should not be mutated", func() {
+				So(pollPolicy.interval, ShouldEqual, 5*time.Second)
+			})
+		})
+		Convey("When merge is called with a nil override", func() {
+			merged := pollPolicy.merge(nil)
+			Convey("Then the merged policy should be equal to the original one", func() {
+				So(merged, ShouldResemble, pollPolicy)
+			})
+		})
+	})
+}
+
+func TestSpecPollPolicyGetInterval(t *testing.T) {
+	Convey("Given a poll policy without backoff multiplier", t, func() {
+		pollPolicy := &specPollPolicy{interval: 5 * time.Second}
+		Convey("When getInterval is called", func() {
+			Convey("Then the interval should be constant", func() {
+				So(pollPolicy.getInterval(1), ShouldEqual, 5*time.Second)
+				So(pollPolicy.getInterval(5), ShouldEqual, 5*time.Second)
+			})
+		})
+	})
+	Convey("Given a poll policy with a backoff multiplier", t, func() {
+		pollPolicy := &specPollPolicy{interval: 10 * time.Second, backoffMultiplier: 2}
+		Convey("When getInterval is called", func() {
+			Convey("Then the interval should be multiplied on each poll", func() {
+				So(pollPolicy.getInterval(1), ShouldEqual, 10*time.Second)
+				So(pollPolicy.getInterval(2), ShouldEqual, 20*time.Second)
+				So(pollPolicy.getInterval(3), ShouldEqual, 40*time.Second)
+			})
+			Convey("And the interval should be capped to the max poll interval", func() {
+				So(pollPolicy.getInterval(20), ShouldEqual, maxPollInterval)
+			})
+		})
+	})
+}
+
+func TestSpecPollPolicyCreateStateChangeConf(t *testing.T) {
+	Convey("Given a poll policy with a backoff multiplier", t, func() {
+		pollPolicy := &specPollPolicy{delay: time.Millisecond, interval: 10 * time.Millisecond, backoffMultiplier: 2}
+		Convey("When the state change conf created is waited on with a refresh func that completes on the third poll", func() {
+			var pollTimes []time.Time
+			refresh := func() (interface{}, string, error) {
+				pollTimes = append(pollTimes, time.Now())
+				if len(pollTimes) < 3 {
+					return "result", "pending", nil
+				}
+				return "result", "completed", nil
+			}
+			stateConf := pollPolicy.createStateChangeConf("waiting for test", []string{"pending"}, []string{"completed"}, refresh, time.Minute)
+			result, err := stateConf.WaitForState()
+			Convey("Then the result should be the one returned by the refresh func once completed", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldEqual, "result")
+			})
+			Convey("And the wait between polls should grow following the backoff multiplier", func() {
+				So(len(pollTimes), ShouldEqual, 3)
+				So(pollTimes[1].Sub(pollTimes[0]), ShouldBeGreaterThanOrEqualTo, 10*time.Millisecond)
+				So(pollTimes[2].Sub(pollTimes[1]), ShouldBeGreaterThanOrEqualTo, 20*time.Millisecond)
+			})
+		})
+	})
+	Convey("Given a poll policy with an interval longer than the timeout", t, func() {
+		pollPolicy := &specPollPolicy{interval: time.Hour}
+		Convey("When the state change conf created is waited on with a refresh func that never completes", func() {
+			refresh := func() (interface{}, string, error) {
+				return "result", "pending", nil
+			}
+			stateConf := pollPolicy.createStateChangeConf("waiting for test", []string{"pending"}, []string{"completed"}, refresh, 50*time.Millisecond)
+			start := time.Now()
+			_, err := stateConf.WaitForState()
+			Convey("Then the wait should not go beyond the timeout", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "timeout while waiting for state to become 'completed'")
+				So(time.Since(start), ShouldBeLessThan, 5*time.Second)
+			})
+		})
+	})
+}
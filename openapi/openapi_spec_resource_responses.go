@@ -1,11 +1,31 @@
 package openapi
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 type specResponses map[int]*specResponse
 
 type specResponse struct {
 	isPollingEnabled    bool
 	pollTargetStatuses  []string
 	pollPendingStatuses []string
+	// pollFailureStatuses contains the resource statuses that mean the asynchronous operation failed, in which case the
+	// polling stops straight away
+	pollFailureStatuses []string
+	// pollErrorField contains the name of the resource property holding the error details when the resource reaches a
+	// failure status; empty if not configured
+	pollErrorField string
+	// pollPolicy contains the polling cadence defined for the response; nil if the response does not override the default one
+	pollPolicy *specPollPolicy
+	// operationPolling contains the configuration to track the long running operation returned in the response; nil if
+	// the response does not return a long running operation
+	operationPolling *specOperationPolling
+	// errorMessagePath contains the dot separated path to the error message in the response body (e,g: error.message);
+	// empty if not configured
+	errorMessagePath string
 }
 
 func (s specResponses) getResponse(responseStatusCode int) *specResponse {
@@ -15,3 +35,97 @@ func (s specResponses) getResponse(responseStatusCode int) *specResponse {
 	}
 	return response
 }
+
+var defaultOperationStatusField = "state"
+var defaultOperationErrorField = "error"
+var defaultOperationResultField = "result"
+var defaultOperationCompletedStatuses = []string{"succeeded"}
+var defaultOperationFailedStatuses = []string{"failed", "canceled"}
+
+// specOperationPolling defines how to track a long running operation returned by the API (e,g: 202 Accepted responses
+// containing a Location or Operation-Location header pointing at an operation object like {state: running|succeeded|failed, error: ...})
+type specOperationPolling struct {
+	// header contains the name of the response header holding the operation URL
+	header string
+	// statusField contains the name of the operation property holding the status of the operation
+	statusField string
+	// errorField contains the name of the operation property holding the error details when the operation fails
+	errorField string
+	// completedStatuses contains the operation statuses that mean the operation finished successfully
+	completedStatuses []string
+	// failedStatuses contains the operation statuses that mean the operation failed
+	failedStatuses []string
+	// resultField contains the name of the operation property holding the resource once the operation completes. It is
+	// used to resolve the identifier of the resource when the response that returned the operation did not include it
+	resultField string
+}
+
+// getStatus returns the status of the given operation payload
+func (o *specOperationPolling) getStatus(operationPayload map[string]interface{}) (string, error) {
+	status, exists := operationPayload[o.statusField]
+	if !exists {
+		return "", fmt.Errorf("operation payload does not contain the status field '%s'", o.statusField)
+	}
+	statusValue, ok := status.(string)
+	if !ok {
+		return "", fmt.Errorf("operation status field '%s' value '%v' is not a string", o.statusField, status)
+	}
+	return statusValue, nil
+}
+
+func (o *specOperationPolling) isCompleted(status string) bool {
+	return containsStatus(o.completedStatuses, status)
+}
+
+func (o *specOperationPolling) isFailed(status string) bool {
+	return containsStatus(o.failedStatuses, status)
+}
+
+// getResult returns the resource contained in the result field of the given operation payload; nil if the operation
+// payload does not contain a result object
+func (o *specOperationPolling) getResult(operationPayload map[string]interface{}) map[string]interface{} {
+	result, _ := operationPayload[o.resultField].(map[string]interface{})
+	return result
+}
+
+// getErrorMessage returns the error details included in the operation payload
+func (o *specOperationPolling) getErrorMessage(operationPayload map[string]interface{}) string {
+	return getPayloadErrorDetail(operationPayload, o.errorField)
+}
+
+// isFailureStatus checks whether the given resource status is one of the poll failure statuses
+func (s *specResponse) isFailureStatus(status string) bool {
+	return s != nil && containsStatus(s.pollFailureStatuses, status)
+}
+
+// getPayloadErrorDetail returns the error details stored in the given payload field. If the field value is an object,
+// its message property is returned if present; otherwise the whole object is returned as JSON
+func getPayloadErrorDetail(payload map[string]interface{}, errorField string) string {
+	errorDetail, exists := payload[errorField]
+	if errorField == "" || !exists || errorDetail == nil {
+		return "no error details provided by the API"
+	}
+	switch e := errorDetail.(type) {
+	case string:
+		return e
+	case map[string]interface{}:
+		if message, ok := e["message"].(string); ok {
+			return message
+		}
+	}
+	b, err := json.Marshal(errorDetail)
+	if err != nil {
+		return fmt.Sprintf("%v", errorDetail)
+	}
+	return string(b)
+}
+
+// containsStatus checks whether the given status is in the list of statuses; the comparison is case insensitive
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpecOperationPollingGetStatus(t *testing.T) {
+	Convey("Given a specOperationPolling configured with a status field", t, func() {
+		operationPolling := &specOperationPolling{statusField: "state"}
+		Convey("When getStatus is called with a payload containing the status field", func() {
+			status, err := operationPolling.getStatus(map[string]interface{}{"state": "running"})
+			Convey("Then the status returned should be the expected one", func() {
+				So(err, ShouldBeNil)
+				So(status, ShouldEqual, "running")
+			})
+		})
+		Convey("When getStatus is called with a payload that does not contain the status field", func() {
+			_, err := operationPolling.getStatus(map[string]interface{}{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "operation payload does not contain the status field 'state'")
+			})
+		})
+		Convey("When getStatus is called with a payload where the status field is not a string", func() {
+			_, err := operationPolling.getStatus(map[string]interface{}{"state": 1})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "operation status field 'state' value '1' is not a string")
+			})
+		})
+	})
+}
+
+func TestSpecOperationPollingStatuses(t *testing.T) {
+	Convey("Given a specOperationPolling configured with completed and failed statuses", t, func() {
+		operationPolling := &specOperationPolling{completedStatuses: []string{"succeeded"}, failedStatuses: []string{"failed", "canceled"}}
+		Convey("When isCompleted and isFailed are called", func() {
+			Convey("Then the statuses should be compared case insensitively", func() {
+				So(operationPolling.isCompleted("Succeeded"), ShouldBeTrue)
+				So(operationPolling.isFailed("CANCELED"), ShouldBeTrue)
+				So(operationPolling.isCompleted("running"), ShouldBeFalse)
+				So(operationPolling.isFailed("running"), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestSpecOperationPollingGetResult(t *testing.T) {
+	Convey("Given a specOperationPolling configured with a result field", t, func() {
+		operationPolling := &specOperationPolling{resultField: "result"}
+		Convey("When getResult is called with a payload where the result is an object", func() {
+			result := operationPolling.getResult(map[string]interface{}{"state": "succeeded", "result": map[string]interface{}{"id": "someID"}})
+			Convey("Then the result returned should be the result object", func() {
+				So(result, ShouldResemble, map[string]interface{}{"id": "someID"})
+			})
+		})
+		Convey("When getResult is called with a payload that does not contain the result", func() {
+			result := operationPolling.getResult(map[string]interface{}{"state": "succeeded"})
+			Convey("Then the result returned should be nil", func() {
+				So(result, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestSpecOperationPollingGetErrorMessage(t *testing.T) {
+	Convey("Given a specOperationPolling configured with an error field", t, func() {
+		operationPolling := &specOperationPolling{errorField: "error"}
+		Convey("When getErrorMessage is called with a payload where the error is a string", func() {
+			message := operationPolling.getErrorMessage(map[string]interface{}{"error": "something went wrong"})
+			Convey("Then the message returned should be the error value", func() {
+				So(message, ShouldEqual, "something went wrong")
+			})
+		})
+		Convey("When getErrorMessage is called with a payload where the error is an object containing a message", func() {
+			message := operationPolling.getErrorMessage(map[string]interface{}{"error": map[string]interface{}{"code": "Conflict", "message": "name already taken"}})
+			Convey("Then the message returned should be the error message", func() {
+				So(message, ShouldEqual, "name already taken")
+			})
+		})
+		Convey("When getErrorMessage is called with a payload where the error is an object without a message", func() {
+			message := operationPolling.getErrorMessage(map[string]interface{}{"error": map[string]interface{}{"code": "Conflict"}})
+			Convey("Then the message returned should be the error object as JSON", func() {
+				So(message, ShouldEqual, `{"code":"Conflict"}`)
+			})
+		})
+		Convey("When getErrorMessage is called with a payload that does not contain the error", func() {
+			message := operationPolling.getErrorMessage(map[string]interface{}{})
+			Convey("Then the message returned should be the expected one", func() {
+				So(message, ShouldEqual, "no error details provided by the API")
+			})
+		})
+	})
+}
+
+func TestSpecResponseIsFailureStatus(t *testing.T) {
+	Convey("Given a specResponse configured with failure statuses", t, func() {
+		response := &specResponse{pollFailureStatuses: []string{"failed"}}
+		Convey("When isFailureStatus is called", func() {
+			Convey("Then only the failure statuses should be considered failures", func() {
+				So(response.isFailureStatus("FAILED"), ShouldBeTrue)
+				So(response.isFailureStatus("pending"), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a nil specResponse", t, func() {
+		var response *specResponse
+		Convey("When isFailureStatus is called", func() {
+			Convey("Then the result should be false", func() {
+				So(response.isFailureStatus("failed"), ShouldBeFalse)
+			})
+		})
+	})
+}
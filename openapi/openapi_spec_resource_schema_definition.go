@@ -12,6 +12,11 @@ type specSchemaDefinitionProperties []*specSchemaDefinitionProperty
 // SpecSchemaDefinition defines a struct for a schema definition
 type specSchemaDefinition struct {
 	Properties specSchemaDefinitionProperties
+	// CompositeIdentifier contains the names of the properties (in order) that together identify the resource. If empty,
+	// the resource is identified by a single property (see getResourceIdentifier)
+	CompositeIdentifier []string
+	// CompositeIdentifierDelimiter is the delimiter used to join the composite identifier values into the resource ID
+	CompositeIdentifierDelimiter string
 }
 
 func (s *specSchemaDefinition) createResourceSchema() (map[string]*schema.Schema, error) {
@@ -7,7 +7,7 @@ type SpecSecurity interface {
 	// GetAPIKeySecurityDefinitions returns all the OpenAPI security definitions from the OpenAPI document and translates those
 	// into SpecSecurityDefinitions
 	GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error)
-	// GetGlobalSecuritySchemes returns all the global security schemes from the OpenAPI document and translates those
-	// into SpecSecuritySchemes
-	GetGlobalSecuritySchemes() (SpecSecuritySchemes, error)
+	// GetGlobalSecurityRequirements returns the global security requirements from the OpenAPI document and translates those
+	// into SpecSecurityRequirements
+	GetGlobalSecurityRequirements() (SpecSecurityRequirements, error)
 }
@@ -0,0 +1,49 @@
+package openapi
+
+import (
+	"fmt"
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+)
+
+// specAPIKeyCookieSecurityDefinition defines a security definition. This struct serves as a translation between the OpenAPI document
+// and the scheme that will be used by the OpenAPI Terraform provider when making API calls to the backend
+type specAPIKeyCookieSecurityDefinition struct {
+	name   string
+	apiKey specAPIKey
+}
+
+// newAPIKeyCookieSecurityDefinition constructs a SpecSecurityDefinition of Cookie type. The secDefName value is the identifier
+// of the security definition, and the apiKeyName is the actual name of the cookie that will be used in the HTTP request.
+func newAPIKeyCookieSecurityDefinition(secDefName, apiKeyName string) specAPIKeyCookieSecurityDefinition {
+	return specAPIKeyCookieSecurityDefinition{secDefName, newAPIKeyCookie(apiKeyName)}
+}
+
+func (s specAPIKeyCookieSecurityDefinition) getName() string {
+	return s.name
+}
+
+func (s specAPIKeyCookieSecurityDefinition) getType() securityDefinitionType {
+	return securityDefinitionAPIKey
+}
+
+func (s specAPIKeyCookieSecurityDefinition) getTerraformConfigurationName() string {
+	return terraformutils.ConvertToTerraformCompliantName(s.name)
+}
+
+func (s specAPIKeyCookieSecurityDefinition) getAPIKey() specAPIKey {
+	return s.apiKey
+}
+
+func (s specAPIKeyCookieSecurityDefinition) buildValue(value string) string {
+	return value
+}
+
+func (s specAPIKeyCookieSecurityDefinition) validate() error {
+	if s.name == "" {
+		return fmt.Errorf("specAPIKeyCookieSecurityDefinition missing mandatory security definition name")
+	}
+	if s.apiKey.Name == "" {
+		return fmt.Errorf("specAPIKeyCookieSecurityDefinition missing mandatory apiKey name")
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewAPIKeyCookieSecurityDefinition(t *testing.T) {
+	Convey("Given a name and an apikey name", t, func() {
+		name := "name"
+		apiKeyName := "apiKey_name"
+		Convey("When newAPIKeyCookieSecurityDefinition method is called", func() {
+			apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition(name, apiKeyName)
+			Convey("Then the apiKeyHeaderAuthenticator should comply with specAPIKeyAuthenticator interface", func() {
+				var _ SpecSecurityDefinition = apiKeyCookieSecurityDefinition
+			})
+		})
+	})
+}
+
+func TestAPIKeyCookieSecurityDefinitionGetName(t *testing.T) {
+	Convey("Given an APIKeyCookieSecurityDefinition", t, func() {
+		expectedName := "apikey_name"
+		apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition(expectedName, "session_id")
+		Convey("When getTerraformConfigurationName method is called", func() {
+			name := apiKeyCookieSecurityDefinition.getName()
+			Convey("Then the result should match the original name", func() {
+				So(name, ShouldEqual, expectedName)
+			})
+		})
+	})
+}
+
+func TestAPIKeyCookieSecurityDefinitionGetType(t *testing.T) {
+	Convey("Given an APIKeyCookieSecurityDefinition", t, func() {
+		apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition("apikey_name", "session_id")
+		Convey("When getType method is called", func() {
+			secDefType := apiKeyCookieSecurityDefinition.getType()
+			Convey("Then the result should be securityDefinitionAPIKey", func() {
+				So(secDefType, ShouldEqual, securityDefinitionAPIKey)
+			})
+		})
+	})
+}
+
+func TestAPIKeyCookieSecurityDefinitionGetTerraformConfigurationName(t *testing.T) {
+	Convey("Given an APIKeyCookieSecurityDefinition with a compliant name", t, func() {
+		apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition("apikey_name", "session_id")
+		Convey("When getTerraformConfigurationName method is called", func() {
+			secDefTfName := apiKeyCookieSecurityDefinition.getTerraformConfigurationName()
+			Convey("Then the result should be securityDefinitionAPIKey", func() {
+				So(secDefTfName, ShouldEqual, "apikey_name")
+			})
+		})
+	})
+
+	Convey("Given an APIKeyCookieSecurityDefinition with a NON compliant name", t, func() {
+		apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition("nonCompliantName", "session_id")
+		Convey("When getTerraformConfigurationName method is called", func() {
+			secDefTfName := apiKeyCookieSecurityDefinition.getTerraformConfigurationName()
+			Convey("Then the result should be securityDefinitionAPIKey", func() {
+				So(secDefTfName, ShouldEqual, "non_compliant_name")
+			})
+		})
+	})
+}
+
+func TestAPIKeyCookieSecurityDefinitionGetAPIKey(t *testing.T) {
+	Convey("Given an APIKeyCookieSecurityDefinition", t, func() {
+		expectedAPIKey := "session_id"
+		apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition("apiKeyName", expectedAPIKey)
+		Convey("When getTerraformConfigurationName method is called", func() {
+			apiKey := apiKeyCookieSecurityDefinition.getAPIKey()
+			Convey("Then the result should contain the right apikey name and location", func() {
+				So(apiKey.Name, ShouldEqual, expectedAPIKey)
+				So(apiKey.In, ShouldEqual, inCookie)
+			})
+		})
+	})
+}
+
+func TestAPIKeyCookieSecurityDefinitionBuildValue(t *testing.T) {
+	Convey("Given an APIKeyCookieSecurityDefinition", t, func() {
+		expectedAPIKey := "session_id"
+		apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition("apiKeyName", expectedAPIKey)
+		Convey("When getTerraformConfigurationName method is called", func() {
+			expectedValue := "someValue"
+			value := apiKeyCookieSecurityDefinition.buildValue("someValue")
+			Convey("Then the value should be the expected value with no modifications", func() {
+				So(value, ShouldEqual, expectedValue)
+			})
+		})
+	})
+}
+
+func TestAPIKeyCookieSecurityDefinitionValidate(t *testing.T) {
+	Convey("Given an APIKeyCookieSecurityDefinition with a security definition name and an apiKeyName", t, func() {
+		apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition("apiKeyName", "session_id")
+		Convey("When validate method is called", func() {
+			err := apiKeyCookieSecurityDefinition.validate()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given an APIKeyCookieSecurityDefinition with an empty security definition name and an apiKeyName", t, func() {
+		apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition("", "session_id")
+		Convey("When validate method is called", func() {
+			err := apiKeyCookieSecurityDefinition.validate()
+			Convey("Then the error returned should NOT be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("Then the error message should match the expected", func() {
+				So(err.Error(), ShouldEqual, "specAPIKeyCookieSecurityDefinition missing mandatory security definition name")
+			})
+		})
+	})
+	Convey("Given an APIKeyCookieSecurityDefinition with a security definition name and an empty apiKeyName", t, func() {
+		apiKeyCookieSecurityDefinition := newAPIKeyCookieSecurityDefinition("apiKeyName", "")
+		Convey("When validate method is called", func() {
+			err := apiKeyCookieSecurityDefinition.validate()
+			Convey("Then the error returned should NOT be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("Then the error message should match the expected", func() {
+				So(err.Error(), ShouldEqual, "specAPIKeyCookieSecurityDefinition missing mandatory apiKey name")
+			})
+		})
+	})
+}
@@ -5,6 +5,7 @@ type apiKeyIn string
 const (
 	inHeader apiKeyIn = "header"
 	inQuery  apiKeyIn = "query"
+	inCookie apiKeyIn = "cookie"
 )
 
 type apiKeyMetadataKey string
@@ -27,6 +28,10 @@ func newAPIKeyQuery(name string) specAPIKey {
 	return newAPIKey(name, inQuery)
 }
 
+func newAPIKeyCookie(name string) specAPIKey {
+	return newAPIKey(name, inCookie)
+}
+
 func newAPIKey(name string, in apiKeyIn) specAPIKey {
 	return specAPIKey{
 		Name: name,
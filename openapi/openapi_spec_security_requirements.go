@@ -0,0 +1,40 @@
+package openapi
+
+import "strings"
+
+// SpecSecurityRequirements groups the alternative security requirements of an operation (or the global security). As
+// per the OpenAPI specification, only one of the alternatives needs to be satisfied (OR) and each alternative contains
+// the security schemes that must be satisfied together (AND)
+type SpecSecurityRequirements []SpecSecuritySchemes
+
+// createSecurityRequirements translates the OpenAPI security requirements into SpecSecurityRequirements. The order of
+// the alternatives is preserved as it defines the priority by which they are selected
+func createSecurityRequirements(securityRequirements []map[string][]string) SpecSecurityRequirements {
+	requirements := SpecSecurityRequirements{}
+	for _, securityRequirement := range securityRequirements {
+		requirements = append(requirements, createSecuritySchemes(securityRequirement))
+	}
+	return requirements
+}
+
+// securitySchemeRequired returns true if the given security definition is part of all the alternatives, and therefore
+// it must be configured for the requirements to be satisfied
+func (s SpecSecurityRequirements) securitySchemeRequired(secDef SpecSecurityDefinition) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, securitySchemes := range s {
+		if !securitySchemes.securitySchemeExists(secDef) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s SpecSecurityRequirements) String() string {
+	var alternatives []string
+	for _, securitySchemes := range s {
+		alternatives = append(alternatives, securitySchemes.String())
+	}
+	return strings.Join(alternatives, " OR ")
+}
@@ -0,0 +1,89 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateSecurityRequirements(t *testing.T) {
+	Convey("Given a list of security requirements with multi auth OR and AND support", t, func() {
+		securityRequirements := []map[string][]string{
+			{
+				"secDef1": {},
+				"secDef2": {},
+			},
+			{
+				"secDef3": {},
+			},
+		}
+		Convey("When createSecurityRequirements method is called with the security requirements", func() {
+			specSecurityRequirements := createSecurityRequirements(securityRequirements)
+			Convey("Then the alternatives should be returned in the same order with their security schemes", func() {
+				So(specSecurityRequirements, ShouldResemble, SpecSecurityRequirements{
+					SpecSecuritySchemes{SpecSecurityScheme{Name: "secDef1"}, SpecSecurityScheme{Name: "secDef2"}},
+					SpecSecuritySchemes{SpecSecurityScheme{Name: "secDef3"}},
+				})
+			})
+		})
+	})
+	Convey("Given an empty list of security requirements", t, func() {
+		securityRequirements := []map[string][]string{}
+		Convey("When createSecurityRequirements method is called with the security requirements", func() {
+			specSecurityRequirements := createSecurityRequirements(securityRequirements)
+			Convey("Then the specSecurityRequirements should be empty", func() {
+				So(specSecurityRequirements, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestSecuritySchemeRequired(t *testing.T) {
+	Convey("Given a list of security requirements alternatives that share the 'secDef1' security scheme", t, func() {
+		specSecurityRequirements := createSecurityRequirements([]map[string][]string{
+			{
+				"secDef1": {},
+				"secDef2": {},
+			},
+			{
+				"secDef1": {},
+			},
+		})
+		Convey("When securitySchemeRequired method is called with a security definition present in all the alternatives", func() {
+			required := specSecurityRequirements.securitySchemeRequired(newAPIKeyHeaderSecurityDefinition("secDef1", "secDef1"))
+			Convey("Then the value returned should be true", func() {
+				So(required, ShouldBeTrue)
+			})
+		})
+		Convey("When securitySchemeRequired method is called with a security definition present only in some of the alternatives", func() {
+			required := specSecurityRequirements.securitySchemeRequired(newAPIKeyHeaderSecurityDefinition("secDef2", "secDef2"))
+			Convey("Then the value returned should be false", func() {
+				So(required, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given an empty list of security requirements", t, func() {
+		specSecurityRequirements := SpecSecurityRequirements{}
+		Convey("When securitySchemeRequired method is called", func() {
+			required := specSecurityRequirements.securitySchemeRequired(newAPIKeyHeaderSecurityDefinition("secDef1", "secDef1"))
+			Convey("Then the value returned should be false", func() {
+				So(required, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestSpecSecurityRequirementsString(t *testing.T) {
+	Convey("Given a list of security requirements alternatives", t, func() {
+		specSecurityRequirements := SpecSecurityRequirements{
+			SpecSecuritySchemes{SpecSecurityScheme{Name: "secDef1"}, SpecSecurityScheme{Name: "secDef2"}},
+			SpecSecuritySchemes{SpecSecurityScheme{Name: "secDef3"}},
+		}
+		Convey("When String method is called", func() {
+			value := specSecurityRequirements.String()
+			Convey("Then the value returned should contain the alternatives joined by OR", func() {
+				So(value, ShouldEqual, "[secDef1 AND secDef2] OR [secDef3]")
+			})
+		})
+	})
+}
@@ -1,24 +1,36 @@
 package openapi
 
-import "github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+import (
+	"fmt"
+	"sort"
+	"strings"
 
-// SpecSecuritySchemes groups a list of SpecSecurityScheme
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+)
+
+// SpecSecuritySchemes groups a list of SpecSecurityScheme that must be satisfied together (e,g: one of the alternatives
+// in SpecSecurityRequirements)
 type SpecSecuritySchemes []SpecSecurityScheme
 
-func createSecuritySchemes(securitySchemes []map[string][]string) SpecSecuritySchemes {
+// createSecuritySchemes translates an OpenAPI security requirement into SpecSecuritySchemes. The schemes are sorted by
+// name so the order in which they are applied is deterministic
+func createSecuritySchemes(securityRequirement map[string][]string) SpecSecuritySchemes {
 	schemes := SpecSecuritySchemes{}
-	for _, securityScheme := range securitySchemes {
-		for securitySchemeName := range securityScheme {
-			schemes = append(schemes, SpecSecurityScheme{Name: securitySchemeName})
-		}
-		// Choosing the first set of security schemes as defined by the service provider. The order defines the priority
-		// by which security schemes are selected, in this case the first set. Hence, disregarding the rest of security
-		// schemes (if defined)
-		break
+	for securitySchemeName := range securityRequirement {
+		schemes = append(schemes, SpecSecurityScheme{Name: securitySchemeName})
 	}
+	sort.Slice(schemes, func(i, j int) bool { return schemes[i].Name < schemes[j].Name })
 	return schemes
 }
 
+func (s SpecSecuritySchemes) String() string {
+	var names []string
+	for _, securityScheme := range s {
+		names = append(names, securityScheme.Name)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(names, " AND "))
+}
+
 func (s SpecSecuritySchemes) securitySchemeExists(secDef SpecSecurityDefinition) bool {
 	for _, securityScheme := range s {
 		if securityScheme.getTerraformConfigurationName() == secDef.getTerraformConfigurationName() {
@@ -30,12 +30,7 @@ func TestSpecSecuritySchemeGetTerraformConfigurationName(t *testing.T) {
 
 func TestSecuritySchemeExists(t *testing.T) {
 	Convey("Given a list of specSecuritySchemes", t, func() {
-		securitySchemes := []map[string][]string{
-			{
-				"secDef1": {},
-			},
-		}
-		specSecuritySchemes := createSecuritySchemes(securitySchemes)
+		specSecuritySchemes := createSecuritySchemes(map[string][]string{"secDef1": {}})
 		Convey("When securitySchemeExists method is called with an existing security definition", func() {
 			secDef := newAPIKeyHeaderSecurityDefinition("secDef1", "secDef1")
 			exists := specSecuritySchemes.securitySchemeExists(secDef)
@@ -46,12 +41,7 @@ func TestSecuritySchemeExists(t *testing.T) {
 	})
 
 	Convey("Given a list of specSecuritySchemes", t, func() {
-		securitySchemes := []map[string][]string{
-			{
-				"secDef1": {},
-			},
-		}
-		specSecuritySchemes := createSecuritySchemes(securitySchemes)
+		specSecuritySchemes := createSecuritySchemes(map[string][]string{"secDef1": {}})
 		Convey("When securitySchemeExists method is called with a NON existing security definition", func() {
 			secDef := newAPIKeyHeaderSecurityDefinition("secDefNonExisting", "secDefNonExisting")
 			exists := specSecuritySchemes.securitySchemeExists(secDef)
@@ -63,46 +53,27 @@ func TestSecuritySchemeExists(t *testing.T) {
 }
 
 func TestCreateSecuritySchemes(t *testing.T) {
-	Convey("Given a map of securitySchemes with multi auth AND support", t, func() {
-		securitySchemes := []map[string][]string{
-			{
-				"secDef1": {},
-				"secDef2": {},
-			},
+	Convey("Given a security requirement with multi auth AND support", t, func() {
+		securityRequirement := map[string][]string{
+			"secDef2": {},
+			"secDef1": {},
 		}
-		Convey("When createSecuritySchemes method is called with the securitySchemes", func() {
-			specSecuritySchemes := createSecuritySchemes(securitySchemes)
-			Convey("Then the specSecuritySchemes should not be empty", func() {
-				So(specSecuritySchemes, ShouldNotBeEmpty)
-			})
-			Convey("Then the specSecuritySchemes name contain the expected items", func() {
-				So(specSecuritySchemes, ShouldContain, SpecSecurityScheme{Name: "secDef1"})
-				So(specSecuritySchemes, ShouldContain, SpecSecurityScheme{Name: "secDef2"})
+		Convey("When createSecuritySchemes method is called with the security requirement", func() {
+			specSecuritySchemes := createSecuritySchemes(securityRequirement)
+			Convey("Then the specSecuritySchemes should contain the expected items sorted by name", func() {
+				So(specSecuritySchemes, ShouldResemble, SpecSecuritySchemes{SpecSecurityScheme{Name: "secDef1"}, SpecSecurityScheme{Name: "secDef2"}})
 			})
 		})
 	})
+}
 
-	Convey("Given a map of securitySchemes with multi auth OR support", t, func() {
-		securitySchemes := []map[string][]string{
-			{
-				"secDef1": {},
-				"secDef2": {},
-			},
-			{
-				"secDef3": {},
-			},
-		}
-		Convey("When createSecuritySchemes method is called with the securitySchemes", func() {
-			specSecuritySchemes := createSecuritySchemes(securitySchemes)
-			Convey("Then the specSecuritySchemes should not be empty", func() {
-				So(specSecuritySchemes, ShouldNotBeEmpty)
-			})
-			Convey("Then the specSecuritySchemes name contain the expected items which are the first one in the array (by design these take preference)", func() {
-				So(specSecuritySchemes, ShouldContain, SpecSecurityScheme{Name: "secDef1"})
-				So(specSecuritySchemes, ShouldContain, SpecSecurityScheme{Name: "secDef2"})
-			})
-			Convey("Then the specSecuritySchemes should not contain anything else", func() {
-				So(specSecuritySchemes, ShouldNotContain, SpecSecurityScheme{Name: "secDef3"})
+func TestSpecSecuritySchemesString(t *testing.T) {
+	Convey("Given a list of specSecuritySchemes", t, func() {
+		specSecuritySchemes := SpecSecuritySchemes{SpecSecurityScheme{Name: "secDef1"}, SpecSecurityScheme{Name: "secDef2"}}
+		Convey("When String method is called", func() {
+			value := specSecuritySchemes.String()
+			Convey("Then the value returned should contain all the schemes joined by AND", func() {
+				So(value, ShouldEqual, "[secDef1 AND secDef2]")
 			})
 		})
 	})
@@ -1,9 +1,9 @@
 package openapi
 
 type specSecurityStub struct {
-	securityDefinitions   *SpecSecurityDefinitions
-	globalSecuritySchemes SpecSecuritySchemes
-	error                 error
+	securityDefinitions        *SpecSecurityDefinitions
+	globalSecurityRequirements SpecSecurityRequirements
+	error                      error
 }
 
 func (s *specSecurityStub) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
@@ -13,9 +13,9 @@ func (s *specSecurityStub) GetAPIKeySecurityDefinitions() (*SpecSecurityDefiniti
 	return s.securityDefinitions, nil
 }
 
-func (s *specSecurityStub) GetGlobalSecuritySchemes() (SpecSecuritySchemes, error) {
+func (s *specSecurityStub) GetGlobalSecurityRequirements() (SpecSecurityRequirements, error) {
 	if s.error != nil {
 		return nil, s.error
 	}
-	return s.globalSecuritySchemes, nil
+	return s.globalSecurityRequirements, nil
 }
@@ -15,6 +15,7 @@ type specStubBackendConfiguration struct {
 	hostErr          error
 	defaultRegionErr error
 	hostByRegionErr  error
+	rateLimit        *RateLimitConfig
 
 	getHTTPSchemeBehavior func() (string, error)
 }
@@ -81,3 +82,7 @@ func (s *specStubBackendConfiguration) isMultiRegion() (bool, string, []string,
 	}
 	return false, "", nil, nil
 }
+
+func (s *specStubBackendConfiguration) getRateLimit() (*RateLimitConfig, error) {
+	return s.rateLimit, nil
+}
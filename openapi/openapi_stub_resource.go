@@ -5,6 +5,7 @@ type specStubResource struct {
 	name                    string
 	host                    string
 	path                    string
+	instancePathTemplate    string
 	shouldIgnore            bool
 	schemaDefinition        *specSchemaDefinition
 	resourceGetOperation    *specResourceOperation
@@ -50,6 +51,8 @@ func (s *specStubResource) getResourcePath(parentIDs []string) (string, error) {
 	return s.path, nil
 }
 
+func (s *specStubResource) getResourceInstancePathTemplate() string { return s.instancePathTemplate }
+
 func (s *specStubResource) getResourceSchema() (*specSchemaDefinition, error) {
 	if s.funcGetResourceSchema != nil {
 		return s.funcGetResourceSchema()
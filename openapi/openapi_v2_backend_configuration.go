@@ -12,6 +12,7 @@ import (
 
 const extTfProviderMultiRegionFQDN = "x-terraform-provider-multiregion-fqdn"
 const extTfProviderRegions = "x-terraform-provider-regions"
+const extTfRateLimit = "x-terraform-rate-limit"
 
 type specV2BackendConfiguration struct {
 	openAPIDocumentURL string
@@ -115,6 +116,38 @@ func (o specV2BackendConfiguration) getProviderRegions() ([]string, error) {
 	return regions, nil
 }
 
+// getRateLimit returns the rate limit configured in the root level x-terraform-rate-limit extension. The extension value
+// must be an object containing the requests_per_second and optionally the burst, e,g: {requests_per_second: 10, burst: 20}
+func (o specV2BackendConfiguration) getRateLimit() (*RateLimitConfig, error) {
+	value, exists := o.spec.Extensions[extTfRateLimit]
+	if !exists {
+		return nil, nil
+	}
+	rateLimitExtension, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'%s' extension value must be an object containing the 'requests_per_second' and optionally the 'burst'", extTfRateLimit)
+	}
+	rateLimit := &RateLimitConfig{}
+	if requestsPerSecond, exists := rateLimitExtension["requests_per_second"]; exists {
+		rps, ok := requestsPerSecond.(float64)
+		if !ok {
+			return nil, fmt.Errorf("'%s' extension 'requests_per_second' value must be a number", extTfRateLimit)
+		}
+		rateLimit.RequestsPerSecond = rps
+	}
+	if burst, exists := rateLimitExtension["burst"]; exists {
+		b, ok := burst.(float64)
+		if !ok {
+			return nil, fmt.Errorf("'%s' extension 'burst' value must be a number", extTfRateLimit)
+		}
+		rateLimit.Burst = int(b)
+	}
+	if err := rateLimit.Validate(); err != nil {
+		return nil, fmt.Errorf("'%s' extension not valid: %s", extTfRateLimit, err)
+	}
+	return rateLimit, nil
+}
+
 func (o specV2BackendConfiguration) getBasePath() string {
 	return o.spec.BasePath
 }
@@ -544,6 +544,65 @@ func TestIsHostMultiRegion(t *testing.T) {
 	})
 }
 
+func TestGetRateLimit(t *testing.T) {
+	Convey("Given a specV2BackendConfiguration that has the x-terraform-rate-limit extension populated", t, func() {
+		spec := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Swagger: "2.0",
+			},
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfRateLimit: map[string]interface{}{"requests_per_second": float64(10), "burst": float64(20)},
+				},
+			},
+		}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getRateLimit() method is called", func() {
+			rateLimit, err := specV2BackendConfiguration.getRateLimit()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the rate limit returned should contain the expected values", func() {
+				So(rateLimit, ShouldResemble, &RateLimitConfig{RequestsPerSecond: 10, Burst: 20})
+			})
+		})
+	})
+	Convey("Given a specV2BackendConfiguration that has the x-terraform-rate-limit extension with a value that is not an object", t, func() {
+		spec := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Swagger: "2.0",
+			},
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfRateLimit: "10",
+				},
+			},
+		}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getRateLimit() method is called", func() {
+			_, err := specV2BackendConfiguration.getRateLimit()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "'x-terraform-rate-limit' extension value must be an object containing the 'requests_per_second' and optionally the 'burst'")
+			})
+		})
+	})
+	Convey("Given a specV2BackendConfiguration that does not have the x-terraform-rate-limit extension", t, func() {
+		spec := &spec.Swagger{
+			SwaggerProps: spec.SwaggerProps{
+				Swagger: "2.0",
+			},
+		}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getRateLimit() method is called", func() {
+			rateLimit, err := specV2BackendConfiguration.getRateLimit()
+			Convey("Then the error and rate limit returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(rateLimit, ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestGetBasePath(t *testing.T) {
 	Convey("Given a specV2BackendConfiguration with the basePath configured", t, func() {
 		spec := &spec.Swagger{
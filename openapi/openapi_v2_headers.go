@@ -3,10 +3,15 @@ package openapi
 import (
 	"github.com/go-openapi/spec"
 	"log"
+	"strings"
 )
 
 const extTfHeader = "x-terraform-header"
 
+// ifMatchHeader is the name of the precondition header used for optimistic concurrency control. The header value is
+// populated by the provider with the ETag of the resource so it is not exposed as a provider configuration property
+const ifMatchHeader = "If-Match"
+
 type parameterGroups [][]spec.Parameter
 
 // getHeaderConfigurations gets all the header configurations for a specific
@@ -17,7 +22,8 @@ func getHeaderConfigurations(parameters []spec.Parameter) SpecHeaderParameters {
 // getHeaderConfigurationsForParameterGroups loops through the provided parametersGroup (collection of parameters per operation) and
 // returns a map containing all the header configurations; the key will either be the value specified in the extTfHeader
 // or if not present the default value will be the name of the header. In any case, the key name will be translated to
-// a terraform compliant field name if needed (more details in convertToTerraformCompliantFieldName method)
+// a terraform compliant field name if needed (more details in convertToTerraformCompliantFieldName method). The If-Match
+// header is skipped as its value is managed by the provider
 func getHeaderConfigurationsForParameterGroups(parametersGroup parameterGroups) SpecHeaderParameters {
 	headerParameters := SpecHeaderParameters{}
 	headers := map[string]string{}
@@ -29,6 +35,9 @@ func getHeaderConfigurationsForParameterGroups(parametersGroup parameterGroups)
 				headers[parameter.Name] = parameter.Name
 				switch parameter.In {
 				case "header":
+					if isIfMatchHeader(parameter) {
+						continue
+					}
 					if preferredName, exists := parameter.Extensions.GetString(extTfHeader); exists {
 						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, TerraformName: preferredName, IsRequired: parameter.Required})
 					} else {
@@ -43,6 +52,20 @@ func getHeaderConfigurationsForParameterGroups(parametersGroup parameterGroups)
 	return headerParameters
 }
 
+// isIfMatchHeaderDeclared checks whether the given parameters contain the If-Match header parameter
+func isIfMatchHeaderDeclared(parameters []spec.Parameter) bool {
+	for _, parameter := range parameters {
+		if isIfMatchHeader(parameter) {
+			return true
+		}
+	}
+	return false
+}
+
+func isIfMatchHeader(parameter spec.Parameter) bool {
+	return parameter.In == "header" && strings.EqualFold(parameter.Name, ifMatchHeader)
+}
+
 // getPathHeaderParams aggregates all header type parameters found in the given path and returns the corresponding
 // header configurations
 func getPathHeaderParams(path spec.PathItem) SpecHeaderParameters {
@@ -176,6 +176,53 @@ func TestGetHeaderConfigurations(t *testing.T) {
 	})
 }
 
+func TestIsIfMatchHeaderDeclared(t *testing.T) {
+	Convey("Given a list of parameters containing the If-Match header parameter", t, func() {
+		parameters := []spec.Parameter{
+			{
+				ParamProps: spec.ParamProps{
+					Name: "if-match",
+					In:   "header",
+				},
+			},
+		}
+		Convey("When isIfMatchHeaderDeclared method is called", func() {
+			declared := isIfMatchHeaderDeclared(parameters)
+			Convey("Then the result returned should be true regardless of the header name case", func() {
+				So(declared, ShouldBeTrue)
+			})
+		})
+		Convey("When getHeaderConfigurations method is called", func() {
+			headerConfigProps := getHeaderConfigurations(parameters)
+			Convey("Then the header configs returned should not contain the If-Match header as its value is managed by the provider", func() {
+				So(headerConfigProps, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a list of parameters that does not contain the If-Match header parameter", t, func() {
+		parameters := []spec.Parameter{
+			{
+				ParamProps: spec.ParamProps{
+					Name: "X-Request-ID",
+					In:   "header",
+				},
+			},
+			{
+				ParamProps: spec.ParamProps{
+					Name: "If-Match",
+					In:   "query",
+				},
+			},
+		}
+		Convey("When isIfMatchHeaderDeclared method is called", func() {
+			declared := isIfMatchHeaderDeclared(parameters)
+			Convey("Then the result returned should be false", func() {
+				So(declared, ShouldBeFalse)
+			})
+		})
+	})
+}
+
 func TestGetAllHeaderParameters(t *testing.T) {
 	Convey("Given a swagger doc containing paths with header type parameters and different header names", t, func() {
 		spec := &spec.Swagger{
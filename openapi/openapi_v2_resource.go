@@ -57,15 +57,36 @@ const extTfFieldStatus = "x-terraform-field-status"
 const extTfID = "x-terraform-id"
 const extTfComputed = "x-terraform-computed"
 const extTfComplexObjectType = "x-terraform-complex-object-legacy-config"
+const extTfCompositeID = "x-terraform-composite-id"
+const extTfCompositeIDDelimiter = "x-terraform-composite-id-delimiter"
 
 // Operation level extensions
 const extTfResourceTimeout = "x-terraform-resource-timeout"
 const extTfResourcePollEnabled = "x-terraform-resource-poll-enabled"
 const extTfResourcePollTargetStatuses = "x-terraform-resource-poll-completed-statuses"
 const extTfResourcePollPendingStatuses = "x-terraform-resource-poll-pending-statuses"
+const extTfResourcePollFailureStatuses = "x-terraform-resource-poll-failure-statuses"
+const extTfResourcePollErrorField = "x-terraform-resource-poll-error-field"
+const extTfResourcePollDelay = "x-terraform-resource-poll-delay"
+const extTfResourcePollInterval = "x-terraform-resource-poll-interval"
+const extTfResourcePollBackoffMultiplier = "x-terraform-resource-poll-backoff-multiplier"
+const extTfResourcePollOperationHeader = "x-terraform-resource-poll-operation-header"
+const extTfResourcePollOperationStatusField = "x-terraform-resource-poll-operation-status-field"
+const extTfResourcePollOperationErrorField = "x-terraform-resource-poll-operation-error-field"
+const extTfResourcePollOperationCompletedStatuses = "x-terraform-resource-poll-operation-completed-statuses"
+const extTfResourcePollOperationFailedStatuses = "x-terraform-resource-poll-operation-failed-statuses"
+const extTfResourcePollOperationResultField = "x-terraform-resource-poll-operation-result-field"
 const extTfExcludeResource = "x-terraform-exclude-resource"
 const extTfResourceName = "x-terraform-resource-name"
 const extTfResourceURL = "x-terraform-resource-host"
+const extTfResourceRetryEnabled = "x-terraform-resource-retry-enabled"
+const extTfResourceRetryMaxAttempts = "x-terraform-resource-retry-max-attempts"
+const extTfResourceRetryInitialInterval = "x-terraform-resource-retry-initial-interval"
+const extTfResourceRetryMaxInterval = "x-terraform-resource-retry-max-interval"
+const extTfIdempotencyKeyHeader = "x-terraform-idempotency-key-header"
+const extTfErrorMessagePath = "x-terraform-error-message-path"
+const extTfRequestPath = "x-terraform-request-path"
+const extTfResponsePath = "x-terraform-response-path"
 
 // SpecV2Resource defines a struct that implements the SpecResource interface and it's based on OpenAPI v2 specification
 type SpecV2Resource struct {
@@ -73,6 +94,8 @@ type SpecV2Resource struct {
 	Region string
 	// Path contains the full relative path to the resource e,g: /v1/resource
 	Path string
+	// InstancePath contains the full relative path to the resource instance e,g: /v1/resource/{id}. Empty for data sources
+	InstancePath string
 	// SpecSchemaDefinition definition represents the representational state (aka model) of the resource
 	SchemaDefinition spec.Schema
 	// RootPathItem contains info about the resource root path e,g: /resource, including the POST operation used to create instances of this resource
@@ -85,6 +108,16 @@ type SpecV2Resource struct {
 	SchemaDefinitions map[string]spec.Schema
 
 	Paths map[string]spec.PathItem
+
+	// Consumes and Produces contain the global media types declared in the OpenAPI document, which are used if the
+	// resource operations do not declare their own
+	Consumes []string
+	Produces []string
+
+	// RequestPath and ResponsePath contain the global x-terraform-request-path and x-terraform-response-path extension
+	// values, which are used if the resource operations do not declare their own
+	RequestPath  string
+	ResponsePath string
 }
 
 // newSpecV2Resource creates a SpecV2Resource with no region and default host
@@ -277,6 +310,17 @@ func (o *SpecV2Resource) shouldIgnoreResource() bool {
 	return false
 }
 
+// getResourceInstancePathTemplate returns the resource instance path relative to the resource path, containing the path
+// parameters that identify the resource instance
+// Example: Given the path "/v1/records" and the instance path "/v1/records/{zone}/{record_name}" the result would be
+// "{zone}/{record_name}"
+func (o *SpecV2Resource) getResourceInstancePathTemplate() string {
+	if o.InstancePath == "" {
+		return ""
+	}
+	return strings.Trim(strings.TrimPrefix(o.InstancePath, strings.TrimRight(o.Path, "/")), "/")
+}
+
 func (o *SpecV2Resource) getParentResourceInfo() *parentResourceInfo {
 	resourceParentRegex, _ := regexp.Compile(resourceParentNameRegex)
 	parentMatches := resourceParentRegex.FindAllStringSubmatch(o.Path, -1)
@@ -365,12 +409,39 @@ func (o *SpecV2Resource) getSchemaDefinitionWithOptions(schema *spec.Schema, add
 		}
 	}
 
+	// Composite identifier properties are part of the resource instance path, hence updating them requires a new resource
+	schemaDefinition.CompositeIdentifier = getCompositeIdentifier(schema.Extensions)
+	schemaDefinition.CompositeIdentifierDelimiter = o.getExtensionStringValue(schema.Extensions, extTfCompositeIDDelimiter)
+	for _, propertyName := range schemaDefinition.CompositeIdentifier {
+		if property, exists := schemaProps[propertyName]; exists && !property.ReadOnly {
+			property.ForceNew = true
+		}
+	}
+
 	for _, property := range schemaProps {
 		schemaDefinition.Properties = append(schemaDefinition.Properties, property)
 	}
 	return schemaDefinition, nil
 }
 
+// getCompositeIdentifier returns the names of the properties that make up the composite identifier of the resource. The
+// extension value can be either a list of property names or a comma separated string (e,g: "zone,record_name,type")
+func getCompositeIdentifier(extensions spec.Extensions) []string {
+	var compositeIdentifier []string
+	if properties, exists := extensions.GetStringSlice(extTfCompositeID); exists {
+		compositeIdentifier = properties
+	} else if properties, exists := extensions.GetString(extTfCompositeID); exists {
+		compositeIdentifier = strings.Split(properties, ",")
+	}
+	var propertyNames []string
+	for _, propertyName := range compositeIdentifier {
+		if propertyName = strings.TrimSpace(propertyName); propertyName != "" {
+			propertyNames = append(propertyNames, propertyName)
+		}
+	}
+	return propertyNames
+}
+
 func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, property spec.Schema, requiredProperties []string) (*specSchemaDefinitionProperty, error) {
 	schemaDefinitionProperty := &specSchemaDefinitionProperty{}
 
@@ -669,12 +740,159 @@ func (o *SpecV2Resource) createResourceOperation(operation *spec.Operation) *spe
 		return nil
 	}
 	headerParameters := getHeaderConfigurations(operation.Parameters)
-	securitySchemes := createSecuritySchemes(operation.Security)
+	securityRequirements := createSecurityRequirements(operation.Security)
 	return &specResourceOperation{
-		HeaderParameters: headerParameters,
-		SecuritySchemes:  securitySchemes,
-		responses:        o.createResponses(operation),
+		HeaderParameters:     headerParameters,
+		SecurityRequirements: securityRequirements,
+		responses:            o.createResponses(operation),
+		retryPolicy:          o.createRetryPolicy(operation),
+		idempotencyKeyHeader: o.getIdempotencyKeyHeader(operation),
+		ifMatchEnabled:       isIfMatchHeaderDeclared(operation.Parameters),
+		errorMessagePath:     o.getErrorMessagePath(operation),
+		consumes:             o.getMediaTypes(operation.Consumes, o.Consumes),
+		produces:             o.getMediaTypes(operation.Produces, o.Produces),
+		xmlRootElement:       o.getXMLRootElement(operation),
+		requestPath:          parsePayloadPath(getPayloadPath(operation, extTfRequestPath, o.RequestPath)),
+		responsePath:         parsePayloadPath(getPayloadPath(operation, extTfResponsePath, o.ResponsePath)),
+	}
+}
+
+// getPayloadPath returns the envelope path configured in the operation with the given extension (x-terraform-request-path
+// or x-terraform-response-path); otherwise the global path passed in
+func getPayloadPath(operation *spec.Operation, extension, globalPath string) string {
+	if operation != nil {
+		if path, exists := operation.Extensions.GetString(extension); exists {
+			return path
+		}
+	}
+	return globalPath
+}
+
+// getMediaTypes returns the operation media types if declared; otherwise the global ones
+func (o *SpecV2Resource) getMediaTypes(operationMediaTypes, globalMediaTypes []string) []string {
+	if len(operationMediaTypes) > 0 {
+		return operationMediaTypes
+	}
+	return globalMediaTypes
+}
+
+// getXMLRootElement returns the xml name of the operation body parameter schema, falling back to the xml name of the
+// resource schema definition. Empty string is returned if none of them specifies an xml name
+func (o *SpecV2Resource) getXMLRootElement(operation *spec.Operation) string {
+	for _, parameter := range operation.Parameters {
+		if parameter.In == "body" && parameter.Schema != nil && parameter.Schema.XML != nil && parameter.Schema.XML.Name != "" {
+			return parameter.Schema.XML.Name
+		}
+	}
+	if o.SchemaDefinition.XML != nil {
+		return o.SchemaDefinition.XML.Name
+	}
+	return ""
+}
+
+// getErrorMessagePath returns the x-terraform-error-message-path configured in the default response of the operation or
+// in the operation itself. If none of them is configured, the path is derived from the schema of the default response
+// (if any). Empty string is returned otherwise
+func (o *SpecV2Resource) getErrorMessagePath(operation *spec.Operation) string {
+	if operation.Responses != nil && operation.Responses.Default != nil {
+		if messagePath := o.getExtensionStringValue(operation.Responses.Default.Extensions, extTfErrorMessagePath); messagePath != "" {
+			return messagePath
+		}
+	}
+	if messagePath := o.getExtensionStringValue(operation.Extensions, extTfErrorMessagePath); messagePath != "" {
+		return messagePath
+	}
+	if operation.Responses != nil && operation.Responses.Default != nil {
+		return getErrorMessagePathFromSchema(operation.Responses.Default.Schema)
+	}
+	return ""
+}
+
+// getResponseErrorMessagePath returns the x-terraform-error-message-path configured in the response. If not configured
+// and the response is an error response (4xx/5xx) with a schema, the path is derived from the schema properties
+func (o *SpecV2Resource) getResponseErrorMessagePath(statusCode int, response spec.Response) string {
+	if messagePath := o.getExtensionStringValue(response.Extensions, extTfErrorMessagePath); messagePath != "" {
+		return messagePath
+	}
+	if statusCode >= 400 {
+		return getErrorMessagePathFromSchema(response.Schema)
+	}
+	return ""
+}
+
+// getErrorMessagePathFromSchema returns the path to the error message property defined in the given error schema. The
+// schema properties are checked in order of preference (e,g: message, detail, etc) and string properties as well as
+// object properties containing a message (e,g: {"error": {"message": "..."}}) are considered. Empty string is returned
+// if the schema does not define any known error message property
+func getErrorMessagePathFromSchema(schema *spec.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	for _, name := range errorMessagePropertyNames {
+		property, exists := schema.Properties[name]
+		if !exists {
+			continue
+		}
+		if property.Type.Contains("string") {
+			return name
+		}
+		if message, exists := property.Properties["message"]; exists && message.Type.Contains("string") {
+			return name + ".message"
+		}
+	}
+	return ""
+}
+
+// getIdempotencyKeyHeader returns the name of the header configured in the x-terraform-idempotency-key-header extension;
+// empty string if the extension is not present
+func (o *SpecV2Resource) getIdempotencyKeyHeader(operation *spec.Operation) string {
+	if headerName, exists := operation.Extensions.GetString(extTfIdempotencyKeyHeader); exists {
+		return strings.TrimSpace(headerName)
 	}
+	return ""
+}
+
+// createRetryPolicy returns the retry policy override configured in the operation via the x-terraform-resource-retry-*
+// extensions; nil is returned if the operation does not contain any of the retry extensions. Invalid values are logged
+// and ignored
+func (o *SpecV2Resource) createRetryPolicy(operation *spec.Operation) *specRetryPolicy {
+	retryPolicy := &specRetryPolicy{}
+	configured := false
+	if enabled, exists := operation.Extensions.GetBool(extTfResourceRetryEnabled); exists {
+		retryPolicy.enabled = &enabled
+		configured = true
+	}
+	if value, exists := operation.Extensions[extTfResourceRetryMaxAttempts]; exists {
+		maxAttempts := 0
+		switch v := value.(type) {
+		case float64:
+			maxAttempts = int(v)
+		case int:
+			maxAttempts = v
+		}
+		if maxAttempts >= 1 {
+			retryPolicy.maxAttempts = maxAttempts
+			configured = true
+		} else {
+			log.Printf("[WARN] ignoring '%s' extension value '%v': the value must be an integer greater than zero", extTfResourceRetryMaxAttempts, value)
+		}
+	}
+	if initialInterval, err := o.getTimeDuration(operation.Extensions, extTfResourceRetryInitialInterval); err != nil {
+		log.Printf("[WARN] ignoring '%s' extension: %s", extTfResourceRetryInitialInterval, err)
+	} else if initialInterval != nil {
+		retryPolicy.initialInterval = *initialInterval
+		configured = true
+	}
+	if maxInterval, err := o.getTimeDuration(operation.Extensions, extTfResourceRetryMaxInterval); err != nil {
+		log.Printf("[WARN] ignoring '%s' extension: %s", extTfResourceRetryMaxInterval, err)
+	} else if maxInterval != nil {
+		retryPolicy.maxInterval = *maxInterval
+		configured = true
+	}
+	if !configured {
+		return nil
+	}
+	return retryPolicy
 }
 
 func (o *SpecV2Resource) createResponses(operation *spec.Operation) specResponses {
@@ -684,11 +902,89 @@ func (o *SpecV2Resource) createResponses(operation *spec.Operation) specResponse
 			isPollingEnabled:    o.isResourcePollingEnabled(response),
 			pollTargetStatuses:  o.getResourcePollTargetStatuses(response),
 			pollPendingStatuses: o.getResourcePollPendingStatuses(response),
+			pollFailureStatuses: o.getPollingStatuses(response, extTfResourcePollFailureStatuses),
+			pollErrorField:      o.getExtensionStringValue(response.Extensions, extTfResourcePollErrorField),
+			operationPolling:    o.createOperationPolling(response),
+			pollPolicy:          o.createPollPolicy(response),
+			errorMessagePath:    o.getResponseErrorMessagePath(statusCode, response),
 		}
 	}
 	return responses
 }
 
+// createPollPolicy returns the polling cadence configured in the response via the x-terraform-resource-poll-delay,
+// x-terraform-resource-poll-interval and x-terraform-resource-poll-backoff-multiplier extensions; nil is returned if the
+// response does not contain any of them. Invalid values are logged and ignored
+func (o *SpecV2Resource) createPollPolicy(response spec.Response) *specPollPolicy {
+	pollPolicy := &specPollPolicy{}
+	configured := false
+	if delay, err := o.getTimeDuration(response.Extensions, extTfResourcePollDelay); err != nil {
+		log.Printf("[WARN] ignoring '%s' extension: %s", extTfResourcePollDelay, err)
+	} else if delay != nil {
+		pollPolicy.delay = *delay
+		configured = true
+	}
+	if interval, err := o.getTimeDuration(response.Extensions, extTfResourcePollInterval); err != nil {
+		log.Printf("[WARN] ignoring '%s' extension: %s", extTfResourcePollInterval, err)
+	} else if interval != nil {
+		pollPolicy.interval = *interval
+		configured = true
+	}
+	if value, exists := response.Extensions[extTfResourcePollBackoffMultiplier]; exists {
+		multiplier := 0.0
+		switch v := value.(type) {
+		case float64:
+			multiplier = v
+		case int:
+			multiplier = float64(v)
+		}
+		if multiplier >= 1 {
+			pollPolicy.backoffMultiplier = multiplier
+			configured = true
+		} else {
+			log.Printf("[WARN] ignoring '%s' extension value '%v': the value must be a number greater than or equal to 1", extTfResourcePollBackoffMultiplier, value)
+		}
+	}
+	if !configured {
+		return nil
+	}
+	return pollPolicy
+}
+
+// createOperationPolling returns the long running operation polling configuration if the response contains the extension
+// 'x-terraform-resource-poll-operation-header'; otherwise nil is returned. The rest of the x-terraform-resource-poll-operation-*
+// extensions are optional and fall back to the default values if not present
+func (o *SpecV2Resource) createOperationPolling(response spec.Response) *specOperationPolling {
+	header := o.getExtensionStringValue(response.Extensions, extTfResourcePollOperationHeader)
+	if header == "" {
+		return nil
+	}
+	operationPolling := &specOperationPolling{
+		header:            strings.TrimSpace(header),
+		statusField:       defaultOperationStatusField,
+		errorField:        defaultOperationErrorField,
+		completedStatuses: defaultOperationCompletedStatuses,
+		failedStatuses:    defaultOperationFailedStatuses,
+		resultField:       defaultOperationResultField,
+	}
+	if statusField := o.getExtensionStringValue(response.Extensions, extTfResourcePollOperationStatusField); statusField != "" {
+		operationPolling.statusField = statusField
+	}
+	if errorField := o.getExtensionStringValue(response.Extensions, extTfResourcePollOperationErrorField); errorField != "" {
+		operationPolling.errorField = errorField
+	}
+	if resultField := o.getExtensionStringValue(response.Extensions, extTfResourcePollOperationResultField); resultField != "" {
+		operationPolling.resultField = resultField
+	}
+	if completedStatuses := o.getPollingStatuses(response, extTfResourcePollOperationCompletedStatuses); len(completedStatuses) > 0 {
+		operationPolling.completedStatuses = completedStatuses
+	}
+	if failedStatuses := o.getPollingStatuses(response, extTfResourcePollOperationFailedStatuses); len(failedStatuses) > 0 {
+		operationPolling.failedStatuses = failedStatuses
+	}
+	return operationPolling
+}
+
 // isResourcePollingEnabled checks whether there is any response code defined for the given responseStatusCode and if so
 // whether that response contains the extension 'x-terraform-resource-poll-enabled' set to true returning true;
 // otherwise false is returned
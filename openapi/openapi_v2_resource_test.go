@@ -1739,6 +1739,30 @@ func TestGetSchemaDefinitionWithOptions(t *testing.T) {
 	})
 }
 
+func TestGetResourceInstancePathTemplate(t *testing.T) {
+	Convey("Given a SpecV2Resource with an instance path containing multiple path parameters", t, func() {
+		r := SpecV2Resource{
+			Path:         "/v1/records/",
+			InstancePath: "/v1/records/{zone}/{record_name}",
+		}
+		Convey("When getResourceInstancePathTemplate is called", func() {
+			Convey("Then the path parameters following the resource path should be returned", func() {
+				So(r.getResourceInstancePathTemplate(), ShouldEqual, "{zone}/{record_name}")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource without instance path", t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/records",
+		}
+		Convey("When getResourceInstancePathTemplate is called", func() {
+			Convey("Then the result should be empty", func() {
+				So(r.getResourceInstancePathTemplate(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func TestGetResourcePath(t *testing.T) {
 
 	Convey("Given a SpecV2Resource with path resource that is not parameterised (root resource)", t, func() {
@@ -3759,6 +3783,33 @@ func TestCreateResponses(t *testing.T) {
 			})
 		})
 
+		Convey("When createResponses method is called with an operation response that has the failure statuses and error field extensions", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollEnabled, true)
+			extensions.Add(extTfResourcePollFailureStatuses, "failed, error")
+			extensions.Add(extTfResourcePollErrorField, "status_reason")
+			operation := &spec.Operation{
+				OperationProps: spec.OperationProps{
+					Responses: &spec.Responses{
+						ResponsesProps: spec.ResponsesProps{
+							StatusCodeResponses: map[int]spec.Response{
+								http.StatusAccepted: {
+									VendorExtensible: spec.VendorExtensible{
+										Extensions: extensions,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			specResponses := r.createResponses(operation)
+			Convey("Then the response should contain the failure statuses and the error field", func() {
+				So(specResponses[http.StatusAccepted].pollFailureStatuses, ShouldResemble, []string{"failed", "error"})
+				So(specResponses[http.StatusAccepted].pollErrorField, ShouldEqual, "status_reason")
+			})
+		})
+
 		Convey("When createResponses method is called with an operation does not have any status responses", func() {
 			operation := &spec.Operation{
 				OperationProps: spec.OperationProps{
@@ -3777,6 +3828,262 @@ func TestCreateResponses(t *testing.T) {
 	})
 }
 
+func TestCreatePollPolicy(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When createPollPolicy method is called with a response that has the poll cadence extensions", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollDelay, "30s")
+			extensions.Add(extTfResourcePollInterval, "1m")
+			extensions.Add(extTfResourcePollBackoffMultiplier, 1.5)
+			pollPolicy := r.createPollPolicy(spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the poll policy returned should contain the values configured", func() {
+				So(pollPolicy, ShouldResemble, &specPollPolicy{delay: 30 * time.Second, interval: time.Minute, backoffMultiplier: 1.5})
+			})
+		})
+		Convey("When createPollPolicy method is called with a response that has invalid poll cadence extensions", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollInterval, "often")
+			extensions.Add(extTfResourcePollBackoffMultiplier, 0.5)
+			pollPolicy := r.createPollPolicy(spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the invalid values should be ignored and the poll policy returned should be nil", func() {
+				So(pollPolicy, ShouldBeNil)
+			})
+		})
+		Convey("When createPollPolicy method is called with a response that does not have any poll cadence extension", func() {
+			pollPolicy := r.createPollPolicy(spec.Response{})
+			Convey("Then the poll policy returned should be nil", func() {
+				So(pollPolicy, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetErrorMessagePathV2(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getErrorMessagePath method is called with an operation whose default response has the '%s' extension", extTfErrorMessagePath), func() {
+			responseExtensions := spec.Extensions{}
+			responseExtensions.Add(extTfErrorMessagePath, "error.message")
+			operationExtensions := spec.Extensions{}
+			operationExtensions.Add(extTfErrorMessagePath, "message")
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: operationExtensions},
+				OperationProps: spec.OperationProps{
+					Responses: &spec.Responses{
+						ResponsesProps: spec.ResponsesProps{
+							Default: &spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: responseExtensions}},
+						},
+					},
+				},
+			}
+			messagePath := r.getErrorMessagePath(operation)
+			Convey("Then the message path returned should be the one configured in the default response", func() {
+				So(messagePath, ShouldEqual, "error.message")
+			})
+		})
+		Convey(fmt.Sprintf("When getErrorMessagePath method is called with an operation that has the '%s' extension only", extTfErrorMessagePath), func() {
+			operationExtensions := spec.Extensions{}
+			operationExtensions.Add(extTfErrorMessagePath, "message")
+			messagePath := r.getErrorMessagePath(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: operationExtensions}})
+			Convey("Then the message path returned should be the one configured in the operation", func() {
+				So(messagePath, ShouldEqual, "message")
+			})
+		})
+		Convey("When getErrorMessagePath method is called with an operation that does not configure the message path", func() {
+			messagePath := r.getErrorMessagePath(&spec.Operation{})
+			Convey("Then the message path returned should be empty", func() {
+				So(messagePath, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetResponseErrorMessagePath(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		errorSchema := &spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Properties: map[string]spec.Schema{
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Type: spec.StringOrArray{"object"},
+							Properties: map[string]spec.Schema{
+								"message": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey(fmt.Sprintf("When getResponseErrorMessagePath method is called with an error response that has the '%s' extension and a schema", extTfErrorMessagePath), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfErrorMessagePath, "fault.description")
+			messagePath := r.getResponseErrorMessagePath(http.StatusBadRequest, spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: extensions}, ResponseProps: spec.ResponseProps{Schema: errorSchema}})
+			Convey("Then the message path returned should be the one configured in the extension", func() {
+				So(messagePath, ShouldEqual, "fault.description")
+			})
+		})
+		Convey("When getResponseErrorMessagePath method is called with an error response that has a schema with a known error property", func() {
+			messagePath := r.getResponseErrorMessagePath(http.StatusInternalServerError, spec.Response{ResponseProps: spec.ResponseProps{Schema: errorSchema}})
+			Convey("Then the message path returned should be derived from the schema", func() {
+				So(messagePath, ShouldEqual, "error.message")
+			})
+		})
+		Convey("When getResponseErrorMessagePath method is called with a successful response that has a schema with a known error property", func() {
+			messagePath := r.getResponseErrorMessagePath(http.StatusOK, spec.Response{ResponseProps: spec.ResponseProps{Schema: errorSchema}})
+			Convey("Then the message path returned should be empty", func() {
+				So(messagePath, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetErrorMessagePathFromSchema(t *testing.T) {
+	testCases := []struct {
+		name                string
+		schema              *spec.Schema
+		expectedMessagePath string
+	}{
+		{
+			name:                "nil schema",
+			schema:              nil,
+			expectedMessagePath: "",
+		},
+		{
+			name: "schema with several known string properties",
+			schema: &spec.Schema{SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{
+				"title":  {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+				"detail": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+			}}},
+			expectedMessagePath: "detail",
+		},
+		{
+			name: "schema with a known property that is not a string nor contains a message",
+			schema: &spec.Schema{SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{
+				"error": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"integer"}}},
+			}}},
+			expectedMessagePath: "",
+		},
+		{
+			name: "schema without known properties",
+			schema: &spec.Schema{SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{
+				"code": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+			}}},
+			expectedMessagePath: "",
+		},
+	}
+	for _, tc := range testCases {
+		messagePath := getErrorMessagePathFromSchema(tc.schema)
+		assert.Equal(t, tc.expectedMessagePath, messagePath, tc.name)
+	}
+}
+
+func TestCreateResourceOperationMediaTypes(t *testing.T) {
+	Convey("Given a SpecV2Resource with global consumes and produces and a schema definition with an xml name", t, func() {
+		r := SpecV2Resource{
+			Consumes:         []string{"application/json"},
+			Produces:         []string{"application/json"},
+			SchemaDefinition: spec.Schema{SwaggerSchemaProps: spec.SwaggerSchemaProps{XML: &spec.XMLObject{Name: "cdn"}}},
+		}
+		Convey("When createResourceOperation is called with an operation that declares its own consumes and a body parameter with an xml name", func() {
+			operation := &spec.Operation{
+				OperationProps: spec.OperationProps{
+					Consumes: []string{"application/x-www-form-urlencoded"},
+					Parameters: []spec.Parameter{
+						{ParamProps: spec.ParamProps{In: "body", Name: "body", Schema: &spec.Schema{SwaggerSchemaProps: spec.SwaggerSchemaProps{XML: &spec.XMLObject{Name: "contentDeliveryNetwork"}}}}},
+					},
+					Responses: &spec.Responses{},
+				},
+			}
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the operation consumes should take preference over the global ones and produces should fall back to the global ones", func() {
+				So(resourceOperation.consumes, ShouldResemble, []string{"application/x-www-form-urlencoded"})
+				So(resourceOperation.produces, ShouldResemble, []string{"application/json"})
+			})
+			Convey("And the xml root element should be the body parameter xml name", func() {
+				So(resourceOperation.xmlRootElement, ShouldEqual, "contentDeliveryNetwork")
+			})
+		})
+		Convey("When createResourceOperation is called with an operation that does not declare a body parameter", func() {
+			resourceOperation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the xml root element should be the schema definition xml name", func() {
+				So(resourceOperation.xmlRootElement, ShouldEqual, "cdn")
+			})
+		})
+	})
+}
+
+func TestCreateResourceOperationEnvelopePaths(t *testing.T) {
+	Convey("Given a SpecV2Resource with global request and response paths", t, func() {
+		r := SpecV2Resource{RequestPath: "data", ResponsePath: "/data"}
+		Convey("When createResourceOperation is called with an operation that does not override the paths", func() {
+			resourceOperation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the operation should use the global paths", func() {
+				So(resourceOperation.requestPath, ShouldResemble, []string{"data"})
+				So(resourceOperation.responsePath, ShouldResemble, []string{"data"})
+			})
+		})
+		Convey(fmt.Sprintf("When createResourceOperation is called with an operation that overrides the paths with the '%s' and '%s' extensions", extTfRequestPath, extTfResponsePath), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfRequestPath, "")
+			extensions.Add(extTfResponsePath, "result.item")
+			resourceOperation := r.createResourceOperation(&spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: extensions}, OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the operation paths should take preference over the global ones", func() {
+				So(resourceOperation.requestPath, ShouldBeNil)
+				So(resourceOperation.responsePath, ShouldResemble, []string{"result", "item"})
+			})
+		})
+	})
+}
+
+func TestCreateOperationPolling(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When createOperationPolling method is called with a response that has the '%s' extension only", extTfResourcePollOperationHeader), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollOperationHeader, "Operation-Location")
+			operationPolling := r.createOperationPolling(spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the operation polling returned should contain the header and the default values", func() {
+				So(operationPolling, ShouldResemble, &specOperationPolling{
+					header:            "Operation-Location",
+					statusField:       "state",
+					errorField:        "error",
+					completedStatuses: []string{"succeeded"},
+					failedStatuses:    []string{"failed", "canceled"},
+					resultField:       "result",
+				})
+			})
+		})
+		Convey("When createOperationPolling method is called with a response that has all the operation polling extensions", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollOperationHeader, "Location")
+			extensions.Add(extTfResourcePollOperationStatusField, "status")
+			extensions.Add(extTfResourcePollOperationErrorField, "failure")
+			extensions.Add(extTfResourcePollOperationCompletedStatuses, "done, ok")
+			extensions.Add(extTfResourcePollOperationFailedStatuses, "error")
+			extensions.Add(extTfResourcePollOperationResultField, "response")
+			operationPolling := r.createOperationPolling(spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: extensions}})
+			Convey("Then the operation polling returned should contain the values configured", func() {
+				So(operationPolling, ShouldResemble, &specOperationPolling{
+					header:            "Location",
+					statusField:       "status",
+					errorField:        "failure",
+					completedStatuses: []string{"done", "ok"},
+					failedStatuses:    []string{"error"},
+					resultField:       "response",
+				})
+			})
+		})
+		Convey(fmt.Sprintf("When createOperationPolling method is called with a response that does not have the '%s' extension", extTfResourcePollOperationHeader), func() {
+			operationPolling := r.createOperationPolling(spec.Response{})
+			Convey("Then the operation polling returned should be nil", func() {
+				So(operationPolling, ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestIsResourcePollingEnabled(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
@@ -4016,6 +4323,76 @@ func TestGetTimeouts(t *testing.T) {
 	})
 }
 
+func TestGetIdempotencyKeyHeader(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getIdempotencyKeyHeader method is called with an operation that has the extension '%s'", extTfIdempotencyKeyHeader), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfIdempotencyKeyHeader, "Idempotency-Key")
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: extensions,
+				},
+			}
+			headerName := r.getIdempotencyKeyHeader(operation)
+			Convey("Then the header name returned should be the one configured in the extension", func() {
+				So(headerName, ShouldEqual, "Idempotency-Key")
+			})
+		})
+		Convey(fmt.Sprintf("When getIdempotencyKeyHeader method is called with an operation that does not have the extension '%s'", extTfIdempotencyKeyHeader), func() {
+			headerName := r.getIdempotencyKeyHeader(&spec.Operation{})
+			Convey("Then the header name returned should be empty", func() {
+				So(headerName, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCreateRetryPolicy(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When createRetryPolicy method is called with an operation that has the retry extensions", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceRetryEnabled, true)
+			extensions.Add(extTfResourceRetryMaxAttempts, float64(5))
+			extensions.Add(extTfResourceRetryInitialInterval, "2s")
+			extensions.Add(extTfResourceRetryMaxInterval, "1m")
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: extensions,
+				},
+			}
+			retryPolicy := r.createRetryPolicy(operation)
+			Convey("Then the retry policy returned should contain the values configured in the extensions", func() {
+				So(*retryPolicy.enabled, ShouldBeTrue)
+				So(retryPolicy.maxAttempts, ShouldEqual, 5)
+				So(retryPolicy.initialInterval, ShouldEqual, 2*time.Second)
+				So(retryPolicy.maxInterval, ShouldEqual, time.Minute)
+			})
+		})
+		Convey("When createRetryPolicy method is called with an operation that has invalid retry extension values", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceRetryMaxAttempts, float64(0))
+			extensions.Add(extTfResourceRetryInitialInterval, "soon")
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: extensions,
+				},
+			}
+			retryPolicy := r.createRetryPolicy(operation)
+			Convey("Then the retry policy returned should be nil as the invalid values are ignored", func() {
+				So(retryPolicy, ShouldBeNil)
+			})
+		})
+		Convey("When createRetryPolicy method is called with an operation that does not have the retry extensions", func() {
+			retryPolicy := r.createRetryPolicy(&spec.Operation{})
+			Convey("Then the retry policy returned should be nil", func() {
+				So(retryPolicy, ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestGetResourceTimeout(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
@@ -4356,3 +4733,73 @@ func TestGetResourceOverrideHost(t *testing.T) {
 		})
 	})
 }
+
+func TestGetCompositeIdentifier(t *testing.T) {
+	Convey("Given extensions containing the composite identifier as a comma separated string", t, func() {
+		extensions := spec.Extensions{}
+		extensions.Add(extTfCompositeID, "zone, record_name,type")
+		Convey("When getCompositeIdentifier is called", func() {
+			compositeIdentifier := getCompositeIdentifier(extensions)
+			Convey("Then the property names should be returned in order", func() {
+				So(compositeIdentifier, ShouldResemble, []string{"zone", "record_name", "type"})
+			})
+		})
+	})
+	Convey("Given extensions containing the composite identifier as a list", t, func() {
+		extensions := spec.Extensions{}
+		extensions.Add(extTfCompositeID, []interface{}{"zone", "record_name"})
+		Convey("When getCompositeIdentifier is called", func() {
+			compositeIdentifier := getCompositeIdentifier(extensions)
+			Convey("Then the property names should be returned in order", func() {
+				So(compositeIdentifier, ShouldResemble, []string{"zone", "record_name"})
+			})
+		})
+	})
+	Convey("Given extensions without the composite identifier", t, func() {
+		extensions := spec.Extensions{}
+		Convey("When getCompositeIdentifier is called", func() {
+			compositeIdentifier := getCompositeIdentifier(extensions)
+			Convey("Then the result should be empty", func() {
+				So(compositeIdentifier, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetResourceSchemaCompositeIdentifier(t *testing.T) {
+	Convey("Given a SpecV2Resource with a schema definition declaring a composite identifier", t, func() {
+		extensions := spec.Extensions{}
+		extensions.Add(extTfCompositeID, "zone,record_name")
+		extensions.Add(extTfCompositeIDDelimiter, "_")
+		r := SpecV2Resource{
+			SchemaDefinition: spec.Schema{
+				VendorExtensible: spec.VendorExtensible{Extensions: extensions},
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"zone":        {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+						"record_name": {SchemaProps: spec.SchemaProps{Type: []string{"string"}}},
+						"ttl":         {SchemaProps: spec.SchemaProps{Type: []string{"integer"}}},
+					},
+				},
+			},
+		}
+		Convey("When getResourceSchema is called", func() {
+			resourceSchema, err := r.getResourceSchema()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema definition should contain the composite identifier configuration", func() {
+				So(resourceSchema.CompositeIdentifier, ShouldResemble, []string{"zone", "record_name"})
+				So(resourceSchema.CompositeIdentifierDelimiter, ShouldEqual, "_")
+			})
+			Convey("And the composite identifier properties should be force new", func() {
+				zone, _ := resourceSchema.getProperty("zone")
+				recordName, _ := resourceSchema.getProperty("record_name")
+				ttl, _ := resourceSchema.getProperty("ttl")
+				So(zone.ForceNew, ShouldBeTrue)
+				So(recordName.ForceNew, ShouldBeTrue)
+				So(ttl.ForceNew, ShouldBeFalse)
+			})
+		})
+	})
+}
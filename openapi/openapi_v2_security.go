@@ -2,6 +2,8 @@ package openapi
 
 import (
 	"fmt"
+	"log"
+
 	"github.com/go-openapi/spec"
 )
 
@@ -35,8 +37,10 @@ func (s *specV2Security) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinition
 				} else {
 					securityDefinition = newAPIKeyQuerySecurityDefinition(secDefName, secDef.Name)
 				}
+			case "cookie":
+				securityDefinition = newAPIKeyCookieSecurityDefinition(secDefName, secDef.Name)
 			default:
-				return nil, fmt.Errorf("apiKey In value '%s' not supported, only 'header' and 'query' values are valid", secDef.In)
+				return nil, fmt.Errorf("apiKey In value '%s' not supported, only 'header', 'query' and 'cookie' values are valid", secDef.In)
 			}
 			if err := securityDefinition.validate(); err != nil {
 				return nil, err
@@ -63,18 +67,41 @@ func (s *specV2Security) isRefreshTokenAuth(secDef *spec.SecurityScheme) string
 	return ""
 }
 
-// GetGlobalSecuritySchemes returns a list of SpecSecuritySchemes that have their corresponding SpecSecurityDefinition
-func (s *specV2Security) GetGlobalSecuritySchemes() (SpecSecuritySchemes, error) {
-	securitySchemes := createSecuritySchemes(s.GlobalSecurity)
-	for _, securityScheme := range securitySchemes {
-		secDef, err := s.GetAPIKeySecurityDefinitions()
-		if err != nil {
-			return SpecSecuritySchemes{}, nil
+// GetGlobalSecurityRequirements returns the global SpecSecurityRequirements whose security schemes have their corresponding
+// SpecSecurityDefinition. Alternatives referring to security schemes that are not defined can not be used and are
+// therefore ignored; however, if none of the alternatives can be used an error is returned
+func (s *specV2Security) GetGlobalSecurityRequirements() (SpecSecurityRequirements, error) {
+	securityRequirements := createSecurityRequirements(s.GlobalSecurity)
+	if len(securityRequirements) == 0 {
+		return securityRequirements, nil
+	}
+	secDefs, err := s.GetAPIKeySecurityDefinitions()
+	if err != nil {
+		return SpecSecurityRequirements{}, nil
+	}
+	usableSecurityRequirements := SpecSecurityRequirements{}
+	var firstErr error
+	for _, securitySchemes := range securityRequirements {
+		if err := s.validateSecuritySchemes(securitySchemes, secDefs); err != nil {
+			log.Printf("[WARN] ignoring global security requirement %s as it can not be used: %s", securitySchemes, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
 		}
-		secDefFound := secDef.findSecurityDefinitionFor(securityScheme.Name)
-		if secDefFound == nil {
-			return nil, fmt.Errorf("global security scheme '%s' not found or not matching supported 'apiKey' type", securityScheme.Name)
+		usableSecurityRequirements = append(usableSecurityRequirements, securitySchemes)
+	}
+	if len(usableSecurityRequirements) == 0 {
+		return nil, firstErr
+	}
+	return usableSecurityRequirements, nil
+}
+
+func (s *specV2Security) validateSecuritySchemes(securitySchemes SpecSecuritySchemes, secDefs *SpecSecurityDefinitions) error {
+	for _, securityScheme := range securitySchemes {
+		if secDefs.findSecurityDefinitionFor(securityScheme.Name) == nil {
+			return fmt.Errorf("global security scheme '%s' not found or not matching supported 'apiKey' type", securityScheme.Name)
 		}
 	}
-	return securitySchemes, nil
+	return nil
 }
@@ -40,6 +40,36 @@ func TestGetAPIKeySecurityDefinitions(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a specV2Security loaded with a security definition of type cookie", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"session_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						In:   "cookie",
+						Type: "apiKey",
+						Name: "session_id",
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+			secDefs := *securityDefinitions
+			Convey("Then the the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the security schemes match the expectations", func() {
+				So(secDefs, ShouldNotBeEmpty)
+			})
+			Convey("And the security schemes should be of type cookie", func() {
+				So(secDefs[0], ShouldHaveSameTypeAs, specAPIKeyCookieSecurityDefinition{})
+				So(secDefs[0].getAPIKey().Name, ShouldEqual, "session_id")
+				So(secDefs[0].getAPIKey().In, ShouldEqual, inCookie)
+				So(secDefs[0].buildValue("someToken"), ShouldEqual, "someToken")
+			})
+		})
+	})
 	Convey("Given a specV2Security loaded with a security definition of type header", t, func() {
 		specV2Security := specV2Security{
 			GlobalSecurity: []map[string][]string{},
@@ -263,13 +293,13 @@ func TestGetAPIKeySecurityDefinitions(t *testing.T) {
 		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
 			_, err := specV2Security.GetAPIKeySecurityDefinitions()
 			Convey("And the error should match the expected one", func() {
-				So(err.Error(), ShouldEqual, "apiKey In value 'some_other_location' not supported, only 'header' and 'query' values are valid")
+				So(err.Error(), ShouldEqual, "apiKey In value 'some_other_location' not supported, only 'header', 'query' and 'cookie' values are valid")
 			})
 		})
 	})
 }
 
-func TestGetGlobalSecuritySchemes(t *testing.T) {
+func TestGetGlobalSecurityRequirements(t *testing.T) {
 	Convey("Given a specV2Security loaded with a global security scheme which is defined in the security definitions", t, func() {
 		expectedSecuritySchemeName := "apikey_auth"
 		specV2Security := specV2Security{
@@ -288,16 +318,16 @@ func TestGetGlobalSecuritySchemes(t *testing.T) {
 				},
 			},
 		}
-		Convey("When GetGlobalSecuritySchemes method is called", func() {
-			specSecuritySchemes, err := specV2Security.GetGlobalSecuritySchemes()
+		Convey("When GetGlobalSecurityRequirements method is called", func() {
+			specSecurityRequirements, err := specV2Security.GetGlobalSecurityRequirements()
 			Convey("Then the the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the security schemes should not be empty", func() {
-				So(specSecuritySchemes, ShouldNotBeEmpty)
+			Convey("And the security requirements should not be empty", func() {
+				So(specSecurityRequirements, ShouldNotBeEmpty)
 			})
-			Convey("And the security schemes should have the right security scheme name", func() {
-				So(specSecuritySchemes[0].Name, ShouldEqual, expectedSecuritySchemeName)
+			Convey("And the security requirements should have the right security scheme name", func() {
+				So(specSecurityRequirements[0][0].Name, ShouldEqual, expectedSecuritySchemeName)
 			})
 		})
 	})
@@ -318,8 +348,8 @@ func TestGetGlobalSecuritySchemes(t *testing.T) {
 				},
 			},
 		}
-		Convey("When GetGlobalSecuritySchemes method is called", func() {
-			_, err := specV2Security.GetGlobalSecuritySchemes()
+		Convey("When GetGlobalSecurityRequirements method is called", func() {
+			_, err := specV2Security.GetGlobalSecurityRequirements()
 			Convey("Then the the error returned should be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -328,6 +358,46 @@ func TestGetGlobalSecuritySchemes(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a specV2Security loaded with multiple global security alternatives where one of them is NOT defined", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{
+				{
+					"nonExistingScheme": []string{},
+				},
+				{
+					"apikey_auth": []string{},
+					"app_id":      []string{},
+				},
+			},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"apikey_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						In:   "header",
+						Type: "apiKey",
+						Name: authorizationHeader,
+					},
+				},
+				"app_id": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						In:   "header",
+						Type: "apiKey",
+						Name: "X-App-ID",
+					},
+				},
+			},
+		}
+		Convey("When GetGlobalSecurityRequirements method is called", func() {
+			specSecurityRequirements, err := specV2Security.GetGlobalSecurityRequirements()
+			Convey("Then the the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the security requirements should only contain the usable alternative", func() {
+				So(specSecurityRequirements, ShouldResemble, SpecSecurityRequirements{
+					SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_auth"}, SpecSecurityScheme{Name: "app_id"}},
+				})
+			})
+		})
+	})
 }
 
 func TestIsBearerScheme(t *testing.T) {
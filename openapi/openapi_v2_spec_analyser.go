@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -25,32 +26,59 @@ type specV2Analyser struct {
 }
 
 // newSpecAnalyserV2 creates an instance of specV2Analyser which implements the SpecAnalyser interface
-// This implementation provides an analyser that understands an OpenAPI v2 document
-func newSpecAnalyserV2(openAPIDocumentFilename string) (*specV2Analyser, error) {
+// This implementation provides an analyser that understands an OpenAPI v2 document. The overlays (if any) are applied
+// to the document before it is analysed
+func newSpecAnalyserV2(openAPIDocumentFilename string, overlays []string) (*specV2Analyser, error) {
 	if openAPIDocumentFilename == "" {
 		return nil, errors.New("open api document filename argument empty, please provide the url of the OpenAPI document")
 	}
+	if len(overlays) > 0 {
+		document, err := loadSwaggerDocument(openAPIDocumentFilename, overlays)
+		if err != nil {
+			return nil, err
+		}
+		return newSpecAnalyserV2FromDocument(openAPIDocumentFilename, document)
+	}
 	apiSpec, err := loads.JSONSpec(openAPIDocumentFilename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
 	}
-	apiSpec, err = apiSpec.Expanded()
+	return newExpandedSpecAnalyserV2(openAPIDocumentFilename, apiSpec)
+}
+
+// newSpecAnalyserV2FromDocument creates an instance of specV2Analyser for the given OpenAPI v2 document (in JSON) which
+// has already been retrieved from openAPIDocumentURL. The URL is still needed to resolve the external refs of the document
+// as well as the API host when the document does not specify one
+func newSpecAnalyserV2FromDocument(openAPIDocumentURL string, document json.RawMessage) (*specV2Analyser, error) {
+	apiSpec, err := loads.Analyzed(document, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to expand the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+		return nil, fmt.Errorf("failed to analyse the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	// The document loaded from memory does not know where it was retrieved from, hence the external refs are resolved
+	// relative to openAPIDocumentURL
+	return newExpandedSpecAnalyserV2(openAPIDocumentURL, apiSpec, &spec.ExpandOptions{RelativeBase: openAPIDocumentURL})
+}
+
+func newExpandedSpecAnalyserV2(openAPIDocumentURL string, apiSpec *loads.Document, expandOptions ...*spec.ExpandOptions) (*specV2Analyser, error) {
+	apiSpec, err := apiSpec.Expanded(expandOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
 	}
 	return &specV2Analyser{
 		d:                  apiSpec,
-		openAPIDocumentURL: openAPIDocumentFilename,
+		openAPIDocumentURL: openAPIDocumentURL,
 	}, nil
 }
 
-func (specAnalyser *specV2Analyser) createMultiRegionResources(regions []string, resourceRootPath string, resourceRoot, pathItem spec.PathItem, resourcePayloadSchemaDef *spec.Schema) ([]SpecResource, error) {
+func (specAnalyser *specV2Analyser) createMultiRegionResources(regions []string, resourceRootPath, resourcePath string, resourceRoot, pathItem spec.PathItem, resourcePayloadSchemaDef *spec.Schema) ([]SpecResource, error) {
 	var resources []SpecResource
 	for _, regionName := range regions {
 		r, err := newSpecV2ResourceWithRegion(regionName, resourceRootPath, *resourcePayloadSchemaDef, resourceRoot, pathItem, specAnalyser.d.Spec().Definitions, specAnalyser.d.Spec().Paths.Paths)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create a resource with region: %s", err)
 		}
+		r.InstancePath = resourcePath
+		specAnalyser.configureGlobalOperationSettings(r)
 		log.Printf("[INFO] multi region resource name = %s, region = '%s'", r.getResourceName(), regionName)
 		resources = append(resources, r)
 	}
@@ -73,6 +101,7 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantDataSources() []SpecRes
 			log.Printf("[WARN] ignoring data source '%s' due to an error while creating a creating the SpecV2Resource: %s", resourcePath, err)
 			continue
 		}
+		specAnalyser.configureGlobalOperationSettings(d)
 
 		log.Printf("[INFO] found terraform compliant data source [name='%s', rootPath='%s']", d.getResourceName(), resourcePath)
 		dataSources = append(dataSources, d)
@@ -99,7 +128,7 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecReso
 		}
 		if isMultiRegion {
 			log.Printf("[INFO] resource '%s' is configured with host override AND multi region; creating one reasource per region", resourceRootPath)
-			multiRegionResources, err := specAnalyser.createMultiRegionResources(regions, resourceRootPath, *resourceRoot, pathItem, resourcePayloadSchemaDef)
+			multiRegionResources, err := specAnalyser.createMultiRegionResources(regions, resourceRootPath, resourcePath, *resourceRoot, pathItem, resourcePayloadSchemaDef)
 			if err != nil {
 				log.Printf("[WARN] ignoring multiregion resource '%s' due to an error: %s", resourceRootPath, err)
 				continue
@@ -113,6 +142,8 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecReso
 			log.Printf("[WARN] ignoring resource '%s' due to an error while creating a creating the SpecV2Resource: %s", resourceRootPath, err)
 			continue
 		}
+		r.InstancePath = resourcePath
+		specAnalyser.configureGlobalOperationSettings(r)
 
 		err = specAnalyser.validateSubResourceTerraformCompliance(*r)
 		if err != nil {
@@ -127,6 +158,33 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecReso
 	return resources, nil
 }
 
+// configureGlobalOperationSettings populates the resource with the settings declared at the root level of the OpenAPI
+// document that apply to all the resource operations unless overridden at the operation level
+func (specAnalyser *specV2Analyser) configureGlobalOperationSettings(r *SpecV2Resource) {
+	r.Consumes, r.Produces = specAnalyser.d.Spec().Consumes, specAnalyser.d.Spec().Produces
+	r.RequestPath = specAnalyser.getGlobalExtensionStringValue(extTfRequestPath)
+	r.ResponsePath = specAnalyser.getGlobalExtensionStringValue(extTfResponsePath)
+}
+
+func (specAnalyser *specV2Analyser) getGlobalExtensionStringValue(extension string) string {
+	if specAnalyser.d == nil {
+		return ""
+	}
+	value, _ := specAnalyser.d.Spec().Extensions.GetString(extension)
+	return value
+}
+
+// getEnvelopeSchema returns the schema located at the operation envelope path (x-terraform-request-path or
+// x-terraform-response-path, falling back to the global extension) inside the given schema. The schema is returned as is
+// if no envelope path is configured
+func (specAnalyser *specV2Analyser) getEnvelopeSchema(operation *spec.Operation, extension string, schema *spec.Schema) (*spec.Schema, error) {
+	path := parsePayloadPath(getPayloadPath(operation, extension, specAnalyser.getGlobalExtensionStringValue(extension)))
+	if len(path) == 0 {
+		return schema, nil
+	}
+	return getSchemaAtPath(schema, path)
+}
+
 func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r SpecV2Resource) error {
 	parentResourceInfo := r.getParentResourceInfo()
 	if parentResourceInfo != nil {
@@ -287,9 +345,45 @@ func (specAnalyser *specV2Analyser) isEndPointFullyTerraformResourceCompliant(re
 	if err != nil {
 		return "", nil, nil, err
 	}
+	err = specAnalyser.validateInstancePathIdentifiers(resourcePath, resourceRootPath, resourceRootPostSchemaDef)
+	if err != nil {
+		return "", nil, nil, err
+	}
 	return resourceRootPath, resourceRootPathItem, resourceRootPostSchemaDef, nil
 }
 
+// validateInstancePathIdentifiers checks that the number of path parameters following the resource root path in the instance
+// path matches the number of properties identifying the resource. Resources with a composite identifier are expected to
+// declare one path parameter per identifier property, in the same order (e,g: /v1/records/{zone}/{record_name}/{type})
+func (specAnalyser *specV2Analyser) validateInstancePathIdentifiers(resourceInstancePath, resourceRootPath string, schema *spec.Schema) error {
+	instancePathParameters := specAnalyser.getInstancePathParameters(resourceInstancePath, resourceRootPath)
+	compositeIdentifier := getCompositeIdentifier(schema.Extensions)
+	if len(compositeIdentifier) == 0 {
+		if len(instancePathParameters) > 1 {
+			return fmt.Errorf("resource instance path '%s' contains multiple path parameters %s but the resource schema does not declare a composite identifier (%s)", resourceInstancePath, instancePathParameters, extTfCompositeID)
+		}
+		return nil
+	}
+	if len(instancePathParameters) != len(compositeIdentifier) {
+		return fmt.Errorf("resource instance path '%s' path parameters %s do not match the composite identifier properties %s", resourceInstancePath, instancePathParameters, compositeIdentifier)
+	}
+	return nil
+}
+
+// getInstancePathParameters returns the path parameters that follow the resource root path in the instance path
+// Example: Given the instance path "/v1/records/{zone}/{record_name}" and the root path "/v1/records" the result would be
+// [{zone} {record_name}]
+func (specAnalyser *specV2Analyser) getInstancePathParameters(resourceInstancePath, resourceRootPath string) []string {
+	instancePath := strings.TrimPrefix(resourceInstancePath, strings.TrimRight(resourceRootPath, "/"))
+	var pathParameters []string
+	for _, segment := range strings.Split(instancePath, "/") {
+		if segment != "" {
+			pathParameters = append(pathParameters, segment)
+		}
+	}
+	return pathParameters
+}
+
 func (specAnalyser *specV2Analyser) isEndPointTerraformDataSourceCompliant(path spec.PathItem) (*spec.Schema, error) {
 	if path.Get == nil {
 		return nil, errors.New("missing get operation")
@@ -302,13 +396,17 @@ func (specAnalyser *specV2Analyser) isEndPointTerraformDataSourceCompliant(path
 		if response.Schema == nil {
 			return nil, errors.New("missing response schema")
 		}
-		if len(response.Schema.Type) > 0 && !response.Schema.Type.Contains("array") {
+		responseSchema, err := specAnalyser.getEnvelopeSchema(path.Get, extTfResponsePath, response.Schema)
+		if err != nil {
+			return nil, err
+		}
+		if len(responseSchema.Type) > 0 && !responseSchema.Type.Contains("array") {
 			return nil, errors.New("response does not return an array of items")
 		}
-		if response.Schema.Items == nil || response.Schema.Items.Schema == nil || !response.Schema.Items.Schema.Type.Contains("object") || len(response.Schema.Items.Schema.Properties) == 0 {
+		if responseSchema.Items == nil || responseSchema.Items.Schema == nil || !responseSchema.Items.Schema.Type.Contains("object") || len(responseSchema.Items.Schema.Properties) == 0 {
 			return nil, errors.New("the response schema is missing the items schema specification or the items schema is not properly defined as object with properties configured")
 		}
-		return response.Schema.Items.Schema, nil
+		return responseSchema.Items.Schema, nil
 	}
 	return nil, errors.New("missing get responses")
 }
@@ -348,6 +446,9 @@ func (specAnalyser *specV2Analyser) validateRootPath(resourcePath string) (strin
 		// Use case where resource does not expect any input as part of the POST root operation, and only produces computed properties
 		if bodyParam == nil {
 			resourceSchema, err := specAnalyser.getSuccessfulResponseDefinition(resourceRootPostOperation)
+			if err == nil {
+				resourceSchema, err = specAnalyser.getEnvelopeSchema(resourceRootPostOperation, extTfResponsePath, resourceSchema)
+			}
 			if err != nil {
 				return "", nil, nil, fmt.Errorf("resource root path '%s' POST operation (without body parameter) error: %s", resourceRootPath, err)
 			}
@@ -360,6 +461,10 @@ func (specAnalyser *specV2Analyser) validateRootPath(resourcePath string) (strin
 		return "", nil, nil, fmt.Errorf("resource root path '%s' POST operation validation error: %s", resourceRootPath, err)
 	}
 
+	resourceRootPostSchemaDef, err = specAnalyser.getEnvelopeSchema(resourceRootPostOperation, extTfRequestPath, resourceRootPostSchemaDef)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("resource root path '%s' POST operation request envelope error: %s", resourceRootPath, err)
+	}
 	return resourceRootPath, &resourceRootPathItem, resourceRootPostSchemaDef, nil
 }
 
@@ -384,6 +489,17 @@ func (specAnalyser *specV2Analyser) getSuccessfulResponseDefinition(operation *s
 
 func (specAnalyser *specV2Analyser) validateResourceSchemaDefWithOptions(schema *spec.Schema, shouldPropBeReadOnly bool) error {
 	containsIdentifier := false
+	if compositeIdentifier := getCompositeIdentifier(schema.Extensions); len(compositeIdentifier) > 0 {
+		for _, propertyName := range compositeIdentifier {
+			if _, exists := schema.Properties[propertyName]; !exists {
+				return fmt.Errorf("resource schema composite identifier property '%s' is missing in the schema properties", propertyName)
+			}
+		}
+		if delimiter, _ := schema.Extensions.GetString(extTfCompositeIDDelimiter); strings.Contains(delimiter, "/") {
+			return fmt.Errorf("resource schema composite identifier delimiter '%s' must not contain forward slashes", delimiter)
+		}
+		containsIdentifier = true
+	}
 	for propertyName, property := range schema.Properties {
 		if propertyName == "id" {
 			containsIdentifier = true
@@ -480,5 +596,34 @@ func (specAnalyser *specV2Analyser) findMatchingResourceRootPath(resourceInstanc
 		return resourceRootPath, nil
 	}
 
+	// Handles the case where the instance path contains multiple consecutive path parameters (composite identifiers),
+	// e,g: /v1/records/{zone}/{record_name}/{type} -> /v1/records
+	if resourceRootPath, exists := specAnalyser.findCompositeResourceRootPath(resourceInstancePath); exists {
+		log.Printf("[DEBUG] found resource root path for instance path with multiple path parameters - %+s", resourceRootPath)
+		return resourceRootPath, nil
+	}
+
 	return "", fmt.Errorf("resource instance path '%s' missing resource root path", resourceInstancePath)
 }
+
+// findCompositeResourceRootPath strips the trailing path parameters of the given instance path one at a time until a
+// matching root path is found. Only consecutive path parameters are stripped, so sub-resource paths are not affected
+func (specAnalyser *specV2Analyser) findCompositeResourceRootPath(resourceInstancePath string) (string, bool) {
+	r, _ := regexp.Compile(`^(.*)/{[^/{}]+}$`)
+	path := strings.TrimRight(resourceInstancePath, "/")
+	for strippedParameters := 0; ; strippedParameters++ {
+		result := r.FindStringSubmatch(path)
+		if len(result) != 2 {
+			return "", false
+		}
+		path = result[1]
+		if strippedParameters == 0 || path == "" {
+			continue
+		}
+		for _, resourceRootPath := range []string{path + "/", path} {
+			if _, exists := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]; exists {
+				return resourceRootPath, true
+			}
+		}
+	}
+}
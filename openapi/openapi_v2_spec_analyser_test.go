@@ -517,7 +517,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 		swaggerFile := initAPISpecFile(swaggerJSON)
 		defer os.Remove(swaggerFile.Name())
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -551,7 +551,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 		defer os.Remove(swaggerFile.Name())
 
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -580,7 +580,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 		defer os.Remove(swaggerFile.Name())
 
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldContainSubstring, "error = invalid character 'h' after object key:value pair")
 			})
@@ -602,7 +602,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 		defer os.Remove(swaggerFile.Name())
 
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned should be the expected error", func() {
 				So(err.Error(), ShouldContainSubstring, "error = read .: is a directory")
 			})
@@ -696,7 +696,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 		swaggerFile := initAPISpecFile(swaggerJSON)
 		defer os.Remove(swaggerFile.Name())
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -751,7 +751,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 		swaggerFile := initAPISpecFile(swaggerJSON)
 		defer os.Remove(swaggerFile.Name())
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -796,7 +796,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 		swaggerFile := initAPISpecFile(swaggerJSON)
 		defer os.Remove(swaggerFile.Name())
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldContainSubstring, "error = object has no key \"NonExistingDef\"")
 			})
@@ -830,7 +830,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 		swaggerFile := initAPISpecFile(swaggerJSON)
 		defer os.Remove(swaggerFile.Name())
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldContainSubstring, "error = invalid character '}' looking for beginning of value")
 			})
@@ -846,7 +846,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 		swaggerFile := initAPISpecFile(swaggerJSON)
 		defer os.Remove(swaggerFile.Name())
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned should be not nil", func() {
 				So(err.Error(), ShouldContainSubstring, "failed to expand the OpenAPI document from ")
 				So(err.Error(), ShouldContainSubstring, " - error = open nosuchfile.json: no such file or directory")
@@ -858,7 +858,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 	})
 
 	Convey("When newSpecAnalyserV2 method is called with an empty string for openAPIDocumentFilename", t, func() {
-		specAnalyserV2, err := newSpecAnalyserV2("")
+		specAnalyserV2, err := newSpecAnalyserV2("", nil)
 		Convey("Then the error returned should be not nil", func() {
 			So(err.Error(), ShouldEqual, "open api document filename argument empty, please provide the url of the OpenAPI document")
 		})
@@ -868,7 +868,7 @@ func TestNewSpecAnalyserV2(t *testing.T) {
 	})
 
 	Convey("When newSpecAnalyserV2 method is called with a bogus value openAPIDocumentFilename", t, func() {
-		specAnalyserV2, err := newSpecAnalyserV2("nosuchthing")
+		specAnalyserV2, err := newSpecAnalyserV2("nosuchthing", nil)
 		Convey("Then the error returned should be not nil", func() {
 			So(err.Error(), ShouldEqual, "failed to retrieve the OpenAPI document from 'nosuchthing' - error = open nosuchthing: no such file or directory")
 		})
@@ -2047,6 +2047,90 @@ definitions:
 	})
 }
 
+func TestValidateRootPathWithEnvelope(t *testing.T) {
+	Convey("Given an specV2Analyser with a global x-terraform-request-path extension and a POST body wrapped in an envelope", t, func() {
+		swaggerContent := `swagger: "2.0"
+x-terraform-request-path: "data"
+paths:
+  /users:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          type: "object"
+          properties:
+            data:
+              type: "object"
+              properties:
+                id:
+                  type: "string"
+                  readOnly: true
+                name:
+                  type: "string"
+            meta:
+              type: "object"
+      responses:
+        201:
+          description: "created"
+  /users/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          description: "ok"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When validateRootPath method is called with '/users/{id}'", func() {
+			_, _, resourceRootPostSchemaDef, err := a.validateRootPath("/users/{id}")
+			Convey("Then the error returned should be nil and the schema returned should be the inner model", func() {
+				So(err, ShouldBeNil)
+				So(resourceRootPostSchemaDef.Properties, ShouldContainKey, "id")
+				So(resourceRootPostSchemaDef.Properties, ShouldContainKey, "name")
+				So(resourceRootPostSchemaDef.Properties, ShouldNotContainKey, "data")
+			})
+		})
+	})
+	Convey("Given an specV2Analyser with a POST operation that has a x-terraform-request-path extension pointing at a property that does not exist", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /users:
+    post:
+      x-terraform-request-path: "/item"
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          type: "object"
+          properties:
+            data:
+              type: "object"
+      responses:
+        201:
+          description: "created"
+  /users/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          description: "ok"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When validateRootPath method is called with '/users/{id}'", func() {
+			_, _, _, err := a.validateRootPath("/users/{id}")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource root path '/users' POST operation request envelope error: envelope schema is missing the property 'item' (path '/item')")
+			})
+		})
+	})
+}
+
 func TestIsEndPointTerraformDataSourceCompliant(t *testing.T) {
 
 	testCases := []struct {
@@ -2620,13 +2704,14 @@ definitions:
 			pathRootItem := a.d.Spec().Paths.Paths["/v1/cdns"]
 			pathItem := a.d.Spec().Paths.Paths["/v1/cdns/{id}"]
 			resourcePayloadSchemaDef := a.d.Spec().Definitions["ContentDeliveryNetwork"]
-			multiRegionResources, err := a.createMultiRegionResources(regions, resourceRootPath, pathRootItem, pathItem, &resourcePayloadSchemaDef)
+			multiRegionResources, err := a.createMultiRegionResources(regions, resourceRootPath, "/v1/cdns/{id}", pathRootItem, pathItem, &resourcePayloadSchemaDef)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
 			Convey("And the list resources return should only contain a resource called cdns_v1_rst1", func() {
 				So(len(multiRegionResources), ShouldEqual, 1)
 				So(multiRegionResources[0].getResourceName(), ShouldEqual, "cdns_v1_rst1")
+				So(multiRegionResources[0].getResourceInstancePathTemplate(), ShouldEqual, "{id}")
 			})
 			cdnMultiRegionResource := multiRegionResources[0]
 			Convey("And the host is correctly configured according to the swagger", func() {
@@ -2641,7 +2726,7 @@ definitions:
 			pathRootItem := a.d.Spec().Paths.Paths["/v1/cdns"]
 			pathItem := a.d.Spec().Paths.Paths["/v1/cdns/{id}"]
 			resourcePayloadSchemaDef := a.d.Spec().Definitions["ContentDeliveryNetwork"]
-			multiRegionResources, err := a.createMultiRegionResources(regions, resourceRootPath, pathRootItem, pathItem, &resourcePayloadSchemaDef)
+			multiRegionResources, err := a.createMultiRegionResources(regions, resourceRootPath, "/v1/cdns/{id}", pathRootItem, pathItem, &resourcePayloadSchemaDef)
 			Convey("Then the error returned should be as expected", func() {
 				So(err.Error(), ShouldEqual, "failed to create a resource with region: path must not be empty")
 			})
@@ -3477,7 +3562,7 @@ definitions:
 		defer os.Remove(swaggerFile.Name())
 
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned by calling newSpecAnalyserV2 should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -3741,7 +3826,7 @@ definitions:
 		defer os.Remove(swaggerFile.Name())
 
 		Convey("When newSpecAnalyserV2 method is called", func() {
-			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name())
+			specAnalyserV2, err := newSpecAnalyserV2(swaggerFile.Name(), nil)
 			Convey("Then the error returned by calling newSpecAnalyserV2 should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -3885,7 +3970,7 @@ func assertPropertyExists(properties specSchemaDefinitionProperties, name string
 func initAPISpecAnalyser(swaggerContent string) specV2Analyser {
 	file := initAPISpecFile(swaggerContent)
 	defer os.Remove(file.Name())
-	specV2Analyser, err := newSpecAnalyserV2(file.Name())
+	specV2Analyser, err := newSpecAnalyserV2(file.Name(), nil)
 	if err != nil {
 		log.Panic("newSpecAnalyserV2 failed: ", err)
 	}
@@ -3952,3 +4037,137 @@ func createExternalSwaggerContent() string {
   }
 }`
 }
+
+func TestCompositeIdentifierResource(t *testing.T) {
+	swaggerTemplate := `swagger: "2.0"
+paths:
+  /v1/records:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          type: "object"
+          x-terraform-composite-id: %s
+          x-terraform-composite-id-delimiter: "%s"
+          properties:
+            zone:
+              type: "string"
+            record_name:
+              type: "string"
+            type:
+              type: "string"
+      responses:
+        201:
+          description: "successful operation"
+  %s:
+    get:
+      responses:
+        200:
+          description: "successful operation"`
+
+	Convey("Given an apiSpecAnalyser with a resource declaring a composite identifier and an instance path with one path parameter per identifier property", t, func() {
+		a := initAPISpecAnalyser(fmt.Sprintf(swaggerTemplate, "zone,record_name,type", "_", "/v1/records/{zone}/{record_name}/{type}"))
+		Convey("When findMatchingResourceRootPath method is called", func() {
+			resourceRootPath, err := a.findMatchingResourceRootPath("/v1/records/{zone}/{record_name}/{type}")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the value returned should be the root path preceding all the path parameters", func() {
+				So(resourceRootPath, ShouldEqual, "/v1/records")
+			})
+		})
+		Convey("When isEndPointFullyTerraformResourceCompliant method is called", func() {
+			resourceRootPath, _, schema, err := a.isEndPointFullyTerraformResourceCompliant("/v1/records/{zone}/{record_name}/{type}")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resource root path and schema should be the expected ones", func() {
+				So(resourceRootPath, ShouldEqual, "/v1/records")
+				So(getCompositeIdentifier(schema.Extensions), ShouldResemble, []string{"zone", "record_name", "type"})
+			})
+		})
+		Convey("When GetTerraformCompliantResources method is called", func() {
+			resources, err := a.GetTerraformCompliantResources()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resource returned should contain the instance path template with the identifier path parameters", func() {
+				So(resources, ShouldHaveLength, 1)
+				So(resources[0].getResourceInstancePathTemplate(), ShouldEqual, "{zone}/{record_name}/{type}")
+			})
+		})
+	})
+
+	Convey("Given an apiSpecAnalyser with a resource declaring a composite identifier and an instance path missing some of the path parameters", t, func() {
+		a := initAPISpecAnalyser(fmt.Sprintf(swaggerTemplate, "zone,record_name,type", "_", "/v1/records/{zone}/{record_name}"))
+		Convey("When isEndPointFullyTerraformResourceCompliant method is called", func() {
+			_, _, _, err := a.isEndPointFullyTerraformResourceCompliant("/v1/records/{zone}/{record_name}")
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource instance path '/v1/records/{zone}/{record_name}' path parameters [{zone} {record_name}] do not match the composite identifier properties [zone record_name type]")
+			})
+		})
+	})
+
+	Convey("Given an apiSpecAnalyser with a resource declaring a composite identifier referring to a missing property", t, func() {
+		a := initAPISpecAnalyser(fmt.Sprintf(swaggerTemplate, "[zone, name]", "_", "/v1/records/{zone}/{name}"))
+		Convey("When isEndPointFullyTerraformResourceCompliant method is called", func() {
+			_, _, _, err := a.isEndPointFullyTerraformResourceCompliant("/v1/records/{zone}/{name}")
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource schema composite identifier property 'name' is missing in the schema properties")
+			})
+		})
+	})
+
+	Convey("Given an apiSpecAnalyser with a resource declaring a composite identifier delimiter containing forward slashes", t, func() {
+		a := initAPISpecAnalyser(fmt.Sprintf(swaggerTemplate, "zone,record_name,type", "/", "/v1/records/{zone}/{record_name}/{type}"))
+		Convey("When isEndPointFullyTerraformResourceCompliant method is called", func() {
+			_, _, _, err := a.isEndPointFullyTerraformResourceCompliant("/v1/records/{zone}/{record_name}/{type}")
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource schema composite identifier delimiter '/' must not contain forward slashes")
+			})
+		})
+	})
+
+	Convey("Given an apiSpecAnalyser with an instance path with multiple path parameters but a resource without a composite identifier", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /v1/records:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          type: "object"
+          properties:
+            id:
+              type: "string"
+              readOnly: true
+      responses:
+        201:
+          description: "successful operation"
+  /v1/records/{zone}/{id}:
+    get:
+      responses:
+        200:
+          description: "successful operation"
+  /v1/cdns/{id}/firewalls/{firewall_id}:
+    get:
+      responses:
+        200:
+          description: "successful operation"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When isEndPointFullyTerraformResourceCompliant method is called", func() {
+			_, _, _, err := a.isEndPointFullyTerraformResourceCompliant("/v1/records/{zone}/{id}")
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource instance path '/v1/records/{zone}/{id}' contains multiple path parameters [{zone} {id}] but the resource schema does not declare a composite identifier (x-terraform-composite-id)")
+			})
+		})
+		Convey("When findMatchingResourceRootPath method is called with a sub-resource instance path whose root path is missing", func() {
+			_, err := a.findMatchingResourceRootPath("/v1/cdns/{id}/firewalls/{firewall_id}")
+			Convey("Then the error returned should not be nil as only consecutive path parameters are stripped", func() {
+				So(err.Error(), ShouldEqual, "resource instance path '/v1/cdns/{id}/firewalls/{firewall_id}' missing resource root path")
+			})
+		})
+	})
+}
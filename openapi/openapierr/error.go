@@ -1,8 +1,31 @@
 package openapierr
 
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
 const (
 	// NotFound const defines the code value for openapi internal NotFound errors
 	NotFound = "NotFound"
+	// Unauthorized const defines the code value for API errors due to missing or invalid credentials (401)
+	Unauthorized = "Unauthorized"
+	// Forbidden const defines the code value for API errors due to insufficient permissions (403)
+	Forbidden = "Forbidden"
+	// Conflict const defines the code value for API errors due to a conflict with the current state of the resource (409)
+	Conflict = "Conflict"
+	// PreconditionFailed const defines the code value for API errors due to a failed request precondition, e,g: If-Match
+	// ETag mismatch (412)
+	PreconditionFailed = "PreconditionFailed"
+	// RateLimited const defines the code value for API errors due to too many requests (429)
+	RateLimited = "RateLimited"
+	// Validation const defines the code value for API errors due to invalid requests (400 and 422)
+	Validation = "Validation"
+	// ServerError const defines the code value for API errors due to failures in the server side (5xx)
+	ServerError = "ServerError"
+	// ClientError const defines the code value for the rest of API errors
+	ClientError = "ClientError"
 )
 
 // Error defines the interface that OpenAPI internal errors must be compliant with
@@ -31,3 +54,156 @@ func (e *NotFoundError) Error() string {
 func (e *NotFoundError) Code() string {
 	return NotFound
 }
+
+// APIError contains the information about an API request that returned an error response. It is embedded in the typed
+// API errors (UnauthorizedError, ForbiddenError, etc)
+type APIError struct {
+	// ResourceName contains the name of the resource the request was performed for
+	ResourceName string
+	// StatusCode contains the HTTP status code returned by the API
+	StatusCode int
+	// Method contains the HTTP method of the request; empty if not known
+	Method string
+	// URL contains the URL of the request (without the query); empty if not known
+	URL string
+	// RequestID contains the request ID returned by the API in the response headers; empty if not returned
+	RequestID string
+	// Hint contains an explanation of the error aimed at the user; empty if there is none
+	Hint string
+	// Message contains the error message returned by the API
+	Message string
+	// Details contains the additional details returned by the API, e,g: per-field validation errors
+	Details []string
+}
+
+// Error returns a concise description of the error including the message and details returned by the API as well as
+// the request information if known
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("[resource='%s'] HTTP Response Status Code %d - %s", e.ResourceName, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Hint != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Hint)
+	}
+	apiMessage := e.Message
+	if len(e.Details) > 0 {
+		if apiMessage != "" {
+			apiMessage += ": "
+		}
+		apiMessage += strings.Join(e.Details, "; ")
+	}
+	if apiMessage != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, apiMessage)
+	}
+	var requestInfo []string
+	if e.Method != "" && e.URL != "" {
+		requestInfo = append(requestInfo, fmt.Sprintf("request: %s %s", e.Method, e.URL))
+	}
+	if e.RequestID != "" {
+		requestInfo = append(requestInfo, fmt.Sprintf("request id: %s", e.RequestID))
+	}
+	if len(requestInfo) > 0 {
+		msg = fmt.Sprintf("%s [%s]", msg, strings.Join(requestInfo, ", "))
+	}
+	return msg
+}
+
+// UnauthorizedError represents a 401 Unauthorized API error and implements the openapi Error interface
+type UnauthorizedError struct {
+	*APIError
+}
+
+// Code returns the code that represents the Unauthorized error
+func (e *UnauthorizedError) Code() string {
+	return Unauthorized
+}
+
+// ForbiddenError represents a 403 Forbidden API error and implements the openapi Error interface
+type ForbiddenError struct {
+	*APIError
+}
+
+// Code returns the code that represents the Forbidden error
+func (e *ForbiddenError) Code() string {
+	return Forbidden
+}
+
+// ConflictError represents a 409 Conflict API error and implements the openapi Error interface
+type ConflictError struct {
+	*APIError
+}
+
+// Code returns the code that represents the Conflict error
+func (e *ConflictError) Code() string {
+	return Conflict
+}
+
+// PreconditionFailedError represents a 412 Precondition Failed API error and implements the openapi Error interface
+type PreconditionFailedError struct {
+	*APIError
+}
+
+// Code returns the code that represents the PreconditionFailed error
+func (e *PreconditionFailedError) Code() string {
+	return PreconditionFailed
+}
+
+// RateLimitedError represents a 429 Too Many Requests API error and implements the openapi Error interface
+type RateLimitedError struct {
+	*APIError
+}
+
+// Code returns the code that represents the RateLimited error
+func (e *RateLimitedError) Code() string {
+	return RateLimited
+}
+
+// ValidationError represents an API error due to an invalid request (400 and 422) and implements the openapi Error interface
+type ValidationError struct {
+	*APIError
+}
+
+// Code returns the code that represents the Validation error
+func (e *ValidationError) Code() string {
+	return Validation
+}
+
+// ServerAPIError represents a 5xx API error and implements the openapi Error interface
+type ServerAPIError struct {
+	*APIError
+}
+
+// Code returns the code that represents the ServerError error
+func (e *ServerAPIError) Code() string {
+	return ServerError
+}
+
+// ClientAPIError represents the rest of API errors not covered by a more specific type and implements the openapi Error
+// interface
+type ClientAPIError struct {
+	*APIError
+}
+
+// Code returns the code that represents the ClientError error
+func (e *ClientAPIError) Code() string {
+	return ClientError
+}
+
+// NewAPIError returns the typed error that corresponds to the status code of the given API error
+func NewAPIError(apiError *APIError) Error {
+	switch {
+	case apiError.StatusCode == http.StatusUnauthorized:
+		return &UnauthorizedError{apiError}
+	case apiError.StatusCode == http.StatusForbidden:
+		return &ForbiddenError{apiError}
+	case apiError.StatusCode == http.StatusConflict:
+		return &ConflictError{apiError}
+	case apiError.StatusCode == http.StatusPreconditionFailed:
+		return &PreconditionFailedError{apiError}
+	case apiError.StatusCode == http.StatusTooManyRequests:
+		return &RateLimitedError{apiError}
+	case apiError.StatusCode == http.StatusBadRequest || apiError.StatusCode == http.StatusUnprocessableEntity:
+		return &ValidationError{apiError}
+	case apiError.StatusCode >= 500:
+		return &ServerAPIError{apiError}
+	}
+	return &ClientAPIError{apiError}
+}
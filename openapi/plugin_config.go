@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
-	"gopkg.in/yaml.v2"
 	"io"
 	"io/ioutil"
 	"log"
@@ -112,11 +111,10 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 			if err != nil {
 				return nil, fmt.Errorf("failed to read %s configuration file", OpenAPIPluginConfigurationFileName)
 			}
-			err = yaml.Unmarshal(source, pluginConfigV1)
+			pluginConfig, err = newPluginConfigSchema(source)
 			if err != nil {
 				return nil, fmt.Errorf("failed to unmarshall %s configuration file - error = %s", OpenAPIPluginConfigurationFileName, err)
 			}
-			pluginConfig = PluginConfigSchema(pluginConfigV1)
 			if err = pluginConfig.Validate(); err != nil {
 				return nil, fmt.Errorf("error occurred while validating '%s' - error = %s", OpenAPIPluginConfigurationFileName, err)
 			}
@@ -129,7 +127,7 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 
 	log.Printf("[DEBUG] serviceConfig = %+v", serviceConfig)
 
-	if serviceConfig == nil || serviceConfig.GetSwaggerURL() == "" {
+	if serviceConfig == nil || (serviceConfig.GetSwaggerURL() == "" && len(serviceConfig.GetSwaggerSpecs()) == 0) {
 		return nil, fmt.Errorf("swagger url not provided, please export OTF_VAR_<provider_name>_SWAGGER_URL env variable with the URL where '%s' service provider is exposing the swagger file OR create a plugin configuration file at ~/.terraform.d/plugins following the Plugin configuration schema specifications", p.ProviderName)
 	}
 
@@ -139,3 +137,40 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 
 	return serviceConfig, err
 }
+
+// getStaticServiceConfiguration returns the service configuration of the provider from the plugin configuration file for
+// the static providers (see StaticProviderOpenAPI). The swagger document is embedded in the static providers, hence the
+// swagger settings (and the OTF_VAR_<provider_name>_SWAGGER_URL env variable) are neither required nor used. Nil is
+// returned if the plugin configuration file is not present or it does not contain the provider's service configuration
+func (p *PluginConfiguration) getStaticServiceConfiguration() (ServiceConfiguration, error) {
+	if p.Configuration == nil {
+		return nil, nil
+	}
+	source, err := ioutil.ReadAll(p.Configuration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s configuration file", OpenAPIPluginConfigurationFileName)
+	}
+	pluginConfig, err := newPluginConfigSchema(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshall %s configuration file - error = %s", OpenAPIPluginConfigurationFileName, err)
+	}
+	if err = pluginConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("error occurred while validating '%s' - error = %s", OpenAPIPluginConfigurationFileName, err)
+	}
+	serviceConfigurations, err := pluginConfig.GetAllServiceConfigurations()
+	if err != nil {
+		return nil, fmt.Errorf("error occurred when getting service configuration from plugin configuration file %s - error = %s", OpenAPIPluginConfigurationFileName, err)
+	}
+	serviceConfig, exists := serviceConfigurations[p.ProviderName]
+	if !exists {
+		log.Printf("[INFO] '%s' not found in provider's services configuration, using the default service configuration", p.ProviderName)
+		return nil, nil
+	}
+	if serviceConfig.GetSwaggerURL() != "" || len(serviceConfig.GetSwaggerSpecs()) > 0 || len(serviceConfig.GetSwaggerOverlays()) > 0 {
+		log.Printf("[WARN] the swagger settings of the '%s' service configuration are ignored, the provider uses the embedded swagger document", p.ProviderName)
+	}
+	if err = validateServiceConfigSettings(serviceConfig.GetPluginVersion(), version.Version, serviceConfig.GetTLSConfiguration(), serviceConfig.GetRetryConfiguration(), serviceConfig.GetRateLimitConfiguration(), serviceConfig.GetPollingConfiguration(), serviceConfig.GetTracingConfiguration()); err != nil {
+		return nil, fmt.Errorf("service configuration for '%s' not valid: %s", p.ProviderName, err)
+	}
+	return serviceConfig, nil
+}
@@ -22,6 +22,42 @@ type PluginConfigSchema interface {
 	Marshal() ([]byte, error)
 }
 
+// pluginConfigSchemaVersion holds the version of a plugin configuration document
+type pluginConfigSchemaVersion struct {
+	Version string `yaml:"version"`
+}
+
+// GetVersion returns the plugin configuration version
+func (p pluginConfigSchemaVersion) GetVersion() (string, error) {
+	return p.Version, nil
+}
+
+// newPluginConfigSchema unmarshals the plugin configuration document into the PluginConfigSchema implementation matching
+// the document version. Version 2 documents are unmarshalled strictly so unknown properties (e,g: typos) are reported as
+// errors; documents with any other version are unmarshalled as version 1 for backwards compatibility and their version
+// is checked when validated
+func newPluginConfigSchema(source []byte) (PluginConfigSchema, error) {
+	var schemaVersion pluginConfigSchemaVersion
+	if err := yaml.Unmarshal(source, &schemaVersion); err != nil {
+		return nil, err
+	}
+	version, _ := schemaVersion.GetVersion()
+	switch version {
+	case pluginConfigSchemaVersion2:
+		pluginConfigV2 := &PluginConfigSchemaV2{}
+		if err := yaml.UnmarshalStrict(source, pluginConfigV2); err != nil {
+			return nil, err
+		}
+		return pluginConfigV2, nil
+	default:
+		pluginConfigV1 := &PluginConfigSchemaV1{}
+		if err := yaml.Unmarshal(source, pluginConfigV1); err != nil {
+			return nil, err
+		}
+		return pluginConfigV1, nil
+	}
+}
+
 // PluginConfigSchemaV1 defines PluginConfigSchema version 1
 // Configuration example:
 // version: '1'
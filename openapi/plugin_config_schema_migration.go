@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// MigratePluginConfigSchemaV1ToV2 converts the given plugin configuration document in version 1 into version 2. The
+// document is edited in place (at the YAML node level) so the ordering of the properties and the comments are preserved
+// where possible; the only changes made are:
+// - the version is bumped to '2'
+// - the 'swagger-url' (or 'swagger-urls'), 'insecure_skip_verify' and 'overlays' properties of each service are moved into
+// the service 'swagger' section
+// The resulting document is validated against the version 2 schema before being returned.
+func MigratePluginConfigSchemaV1ToV2(source []byte) ([]byte, error) {
+	var document yamlv3.Node
+	if err := yamlv3.Unmarshal(source, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin configuration: %s", err)
+	}
+	if document.Kind != yamlv3.DocumentNode || len(document.Content) != 1 || document.Content[0].Kind != yamlv3.MappingNode {
+		return nil, fmt.Errorf("plugin configuration must be a YAML mapping")
+	}
+	root := document.Content[0]
+
+	version := mappingValue(root, "version")
+	if version == nil {
+		return nil, fmt.Errorf("plugin configuration is missing the 'version' property")
+	}
+	switch version.Value {
+	case pluginConfigSchemaVersion2:
+		return nil, fmt.Errorf("plugin configuration is already in version '%s'", pluginConfigSchemaVersion2)
+	case "1":
+	default:
+		return nil, fmt.Errorf("plugin configuration version '%s' not supported, only version '1' can be migrated", version.Value)
+	}
+	version.Value = pluginConfigSchemaVersion2
+	version.Style = yamlv3.SingleQuotedStyle
+
+	if services := mappingValue(root, "services"); services != nil && services.Kind == yamlv3.MappingNode {
+		for i := 0; i+1 < len(services.Content); i += 2 {
+			if err := migrateServiceConfigV1ToV2(services.Content[i+1]); err != nil {
+				return nil, fmt.Errorf("failed to migrate service '%s': %s", services.Content[i].Value, err)
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	encoder := yamlv3.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	pluginConfig, err := newPluginConfigSchema(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("migrated plugin configuration is not valid: %s", err)
+	}
+	if err := pluginConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("migrated plugin configuration is not valid: %s", err)
+	}
+	return out.Bytes(), nil
+}
+
+// migrateServiceConfigV1ToV2 moves the swagger related properties of the service into the swagger section, which takes
+// the position of the 'swagger-url' (or 'swagger-urls') property. The comments attached to the moved properties are kept.
+func migrateServiceConfigV1ToV2(service *yamlv3.Node) error {
+	if service.Kind != yamlv3.MappingNode {
+		return fmt.Errorf("service configuration must be a YAML mapping")
+	}
+	swagger := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+	swaggerIndex := -1
+	var content []*yamlv3.Node
+	for i := 0; i+1 < len(service.Content); i += 2 {
+		key, value := service.Content[i], service.Content[i+1]
+		switch key.Value {
+		case "swagger-url", "swagger-urls":
+			key.Value = strings.TrimPrefix(key.Value, "swagger-")
+			swagger.Content = append([]*yamlv3.Node{key, value}, swagger.Content...)
+			swaggerIndex = len(content)
+		case "insecure_skip_verify", "overlays":
+			swagger.Content = append(swagger.Content, key, value)
+		case "swagger":
+			return fmt.Errorf("service configuration already contains a 'swagger' property")
+		default:
+			content = append(content, key, value)
+		}
+	}
+	if swaggerIndex < 0 {
+		return fmt.Errorf("service configuration is missing the 'swagger-url' or 'swagger-urls' property")
+	}
+	// the head comment of the swagger url property is kept on top of the swagger section
+	swaggerKey := &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: "swagger", HeadComment: swagger.Content[0].HeadComment}
+	swagger.Content[0].HeadComment = ""
+	content = append(content[:swaggerIndex], append([]*yamlv3.Node{swaggerKey, swagger}, content[swaggerIndex:]...)...)
+	service.Content = content
+	return nil
+}
+
+// mappingValue returns the value node for the given key in the mapping node; nil if the key is not present
+func mappingValue(mapping *yamlv3.Node, key string) *yamlv3.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigratePluginConfigSchemaV1ToV2(t *testing.T) {
+	source := `# OpenAPI plugin configuration
+version: '1'
+services:
+  # monitor service
+  monitor:
+    # swagger served by the monitor API
+    swagger-url: http://monitor-api.com/swagger.json # self-signed cert
+    plugin_version: 0.14.0
+    insecure_skip_verify: true
+    retry:
+      max_attempts: 3
+  cdn:
+    swagger-url: https://cdn-api.com/swagger.json
+    schema_configuration:
+      - schema_property_name: apikey_auth
+        default_value: apiKeyValue
+`
+	expected := `# OpenAPI plugin configuration
+version: '2'
+services:
+  # monitor service
+  monitor:
+    # swagger served by the monitor API
+    swagger:
+      url: http://monitor-api.com/swagger.json # self-signed cert
+      insecure_skip_verify: true
+    plugin_version: 0.14.0
+    retry:
+      max_attempts: 3
+  cdn:
+    swagger:
+      url: https://cdn-api.com/swagger.json
+    schema_configuration:
+      - schema_property_name: apikey_auth
+        default_value: apiKeyValue
+`
+	migrated, err := MigratePluginConfigSchemaV1ToV2([]byte(source))
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(migrated))
+
+	pluginConfig, err := newPluginConfigSchema(migrated)
+	require.NoError(t, err)
+	serviceConfig, err := pluginConfig.GetServiceConfig("monitor")
+	require.NoError(t, err)
+	assert.Equal(t, "http://monitor-api.com/swagger.json", serviceConfig.GetSwaggerURL())
+	assert.True(t, serviceConfig.IsInsecureSkipVerifyEnabled())
+	assert.Equal(t, "0.14.0", serviceConfig.GetPluginVersion())
+	assert.Equal(t, &RetryConfig{MaxAttempts: 3}, serviceConfig.GetRetryConfiguration())
+}
+
+func TestMigratePluginConfigSchemaV1ToV2SwaggerURLs(t *testing.T) {
+	source := `version: '1'
+services:
+  platform:
+    swagger-urls:
+      - url: https://users-api.com/swagger.json
+        name_prefix: users
+      - url: https://billing-api.com/swagger.json
+        host: billing.internal
+        overlays:
+          - billing-overlay.yaml
+    insecure_skip_verify: true
+  cdn:
+    swagger-url: https://cdn-api.com/swagger.json
+    overlays:
+      - cdn-overlay.yaml
+`
+	expected := `version: '2'
+services:
+  platform:
+    swagger:
+      urls:
+        - url: https://users-api.com/swagger.json
+          name_prefix: users
+        - url: https://billing-api.com/swagger.json
+          host: billing.internal
+          overlays:
+            - billing-overlay.yaml
+      insecure_skip_verify: true
+  cdn:
+    swagger:
+      url: https://cdn-api.com/swagger.json
+      overlays:
+        - cdn-overlay.yaml
+`
+	migrated, err := MigratePluginConfigSchemaV1ToV2([]byte(source))
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(migrated))
+
+	pluginConfig, err := newPluginConfigSchema(migrated)
+	require.NoError(t, err)
+	serviceConfig, err := pluginConfig.GetServiceConfig("platform")
+	require.NoError(t, err)
+	assert.Equal(t, []SwaggerSpecConfigV1{
+		{URL: "https://users-api.com/swagger.json", NamePrefix: "users"},
+		{URL: "https://billing-api.com/swagger.json", Host: "billing.internal", Overlays: []string{"billing-overlay.yaml"}},
+	}, serviceConfig.GetSwaggerSpecs())
+
+	serviceConfig, err = pluginConfig.GetServiceConfig("cdn")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cdn-overlay.yaml"}, serviceConfig.GetSwaggerOverlays())
+}
+
+func TestMigratePluginConfigSchemaV1ToV2Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		source        string
+		expectedError string
+	}{
+		{
+			name:          "invalid yaml",
+			source:        "version: [",
+			expectedError: "failed to parse plugin configuration: yaml: line 1: did not find expected node content",
+		},
+		{
+			name:          "not a mapping",
+			source:        "- version",
+			expectedError: "plugin configuration must be a YAML mapping",
+		},
+		{
+			name:          "missing version",
+			source:        "services: {}",
+			expectedError: "plugin configuration is missing the 'version' property",
+		},
+		{
+			name:          "already version 2",
+			source:        "version: '2'",
+			expectedError: "plugin configuration is already in version '2'",
+		},
+		{
+			name:          "unsupported version",
+			source:        "version: '3'",
+			expectedError: "plugin configuration version '3' not supported, only version '1' can be migrated",
+		},
+		{
+			name:          "service missing swagger-url",
+			source:        "version: '1'\nservices:\n  cdn:\n    plugin_version: 0.14.0",
+			expectedError: "failed to migrate service 'cdn': service configuration is missing the 'swagger-url' or 'swagger-urls' property",
+		},
+		{
+			name:          "service containing unknown properties",
+			source:        "version: '1'\nservices:\n  cdn:\n    swagger-url: https://cdn-api.com/swagger.json\n    unknown: value",
+			expectedError: "migrated plugin configuration is not valid: yaml: unmarshal errors:\n  line 6: field unknown not found in type openapi.ServiceConfigV2",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := MigratePluginConfigSchemaV1ToV2([]byte(tc.source))
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
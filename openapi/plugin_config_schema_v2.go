@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// pluginConfigSchemaVersion2 is the version of the PluginConfigSchemaV2
+const pluginConfigSchemaVersion2 = "2"
+
+// PluginConfigSchemaV2 defines PluginConfigSchema version 2. Unlike version 1, unknown properties are not allowed (the
+// configuration fails to load if present) and the JSON Schema of the document is published in the docs folder
+// (docs/plugin_configuration_schema_v2.json) so editors can validate the configuration file.
+// Configuration example:
+// version: '2'
+// services:
+//   monitor:
+//     swagger:
+//       url: http://monitor-api.com/swagger.json
+//       insecure_skip_verify: true
+//     plugin_version: 0.14.0
+//   cdn:
+//     swagger:
+//       url: https://cdn-api.com/swagger.json
+type PluginConfigSchemaV2 struct {
+	Version  string                      `yaml:"version"`
+	Services map[string]*ServiceConfigV2 `yaml:"services"`
+}
+
+// NewPluginConfigSchemaV2 creates a new PluginConfigSchemaV2 that implements PluginConfigSchema interface
+func NewPluginConfigSchemaV2(services map[string]*ServiceConfigV2) *PluginConfigSchemaV2 {
+	return &PluginConfigSchemaV2{
+		Version:  pluginConfigSchemaVersion2,
+		Services: services,
+	}
+}
+
+// Validate makes sure that schema data is correct
+func (p *PluginConfigSchemaV2) Validate() error {
+	if p.Version != pluginConfigSchemaVersion2 {
+		return fmt.Errorf("provider configuration version not matching current implementation, please use version '%s' of provider configuration specification", pluginConfigSchemaVersion2)
+	}
+	return nil
+}
+
+// GetServiceConfig returns the configuration for the given provider name
+func (p *PluginConfigSchemaV2) GetServiceConfig(providerName string) (ServiceConfiguration, error) {
+	if providerName == "" {
+		return nil, fmt.Errorf("providerName not specified")
+	}
+	serviceConfig, exists := p.Services[providerName]
+	if !exists {
+		return nil, fmt.Errorf("'%s' not found in provider's services configuration", providerName)
+	}
+	return serviceConfig, nil
+}
+
+// GetVersion returns the plugin configuration version
+func (p *PluginConfigSchemaV2) GetVersion() (string, error) {
+	return p.Version, nil
+}
+
+// GetAllServiceConfigurations returns all the service configuration
+func (p *PluginConfigSchemaV2) GetAllServiceConfigurations() (ServiceConfigurations, error) {
+	serviceConfigurations := ServiceConfigurations{}
+	for k, v := range p.Services {
+		serviceConfigurations[k] = v
+	}
+	return serviceConfigurations, nil
+}
+
+// Marshal serializes the value provided into a YAML document
+func (p *PluginConfigSchemaV2) Marshal() ([]byte, error) {
+	out, err := yaml.Marshal(p)
+	return out, err
+}
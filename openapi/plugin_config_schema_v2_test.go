@@ -0,0 +1,282 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewPluginConfigSchemaV2(t *testing.T) {
+	Convey("Given a map of services and their swagger configuration", t, func() {
+		services := map[string]*ServiceConfigV2{
+			"test": {
+				Swagger: SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml", InsecureSkipVerify: true},
+			},
+		}
+		Convey("When NewPluginConfigSchemaV2 method is called", func() {
+			pluginConfigSchemaV2 := NewPluginConfigSchemaV2(services)
+			Convey("Then the pluginConfigSchema returned should implement PluginConfigSchema interface", func() {
+				var _ PluginConfigSchema = pluginConfigSchemaV2
+			})
+			Convey("And the pluginConfigSchema should contain the version and services", func() {
+				So(pluginConfigSchemaV2.Version, ShouldEqual, "2")
+				So(pluginConfigSchemaV2.Services, ShouldResemble, services)
+			})
+		})
+	})
+}
+
+func TestPluginConfigSchemaV2Validate(t *testing.T) {
+	Convey("Given a PluginConfigSchemaV2 containing a version supported", t, func() {
+		pluginConfigSchema := NewPluginConfigSchemaV2(map[string]*ServiceConfigV2{})
+		Convey("When Validate method is called", func() {
+			err := pluginConfigSchema.Validate()
+			Convey("Then the error returned should be nil as configuration is correct", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a PluginConfigSchemaV2 containing a version that is NOT supported", t, func() {
+		pluginConfigSchema := &PluginConfigSchemaV2{Version: "1"}
+		Convey("When Validate method is called", func() {
+			err := pluginConfigSchema.Validate()
+			Convey("Then the error returned be equal to", func() {
+				So(err.Error(), ShouldEqual, "provider configuration version not matching current implementation, please use version '2' of provider configuration specification")
+			})
+		})
+	})
+}
+
+func TestPluginConfigSchemaV2GetServiceConfig(t *testing.T) {
+	Convey("Given a PluginConfigSchemaV2 containing a service", t, func() {
+		expectedService := &ServiceConfigV2{Swagger: SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml"}}
+		pluginConfigSchema := NewPluginConfigSchemaV2(map[string]*ServiceConfigV2{"test": expectedService})
+		Convey("When GetServiceConfig method is called with the service name", func() {
+			serviceConfig, err := pluginConfigSchema.GetServiceConfig("test")
+			Convey("Then the service configuration should be returned", func() {
+				So(err, ShouldBeNil)
+				So(serviceConfig, ShouldEqual, expectedService)
+				So(serviceConfig.GetSwaggerURL(), ShouldEqual, "http://sevice-api.com/swagger.yaml")
+			})
+		})
+		Convey("When GetServiceConfig method is called with a service name that does not exist", func() {
+			_, err := pluginConfigSchema.GetServiceConfig("non-existing")
+			Convey("Then the error returned be equal to", func() {
+				So(err.Error(), ShouldEqual, "'non-existing' not found in provider's services configuration")
+			})
+		})
+		Convey("When GetServiceConfig method is called with an empty service name", func() {
+			_, err := pluginConfigSchema.GetServiceConfig("")
+			Convey("Then the error returned be equal to", func() {
+				So(err.Error(), ShouldEqual, "providerName not specified")
+			})
+		})
+	})
+}
+
+func TestPluginConfigSchemaV2GetVersion(t *testing.T) {
+	Convey("Given a PluginConfigSchemaV2", t, func() {
+		pluginConfigSchema := NewPluginConfigSchemaV2(map[string]*ServiceConfigV2{})
+		Convey("When GetVersion method is called", func() {
+			configVersion, err := pluginConfigSchema.GetVersion()
+			Convey("Then the version returned should be 2", func() {
+				So(err, ShouldBeNil)
+				So(configVersion, ShouldEqual, "2")
+			})
+		})
+	})
+}
+
+func TestPluginConfigSchemaV2GetAllServiceConfigurations(t *testing.T) {
+	Convey("Given a PluginConfigSchemaV2 containing some services", t, func() {
+		pluginConfigSchema := NewPluginConfigSchemaV2(map[string]*ServiceConfigV2{
+			"test":  {Swagger: SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml"}},
+			"other": {Swagger: SwaggerConfigV2{URL: "http://other-api.com/swagger.yaml"}},
+		})
+		Convey("When GetAllServiceConfigurations method is called", func() {
+			serviceConfigurations, err := pluginConfigSchema.GetAllServiceConfigurations()
+			Convey("Then all the service configurations should be returned", func() {
+				So(err, ShouldBeNil)
+				So(len(serviceConfigurations), ShouldEqual, 2)
+				So(serviceConfigurations["test"].GetSwaggerURL(), ShouldEqual, "http://sevice-api.com/swagger.yaml")
+				So(serviceConfigurations["other"].GetSwaggerURL(), ShouldEqual, "http://other-api.com/swagger.yaml")
+			})
+		})
+	})
+}
+
+func TestPluginConfigSchemaV2Marshal(t *testing.T) {
+	Convey("Given a PluginConfigSchemaV2 containing some services", t, func() {
+		pluginConfigSchema := NewPluginConfigSchemaV2(map[string]*ServiceConfigV2{
+			"test": {
+				Swagger:       SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml", InsecureSkipVerify: true},
+				PluginVersion: "0.14.0",
+				RetryConfig:   &RetryConfig{MaxAttempts: 3},
+			},
+		})
+		Convey("When Marshal method is called", func() {
+			marshalConfig, err := pluginConfigSchema.Marshal()
+			Convey("Then the marshalConfig should contain the right marshal configuration", func() {
+				So(err, ShouldBeNil)
+				So(string(marshalConfig), ShouldEqual, `version: "2"
+services:
+  test:
+    swagger:
+      url: http://sevice-api.com/swagger.yaml
+      insecure_skip_verify: true
+    plugin_version: 0.14.0
+    retry:
+      max_attempts: 3
+`)
+			})
+			Convey("And the marshalConfig should be loaded back into the same plugin configuration", func() {
+				pluginConfig, err := newPluginConfigSchema(marshalConfig)
+				So(err, ShouldBeNil)
+				So(pluginConfig, ShouldResemble, pluginConfigSchema)
+			})
+		})
+	})
+}
+
+func TestNewPluginConfigSchema(t *testing.T) {
+	Convey("Given a plugin configuration in version 1", t, func() {
+		source := []byte(`version: '1'
+services:
+  test:
+    swagger-url: http://sevice-api.com/swagger.yaml
+    unknown_property: value`)
+		Convey("When newPluginConfigSchema method is called", func() {
+			pluginConfig, err := newPluginConfigSchema(source)
+			Convey("Then a PluginConfigSchemaV1 ignoring the unknown properties should be returned", func() {
+				So(err, ShouldBeNil)
+				So(pluginConfig, ShouldResemble, NewPluginConfigSchemaV1(map[string]*ServiceConfigV1{"test": {SwaggerURL: "http://sevice-api.com/swagger.yaml"}}))
+			})
+		})
+	})
+	Convey("Given a plugin configuration in version 2", t, func() {
+		source := []byte(`version: '2'
+services:
+  test:
+    swagger:
+      url: http://sevice-api.com/swagger.yaml`)
+		Convey("When newPluginConfigSchema method is called", func() {
+			pluginConfig, err := newPluginConfigSchema(source)
+			Convey("Then a PluginConfigSchemaV2 should be returned", func() {
+				So(err, ShouldBeNil)
+				So(pluginConfig, ShouldResemble, NewPluginConfigSchemaV2(map[string]*ServiceConfigV2{"test": {Swagger: SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml"}}}))
+			})
+		})
+	})
+	Convey("Given a plugin configuration in version 2 containing an unknown property", t, func() {
+		source := []byte(`version: '2'
+services:
+  test:
+    swagger:
+      url: http://sevice-api.com/swagger.yaml
+      insecure_skip_verfy: true`)
+		Convey("When newPluginConfigSchema method is called", func() {
+			_, err := newPluginConfigSchema(source)
+			Convey("Then the error returned should mention the unknown property", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "field insecure_skip_verfy not found in type openapi.SwaggerConfigV2")
+			})
+		})
+	})
+	Convey("Given a plugin configuration that is not valid YAML", t, func() {
+		source := []byte(`version: [`)
+		Convey("When newPluginConfigSchema method is called", func() {
+			_, err := newPluginConfigSchema(source)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+// TestPluginConfigSchemaV2JSONSchema makes sure the JSON Schema published in the docs folder is kept in sync with the
+// properties supported by PluginConfigSchemaV2
+func TestPluginConfigSchemaV2JSONSchema(t *testing.T) {
+	Convey("Given the JSON Schema of the plugin configuration version 2", t, func() {
+		data, err := ioutil.ReadFile("../docs/plugin_configuration_schema_v2.json")
+		So(err, ShouldBeNil)
+		var jsonSchema map[string]interface{}
+		So(json.Unmarshal(data, &jsonSchema), ShouldBeNil)
+		Convey("When the JSON Schema properties are compared against the PluginConfigSchemaV2 yaml properties", func() {
+			mismatches := compareJSONSchemaProperties(jsonSchema, jsonSchema, reflect.TypeOf(PluginConfigSchemaV2{}), "")
+			Convey("Then there should not be any mismatches", func() {
+				So(mismatches, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+// compareJSONSchemaProperties returns the paths where the JSON Schema properties do not match the yaml properties of the
+// given type
+func compareJSONSchemaProperties(root, schema map[string]interface{}, t reflect.Type, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		definition := root
+		for _, name := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			definition, _ = definition[name].(map[string]interface{})
+		}
+		return compareJSONSchemaProperties(root, definition, t, path)
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return compareJSONSchemaProperties(root, schema, t.Elem(), path)
+	case reflect.Slice:
+		items, _ := schema["items"].(map[string]interface{})
+		return compareJSONSchemaProperties(root, items, t.Elem(), path+"[]")
+	case reflect.Map:
+		additionalProperties, ok := schema["additionalProperties"].(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected additionalProperties schema for map", path)}
+		}
+		return compareJSONSchemaProperties(root, additionalProperties, t.Elem(), path+".*")
+	case reflect.Struct:
+		properties, _ := schema["properties"].(map[string]interface{})
+		fields := yamlFields(t)
+		var mismatches []string
+		for name, field := range fields {
+			propertySchema, ok := properties[name].(map[string]interface{})
+			if !ok {
+				mismatches = append(mismatches, fmt.Sprintf("%s.%s: missing in JSON Schema", path, name))
+				continue
+			}
+			mismatches = append(mismatches, compareJSONSchemaProperties(root, propertySchema, field.Type, path+"."+name)...)
+		}
+		for name := range properties {
+			if _, ok := fields[name]; !ok {
+				mismatches = append(mismatches, fmt.Sprintf("%s.%s: not supported by %s", path, name, t.Name()))
+			}
+		}
+		sort.Strings(mismatches)
+		return mismatches
+	}
+	return nil
+}
+
+// yamlFields returns the exported struct fields indexed by their yaml name, including the ones of inlined structs
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+		if len(tag) > 1 && tag[1] == "inline" {
+			for name, inlineField := range yamlFields(field.Type) {
+				fields[name] = inlineField
+			}
+			continue
+		}
+		fields[tag[0]] = field
+	}
+	return fields
+}
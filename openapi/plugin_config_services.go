@@ -2,15 +2,19 @@ package openapi
 
 import (
 	"fmt"
-	"github.com/asaskevich/govalidator"
 	"log"
-	"os"
+	"strings"
 )
 
 // ServiceConfiguration defines the interface/expected behaviour for ServiceConfiguration implementations.
 type ServiceConfiguration interface {
 	// GetSwaggerURL returns the URL where the service swagger doc is exposed
 	GetSwaggerURL() string
+	// GetSwaggerSpecs returns the list of swagger docs the service provider is composed of; empty if the service is
+	// described by a single swagger doc (see GetSwaggerURL)
+	GetSwaggerSpecs() []SwaggerSpecConfigV1
+	// GetSwaggerOverlays returns the overlays applied to the swagger doc exposed at the swagger URL (see GetSwaggerURL)
+	GetSwaggerOverlays() []string
 	// GetSPluginVersion returns the OpenAPI Plugin version
 	GetPluginVersion() string
 	// IsInsecureSkipVerifyEnabled returns true if the given provider's service configuration has InsecureSkipVerify enabled; false
@@ -23,6 +27,21 @@ type ServiceConfiguration interface {
 
 	// GetTelemetryConfiguration returns the telemetry configuration for this service provider
 	GetTelemetryConfiguration() TelemetryProvider
+
+	// GetTLSConfiguration returns the TLS configuration for this service provider; nil if not configured
+	GetTLSConfiguration() *TLSConfig
+
+	// GetRetryConfiguration returns the retry configuration for this service provider; nil if not configured
+	GetRetryConfiguration() *RetryConfig
+
+	// GetRateLimitConfiguration returns the client side rate limit configuration for this service provider; nil if not configured
+	GetRateLimitConfiguration() *RateLimitConfig
+
+	// GetPollingConfiguration returns the polling cadence configuration for this service provider; nil if not configured
+	GetPollingConfiguration() *PollingConfig
+
+	// GetTracingConfiguration returns the distributed tracing configuration for this service provider; nil if not configured
+	GetTracingConfiguration() *TracingConfig
 }
 
 // TelemetryConfig contains the configuration for the telemetry
@@ -31,12 +50,93 @@ type TelemetryConfig struct {
 	Graphite *TelemetryProviderGraphite `yaml:"graphite,omitempty"`
 	// HTTPEndpoint defines the configuration needed to ship telemetry to an http endpoint
 	HTTPEndpoint *TelemetryProviderHTTPEndpoint `yaml:"http_endpoint,omitempty"`
+	// OTLP defines the configuration needed to ship telemetry to an OpenTelemetry collector via OTLP (HTTP/protobuf)
+	OTLP *TelemetryProviderOTLP `yaml:"otlp,omitempty"`
+	// Prometheus defines the configuration needed to write telemetry to a node exporter textfile and/or push it to a Pushgateway
+	Prometheus *TelemetryProviderPrometheus `yaml:"prometheus,omitempty"`
+}
+
+// getConfiguredProviders returns the names of the telemetry providers configured
+func (t *TelemetryConfig) getConfiguredProviders() []string {
+	var configuredProviders []string
+	if t.Graphite != nil {
+		configuredProviders = append(configuredProviders, "graphite")
+	}
+	if t.HTTPEndpoint != nil {
+		configuredProviders = append(configuredProviders, "http_endpoint")
+	}
+	if t.OTLP != nil {
+		configuredProviders = append(configuredProviders, "otlp")
+	}
+	if t.Prometheus != nil {
+		configuredProviders = append(configuredProviders, "prometheus")
+	}
+	return configuredProviders
+}
+
+// getTelemetryProvider returns the validated TelemetryProvider configured (Graphite, HTTPEndpoint, OTLP or Prometheus); nil
+// is returned if telemetry is not configured, multiple providers are configured or the provider configuration is not valid
+func (t *TelemetryConfig) getTelemetryProvider() TelemetryProvider {
+	if t != nil {
+		if configuredProviders := t.getConfiguredProviders(); len(configuredProviders) > 1 {
+			log.Printf("[WARN] ignoring telemetry due multiple telemetry providers configured (%s): select only one", strings.Join(configuredProviders, " and "))
+			return nil
+		}
+		if t.Graphite != nil {
+			log.Printf("[DEBUG] graphite telemetry configuration present")
+			err := t.Graphite.Validate()
+			if err != nil {
+				log.Printf("[WARN] ignoring graphite telemetry due to the following validation error: %s", err)
+				return nil
+			}
+			log.Printf("[DEBUG] graphite telemetry provider enabled")
+			return t.Graphite
+		}
+		if t.HTTPEndpoint != nil {
+			log.Printf("[DEBUG] http endpoint telemetry configuration present")
+			err := t.HTTPEndpoint.Validate()
+			if err != nil {
+				log.Printf("[WARN] ignoring http endpoint telemetry due to the following validation error: %s", err)
+				return nil
+			}
+			log.Printf("[DEBUG] http endpoint telemetry provider enabled")
+			return t.HTTPEndpoint
+		}
+		if t.OTLP != nil {
+			log.Printf("[DEBUG] otlp telemetry configuration present")
+			err := t.OTLP.Validate()
+			if err != nil {
+				log.Printf("[WARN] ignoring otlp telemetry due to the following validation error: %s", err)
+				return nil
+			}
+			log.Printf("[DEBUG] otlp telemetry provider enabled")
+			return t.OTLP
+		}
+		if t.Prometheus != nil {
+			log.Printf("[DEBUG] prometheus telemetry configuration present")
+			err := t.Prometheus.Validate()
+			if err != nil {
+				log.Printf("[WARN] ignoring prometheus telemetry due to the following validation error: %s", err)
+				return nil
+			}
+			log.Printf("[DEBUG] prometheus telemetry provider enabled")
+			return t.Prometheus
+		}
+	}
+	log.Printf("[DEBUG] telemetry not configured")
+	return nil
 }
 
 // ServiceConfigV1 defines configuration for the service provider
 type ServiceConfigV1 struct {
 	// SwaggerURL defines where the swagger is located
-	SwaggerURL string `yaml:"swagger-url"`
+	SwaggerURL string `yaml:"swagger-url,omitempty"`
+	// SwaggerSpecs defines the list of swagger docs the provider is composed of, used instead of SwaggerURL when the
+	// service API is split across multiple swagger docs (eg: one per microservice)
+	SwaggerSpecs []SwaggerSpecConfigV1 `yaml:"swagger-urls,omitempty"`
+	// Overlays defines the list of JSON Patch or OpenAPI Overlay documents applied in order to the swagger doc located at
+	// SwaggerURL, which enables the addition of the terraform extensions to swagger docs that can not be edited
+	Overlays []string `yaml:"overlays,omitempty"`
 	// PluginVersion defines the version of the OpenAPI Terraform plugin installed when generating the plugin configuration
 	PluginVersion string `yaml:"plugin_version,omitempty"`
 	// InsecureSkipVerify defines whether the internal http client used to fetch the swagger file should verify the server cert
@@ -46,6 +146,22 @@ type ServiceConfigV1 struct {
 	SchemaConfigurationV1 []ServiceSchemaPropertyConfigurationV1 `yaml:"schema_configuration,omitempty"`
 
 	TelemetryConfig *TelemetryConfig `yaml:"telemetry,omitempty"`
+
+	// TLSConfig defines the TLS settings (CA bundle, client certificate for mutual TLS and min TLS version) used when
+	// connecting to the API and retrieving the swagger file
+	TLSConfig *TLSConfig `yaml:"tls,omitempty"`
+
+	// RetryConfig defines how API requests that fail due to network errors or retryable status codes are retried
+	RetryConfig *RetryConfig `yaml:"retry,omitempty"`
+
+	// RateLimitConfig defines the client side rate limit applied to the API requests per host
+	RateLimitConfig *RateLimitConfig `yaml:"rate_limit,omitempty"`
+
+	// PollingConfig defines the polling cadence used when waiting for asynchronous resources
+	PollingConfig *PollingConfig `yaml:"polling,omitempty"`
+
+	// TracingConfig defines where the spans of the provider operations and API requests are exported to
+	TracingConfig *TracingConfig `yaml:"tracing,omitempty"`
 }
 
 // NewServiceConfigV1 creates a new instance of NewServiceConfigV1 struct with the values provided
@@ -63,6 +179,16 @@ func (s *ServiceConfigV1) GetSwaggerURL() string {
 	return s.SwaggerURL
 }
 
+// GetSwaggerSpecs returns the list of swagger docs the service provider is composed of
+func (s *ServiceConfigV1) GetSwaggerSpecs() []SwaggerSpecConfigV1 {
+	return s.SwaggerSpecs
+}
+
+// GetSwaggerOverlays returns the overlays applied to the swagger doc
+func (s *ServiceConfigV1) GetSwaggerOverlays() []string {
+	return s.Overlays
+}
+
 // GetPluginVersion returns the OpenAPI Plugin version
 func (s *ServiceConfigV1) GetPluginVersion() string {
 	return s.PluginVersion
@@ -74,36 +200,34 @@ func (s *ServiceConfigV1) IsInsecureSkipVerifyEnabled() bool {
 	return s.InsecureSkipVerify
 }
 
-// GetTelemetryConfiguration returns a TelemetryProvider configured for Graphite or HTTPEndpoint
+// GetTelemetryConfiguration returns a TelemetryProvider configured for Graphite, HTTPEndpoint, OTLP or Prometheus
 func (s *ServiceConfigV1) GetTelemetryConfiguration() TelemetryProvider {
-	if s.TelemetryConfig != nil {
-		if s.TelemetryConfig.Graphite != nil && s.TelemetryConfig.HTTPEndpoint != nil {
-			log.Printf("[WARN] ignoring telemetry due multiple telemetry providers configured (graphite and http_endpoint): select only one")
-			return nil
-		}
-		if s.TelemetryConfig.Graphite != nil {
-			log.Printf("[DEBUG] graphite telemetry configuration present")
-			err := s.TelemetryConfig.Graphite.Validate()
-			if err != nil {
-				log.Printf("[WARN] ignoring graphite telemetry due to the following validation error: %s", err)
-				return nil
-			}
-			log.Printf("[DEBUG] graphite telemetry provider enabled")
-			return s.TelemetryConfig.Graphite
-		}
-		if s.TelemetryConfig.HTTPEndpoint != nil {
-			log.Printf("[DEBUG] http endpoint telemetry configuration present")
-			err := s.TelemetryConfig.HTTPEndpoint.Validate()
-			if err != nil {
-				log.Printf("[WARN] ignoring http endpoint telemetry due to the following validation error: %s", err)
-				return nil
-			}
-			log.Printf("[DEBUG] http endpoint telemetry provider enabled")
-			return s.TelemetryConfig.HTTPEndpoint
-		}
-	}
-	log.Printf("[DEBUG] telemetry not configured")
-	return nil
+	return s.TelemetryConfig.getTelemetryProvider()
+}
+
+// GetTLSConfiguration returns the TLS configuration; nil if not configured
+func (s *ServiceConfigV1) GetTLSConfiguration() *TLSConfig {
+	return s.TLSConfig
+}
+
+// GetRetryConfiguration returns the retry configuration; nil if not configured
+func (s *ServiceConfigV1) GetRetryConfiguration() *RetryConfig {
+	return s.RetryConfig
+}
+
+// GetRateLimitConfiguration returns the rate limit configuration; nil if not configured
+func (s *ServiceConfigV1) GetRateLimitConfiguration() *RateLimitConfig {
+	return s.RateLimitConfig
+}
+
+// GetPollingConfiguration returns the polling configuration; nil if not configured
+func (s *ServiceConfigV1) GetPollingConfiguration() *PollingConfig {
+	return s.PollingConfig
+}
+
+// GetTracingConfiguration returns the tracing configuration; nil if not configured
+func (s *ServiceConfigV1) GetTracingConfiguration() *TracingConfig {
+	return s.TracingConfig
 }
 
 // GetSchemaPropertyConfiguration returns the external configuration for the given schema property name; nil is returned
@@ -118,19 +242,62 @@ func (s *ServiceConfigV1) GetSchemaPropertyConfiguration(schemaPropertyName stri
 }
 
 // Validate makes sure the configuration is valid:
+// - either the swagger URL or the list of swagger URLs must be specified, and the URLs must be valid
+// - the overlays must be valid URLs or paths to existing files, and can only be specified along with the swagger URL
 // - if the user has specified an OpenAPI plugin version, and if the plugin does not match the version then something is off
+// - if the user has specified a TLS configuration, the configuration must be well formed
+// - if the user has specified a retry configuration, the configuration must be well formed
+// - if the user has specified a rate limit configuration, the configuration must be well formed
+// - if the user has specified a polling configuration, the configuration must be well formed
+// - if the user has specified a tracing configuration, the configuration must be well formed
 func (s *ServiceConfigV1) Validate(runningPluginVersion string) error {
-	if !govalidator.IsURL(s.SwaggerURL) {
-		// fall back to try to load the swagger file from disk in case the path provided is a path to a file on disk
-		if _, err := os.Stat(s.SwaggerURL); os.IsNotExist(err) {
-			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk", s.SwaggerURL)
+	return validateServiceConfig(s.SwaggerURL, s.SwaggerSpecs, s.Overlays, s.PluginVersion, runningPluginVersion, s.TLSConfig, s.RetryConfig, s.RateLimitConfig, s.PollingConfig, s.TracingConfig)
+}
+
+// validateServiceConfig validates the service configuration settings shared by all the plugin configuration schema versions
+func validateServiceConfig(swaggerURL string, swaggerSpecs []SwaggerSpecConfigV1, overlays []string, pluginVersion, runningPluginVersion string, tlsConfig *TLSConfig, retryConfig *RetryConfig, rateLimitConfig *RateLimitConfig, pollingConfig *PollingConfig, tracingConfig *TracingConfig) error {
+	if len(swaggerSpecs) > 0 {
+		if swaggerURL != "" {
+			return fmt.Errorf("service configuration must contain either a single swagger URL or a list of swagger URLs, not both")
 		}
-	}
-	if s.PluginVersion != "" {
-		if s.PluginVersion != runningPluginVersion {
-			return fmt.Errorf("plugin version '%s' in the plugin configuration file does not match the version of the OpenAPI plugin that is running '%s'", s.PluginVersion, runningPluginVersion)
+		if len(overlays) > 0 {
+			return fmt.Errorf("service configuration overlays can only be used along with a single swagger URL, the overlays of each of the swagger URLs must be configured in the list of swagger URLs")
+		}
+		if err := validateSwaggerSpecs(swaggerSpecs); err != nil {
+			return err
+		}
+	} else {
+		if err := validateSwaggerURL(swaggerURL); err != nil {
+			return err
+		}
+		if err := validateOverlays(overlays); err != nil {
+			return err
 		}
 	}
+	return validateServiceConfigSettings(pluginVersion, runningPluginVersion, tlsConfig, retryConfig, rateLimitConfig, pollingConfig, tracingConfig)
+}
 
+// validateServiceConfigSettings validates the service configuration settings other than the swagger ones (e,g: retry, polling)
+func validateServiceConfigSettings(pluginVersion, runningPluginVersion string, tlsConfig *TLSConfig, retryConfig *RetryConfig, rateLimitConfig *RateLimitConfig, pollingConfig *PollingConfig, tracingConfig *TracingConfig) error {
+	if pluginVersion != "" {
+		if pluginVersion != runningPluginVersion {
+			return fmt.Errorf("plugin version '%s' in the plugin configuration file does not match the version of the OpenAPI plugin that is running '%s'", pluginVersion, runningPluginVersion)
+		}
+	}
+	if err := tlsConfig.Validate(); err != nil {
+		return err
+	}
+	if err := retryConfig.Validate(); err != nil {
+		return err
+	}
+	if err := rateLimitConfig.Validate(); err != nil {
+		return err
+	}
+	if err := pollingConfig.Validate(); err != nil {
+		return err
+	}
+	if err := tracingConfig.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// PollingConfig contains the polling cadence used by the OpenAPI provider when waiting for asynchronous resources to reach
+// a completion status. The values override the ones defined in the OpenAPI document
+type PollingConfig struct {
+	PollingSettings `yaml:",inline"`
+	// Resources contains the polling settings specific to a given resource (keyed by resource name, e,g: cdn_v1) which take
+	// preference over the service level settings
+	Resources map[string]PollingSettings `yaml:"resources,omitempty"`
+}
+
+// PollingSettings defines the polling cadence
+type PollingSettings struct {
+	// Delay defines how long to wait before the first poll (e,g: 10s)
+	Delay string `yaml:"delay,omitempty"`
+	// Interval defines how long to wait between polls (e,g: 30s)
+	Interval string `yaml:"interval,omitempty"`
+	// BackoffMultiplier multiplies the interval after each poll (e,g: 1.5). The value must be greater than or equal to 1
+	BackoffMultiplier float64 `yaml:"backoff_multiplier,omitempty"`
+}
+
+// Validate checks whether the polling configuration is well formed
+func (p *PollingConfig) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if err := p.PollingSettings.validate("polling"); err != nil {
+		return err
+	}
+	for resourceName, settings := range p.Resources {
+		if err := settings.validate(fmt.Sprintf("polling resource '%s'", resourceName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getPollPolicy returns the poll policy resulting from overriding the service level settings with the settings specific
+// to the given resource if any; nil is returned if there is no polling configuration
+func (p *PollingConfig) getPollPolicy(resourceName string) (*specPollPolicy, error) {
+	if p == nil {
+		return nil, nil
+	}
+	pollPolicy, err := p.PollingSettings.getPollPolicy("polling")
+	if err != nil {
+		return nil, err
+	}
+	if settings, exists := p.Resources[resourceName]; exists {
+		resourcePollPolicy, err := settings.getPollPolicy(fmt.Sprintf("polling resource '%s'", resourceName))
+		if err != nil {
+			return nil, err
+		}
+		pollPolicy = pollPolicy.merge(resourcePollPolicy)
+	}
+	return pollPolicy, nil
+}
+
+func (s PollingSettings) validate(configName string) error {
+	_, err := s.getPollPolicy(configName)
+	return err
+}
+
+func (s PollingSettings) getPollPolicy(configName string) (*specPollPolicy, error) {
+	delay, err := parsePollingDuration(configName, "delay", s.Delay)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := parsePollingDuration(configName, "interval", s.Interval)
+	if err != nil {
+		return nil, err
+	}
+	if s.BackoffMultiplier != 0 && s.BackoffMultiplier < 1 {
+		return nil, fmt.Errorf("%s configuration 'backoff_multiplier' value '%v' not valid, the value must be greater than or equal to 1", configName, s.BackoffMultiplier)
+	}
+	return &specPollPolicy{delay: delay, interval: interval, backoffMultiplier: s.BackoffMultiplier}, nil
+}
+
+func parsePollingDuration(configName, name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("%s configuration '%s' value '%s' not valid, the value must be a positive duration (e,g: 500ms, 1s, 1m)", configName, name, value)
+	}
+	return duration, nil
+}
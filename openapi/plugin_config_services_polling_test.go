@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/yaml.v2"
+)
+
+func TestPollingConfigUnmarshal(t *testing.T) {
+	Convey("Given a polling configuration in yaml containing service level and resource level settings", t, func() {
+		config := `delay: 10s
+interval: 30s
+backoff_multiplier: 1.5
+resources:
+  db_cluster_v1:
+    interval: 2m
+`
+		Convey("When the yaml is unmarshalled", func() {
+			pollingConfig := &PollingConfig{}
+			err := yaml.Unmarshal([]byte(config), pollingConfig)
+			Convey("Then the polling config should contain the expected values", func() {
+				So(err, ShouldBeNil)
+				So(pollingConfig.Delay, ShouldEqual, "10s")
+				So(pollingConfig.Interval, ShouldEqual, "30s")
+				So(pollingConfig.BackoffMultiplier, ShouldEqual, 1.5)
+				So(pollingConfig.Resources["db_cluster_v1"].Interval, ShouldEqual, "2m")
+			})
+		})
+	})
+}
+
+func TestPollingConfigValidate(t *testing.T) {
+	Convey("Given a nil PollingConfig", t, func() {
+		var pollingConfig *PollingConfig
+		Convey("When Validate is called", func() {
+			err := pollingConfig.Validate()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a PollingConfig with an interval that is not valid", t, func() {
+		pollingConfig := &PollingConfig{PollingSettings: PollingSettings{Interval: "often"}}
+		Convey("When Validate is called", func() {
+			err := pollingConfig.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "polling configuration 'interval' value 'often' not valid, the value must be a positive duration (e,g: 500ms, 1s, 1m)")
+			})
+		})
+	})
+	Convey("Given a PollingConfig with a resource backoff multiplier lower than 1", t, func() {
+		pollingConfig := &PollingConfig{Resources: map[string]PollingSettings{"cdn_v1": {BackoffMultiplier: 0.5}}}
+		Convey("When Validate is called", func() {
+			err := pollingConfig.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "polling resource 'cdn_v1' configuration 'backoff_multiplier' value '0.5' not valid, the value must be greater than or equal to 1")
+			})
+		})
+	})
+}
+
+func TestPollingConfigGetPollPolicy(t *testing.T) {
+	Convey("Given a PollingConfig with service level and resource level settings", t, func() {
+		pollingConfig := &PollingConfig{
+			PollingSettings: PollingSettings{Delay: "10s", Interval: "30s"},
+			Resources: map[string]PollingSettings{
+				"db_cluster_v1": {Interval: "2m", BackoffMultiplier: 2},
+			},
+		}
+		Convey("When getPollPolicy is called for a resource that has specific settings", func() {
+			pollPolicy, err := pollingConfig.getPollPolicy("db_cluster_v1")
+			Convey("Then the resource settings should take preference over the service level ones", func() {
+				So(err, ShouldBeNil)
+				So(pollPolicy, ShouldResemble, &specPollPolicy{delay: 10 * time.Second, interval: 2 * time.Minute, backoffMultiplier: 2})
+			})
+		})
+		Convey("When getPollPolicy is called for a resource that does not have specific settings", func() {
+			pollPolicy, err := pollingConfig.getPollPolicy("cdn_v1")
+			Convey("Then the service level settings should be returned", func() {
+				So(err, ShouldBeNil)
+				So(pollPolicy, ShouldResemble, &specPollPolicy{delay: 10 * time.Second, interval: 30 * time.Second})
+			})
+		})
+	})
+	Convey("Given a nil PollingConfig", t, func() {
+		var pollingConfig *PollingConfig
+		Convey("When getPollPolicy is called", func() {
+			pollPolicy, err := pollingConfig.getPollPolicy("cdn_v1")
+			Convey("Then the poll policy returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(pollPolicy, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"fmt"
+)
+
+// RateLimitConfig contains the client side rate limit configuration applied to the API requests. Limits are applied per
+// host, so resources pointing at different hosts (e,g: multi-region hosts or endpoint overrides) are limited independently
+type RateLimitConfig struct {
+	// RequestsPerSecond defines the number of requests per second allowed for a given host. Zero means no limit
+	RequestsPerSecond float64 `yaml:"requests_per_second,omitempty"`
+	// Burst defines the max number of requests that can be performed at once for a given host. If not set, the burst
+	// will be the ceiling of RequestsPerSecond
+	Burst int `yaml:"burst,omitempty"`
+}
+
+// Validate checks whether the rate limit configuration is well formed
+func (r *RateLimitConfig) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.RequestsPerSecond < 0 {
+		return fmt.Errorf("rate limit configuration 'requests_per_second' value '%v' not valid, the value must be greater than zero", r.RequestsPerSecond)
+	}
+	if r.Burst < 0 {
+		return fmt.Errorf("rate limit configuration 'burst' value '%d' not valid, the value must be greater than zero", r.Burst)
+	}
+	return nil
+}
+
+// isEmpty returns true if the rate limit is not configured
+func (r *RateLimitConfig) isEmpty() bool {
+	return r == nil || r.RequestsPerSecond == 0
+}
+
+// merge returns a new RateLimitConfig where the values of the override configuration (if populated) take preference over
+// the ones of r
+func (r *RateLimitConfig) merge(override *RateLimitConfig) *RateLimitConfig {
+	merged := &RateLimitConfig{}
+	if r != nil {
+		*merged = *r
+	}
+	if override == nil {
+		return merged
+	}
+	if override.RequestsPerSecond > 0 {
+		merged.RequestsPerSecond = override.RequestsPerSecond
+	}
+	if override.Burst > 0 {
+		merged.Burst = override.Burst
+	}
+	return merged
+}
@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRateLimitConfigValidate(t *testing.T) {
+	Convey("Given a nil RateLimitConfig", t, func() {
+		var rateLimit *RateLimitConfig
+		Convey("When Validate is called", func() {
+			err := rateLimit.Validate()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a RateLimitConfig with negative requests per second", t, func() {
+		rateLimit := &RateLimitConfig{RequestsPerSecond: -1}
+		Convey("When Validate is called", func() {
+			err := rateLimit.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "rate limit configuration 'requests_per_second' value '-1' not valid, the value must be greater than zero")
+			})
+		})
+	})
+	Convey("Given a RateLimitConfig with negative burst", t, func() {
+		rateLimit := &RateLimitConfig{RequestsPerSecond: 1, Burst: -1}
+		Convey("When Validate is called", func() {
+			err := rateLimit.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "rate limit configuration 'burst' value '-1' not valid, the value must be greater than zero")
+			})
+		})
+	})
+}
+
+func TestRateLimitConfigMerge(t *testing.T) {
+	Convey("Given a RateLimitConfig from the OpenAPI document and overrides from the plugin and provider configuration", t, func() {
+		specRateLimit := &RateLimitConfig{RequestsPerSecond: 10, Burst: 20}
+		pluginRateLimit := &RateLimitConfig{RequestsPerSecond: 5}
+		providerRateLimit := &RateLimitConfig{Burst: 1}
+		Convey("When merge is called", func() {
+			merged := specRateLimit.merge(pluginRateLimit).merge(providerRateLimit)
+			Convey("Then the values from the overrides should take preference", func() {
+				So(merged, ShouldResemble, &RateLimitConfig{RequestsPerSecond: 5, Burst: 1})
+			})
+			Convey("And the original config should not be mutated", func() {
+				So(specRateLimit, ShouldResemble, &RateLimitConfig{RequestsPerSecond: 10, Burst: 20})
+			})
+		})
+	})
+	Convey("Given a nil RateLimitConfig", t, func() {
+		var rateLimit *RateLimitConfig
+		Convey("When merge is called with nil", func() {
+			merged := rateLimit.merge(nil)
+			Convey("Then the merged config should be empty", func() {
+				So(merged.isEmpty(), ShouldBeTrue)
+			})
+		})
+	})
+}
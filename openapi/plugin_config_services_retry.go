@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryConfig contains the configuration used by the OpenAPI provider to retry API requests that failed due to network
+// errors or retryable status codes (429 Too Many Requests and 5xx). By default only idempotent methods (GET, PUT and
+// DELETE) are retried
+type RetryConfig struct {
+	// MaxAttempts defines the max number of attempts (including the first one) performed for a given API request. A value
+	// of 1 disables retries and 0 (or not set) means the default max attempts is used
+	MaxAttempts int `yaml:"max_attempts,omitempty"`
+	// InitialInterval defines how long to wait before the first retry (e,g: 500ms, 1s). The wait time doubles on each retry
+	InitialInterval string `yaml:"initial_interval,omitempty"`
+	// MaxInterval defines the max wait time between retries (e,g: 30s)
+	MaxInterval string `yaml:"max_interval,omitempty"`
+}
+
+// Validate checks whether the retry configuration is well formed
+func (r *RetryConfig) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.MaxAttempts < 0 {
+		return fmt.Errorf("retry configuration 'max_attempts' value '%d' not valid, the value must not be negative", r.MaxAttempts)
+	}
+	if _, err := parseRetryInterval("initial_interval", r.InitialInterval); err != nil {
+		return err
+	}
+	if _, err := parseRetryInterval("max_interval", r.MaxInterval); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getRetryPolicy returns the retry policy resulting from overriding the default retry policy with the values configured
+func (r *RetryConfig) getRetryPolicy() (*specRetryPolicy, error) {
+	policy := newDefaultRetryPolicy()
+	if r == nil {
+		return policy, nil
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if r.MaxAttempts > 0 {
+		policy.maxAttempts = r.MaxAttempts
+	}
+	if initialInterval, _ := parseRetryInterval("initial_interval", r.InitialInterval); initialInterval > 0 {
+		policy.initialInterval = initialInterval
+	}
+	if maxInterval, _ := parseRetryInterval("max_interval", r.MaxInterval); maxInterval > 0 {
+		policy.maxInterval = maxInterval
+	}
+	return policy, nil
+}
+
+func parseRetryInterval(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("retry configuration '%s' value '%s' not valid, the value must be a positive duration (e,g: 500ms, 1s, 1m)", name, value)
+	}
+	return interval, nil
+}
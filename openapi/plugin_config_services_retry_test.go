@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRetryConfigValidate(t *testing.T) {
+	Convey("Given a nil RetryConfig", t, func() {
+		var retryConfig *RetryConfig
+		Convey("When Validate is called", func() {
+			err := retryConfig.Validate()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a RetryConfig with a negative max attempts", t, func() {
+		retryConfig := &RetryConfig{MaxAttempts: -1}
+		Convey("When Validate is called", func() {
+			err := retryConfig.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "retry configuration 'max_attempts' value '-1' not valid, the value must not be negative")
+			})
+		})
+	})
+	Convey("Given a RetryConfig with an invalid initial interval", t, func() {
+		retryConfig := &RetryConfig{InitialInterval: "soon"}
+		Convey("When Validate is called", func() {
+			err := retryConfig.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "retry configuration 'initial_interval' value 'soon' not valid, the value must be a positive duration (e,g: 500ms, 1s, 1m)")
+			})
+		})
+	})
+}
+
+func TestRetryConfigGetRetryPolicy(t *testing.T) {
+	Convey("Given a nil RetryConfig", t, func() {
+		var retryConfig *RetryConfig
+		Convey("When getRetryPolicy is called", func() {
+			retryPolicy, err := retryConfig.getRetryPolicy()
+			Convey("Then the error returned should be nil and the default retry policy should be returned", func() {
+				So(err, ShouldBeNil)
+				So(retryPolicy, ShouldResemble, newDefaultRetryPolicy())
+			})
+		})
+	})
+	Convey("Given a RetryConfig with some values configured", t, func() {
+		retryConfig := &RetryConfig{MaxAttempts: 5, InitialInterval: "500ms"}
+		Convey("When getRetryPolicy is called", func() {
+			retryPolicy, err := retryConfig.getRetryPolicy()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the retry policy should contain the configured values and the defaults for the rest", func() {
+				So(retryPolicy.maxAttempts, ShouldEqual, 5)
+				So(retryPolicy.initialInterval, ShouldEqual, 500*time.Millisecond)
+				So(retryPolicy.maxInterval, ShouldEqual, defaultRetryMaxInterval)
+			})
+		})
+	})
+	Convey("Given a RetryConfig with max attempts set to 1", t, func() {
+		retryConfig := &RetryConfig{MaxAttempts: 1}
+		Convey("When getRetryPolicy is called", func() {
+			retryPolicy, err := retryConfig.getRetryPolicy()
+			Convey("Then the retry policy returned should have retries disabled", func() {
+				So(err, ShouldBeNil)
+				So(retryPolicy.isRetryEnabled(httpGet), ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// commandOutputCacheDir returns the directory where the command outputs are cached. The outputs are persisted on disk as
+// the plugin is executed as a new process on every terraform command
+var commandOutputCacheDir = func() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "terraform-provider-openapi", "cmd_output"), nil
+}
+
+// commandOutputCache caches the output of a schema property command. Since the output may contain secrets, the cache files
+// are only readable by the user running the plugin and their name is derived from a hash of the property name and command
+type commandOutputCache struct {
+	key string
+}
+
+// commandOutputCacheEntry defines the content of the cache file
+type commandOutputCacheEntry struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Output    string    `json:"output"`
+}
+
+func newCommandOutputCache(schemaPropertyName string, command []string) commandOutputCache {
+	hash := sha256.Sum256([]byte(schemaPropertyName + "\x00" + strings.Join(command, "\x00")))
+	return commandOutputCache{key: hex.EncodeToString(hash[:])}
+}
+
+// get returns the cached output; false is returned if the output is not cached or the cached output has expired
+func (c commandOutputCache) get() (string, bool) {
+	path, err := c.path()
+	if err != nil {
+		return "", false
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	var entry commandOutputCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || time.Now().After(entry.ExpiresAt) {
+		return "", false
+	}
+	return entry.Output, true
+}
+
+// put caches the output for the given ttl. The cache file is written atomically so concurrent plugin executions never
+// read a partially written file
+func (c commandOutputCache) put(output string, ttl time.Duration) error {
+	path, err := c.path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(commandOutputCacheEntry{ExpiresAt: time.Now().Add(ttl), Output: output})
+	if err != nil {
+		return err
+	}
+	// ioutil.TempFile creates the file with 0600 permissions
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), c.key)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
+
+func (c commandOutputCache) path() (string, error) {
+	dir, err := commandOutputCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, c.key+".json"), nil
+}
@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setCommandOutputCacheDir(t *testing.T) (string, func()) {
+	cacheDir, err := ioutil.TempDir("", "cmd_output")
+	require.NoError(t, err)
+	defaultCommandOutputCacheDir := commandOutputCacheDir
+	commandOutputCacheDir = func() (string, error) { return filepath.Join(cacheDir, "cmd_output"), nil }
+	return cacheDir, func() {
+		commandOutputCacheDir = defaultCommandOutputCacheDir
+		os.RemoveAll(cacheDir)
+	}
+}
+
+func TestCommandOutputCache(t *testing.T) {
+	cacheDir, cleanUp := setCommandOutputCacheDir(t)
+	defer cleanUp()
+
+	cache := newCommandOutputCache("token", []string{"get-token", "--profile", "dev"})
+	_, cached := cache.get()
+	assert.False(t, cached, "output should not be cached before being put")
+
+	require.NoError(t, cache.put("secret", time.Hour))
+	output, cached := cache.get()
+	assert.True(t, cached)
+	assert.Equal(t, "secret", output)
+
+	files, err := ioutil.ReadDir(filepath.Join(cacheDir, "cmd_output"))
+	require.NoError(t, err)
+	require.Len(t, files, 1, "only the cache file should be left in the cache dir")
+	assert.Equal(t, os.FileMode(0600), files[0].Mode().Perm(), "cache files should only be readable by the user")
+	assert.NotContains(t, files[0].Name(), "token", "cache file names should not reveal the property name")
+	dirInfo, err := os.Stat(filepath.Join(cacheDir, "cmd_output"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), dirInfo.Mode().Perm())
+
+	_, cached = newCommandOutputCache("token", []string{"get-token", "--profile", "prod"}).get()
+	assert.False(t, cached, "commands with different arguments should not share the cached output")
+}
+
+func TestCommandOutputCacheExpired(t *testing.T) {
+	_, cleanUp := setCommandOutputCacheDir(t)
+	defer cleanUp()
+
+	cache := newCommandOutputCache("token", []string{"get-token"})
+	require.NoError(t, cache.put("secret", -time.Second))
+	_, cached := cache.get()
+	assert.False(t, cached, "expired outputs should not be returned")
+}
+
+func TestCommandOutputCacheCorrupted(t *testing.T) {
+	_, cleanUp := setCommandOutputCacheDir(t)
+	defer cleanUp()
+
+	cache := newCommandOutputCache("token", []string{"get-token"})
+	path, err := cache.path()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, ioutil.WriteFile(path, []byte("not json"), 0600))
+	_, cached := cache.get()
+	assert.False(t, cached, "corrupted cache files should be ignored")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/oliveagle/jsonpath"
 	"log"
 	"os/exec"
+	"strings"
 	"time"
 )
 
@@ -28,27 +29,48 @@ type ServiceSchemaPropertyConfigurationV1 struct {
 	Command               []string                                     `yaml:"cmd,flow,omitempty"`
 	CommandTimeout        int                                          `yaml:"cmd_timeout,omitempty"`
 	ExternalConfiguration ServiceSchemaPropertyExternalConfigurationV1 `yaml:"schema_property_external_configuration,omitempty"`
+	CommandOutput         *ServiceSchemaPropertyCommandOutputV1        `yaml:"cmd_output,omitempty"`
+}
+
+// ServiceSchemaPropertyCommandOutputV1 defines how the output (stdout) of the 'cmd' is used as the value of the provider
+// property, enabling the use of credential helpers that print the credentials rather than writing them to a file
+type ServiceSchemaPropertyCommandOutputV1 struct {
+	// ContentType defines the type of content the command prints to stdout. Supported types: raw, json
+	ContentType string `yaml:"content_type"`
+	// KeyName defines the specific key to look for within the command output (only when json content type)
+	KeyName string `yaml:"key_name,omitempty"`
+	// CacheTTL defines for how long the command output is cached (eg: 10m, 1h) so the command is not executed every time
+	// the provider is configured. If not set, the output is not cached
+	CacheTTL string `yaml:"cache_ttl,omitempty"`
 }
 
 // ServiceSchemaPropertyExternalConfigurationV1 defines the external configuration for a provider property.
 type ServiceSchemaPropertyExternalConfigurationV1 struct {
 	// File defines the file containing the value of the schema property
 	File string `yaml:"file"`
-	// KeyName defines the specific key to look for within the File (only when json content type)
+	// KeyName defines the specific key to look for within the File (not applicable to raw content type)
 	KeyName string `yaml:"key_name"`
 	// ContentType defines the type of content the File has
-	ContentType string `yaml:"content_type"` // Currently supported types: raw, json
+	ContentType string `yaml:"content_type"` // Currently supported types: raw, json, yaml, dotenv, netrc
 }
 
 // GetDefaultValue returns the default value for the schema property configuration. The following logic defines the preference
 // when deciding what should be the default value of the property:
+// - if the property has a 'cmd' and 'cmd_output' configured, the command is executed (unless its output is cached) and the
+// value used will be the command output parsed as described by the 'cmd_output' content type
 // - if the property does not have external configuration ('schema_property_external_configuration') and it does have a 'default_value' is set, then value used will be the one specified in the 'default_value' field
 // - if the property has both the external configuration ('schema_property_external_configuration') and the 'default_value' fields set:
 //    - If 'file' field is populated then:
 //      - If the 'content_type' is raw the contents of the 'file' will be used as default value
 //      - If the 'content_type' is json then the content of the 'file' must be json structure and the default value used will be the one defined in the 'key_name'
+//      - If the 'content_type' is yaml then the content of the 'file' must be yaml structure and the default value used will be the one defined in the 'key_name'
+//      - If the 'content_type' is dotenv then the default value used will be the one of the variable named 'key_name'
+//      - If the 'content_type' is netrc then the default value used will be the field of the machine defined in the 'key_name' (<machine>/<field>)
 //    - An error is thrown otherwise
 func (s ServiceSchemaPropertyConfigurationV1) GetDefaultValue() (string, error) {
+	if s.CommandOutput != nil && len(s.Command) > 0 {
+		return s.getCommandOutputValue()
+	}
 	if &s.ExternalConfiguration != nil {
 		if s.ExternalConfiguration.File != "" {
 			log.Printf("[DEBUG] provider schema property '%s' configured to use as default value [ContentType=%s; File=%s, KeyName=%s]", s.SchemaPropertyName, s.ExternalConfiguration.ContentType, s.ExternalConfiguration.File, s.ExternalConfiguration.KeyName)
@@ -71,7 +93,13 @@ func (s ServiceSchemaPropertyConfigurationV1) GetDefaultValue() (string, error)
 // - If the command execution does not finish within the expected time (either before CommandTimeout or before the default timeout 10s)
 // a timeout error will be returned
 // - Otherwise, a nil error will be returned should the command executes successfully with a clean exit code
+// If the command output is used as the value of the property ('cmd_output'), the command is executed when GetDefaultValue
+// is called instead.
 func (s ServiceSchemaPropertyConfigurationV1) ExecuteCommand() error {
+	if s.CommandOutput != nil {
+		log.Printf("[DEBUG] provider schema property '%s' command output is used as the property value, the command will be executed when getting the default value", s.SchemaPropertyName)
+		return nil
+	}
 	doneChan := make(chan error)
 	// execute the command in a routine and wait for completion
 	go s.exec(doneChan)
@@ -84,43 +112,100 @@ func (s ServiceSchemaPropertyConfigurationV1) ExecuteCommand() error {
 
 func (s ServiceSchemaPropertyConfigurationV1) exec(doneChan chan error) {
 	if len(s.Command) > 0 {
-		start := time.Now()
-		log.Printf("[INFO] executing '%s' command '%s'", s.SchemaPropertyName, s.Command)
+		_, err := s.runCommand()
+		doneChan <- err
+		return
+	}
+	doneChan <- nil
+}
 
-		timeout := cmdTimeout
-		if s.CommandTimeout > 0 {
-			timeout = s.CommandTimeout
-		}
+// runCommand executes the 'Command' and returns its stdout. The output is never logged as it may contain secrets
+// (eg: tokens printed by credential helpers)
+func (s ServiceSchemaPropertyConfigurationV1) runCommand() (string, error) {
+	start := time.Now()
+	log.Printf("[INFO] executing '%s' command '%s'", s.SchemaPropertyName, s.Command)
+
+	timeout := cmdTimeout
+	if s.CommandTimeout > 0 {
+		timeout = s.CommandTimeout
+	}
 
-		// Create a new context and add a timeout to it
-		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-		defer cancel() // The cancel should be deferred so resources are cleaned up
+	// Create a new context and add a timeout to it
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel() // The cancel should be deferred so resources are cleaned up
 
-		// Create the command with our context
-		cmd := exec.CommandContext(ctx, s.Command[0], s.Command[1:]...)
+	// Create the command with our context
+	cmd := exec.CommandContext(ctx, s.Command[0], s.Command[1:]...)
 
-		// Capture stdout and stderr
-		var stdout, stderr bytes.Buffer
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
+	// Capture stdout and stderr
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
 
-		err := cmd.Run()
+	err := cmd.Run()
 
-		// We want to check the context error to see if the timeout was executed. The error returned by cmd.Output() will be OS specific based on what
-		// happens when a process is killed.
-		if ctx.Err() == context.DeadlineExceeded {
-			doneChan <- fmt.Errorf("command '%s' did not finish executing within the expected time %ds (%s)", s.Command, timeout, err)
-			return
-		}
+	// We want to check the context error to see if the timeout was executed. The error returned by cmd.Output() will be OS specific based on what
+	// happens when a process is killed.
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("command '%s' did not finish executing within the expected time %ds (%s)", s.Command, timeout, err)
+	}
 
-		// If there's no context error, we know the command completed (or errored).
+	// If there's no context error, we know the command completed (or errored).
+	if err != nil {
+		return "", fmt.Errorf("command '%s' failed: %s(%s)", s.Command, stderr.String(), err)
+	}
+	log.Printf("[INFO] provider schema property '%s' command '%s' executed successfully (time:%s, output size:%d bytes)", s.SchemaPropertyName, s.Command, time.Since(start), stdout.Len())
+	return stdout.String(), nil
+}
+
+// getCommandOutputValue returns the value of the property from the command output, executing the command only if there
+// is no cached output that has not expired yet
+func (s ServiceSchemaPropertyConfigurationV1) getCommandOutputValue() (string, error) {
+	var cacheTTL time.Duration
+	if s.CommandOutput.CacheTTL != "" {
+		ttl, err := time.ParseDuration(s.CommandOutput.CacheTTL)
+		if err != nil || ttl < 0 {
+			return "", fmt.Errorf("schema property '%s' cmd_output cache_ttl '%s' is not a valid duration", s.SchemaPropertyName, s.CommandOutput.CacheTTL)
+		}
+		cacheTTL = ttl
+	}
+	cache := newCommandOutputCache(s.SchemaPropertyName, s.Command)
+	output, cached := "", false
+	if cacheTTL > 0 {
+		output, cached = cache.get()
+	}
+	if !cached {
+		var err error
+		output, err = s.runCommand()
 		if err != nil {
-			doneChan <- fmt.Errorf("command '%s' failed: %s(%s)", s.Command, stderr.String(), err)
-			return
+			return "", fmt.Errorf("provider schema property '%s' command failed: %s", s.SchemaPropertyName, err)
+		}
+		if cacheTTL > 0 {
+			if err := cache.put(output, cacheTTL); err != nil {
+				log.Printf("[WARN] failed to cache the output of the provider schema property '%s' command: %s", s.SchemaPropertyName, err)
+			}
 		}
-		log.Printf("[INFO] provider schema property '%s' command '%s' executed successfully (time:%s): %s", s.SchemaPropertyName, s.Command, time.Since(start), stdout.String())
 	}
-	doneChan <- nil
+	parser, err := s.CommandOutput.getOutputParser(output)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the command output for schema property '%s': %s", s.SchemaPropertyName, err)
+	}
+	return parser.getValue()
+}
+
+func (c ServiceSchemaPropertyCommandOutputV1) getOutputParser(output string) (schemaFileParser, error) {
+	switch c.ContentType {
+	case "raw":
+		if c.KeyName != "" {
+			log.Printf("[WARN] command output of type 'raw' configured with key value '%s'", c.KeyName)
+		}
+		// commands usually print a trailing new line that should not be part of the value
+		return parserRaw{content: strings.TrimRight(output, "\r\n")}, nil
+	case "json":
+		return parserJSON{jsonContent: output, keyName: c.KeyName}, nil
+	default:
+		return nil, fmt.Errorf("'%s' content type not supported", c.ContentType)
+	}
 }
 
 func (c ServiceSchemaPropertyExternalConfigurationV1) getFileParser() (schemaFileParser, error) {
@@ -136,6 +221,12 @@ func (c ServiceSchemaPropertyExternalConfigurationV1) getFileParser() (schemaFil
 		return parserRaw{content: schemaFileContent}, nil
 	case "json":
 		return parserJSON{jsonContent: schemaFileContent, keyName: c.KeyName}, nil
+	case "yaml":
+		return parserYAML{yamlContent: schemaFileContent, keyName: c.KeyName}, nil
+	case "dotenv":
+		return parserDotEnv{content: schemaFileContent, keyName: c.KeyName}, nil
+	case "netrc":
+		return parserNetrc{content: schemaFileContent, keyName: c.KeyName}, nil
 	default:
 		return nil, fmt.Errorf("'%s' content type not supported", c.ContentType)
 	}
@@ -160,10 +251,16 @@ type parserJSON struct {
 
 func (p parserJSON) getValue() (string, error) {
 	var jsonData interface{}
-	json.Unmarshal([]byte(p.jsonContent), &jsonData)
+	if err := json.Unmarshal([]byte(p.jsonContent), &jsonData); err != nil {
+		return "", fmt.Errorf("content is not valid json: %s", err)
+	}
 	res, err := jsonpath.JsonPathLookup(jsonData, p.keyName)
 	if err != nil {
 		return "", err
 	}
-	return res.(string), nil
+	value, ok := res.(string)
+	if !ok {
+		return "", fmt.Errorf("value of key '%s' is not a string", p.keyName)
+	}
+	return value, nil
 }
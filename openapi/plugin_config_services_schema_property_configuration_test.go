@@ -266,6 +266,140 @@ func TestServiceSchemaConfigurationV1GetDefaultValue(t *testing.T) {
 	})
 }
 
+func TestServiceSchemaConfigurationV1GetDefaultValueFromCommandOutput(t *testing.T) {
+	_, cleanUp := setCommandOutputCacheDir(t)
+	defer cleanUp()
+
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a command whose 'raw' output is used as the value", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "schemaPropertyName",
+			DefaultValue:       "defaultValue",
+			Command:            []string{"echo", "some token"},
+			CommandOutput:      &ServiceSchemaPropertyCommandOutputV1{ContentType: "raw"},
+		}
+		Convey("When ExecuteCommand method is called", func() {
+			err := serviceSchemaConfigurationV1.ExecuteCommand()
+			Convey("Then the err returned should be nil as the command is executed when getting the default value", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When GetDefaultValue method is called", func() {
+			value, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the value returned should be the command output without the trailing new line", func() {
+				So(value, ShouldEqual, "some token")
+			})
+		})
+	})
+
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a command whose 'json' output is used as the value", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "schemaPropertyName",
+			Command:            []string{"echo", `{"access_token":"superSecret","expires_in":3600}`},
+			CommandOutput:      &ServiceSchemaPropertyCommandOutputV1{ContentType: "json", KeyName: "$.access_token"},
+		}
+		Convey("When GetDefaultValue method is called", func() {
+			value, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the value returned should be the one defined in the key name", func() {
+				So(value, ShouldEqual, "superSecret")
+			})
+		})
+		Convey("When GetDefaultValue method is called and the key name points to a non string value", func() {
+			serviceSchemaConfigurationV1.CommandOutput.KeyName = "$.expires_in"
+			_, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "value of key '$.expires_in' is not a string")
+			})
+		})
+	})
+
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a command whose output is cached", t, func() {
+		counterFile, err := ioutil.TempFile("", "counter")
+		So(err, ShouldBeNil)
+		defer os.Remove(counterFile.Name())
+		// the command appends a line to the counter file every time it is executed and prints the number of executions
+		command := []string{"sh", "-c", fmt.Sprintf("echo x >> %s && wc -l < %s | tr -d ' '", counterFile.Name(), counterFile.Name())}
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "cachedSchemaPropertyName",
+			Command:            command,
+			CommandOutput:      &ServiceSchemaPropertyCommandOutputV1{ContentType: "raw", CacheTTL: "1h"},
+		}
+		Convey("When GetDefaultValue method is called twice", func() {
+			firstValue, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			So(err, ShouldBeNil)
+			secondValue, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			So(err, ShouldBeNil)
+			Convey("Then the command should only be executed once", func() {
+				So(firstValue, ShouldEqual, "1")
+				So(secondValue, ShouldEqual, "1")
+			})
+		})
+		Convey("When GetDefaultValue method is called twice without cache ttl", func() {
+			serviceSchemaConfigurationV1.CommandOutput.CacheTTL = ""
+			serviceSchemaConfigurationV1.SchemaPropertyName = "nonCachedSchemaPropertyName"
+			firstValue, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			So(err, ShouldBeNil)
+			secondValue, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			So(err, ShouldBeNil)
+			Convey("Then the command should be executed every time", func() {
+				So(firstValue, ShouldEqual, "1")
+				So(secondValue, ShouldEqual, "2")
+			})
+		})
+	})
+
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a command output configured with an invalid cache ttl", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "schemaPropertyName",
+			Command:            []string{"echo", "some token"},
+			CommandOutput:      &ServiceSchemaPropertyCommandOutputV1{ContentType: "raw", CacheTTL: "forever"},
+		}
+		Convey("When GetDefaultValue method is called", func() {
+			_, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			Convey("Then the err message should be", func() {
+				So(err.Error(), ShouldEqual, "schema property 'schemaPropertyName' cmd_output cache_ttl 'forever' is not a valid duration")
+			})
+		})
+	})
+
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a command output configured with a non supported content type", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "schemaPropertyName",
+			Command:            []string{"echo", "some token"},
+			CommandOutput:      &ServiceSchemaPropertyCommandOutputV1{ContentType: "nonSupported"},
+		}
+		Convey("When GetDefaultValue method is called", func() {
+			_, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			Convey("Then the err message should be", func() {
+				So(err.Error(), ShouldEqual, "failed to parse the command output for schema property 'schemaPropertyName': 'nonSupported' content type not supported")
+			})
+		})
+	})
+
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a command output configured and a command that fails", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "schemaPropertyName",
+			Command:            []string{"cat", "nonexistingfile"},
+			CommandOutput:      &ServiceSchemaPropertyCommandOutputV1{ContentType: "raw", CacheTTL: "1h"},
+		}
+		Convey("When GetDefaultValue method is called", func() {
+			_, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			Convey("Then the err message should be", func() {
+				So(err.Error(), ShouldEqual, "provider schema property 'schemaPropertyName' command failed: command '[cat nonexistingfile]' failed: cat: nonexistingfile: No such file or directory\n(exit status 1)")
+			})
+			Convey("And the failure should not be cached", func() {
+				_, cached := newCommandOutputCache("schemaPropertyName", []string{"cat", "nonexistingfile"}).get()
+				So(cached, ShouldBeFalse)
+			})
+		})
+	})
+}
+
 func TestServiceExternalConfigurationV1GetFileParser(t *testing.T) {
 	Convey("Given a ServiceSchemaPropertyExternalConfigurationV1 configured with 'raw' content", t, func() {
 		expectedValue := "some content"
@@ -320,6 +454,27 @@ func TestServiceExternalConfigurationV1GetFileParser(t *testing.T) {
 		})
 	})
 
+	Convey("Given a ServiceSchemaPropertyExternalConfigurationV1 configured with 'yaml', 'dotenv' and 'netrc' content", t, func() {
+		tmpFile, err := ioutil.TempFile("", "")
+		defer os.Remove(tmpFile.Name())
+		So(err, ShouldBeNil)
+		Convey("When getFileParser method is called", func() {
+			yamlParser, yamlErr := ServiceSchemaPropertyExternalConfigurationV1{ContentType: "yaml", File: tmpFile.Name(), KeyName: "$.token"}.getFileParser()
+			dotEnvParser, dotEnvErr := ServiceSchemaPropertyExternalConfigurationV1{ContentType: "dotenv", File: tmpFile.Name(), KeyName: "TOKEN"}.getFileParser()
+			netrcParser, netrcErr := ServiceSchemaPropertyExternalConfigurationV1{ContentType: "netrc", File: tmpFile.Name(), KeyName: "api.example.com"}.getFileParser()
+			Convey("Then the errors returned should be nil", func() {
+				So(yamlErr, ShouldBeNil)
+				So(dotEnvErr, ShouldBeNil)
+				So(netrcErr, ShouldBeNil)
+			})
+			Convey("And the parsers returned should be of the content type", func() {
+				So(yamlParser, ShouldHaveSameTypeAs, parserYAML{})
+				So(dotEnvParser, ShouldHaveSameTypeAs, parserDotEnv{})
+				So(netrcParser, ShouldHaveSameTypeAs, parserNetrc{})
+			})
+		})
+	})
+
 	Convey("Given a ServiceSchemaPropertyExternalConfigurationV1 configured with a non supported content", t, func() {
 		expectedValue := "some content"
 		tmpFile, err := ioutil.TempFile("", "")
@@ -429,3 +584,30 @@ func TestSchemaFileParserJsonGetValue(t *testing.T) {
 		})
 	})
 }
+
+func TestSchemaFileParserJsonGetValueErrors(t *testing.T) {
+	Convey("Given a content that is not valid json", t, func() {
+		parserJSON := parserJSON{
+			jsonContent: "not json",
+			keyName:     "$.firstName",
+		}
+		Convey("When getValue method is called", func() {
+			_, err := parserJSON.getValue()
+			Convey("Then the error returned should be", func() {
+				So(err.Error(), ShouldEqual, "content is not valid json: invalid character 'o' in literal null (expecting 'u')")
+			})
+		})
+	})
+	Convey("Given a jsonContent and a key name pointing to a non string value", t, func() {
+		parserJSON := parserJSON{
+			jsonContent: `{"age":30}`,
+			keyName:     "$.age",
+		}
+		Convey("When getValue method is called", func() {
+			_, err := parserJSON.getValue()
+			Convey("Then the error returned should be", func() {
+				So(err.Error(), ShouldEqual, "value of key '$.age' is not a string")
+			})
+		})
+	})
+}
@@ -0,0 +1,217 @@
+package openapi
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oliveagle/jsonpath"
+	"gopkg.in/yaml.v2"
+)
+
+// netrcDefaultMachine is the name used in the key name to refer to the netrc 'default' entry
+const netrcDefaultMachine = "default"
+
+// parserYAML looks up the value of the key name (using the JsonPath syntax, eg: $.credentials.token) in YAML content
+type parserYAML struct {
+	yamlContent string
+	keyName     string
+}
+
+func (p parserYAML) getValue() (string, error) {
+	if p.keyName == "" {
+		return "", fmt.Errorf("key_name is required for 'yaml' content type")
+	}
+	var yamlData interface{}
+	if err := yaml.Unmarshal([]byte(p.yamlContent), &yamlData); err != nil {
+		return "", fmt.Errorf("content is not valid yaml: %s", err)
+	}
+	res, err := jsonpath.JsonPathLookup(toJSONPathCompatible(yamlData), p.keyName)
+	if err != nil {
+		return "", err
+	}
+	switch value := res.(type) {
+	case string:
+		return value, nil
+	// YAML scalars are typed implicitly (eg: an unquoted numeric password), so they are converted back into strings
+	case int, int64, uint64, float64, bool:
+		return fmt.Sprint(value), nil
+	default:
+		return "", fmt.Errorf("value of key '%s' is not a scalar", p.keyName)
+	}
+}
+
+// toJSONPathCompatible converts the maps unmarshalled by yaml (map[interface{}]interface{}) into map[string]interface{}
+// so the JsonPath lookups can be performed
+func toJSONPathCompatible(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, v := range value {
+			m[fmt.Sprint(k)] = toJSONPathCompatible(v)
+		}
+		return m
+	case []interface{}:
+		for i, v := range value {
+			value[i] = toJSONPathCompatible(v)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// parserDotEnv looks up the value of the variable with the key name in dotenv content. The following syntax is supported:
+// - KEY=value lines, optionally prefixed with 'export '
+// - empty lines and lines starting with '#' are ignored, as well as inline comments (' #') after unquoted values
+// - single quoted values are used literally; double quoted values support the \n, \t, \" and \\ escape sequences
+type parserDotEnv struct {
+	content string
+	keyName string
+}
+
+func (p parserDotEnv) getValue() (string, error) {
+	if p.keyName == "" {
+		return "", fmt.Errorf("key_name is required for 'dotenv' content type")
+	}
+	scanner := bufio.NewScanner(strings.NewReader(p.content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		separator := strings.Index(line, "=")
+		if separator < 0 {
+			return "", fmt.Errorf("dotenv line %d is not valid, expected KEY=value format", lineNumber)
+		}
+		if strings.TrimSpace(line[:separator]) != p.keyName {
+			continue
+		}
+		value, err := parseDotEnvValue(strings.TrimSpace(line[separator+1:]))
+		if err != nil {
+			return "", fmt.Errorf("dotenv line %d is not valid: %s", lineNumber, err)
+		}
+		return value, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("key '%s' not found in dotenv content", p.keyName)
+}
+
+func parseDotEnvValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("missing closing single quote")
+		}
+		return value[1 : end+1], nil
+	case strings.HasPrefix(value, `"`):
+		for end := 1; end < len(value); end++ {
+			if value[end] == '\\' {
+				end++
+				continue
+			}
+			if value[end] == '"' {
+				unquoted, err := strconv.Unquote(value[:end+1])
+				if err != nil {
+					return "", fmt.Errorf("invalid double quoted value: %s", err)
+				}
+				return unquoted, nil
+			}
+		}
+		return "", fmt.Errorf("missing closing double quote")
+	default:
+		if comment := strings.Index(value, " #"); comment >= 0 {
+			value = value[:comment]
+		}
+		return strings.TrimSpace(value), nil
+	}
+}
+
+// parserNetrc looks up a field of a machine entry in netrc content. The key name has the format '<machine>/<field>' where
+// field is one of login, password or account (eg: api.example.com/password). If the field is not specified the password is
+// returned. The 'default' machine name refers to the netrc default entry, which is also used when the machine is not found
+type parserNetrc struct {
+	content string
+	keyName string
+}
+
+func (p parserNetrc) getValue() (string, error) {
+	if p.keyName == "" {
+		return "", fmt.Errorf("key_name is required for 'netrc' content type")
+	}
+	machine, field := p.keyName, "password"
+	if separator := strings.LastIndex(p.keyName, "/"); separator >= 0 {
+		machine, field = p.keyName[:separator], p.keyName[separator+1:]
+	}
+	switch field {
+	case "login", "password", "account":
+	default:
+		return "", fmt.Errorf("netrc field '%s' not supported, supported fields are: login, password, account", field)
+	}
+
+	entries := parseNetrc(p.content)
+	entry, ok := entries[machine]
+	if !ok {
+		entry, ok = entries[netrcDefaultMachine]
+		if !ok {
+			return "", fmt.Errorf("machine '%s' not found in netrc content", machine)
+		}
+	}
+	value, ok := entry[field]
+	if !ok {
+		return "", fmt.Errorf("machine '%s' does not have the '%s' field in netrc content", machine, field)
+	}
+	return value, nil
+}
+
+// parseNetrc returns the netrc entries indexed by machine name, being 'default' the name of the default entry. Macro
+// definitions (macdef) are skipped
+func parseNetrc(content string) map[string]map[string]string {
+	entries := map[string]map[string]string{}
+	var entry map[string]string
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		fields := strings.Fields(lines[i])
+		for j := 0; j < len(fields); j++ {
+			token := fields[j]
+			if strings.HasPrefix(token, "#") {
+				break
+			}
+			switch token {
+			case "default":
+				entry = map[string]string{}
+				if _, exists := entries[netrcDefaultMachine]; !exists {
+					entries[netrcDefaultMachine] = entry
+				}
+			case "machine":
+				if j+1 < len(fields) {
+					j++
+					entry = map[string]string{}
+					// the first entry for a machine takes preference, as with other netrc implementations
+					if _, exists := entries[fields[j]]; !exists {
+						entries[fields[j]] = entry
+					}
+				}
+			case "login", "password", "account":
+				if j+1 < len(fields) && entry != nil {
+					j++
+					entry[token] = fields[j]
+				}
+			case "macdef":
+				// the macro definition runs until the next empty line
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+					i++
+				}
+				j = len(fields)
+			}
+		}
+	}
+	return entries
+}
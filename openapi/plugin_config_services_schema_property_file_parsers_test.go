@@ -0,0 +1,126 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserYAMLGetValue(t *testing.T) {
+	content := `credentials:
+  token: superSecret
+  pin: 1234
+  users:
+    - name: admin
+      password: "adminSecret"
+`
+	testCases := []struct {
+		name          string
+		keyName       string
+		content       string
+		expectedValue string
+		expectedError string
+	}{
+		{name: "string value", keyName: "$.credentials.token", content: content, expectedValue: "superSecret"},
+		{name: "numeric value", keyName: "$.credentials.pin", content: content, expectedValue: "1234"},
+		{name: "value within a list", keyName: "$.credentials.users[0].password", content: content, expectedValue: "adminSecret"},
+		{name: "non scalar value", keyName: "$.credentials.users", content: content, expectedError: "value of key '$.credentials.users' is not a scalar"},
+		{name: "missing key", keyName: "$.credentials.missing", content: content, expectedError: "key error: missing not found in object"},
+		{name: "missing key name", keyName: "", content: content, expectedError: "key_name is required for 'yaml' content type"},
+		{name: "invalid yaml", keyName: "$.token", content: "token: [", expectedError: "content is not valid yaml: yaml: line 1: did not find expected node content"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := parserYAML{yamlContent: tc.content, keyName: tc.keyName}.getValue()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
+
+func TestParserDotEnvGetValue(t *testing.T) {
+	content := `# API credentials
+API_URL=https://api.example.com
+export API_TOKEN=superSecret
+SPACED = spaced value # inline comment
+SINGLE_QUOTED='literal # \n value'
+DOUBLE_QUOTED="multi\nline \"value\"" # inline comment
+EMPTY=
+`
+	testCases := []struct {
+		name          string
+		keyName       string
+		content       string
+		expectedValue string
+		expectedError string
+	}{
+		{name: "simple value", keyName: "API_URL", content: content, expectedValue: "https://api.example.com"},
+		{name: "exported value", keyName: "API_TOKEN", content: content, expectedValue: "superSecret"},
+		{name: "value with spaces and inline comment", keyName: "SPACED", content: content, expectedValue: "spaced value"},
+		{name: "single quoted value", keyName: "SINGLE_QUOTED", content: content, expectedValue: `literal # \n value`},
+		{name: "double quoted value", keyName: "DOUBLE_QUOTED", content: content, expectedValue: "multi\nline \"value\""},
+		{name: "empty value", keyName: "EMPTY", content: content, expectedValue: ""},
+		{name: "missing key", keyName: "MISSING", content: content, expectedError: "key 'MISSING' not found in dotenv content"},
+		{name: "missing key name", keyName: "", content: content, expectedError: "key_name is required for 'dotenv' content type"},
+		{name: "invalid line", keyName: "API_TOKEN", content: "API_URL\nAPI_TOKEN=secret", expectedError: "dotenv line 1 is not valid, expected KEY=value format"},
+		{name: "unterminated quote", keyName: "API_TOKEN", content: "API_TOKEN=\"secret", expectedError: "dotenv line 1 is not valid: missing closing double quote"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := parserDotEnv{content: tc.content, keyName: tc.keyName}.getValue()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
+
+func TestParserNetrcGetValue(t *testing.T) {
+	content := `# netrc credentials
+machine api.example.com
+  login admin
+  password superSecret
+
+machine other.example.com login other password otherSecret account team
+
+macdef init
+machine ignored.example.com password ignored
+
+machine api.example.com login duplicated password duplicated
+`
+	testCases := []struct {
+		name          string
+		keyName       string
+		content       string
+		expectedValue string
+		expectedError string
+	}{
+		{name: "password by default", keyName: "api.example.com", content: content, expectedValue: "superSecret"},
+		{name: "login", keyName: "api.example.com/login", content: content, expectedValue: "admin"},
+		{name: "password in single line entry", keyName: "other.example.com/password", content: content, expectedValue: "otherSecret"},
+		{name: "account", keyName: "other.example.com/account", content: content, expectedValue: "team"},
+		{name: "machines in macro definitions are ignored", keyName: "ignored.example.com", content: content, expectedError: "machine 'ignored.example.com' not found in netrc content"},
+		{name: "missing field", keyName: "api.example.com/account", content: content, expectedError: "machine 'api.example.com' does not have the 'account' field in netrc content"},
+		{name: "unsupported field", keyName: "api.example.com/token", content: content, expectedError: "netrc field 'token' not supported, supported fields are: login, password, account"},
+		{name: "missing key name", keyName: "", content: content, expectedError: "key_name is required for 'netrc' content type"},
+		{name: "default entry", keyName: "missing.example.com/login", content: content + "default login anonymous password guest\n", expectedValue: "anonymous"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := parserNetrc{content: tc.content, keyName: tc.keyName}.getValue()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
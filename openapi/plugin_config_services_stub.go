@@ -5,9 +5,16 @@ package openapi
 // with the URL where the openapi doc is hosted.
 type ServiceConfigStub struct {
 	SwaggerURL          string
+	SwaggerSpecs        []SwaggerSpecConfigV1
+	SwaggerOverlays     []string
 	PluginVersion       string
 	InsecureSkipVerify  bool
 	Telemetry           TelemetryProvider
+	TLS                 *TLSConfig
+	Retry               *RetryConfig
+	RateLimit           *RateLimitConfig
+	Polling             *PollingConfig
+	Tracing             *TracingConfig
 	SchemaConfiguration []*ServiceSchemaPropertyConfigurationStub
 	Err                 error
 }
@@ -27,6 +34,16 @@ func (s *ServiceConfigStub) GetSwaggerURL() string {
 	return s.SwaggerURL
 }
 
+// GetSwaggerSpecs returns the swagger docs configured in the ServiceConfigStub.SwaggerSpecs field
+func (s *ServiceConfigStub) GetSwaggerSpecs() []SwaggerSpecConfigV1 {
+	return s.SwaggerSpecs
+}
+
+// GetSwaggerOverlays returns the overlays configured in the ServiceConfigStub.SwaggerOverlays field
+func (s *ServiceConfigStub) GetSwaggerOverlays() []string {
+	return s.SwaggerOverlays
+}
+
 // GetPluginVersion returns the plugin version value configured in the ServiceConfigStub.PluginVersion field
 func (s *ServiceConfigStub) GetPluginVersion() string {
 	return s.PluginVersion
@@ -57,6 +74,31 @@ func (s ServiceConfigStub) GetTelemetryConfiguration() TelemetryProvider {
 	return s.Telemetry
 }
 
+// GetTLSConfiguration returns the TLSConfig configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetTLSConfiguration() *TLSConfig {
+	return s.TLS
+}
+
+// GetRetryConfiguration returns the RetryConfig configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetRetryConfiguration() *RetryConfig {
+	return s.Retry
+}
+
+// GetRateLimitConfiguration returns the RateLimitConfig configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetRateLimitConfiguration() *RateLimitConfig {
+	return s.RateLimit
+}
+
+// GetPollingConfiguration returns the PollingConfig configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetPollingConfiguration() *PollingConfig {
+	return s.Polling
+}
+
+// GetTracingConfiguration returns the TracingConfig configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetTracingConfiguration() *TracingConfig {
+	return s.Tracing
+}
+
 // GetDefaultValue returns the default value configured in the ServiceSchemaPropertyConfigurationStub.defaultValue field
 func (s *ServiceSchemaPropertyConfigurationStub) GetDefaultValue() (string, error) {
 	if s.GetDefaultValueFunc != nil {
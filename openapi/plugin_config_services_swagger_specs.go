@@ -0,0 +1,70 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// swaggerSpecNamePrefixRegex defines the format of the name prefixes so the resulting resource names are terraform compliant
+var swaggerSpecNamePrefixRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// SwaggerSpecConfigV1 defines one of the swagger docs a service provider is composed of
+type SwaggerSpecConfigV1 struct {
+	// URL defines where the swagger is located
+	URL string `yaml:"url"`
+	// NamePrefix is prepended (joined with '_') to the names of the resources and data sources exposed by the swagger,
+	// useful to avoid name collisions between swagger docs
+	NamePrefix string `yaml:"name_prefix,omitempty"`
+	// Host overrides the host the API requests of the resources and data sources exposed by the swagger are sent to
+	Host string `yaml:"host,omitempty"`
+	// Overlays defines the list of JSON Patch or OpenAPI Overlay documents applied in order to the swagger doc
+	Overlays []string `yaml:"overlays,omitempty"`
+}
+
+// validateSwaggerURL makes sure the URL is either a valid formed URL or a path to an existing file stored in the disk
+func validateSwaggerURL(swaggerURL string) error {
+	if !govalidator.IsURL(swaggerURL) {
+		// fall back to try to load the swagger file from disk in case the path provided is a path to a file on disk
+		if _, err := os.Stat(swaggerURL); os.IsNotExist(err) {
+			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk", swaggerURL)
+		}
+	}
+	return nil
+}
+
+// validateOverlays makes sure the overlays are either valid formed URLs or paths to existing files stored in the disk
+func validateOverlays(overlays []string) error {
+	for _, overlay := range overlays {
+		if !govalidator.IsURL(overlay) {
+			if _, err := os.Stat(overlay); os.IsNotExist(err) {
+				return fmt.Errorf("overlay configuration not valid ('%s'). Overlay must be either a valid formed URL or a path to an existing file stored in the disk", overlay)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSwaggerSpecs makes sure the swagger URLs and overlays are valid, the name prefixes are well formed and the
+// swagger docs are not configured more than once
+func validateSwaggerSpecs(swaggerSpecs []SwaggerSpecConfigV1) error {
+	urls := map[string]bool{}
+	for _, swaggerSpec := range swaggerSpecs {
+		if err := validateSwaggerURL(swaggerSpec.URL); err != nil {
+			return err
+		}
+		if urls[swaggerSpec.URL] {
+			return fmt.Errorf("swagger URL '%s' is configured more than once", swaggerSpec.URL)
+		}
+		urls[swaggerSpec.URL] = true
+		if err := validateOverlays(swaggerSpec.Overlays); err != nil {
+			return err
+		}
+		if swaggerSpec.NamePrefix != "" && !swaggerSpecNamePrefixRegex.MatchString(swaggerSpec.NamePrefix) {
+			return fmt.Errorf("swagger URL '%s' name_prefix '%s' not valid, it must start with a lowercase letter and contain only lowercase letters, numbers and underscores", swaggerSpec.URL, swaggerSpec.NamePrefix)
+		}
+	}
+	return nil
+}
@@ -164,6 +164,88 @@ func TestServiceConfigV1Validate(t *testing.T) {
 		})
 	})
 
+	Convey("Given a ServiceConfigV1 containing a swagger URL and overlays", t, func() {
+		overlayFile, _ := ioutil.TempFile("", "overlay")
+		defer os.Remove(overlayFile.Name())
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			Overlays:   []string{overlayFile.Name(), "http://sevice-api.com/overlay.yaml"},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the overlays returned should be the configured ones", func() {
+				So(serviceConfiguration.GetSwaggerOverlays(), ShouldResemble, []string{overlayFile.Name(), "http://sevice-api.com/overlay.yaml"})
+			})
+		})
+		Convey("When Validate method is called and one of the overlays does not exist", func() {
+			serviceConfiguration.Overlays = []string{"htpt:/non-valid-overlay"}
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "overlay configuration not valid ('htpt:/non-valid-overlay'). Overlay must be either a valid formed URL or a path to an existing file stored in the disk")
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing a list of swagger URLs", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerSpecs: []SwaggerSpecConfigV1{
+				{URL: "http://users-api.com/swagger.yaml", NamePrefix: "users"},
+				{URL: "http://billing-api.com/swagger.yaml", NamePrefix: "billing_v2", Host: "billing.internal"},
+			},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When Validate method is called and the swagger URL is also configured", func() {
+			serviceConfiguration.SwaggerURL = "http://sevice-api.com/swagger.yaml"
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service configuration must contain either a single swagger URL or a list of swagger URLs, not both")
+			})
+		})
+		Convey("When Validate method is called and one of the swagger URLs is not valid", func() {
+			serviceConfiguration.SwaggerSpecs[1].URL = "htpt:/non-valid-url"
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service swagger URL configuration not valid ('htpt:/non-valid-url'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk")
+			})
+		})
+		Convey("When Validate method is called and the same swagger URL is configured twice", func() {
+			serviceConfiguration.SwaggerSpecs[1].URL = "http://users-api.com/swagger.yaml"
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "swagger URL 'http://users-api.com/swagger.yaml' is configured more than once")
+			})
+		})
+		Convey("When Validate method is called and one of the overlays does not exist", func() {
+			serviceConfiguration.SwaggerSpecs[1].Overlays = []string{"htpt:/non-valid-overlay"}
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "overlay configuration not valid ('htpt:/non-valid-overlay'). Overlay must be either a valid formed URL or a path to an existing file stored in the disk")
+			})
+		})
+		Convey("When Validate method is called and the service overlays are also configured", func() {
+			serviceConfiguration.Overlays = []string{"http://sevice-api.com/overlay.yaml"}
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service configuration overlays can only be used along with a single swagger URL, the overlays of each of the swagger URLs must be configured in the list of swagger URLs")
+			})
+		})
+		Convey("When Validate method is called and one of the name prefixes is not valid", func() {
+			serviceConfiguration.SwaggerSpecs[1].NamePrefix = "Billing-"
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "swagger URL 'http://billing-api.com/swagger.yaml' name_prefix 'Billing-' not valid, it must start with a lowercase letter and contain only lowercase letters, numbers and underscores")
+			})
+		})
+	})
+
 	Convey("Given a ServiceConfigV1 containing a valid swagger file and a specific plugin version", t, func() {
 		var serviceConfiguration ServiceConfiguration
 		expectedPluginVersion := "0.14.0"
@@ -235,6 +317,72 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 			expectedType:    nil,
 			expectedLogging: []string{"[WARN] ignoring telemetry due multiple telemetry providers configured (graphite and http_endpoint): select only one"},
 		},
+		{
+			name: "service is configured correctly with an otlp provider",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					OTLP: &TelemetryProviderOTLP{
+						Endpoint: "http://localhost:4318",
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    &TelemetryProviderOTLP{},
+			expectedLogging: []string{"[DEBUG] otlp telemetry provider enabled"},
+		},
+		{
+			name: "service is configured with httpendpoint and otlp providers",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+						URL: "http://telemetry.myhost.com/v1/metrics",
+					},
+					OTLP: &TelemetryProviderOTLP{
+						Endpoint: "http://localhost:4318",
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    nil,
+			expectedLogging: []string{"[WARN] ignoring telemetry due multiple telemetry providers configured (http_endpoint and otlp): select only one"},
+		},
+		{
+			name: "service is configured correctly with a prometheus provider",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					Prometheus: &TelemetryProviderPrometheus{
+						TextfilePath: "/var/lib/node_exporter/textfile/openapi.prom",
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    &TelemetryProviderPrometheus{},
+			expectedLogging: []string{"[DEBUG] prometheus telemetry provider enabled"},
+		},
+		{
+			name: "service skips prometheus telemetry due to the validation not passing",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					Prometheus: &TelemetryProviderPrometheus{},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    nil,
+			expectedLogging: []string{"[WARN] ignoring prometheus telemetry due to the following validation error: prometheus telemetry configuration is missing a value for the 'textfile_path' or 'pushgateway_url' properties"},
+		},
+		{
+			name: "service skips otlp telemetry due to the validation not passing",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					OTLP: &TelemetryProviderOTLP{
+						Endpoint: "", // Configuration is missing the required endpoint
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    nil,
+			expectedLogging: []string{"[WARN] ignoring otlp telemetry due to the following validation error: otlp telemetry configuration is missing a value for the 'endpoint property'"},
+		},
 		{
 			name: "service skips graphite telemetry due to the validation not passing",
 			serviceConfigV1: &ServiceConfigV1{
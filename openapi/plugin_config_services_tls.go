@@ -0,0 +1,159 @@
+package openapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const pemBlockPrefix = "-----BEGIN"
+
+// tlsVersions contains the TLS versions supported in the min_version configuration
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSConfig contains the TLS configuration used by the OpenAPI provider when connecting to the API as well as when
+// retrieving the swagger file. Values for CABundle, ClientCert and ClientKey can be either a path to a file (paths starting
+// with ~ will be expanded to the user's home directory) or the PEM encoded content itself
+type TLSConfig struct {
+	// CABundle defines the CA certificates (PEM) that will be trusted in addition to the system CAs when verifying the server cert
+	CABundle string `yaml:"ca_bundle,omitempty"`
+	// ClientCert defines the client certificate (PEM) presented to servers that require mutual TLS
+	ClientCert string `yaml:"client_cert,omitempty"`
+	// ClientKey defines the private key (PEM) matching the ClientCert
+	ClientKey string `yaml:"client_key,omitempty"`
+	// MinVersion defines the minimum TLS version accepted. Supported values: 1.0, 1.1, 1.2, 1.3
+	MinVersion string `yaml:"min_version,omitempty"`
+}
+
+// Validate checks whether the TLS configuration is well formed
+func (t *TLSConfig) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if (t.ClientCert == "") != (t.ClientKey == "") {
+		return fmt.Errorf("tls configuration must contain both 'client_cert' and 'client_key' when mutual TLS is configured")
+	}
+	if t.MinVersion != "" {
+		if _, exists := tlsVersions[t.MinVersion]; !exists {
+			return fmt.Errorf("tls configuration 'min_version' value '%s' not supported, supported values are: 1.0, 1.1, 1.2, 1.3", t.MinVersion)
+		}
+	}
+	return nil
+}
+
+// isEmpty returns true if none of the TLS settings are configured
+func (t *TLSConfig) isEmpty() bool {
+	return t == nil || (t.CABundle == "" && t.ClientCert == "" && t.ClientKey == "" && t.MinVersion == "")
+}
+
+// merge returns a new TLSConfig where the values of the override configuration (if populated) take preference over
+// the ones of t
+func (t *TLSConfig) merge(override *TLSConfig) *TLSConfig {
+	merged := &TLSConfig{}
+	if t != nil {
+		*merged = *t
+	}
+	if override == nil {
+		return merged
+	}
+	if override.CABundle != "" {
+		merged.CABundle = override.CABundle
+	}
+	if override.ClientCert != "" {
+		merged.ClientCert = override.ClientCert
+	}
+	if override.ClientKey != "" {
+		merged.ClientKey = override.ClientKey
+	}
+	if override.MinVersion != "" {
+		merged.MinVersion = override.MinVersion
+	}
+	return merged
+}
+
+// buildTLSConfig translates the TLSConfig into a tls.Config that can be used by http transports
+func (t *TLSConfig) buildTLSConfig(insecureSkipVerify bool) (*tls.Config, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+	if t == nil {
+		return tlsConfig, nil
+	}
+	if t.MinVersion != "" {
+		tlsConfig.MinVersion = tlsVersions[t.MinVersion]
+	}
+	if t.CABundle != "" {
+		caBundle, err := readPEM(t.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls 'ca_bundle': %s", err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			log.Printf("[WARN] could not load the system cert pool, only the CAs configured in the 'ca_bundle' will be trusted: %s", err)
+			rootCAs = x509.NewCertPool()
+		}
+		if ok := rootCAs.AppendCertsFromPEM(caBundle); !ok {
+			return nil, fmt.Errorf("tls 'ca_bundle' does not contain any valid PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if t.ClientCert != "" {
+		clientCert, err := readPEM(t.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls 'client_cert': %s", err)
+		}
+		clientKey, err := readPEM(t.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls 'client_key': %s", err)
+		}
+		certificate, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls client certificate key pair: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// newHTTPTransport returns an http.Transport configured with the given tls.Config. The rest of the transport settings
+// mirror the ones used by http.DefaultTransport
+func newHTTPTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+}
+
+// readPEM returns the PEM content of value. If the value already contains a PEM block it is returned as is; otherwise
+// the value is considered a path to a file containing the PEM content
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, pemBlockPrefix) {
+		return []byte(value), nil
+	}
+	content, err := getFileContent(value)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
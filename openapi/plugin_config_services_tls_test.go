@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTLSConfigValidate(t *testing.T) {
+	Convey("Given a nil TLSConfig", t, func() {
+		var tlsConfig *TLSConfig
+		Convey("When Validate is called", func() {
+			err := tlsConfig.Validate()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a TLSConfig with a client cert but missing the client key", t, func() {
+		tlsConfig := &TLSConfig{ClientCert: "/path/to/cert.pem"}
+		Convey("When Validate is called", func() {
+			err := tlsConfig.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "tls configuration must contain both 'client_cert' and 'client_key' when mutual TLS is configured")
+			})
+		})
+	})
+	Convey("Given a TLSConfig with a min version that is not supported", t, func() {
+		tlsConfig := &TLSConfig{MinVersion: "2.0"}
+		Convey("When Validate is called", func() {
+			err := tlsConfig.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "tls configuration 'min_version' value '2.0' not supported, supported values are: 1.0, 1.1, 1.2, 1.3")
+			})
+		})
+	})
+}
+
+func TestTLSConfigMerge(t *testing.T) {
+	Convey("Given a TLSConfig from the plugin configuration and an override from the provider configuration", t, func() {
+		pluginTLSConfig := &TLSConfig{CABundle: "plugin_ca.pem", MinVersion: "1.2"}
+		providerTLSConfig := &TLSConfig{MinVersion: "1.3", ClientCert: "cert.pem", ClientKey: "key.pem"}
+		Convey("When merge is called", func() {
+			merged := pluginTLSConfig.merge(providerTLSConfig)
+			Convey("Then the values from the override should take preference and the rest should be kept", func() {
+				So(merged, ShouldResemble, &TLSConfig{CABundle: "plugin_ca.pem", MinVersion: "1.3", ClientCert: "cert.pem", ClientKey: "key.pem"})
+			})
+			Convey("And the original config should not be mutated", func() {
+				So(pluginTLSConfig.MinVersion, ShouldEqual, "1.2")
+			})
+		})
+	})
+	Convey("Given a nil TLSConfig", t, func() {
+		var pluginTLSConfig *TLSConfig
+		Convey("When merge is called with a nil override", func() {
+			merged := pluginTLSConfig.merge(nil)
+			Convey("Then the merged config should be empty", func() {
+				So(merged.isEmpty(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestTLSConfigBuildTLSConfig(t *testing.T) {
+	Convey("Given a TLS server and a TLSConfig with the server cert as CA bundle (PEM content)", t, func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+		tlsConfig := &TLSConfig{CABundle: caBundle, MinVersion: "1.2"}
+		Convey("When buildTLSConfig is called", func() {
+			clientTLSConfig, err := tlsConfig.buildTLSConfig(false)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the min version should be the expected one", func() {
+				So(clientTLSConfig.MinVersion, ShouldEqual, uint16(tls.VersionTLS12))
+			})
+			Convey("And a client using the config should trust the server", func() {
+				c := &http.Client{Transport: newHTTPTransport(clientTLSConfig)}
+				resp, err := c.Get(server.URL)
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+	Convey("Given a TLS server requiring client certificates and a TLSConfig with client cert and key stored in files", t, func() {
+		clientCertPEM, clientKeyPEM, clientCert := generateTestCertificate(t)
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(clientCert)
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+		server.StartTLS()
+		defer server.Close()
+
+		certFile := createTempFile(t, clientCertPEM)
+		defer os.Remove(certFile)
+		keyFile := createTempFile(t, clientKeyPEM)
+		defer os.Remove(keyFile)
+
+		caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+		tlsConfig := &TLSConfig{CABundle: caBundle, ClientCert: certFile, ClientKey: keyFile}
+		Convey("When buildTLSConfig is called", func() {
+			clientTLSConfig, err := tlsConfig.buildTLSConfig(false)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And a client using the config should be able to perform a mutual TLS handshake", func() {
+				c := &http.Client{Transport: newHTTPTransport(clientTLSConfig)}
+				resp, err := c.Get(server.URL)
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+	Convey("Given a TLSConfig with a CA bundle that does not contain valid certificates", t, func() {
+		tlsConfig := &TLSConfig{CABundle: "-----BEGIN CERTIFICATE-----\nbogus\n-----END CERTIFICATE-----"}
+		Convey("When buildTLSConfig is called", func() {
+			_, err := tlsConfig.buildTLSConfig(false)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "tls 'ca_bundle' does not contain any valid PEM encoded certificate")
+			})
+		})
+	})
+	Convey("Given a TLSConfig with a CA bundle pointing at a file that does not exist", t, func() {
+		tlsConfig := &TLSConfig{CABundle: "/non/existing/ca.pem"}
+		Convey("When buildTLSConfig is called", func() {
+			_, err := tlsConfig.buildTLSConfig(false)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to read tls 'ca_bundle': open /non/existing/ca.pem: no such file or directory")
+			})
+		})
+	})
+	Convey("Given a nil TLSConfig", t, func() {
+		var tlsConfig *TLSConfig
+		Convey("When buildTLSConfig is called with insecure skip verify enabled", func() {
+			clientTLSConfig, err := tlsConfig.buildTLSConfig(true)
+			Convey("Then the error returned should be nil and the config should have insecure skip verify enabled", func() {
+				So(err, ShouldBeNil)
+				So(clientTLSConfig.InsecureSkipVerify, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func generateTestCertificate(t *testing.T) (certPEM, keyPEM string, cert *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-provider-openapi"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM, cert
+}
+
+func createTempFile(t *testing.T, content string) string {
+	file, err := ioutil.TempFile("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+	return file.Name()
+}
@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// TracingConfig contains the distributed tracing configuration. When configured, the API requests performed by the provider
+// (including the ones performed by the authenticators) propagate the W3C traceparent header and the spans are exported either
+// to an OpenTelemetry collector via OTLP (HTTP/protobuf) or to a local file
+type TracingConfig struct {
+	// OTLPEndpoint describes the OTLP HTTP endpoint of the collector (e,g: http://localhost:4318). The '/v1/traces' path is
+	// appended unless the endpoint already contains it
+	OTLPEndpoint string `yaml:"otlp_endpoint,omitempty"`
+	// Headers defines static headers to be sent along with the spans exported to the OTLP endpoint (e,g: collector
+	// authentication headers)
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// File defines the path of the file where the spans are appended to, one JSON object per line
+	File string `yaml:"file,omitempty"`
+}
+
+// Validate checks whether the tracing configuration is well formed
+func (t *TracingConfig) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if t.OTLPEndpoint == "" && t.File == "" {
+		return errors.New("tracing configuration is missing a value for either the 'otlp_endpoint' or 'file' property")
+	}
+	if t.OTLPEndpoint != "" && t.File != "" {
+		return errors.New("tracing configuration must contain only one of the 'otlp_endpoint' or 'file' properties")
+	}
+	if t.OTLPEndpoint != "" && !govalidator.IsURL(t.OTLPEndpoint) {
+		return fmt.Errorf("tracing configuration does not have a valid otlp_endpoint '%s'", t.OTLPEndpoint)
+	}
+	return nil
+}
+
+// newTracer returns a tracer exporting the spans to the configured destination; nil is returned if tracing is not configured
+func (t *TracingConfig) newTracer(providerName string) (*tracer, error) {
+	if t == nil {
+		return nil, nil
+	}
+	if t.OTLPEndpoint != "" {
+		return newTracer(providerName, traceExporterOTLP{endpoint: t.OTLPEndpoint, headers: t.Headers}), nil
+	}
+	file, err := expandPath(t.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand the tracing file '%s': %s", t.File, err)
+	}
+	return newTracer(providerName, &traceExporterFile{path: file}), nil
+}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTracingConfigValidate(t *testing.T) {
+	Convey("Given a nil TracingConfig", t, func() {
+		var tracing *TracingConfig
+		Convey("When Validate is called", func() {
+			err := tracing.Validate()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a TracingConfig with an OTLP endpoint", t, func() {
+		tracing := &TracingConfig{OTLPEndpoint: "http://localhost:4318"}
+		Convey("When Validate is called", func() {
+			err := tracing.Validate()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a TracingConfig with a file", t, func() {
+		tracing := &TracingConfig{File: "/tmp/traces.json"}
+		Convey("When Validate is called", func() {
+			err := tracing.Validate()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a TracingConfig without OTLP endpoint nor file", t, func() {
+		tracing := &TracingConfig{}
+		Convey("When Validate is called", func() {
+			err := tracing.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "tracing configuration is missing a value for either the 'otlp_endpoint' or 'file' property")
+			})
+		})
+	})
+	Convey("Given a TracingConfig with both an OTLP endpoint and a file", t, func() {
+		tracing := &TracingConfig{OTLPEndpoint: "http://localhost:4318", File: "/tmp/traces.json"}
+		Convey("When Validate is called", func() {
+			err := tracing.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "tracing configuration must contain only one of the 'otlp_endpoint' or 'file' properties")
+			})
+		})
+	})
+	Convey("Given a TracingConfig with an invalid OTLP endpoint", t, func() {
+		tracing := &TracingConfig{OTLPEndpoint: "not a url"}
+		Convey("When Validate is called", func() {
+			err := tracing.Validate()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "tracing configuration does not have a valid otlp_endpoint 'not a url'")
+			})
+		})
+	})
+}
+
+func TestTracingConfigNewTracer(t *testing.T) {
+	Convey("Given a nil TracingConfig", t, func() {
+		var tracing *TracingConfig
+		Convey("When newTracer is called", func() {
+			tracer, err := tracing.newTracer("cdn")
+			Convey("Then the tracer returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(tracer, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a TracingConfig with an OTLP endpoint", t, func() {
+		tracing := &TracingConfig{OTLPEndpoint: "http://localhost:4318", Headers: map[string]string{"Authorization": "Bearer token"}}
+		Convey("When newTracer is called", func() {
+			tracer, err := tracing.newTracer("cdn")
+			Convey("Then the tracer returned should export the spans to the OTLP endpoint", func() {
+				So(err, ShouldBeNil)
+				So(tracer.serviceName, ShouldEqual, "terraform-provider-cdn")
+				So(tracer.exporter, ShouldResemble, traceExporterOTLP{endpoint: "http://localhost:4318", headers: map[string]string{"Authorization": "Bearer token"}})
+			})
+		})
+	})
+	Convey("Given a TracingConfig with a file", t, func() {
+		tracing := &TracingConfig{File: "/tmp/traces.json"}
+		Convey("When newTracer is called", func() {
+			tracer, err := tracing.newTracer("cdn")
+			Convey("Then the tracer returned should export the spans to the file", func() {
+				So(err, ShouldBeNil)
+				So(tracer.exporter, ShouldResemble, &traceExporterFile{path: "/tmp/traces.json"})
+			})
+		})
+	})
+}
@@ -0,0 +1,112 @@
+package openapi
+
+// ServiceConfigV2 defines configuration for the service provider in the version 2 of the plugin configuration schema. The
+// settings related to the retrieval of the swagger document are grouped in the swagger section, whereas the rest of
+// sections apply to the provider as a whole
+type ServiceConfigV2 struct {
+	// Swagger defines where the service swagger document is located and how it is retrieved
+	Swagger SwaggerConfigV2 `yaml:"swagger"`
+	// PluginVersion defines the version of the OpenAPI Terraform plugin installed when generating the plugin configuration
+	PluginVersion string `yaml:"plugin_version,omitempty"`
+	// SchemaConfiguration represents the list of schema property configurations
+	SchemaConfiguration []ServiceSchemaPropertyConfigurationV1 `yaml:"schema_configuration,omitempty"`
+	// TelemetryConfig defines the telemetry provider the metrics are shipped to
+	TelemetryConfig *TelemetryConfig `yaml:"telemetry,omitempty"`
+	// TracingConfig defines where the spans of the provider operations and API requests are exported to
+	TracingConfig *TracingConfig `yaml:"tracing,omitempty"`
+	// TLSConfig defines the TLS settings (CA bundle, client certificate for mutual TLS and min TLS version) used when
+	// connecting to the API and retrieving the swagger file
+	TLSConfig *TLSConfig `yaml:"tls,omitempty"`
+	// RetryConfig defines how API requests that fail due to network errors or retryable status codes are retried
+	RetryConfig *RetryConfig `yaml:"retry,omitempty"`
+	// RateLimitConfig defines the client side rate limit applied to the API requests per host
+	RateLimitConfig *RateLimitConfig `yaml:"rate_limit,omitempty"`
+	// PollingConfig defines the polling cadence used when waiting for asynchronous resources
+	PollingConfig *PollingConfig `yaml:"polling,omitempty"`
+}
+
+// SwaggerConfigV2 defines where the service swagger document is located and how it is retrieved
+type SwaggerConfigV2 struct {
+	// URL defines where the swagger is located, either a URL or a path to a file stored in the disk
+	URL string `yaml:"url,omitempty"`
+	// URLs defines the list of swagger docs the provider is composed of, used instead of URL when the service API is
+	// split across multiple swagger docs (eg: one per microservice)
+	URLs []SwaggerSpecConfigV1 `yaml:"urls,omitempty"`
+	// Overlays defines the list of JSON Patch or OpenAPI Overlay documents applied in order to the swagger doc located at URL
+	Overlays []string `yaml:"overlays,omitempty"`
+	// InsecureSkipVerify defines whether the internal http client used to fetch the swagger file should verify the server cert
+	// or not. This should only be used purposefully if the server is using a self-signed cert and only if the server is trusted
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty"`
+}
+
+// GetSwaggerURL returns the URL where the service swagger doc is exposed
+func (s *ServiceConfigV2) GetSwaggerURL() string {
+	return s.Swagger.URL
+}
+
+// GetSwaggerSpecs returns the list of swagger docs the service provider is composed of
+func (s *ServiceConfigV2) GetSwaggerSpecs() []SwaggerSpecConfigV1 {
+	return s.Swagger.URLs
+}
+
+// GetSwaggerOverlays returns the overlays applied to the swagger doc
+func (s *ServiceConfigV2) GetSwaggerOverlays() []string {
+	return s.Swagger.Overlays
+}
+
+// GetPluginVersion returns the OpenAPI Plugin version
+func (s *ServiceConfigV2) GetPluginVersion() string {
+	return s.PluginVersion
+}
+
+// IsInsecureSkipVerifyEnabled returns true if the given provider's service configuration has InsecureSkipVerify enabled; false
+// otherwise
+func (s *ServiceConfigV2) IsInsecureSkipVerifyEnabled() bool {
+	return s.Swagger.InsecureSkipVerify
+}
+
+// GetTelemetryConfiguration returns a TelemetryProvider configured for Graphite, HTTPEndpoint, OTLP or Prometheus
+func (s *ServiceConfigV2) GetTelemetryConfiguration() TelemetryProvider {
+	return s.TelemetryConfig.getTelemetryProvider()
+}
+
+// GetTLSConfiguration returns the TLS configuration; nil if not configured
+func (s *ServiceConfigV2) GetTLSConfiguration() *TLSConfig {
+	return s.TLSConfig
+}
+
+// GetRetryConfiguration returns the retry configuration; nil if not configured
+func (s *ServiceConfigV2) GetRetryConfiguration() *RetryConfig {
+	return s.RetryConfig
+}
+
+// GetRateLimitConfiguration returns the rate limit configuration; nil if not configured
+func (s *ServiceConfigV2) GetRateLimitConfiguration() *RateLimitConfig {
+	return s.RateLimitConfig
+}
+
+// GetPollingConfiguration returns the polling configuration; nil if not configured
+func (s *ServiceConfigV2) GetPollingConfiguration() *PollingConfig {
+	return s.PollingConfig
+}
+
+// GetTracingConfiguration returns the tracing configuration; nil if not configured
+func (s *ServiceConfigV2) GetTracingConfiguration() *TracingConfig {
+	return s.TracingConfig
+}
+
+// GetSchemaPropertyConfiguration returns the external configuration for the given schema property name; nil is returned
+// if no such property exists
+func (s *ServiceConfigV2) GetSchemaPropertyConfiguration(schemaPropertyName string) ServiceSchemaPropertyConfiguration {
+	for _, schemaPropertyConfig := range s.SchemaConfiguration {
+		if schemaPropertyConfig.SchemaPropertyName == schemaPropertyName {
+			return schemaPropertyConfig
+		}
+	}
+	return nil
+}
+
+// Validate makes sure the configuration is valid. The same validations as in ServiceConfigV1 are performed
+func (s *ServiceConfigV2) Validate(runningPluginVersion string) error {
+	return validateServiceConfig(s.Swagger.URL, s.Swagger.URLs, s.Swagger.Overlays, s.PluginVersion, runningPluginVersion, s.TLSConfig, s.RetryConfig, s.RateLimitConfig, s.PollingConfig, s.TracingConfig)
+}
@@ -0,0 +1,111 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestServiceConfigV2(t *testing.T) {
+	Convey("Given a ServiceConfigV2 with all the sections configured", t, func() {
+		tls := &TLSConfig{MinVersion: "1.2"}
+		retry := &RetryConfig{MaxAttempts: 3}
+		rateLimit := &RateLimitConfig{RequestsPerSecond: 10}
+		polling := &PollingConfig{PollingSettings: PollingSettings{Interval: "5s"}}
+		tracing := &TracingConfig{File: "/tmp/traces.json"}
+		serviceConfig := &ServiceConfigV2{
+			Swagger:       SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml", Overlays: []string{"http://sevice-api.com/overlay.yaml"}, InsecureSkipVerify: true},
+			PluginVersion: "0.14.0",
+			SchemaConfiguration: []ServiceSchemaPropertyConfigurationV1{
+				{SchemaPropertyName: "apikey_auth", DefaultValue: "apiKeyValue"},
+			},
+			TelemetryConfig: &TelemetryConfig{HTTPEndpoint: &TelemetryProviderHTTPEndpoint{URL: "http://my-api.com/v1/metrics"}},
+			TLSConfig:       tls,
+			RetryConfig:     retry,
+			RateLimitConfig: rateLimit,
+			PollingConfig:   polling,
+			TracingConfig:   tracing,
+		}
+		Convey("Then the ServiceConfigV2 should comply with ServiceConfiguration interface", func() {
+			var _ ServiceConfiguration = serviceConfig
+		})
+		Convey("When the swagger configuration getters are called", func() {
+			Convey("Then the values of the swagger section should be returned", func() {
+				So(serviceConfig.GetSwaggerURL(), ShouldEqual, "http://sevice-api.com/swagger.yaml")
+				So(serviceConfig.GetSwaggerOverlays(), ShouldResemble, []string{"http://sevice-api.com/overlay.yaml"})
+				So(serviceConfig.IsInsecureSkipVerifyEnabled(), ShouldBeTrue)
+				So(serviceConfig.GetPluginVersion(), ShouldEqual, "0.14.0")
+			})
+		})
+		Convey("When the sections getters are called", func() {
+			Convey("Then the configured sections should be returned", func() {
+				So(serviceConfig.GetTelemetryConfiguration(), ShouldResemble, TelemetryProvider(&TelemetryProviderHTTPEndpoint{URL: "http://my-api.com/v1/metrics"}))
+				So(serviceConfig.GetTLSConfiguration(), ShouldEqual, tls)
+				So(serviceConfig.GetRetryConfiguration(), ShouldEqual, retry)
+				So(serviceConfig.GetRateLimitConfiguration(), ShouldEqual, rateLimit)
+				So(serviceConfig.GetPollingConfiguration(), ShouldEqual, polling)
+				So(serviceConfig.GetTracingConfiguration(), ShouldEqual, tracing)
+			})
+		})
+		Convey("When GetSchemaPropertyConfiguration is called", func() {
+			Convey("Then the schema property configuration should be returned if present", func() {
+				So(serviceConfig.GetSchemaPropertyConfiguration("apikey_auth"), ShouldResemble, serviceConfig.SchemaConfiguration[0])
+				So(serviceConfig.GetSchemaPropertyConfiguration("non_existing"), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestServiceConfigV2Validate(t *testing.T) {
+	Convey("Given a ServiceConfigV2 with a valid swagger URL and plugin version", t, func() {
+		serviceConfig := &ServiceConfigV2{Swagger: SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml"}, PluginVersion: "0.14.0"}
+		Convey("When Validate is called with the same running plugin version", func() {
+			err := serviceConfig.Validate("0.14.0")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When Validate is called with a different running plugin version", func() {
+			err := serviceConfig.Validate("0.15.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "plugin version '0.14.0' in the plugin configuration file does not match the version of the OpenAPI plugin that is running '0.15.0'")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV2 with an invalid swagger URL", t, func() {
+		serviceConfig := &ServiceConfigV2{Swagger: SwaggerConfigV2{URL: "htpt:/non-valid-url"}}
+		Convey("When Validate is called", func() {
+			err := serviceConfig.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service swagger URL configuration not valid ('htpt:/non-valid-url'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV2 with both a swagger URL and a list of swagger URLs", t, func() {
+		serviceConfig := &ServiceConfigV2{Swagger: SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml", URLs: []SwaggerSpecConfigV1{{URL: "http://users-api.com/swagger.yaml"}}}}
+		Convey("When Validate is called", func() {
+			err := serviceConfig.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service configuration must contain either a single swagger URL or a list of swagger URLs, not both")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV2 with an invalid overlay", t, func() {
+		serviceConfig := &ServiceConfigV2{Swagger: SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml", Overlays: []string{"htpt:/non-valid-overlay"}}}
+		Convey("When Validate is called", func() {
+			err := serviceConfig.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "overlay configuration not valid ('htpt:/non-valid-overlay'). Overlay must be either a valid formed URL or a path to an existing file stored in the disk")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV2 with an invalid tracing configuration", t, func() {
+		serviceConfig := &ServiceConfigV2{Swagger: SwaggerConfigV2{URL: "http://sevice-api.com/swagger.yaml"}, TracingConfig: &TracingConfig{}}
+		Convey("When Validate is called", func() {
+			err := serviceConfig.Validate("0.14.0")
+			Convey("Then the error returned should be the tracing one", func() {
+				So(err.Error(), ShouldEqual, "tracing configuration is missing a value for either the 'otlp_endpoint' or 'file' property")
+			})
+		})
+	})
+}
@@ -1,6 +1,10 @@
 package openapi
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
 
 // TelemetryProviderConfiguration defines the struct type that specific telemetry providers can configure based on the
 // resource data received in GetTelemetryProviderConfiguration. The struct serves as a way to document in the metric
@@ -24,8 +28,21 @@ const (
 	TelemetryResourceOperationImport TelemetryResourceOperation = "import"
 )
 
-// TelemetryProvider holds the behaviour expected to be implemented for the Telemetry Providers supported. At the moment
-// only Graphite is supported.
+// TelemetryResourceOperationOutcome describes how a resource operation (CRUD) went, including how long it took, the status
+// code of the last HTTP response received from the API (0 if no response was received) and the error returned (if any)
+type TelemetryResourceOperationOutcome struct {
+	Duration   time.Duration
+	StatusCode int
+	Err        error
+}
+
+// Failed returns true if the resource operation returned an error
+func (o TelemetryResourceOperationOutcome) Failed() bool {
+	return o.Err != nil
+}
+
+// TelemetryProvider holds the behaviour expected to be implemented for the Telemetry Providers supported (Graphite, HTTP
+// endpoint, OTLP and Prometheus).
 type TelemetryProvider interface {
 	// Validate performs a check to confirm that the telemetry configuration is valid
 	Validate() error
@@ -37,3 +54,50 @@ type TelemetryProvider interface {
 	// GetTelemetryProviderConfiguration is the method responsible for getting a specific telemetry provider config given the input data provided
 	GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration
 }
+
+// TelemetryProviderOperationMetrics holds the behaviour expected to be implemented by the Telemetry Providers that support
+// submitting latency and error metrics for the resource operations on top of the run counters
+type TelemetryProviderOperationMetrics interface {
+	// SubmitServiceProviderResourceOperationMetrics is the method responsible for submitting to the corresponding telemetry platform the latency of
+	// the resource operation and, if the operation failed, an error counter increase. The metrics are tagged with the provider name, resource name,
+	// Terraform operation and HTTP status code
+	SubmitServiceProviderResourceOperationMetrics(providerName, resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, telemetryProviderConfiguration TelemetryProviderConfiguration) error
+}
+
+// TelemetryProviderDroppedEventsMetrics holds the behaviour expected to be implemented by the Telemetry Providers that support
+// submitting the number of telemetry events dropped by the plugin because the telemetry queue was full
+type TelemetryProviderDroppedEventsMetrics interface {
+	// IncTelemetryDroppedEventsCounter is the method responsible for submitting to the corresponding telemetry platform the counter increase
+	// for the telemetry events dropped since the last submission, tagged with the provider name
+	IncTelemetryDroppedEventsCounter(providerName string, droppedEvents int64, telemetryProviderConfiguration TelemetryProviderConfiguration) error
+}
+
+// TelemetryEventType defines the kind of metrics a TelemetryEvent refers to
+type TelemetryEventType string
+
+const (
+	// TelemetryEventPluginExecution represents the plugin execution metrics (IncOpenAPIPluginVersionTotalRunsCounter)
+	TelemetryEventPluginExecution TelemetryEventType = "plugin_execution"
+	// TelemetryEventResourceExecution represents the resource execution metrics (IncServiceProviderResourceTotalRunsCounter)
+	TelemetryEventResourceExecution TelemetryEventType = "resource_execution"
+	// TelemetryEventResourceOperation represents the resource operation outcome metrics (SubmitServiceProviderResourceOperationMetrics)
+	TelemetryEventResourceOperation TelemetryEventType = "resource_operation"
+)
+
+// TelemetryEvent holds the information needed to submit the metrics of the given type. Events are queued by the telemetry
+// handler and submitted in the background
+type TelemetryEvent struct {
+	Type                 TelemetryEventType
+	OpenAPIPluginVersion string
+	ProviderName         string
+	ResourceName         string
+	TFOperation          TelemetryResourceOperation
+	Outcome              TelemetryResourceOperationOutcome
+}
+
+// TelemetryProviderBatch holds the behaviour expected to be implemented by the Telemetry Providers that support submitting
+// the metrics of several events in one go (eg: one single API request)
+type TelemetryProviderBatch interface {
+	// SubmitTelemetryEvents is the method responsible for submitting to the corresponding telemetry platform the metrics of all the given events
+	SubmitTelemetryEvents(events []TelemetryEvent, telemetryProviderConfiguration TelemetryProviderConfiguration) error
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
+	"net/http"
 	"time"
 )
 
@@ -17,6 +18,9 @@ type TelemetryHandler interface {
 	SubmitPluginExecutionMetrics()
 	// SubmitResourceExecutionMetrics submits the metrics related to resource operation execution
 	SubmitResourceExecutionMetrics(resourceName string, tfOperation TelemetryResourceOperation)
+	// SubmitResourceOperationMetrics submits the metrics related to the resource operation outcome (latency and errors). This is
+	// a no-op if the telemetry provider does not support operation metrics
+	SubmitResourceOperationMetrics(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome)
 }
 
 const telemetryTimeout = 2
@@ -54,6 +58,40 @@ func (t telemetryHandlerTimeoutSupport) SubmitResourceExecutionMetrics(resourceN
 	})
 }
 
+func (t telemetryHandlerTimeoutSupport) SubmitResourceOperationMetrics(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome) {
+	if t.telemetryProvider == nil {
+		log.Println("[INFO] Telemetry provider not configured")
+		return
+	}
+	operationMetricsProvider, ok := t.telemetryProvider.(TelemetryProviderOperationMetrics)
+	if !ok {
+		log.Println("[DEBUG] Telemetry provider does not support operation metrics")
+		return
+	}
+	telemetryConfig := t.telemetryProvider.GetTelemetryProviderConfiguration(t.data)
+	t.submitMetric("SubmitServiceProviderResourceOperationMetrics", func() error {
+		return operationMetricsProvider.SubmitServiceProviderResourceOperationMetrics(t.providerName, resourceName, tfOperation, outcome, telemetryConfig)
+	})
+}
+
+// SubmitDroppedEventsMetrics submits the counter increase for the telemetry events dropped. This is a no-op if the telemetry
+// provider does not support dropped events metrics
+func (t telemetryHandlerTimeoutSupport) SubmitDroppedEventsMetrics(droppedEvents int64) {
+	if t.telemetryProvider == nil {
+		log.Println("[INFO] Telemetry provider not configured")
+		return
+	}
+	droppedEventsMetricsProvider, ok := t.telemetryProvider.(TelemetryProviderDroppedEventsMetrics)
+	if !ok {
+		log.Println("[DEBUG] Telemetry provider does not support dropped events metrics")
+		return
+	}
+	telemetryConfig := t.telemetryProvider.GetTelemetryProviderConfiguration(t.data)
+	t.submitMetric("IncTelemetryDroppedEventsCounter", func() error {
+		return droppedEventsMetricsProvider.IncTelemetryDroppedEventsCounter(t.providerName, droppedEvents, telemetryConfig)
+	})
+}
+
 func (t telemetryHandlerTimeoutSupport) submitMetric(metricName string, metricSubmitter MetricSubmitter) {
 	doneChan := make(chan error)
 	go func() {
@@ -85,3 +123,50 @@ func submitTelemetryMetric(providerClient ClientOpenAPI, tfOperation TelemetryRe
 func submitTelemetryMetricDataSource(providerClient ClientOpenAPI, tfOperation TelemetryResourceOperation, resourceName string) {
 	submitTelemetryMetric(providerClient, tfOperation, resourceName, "data_")
 }
+
+// telemetryOperationRecorder keeps track of the time a resource operation takes and the last HTTP response status code
+// received so the corresponding operation metrics can be submitted once the operation finishes
+type telemetryOperationRecorder struct {
+	providerClient ClientOpenAPI
+	tfOperation    TelemetryResourceOperation
+	resourceName   string
+	start          time.Time
+	statusCode     int
+}
+
+func newTelemetryOperationRecorder(providerClient ClientOpenAPI, tfOperation TelemetryResourceOperation, resourceName string, prefix string) *telemetryOperationRecorder {
+	return &telemetryOperationRecorder{
+		providerClient: providerClient,
+		tfOperation:    tfOperation,
+		resourceName:   fmt.Sprintf("%s%s", prefix, resourceName),
+		start:          time.Now(),
+	}
+}
+
+func newTelemetryOperationRecorderDataSource(providerClient ClientOpenAPI, tfOperation TelemetryResourceOperation, resourceName string) *telemetryOperationRecorder {
+	return newTelemetryOperationRecorder(providerClient, tfOperation, resourceName, "data_")
+}
+
+// recordResponse keeps the status code of the given response; nil responses are ignored
+func (t *telemetryOperationRecorder) recordResponse(res *http.Response) {
+	if res != nil {
+		t.statusCode = res.StatusCode
+	}
+}
+
+// submit submits the operation metrics with the time elapsed since the recorder was created and the error returned by
+// the operation (if any)
+func (t *telemetryOperationRecorder) submit(err error) {
+	if t.providerClient == nil || t.resourceName == "" {
+		return
+	}
+	telemetryHandler := t.providerClient.GetTelemetryHandler()
+	if telemetryHandler == nil {
+		return
+	}
+	telemetryHandler.SubmitResourceOperationMetrics(t.resourceName, t.tfOperation, TelemetryResourceOperationOutcome{
+		Duration:   time.Since(t.start),
+		StatusCode: t.statusCode,
+		Err:        err,
+	})
+}
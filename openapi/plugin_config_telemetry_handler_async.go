@@ -0,0 +1,256 @@
+package openapi
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// telemetryQueueSize is the maximum number of events kept in memory waiting to be submitted. When the queue is full
+	// the oldest events are dropped
+	telemetryQueueSize = 1000
+	// telemetryBatchSize is the maximum number of events submitted in one go
+	telemetryBatchSize = 100
+	// telemetryFlushInterval is how often the events queued are submitted even if the batch is not full
+	telemetryFlushInterval = 1 * time.Second
+	// telemetryShutdownTimeout is the maximum time (in seconds) the provider shutdown waits for the events queued and the
+	// trace spans to be submitted. It allows for the submission of the last batch and the dropped events counter, each of
+	// them bounded by telemetryTimeout
+	telemetryShutdownTimeout = 2 * telemetryTimeout
+)
+
+// telemetryHandlers holds the async telemetry handlers created during the plugin execution so they can be flushed on shutdown
+var telemetryHandlers = struct {
+	sync.Mutex
+	handlers []*telemetryHandlerAsync
+}{}
+
+// ShutdownTelemetry submits the telemetry events still queued and the trace spans still being exported waiting at most
+// telemetryShutdownTimeout seconds. The telemetry handlers and the tracers are shut down concurrently so each of them is
+// given the whole timeout. This method is expected to be called once the plugin is about to exit
+func ShutdownTelemetry() {
+	timeout := telemetryShutdownTimeout * time.Second
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		shutdownTelemetryHandlers(timeout)
+	}()
+	go func() {
+		defer wg.Done()
+		shutdownTracers(timeout)
+	}()
+	wg.Wait()
+}
+
+// shutdownTelemetryHandlers shuts down concurrently all the telemetry handlers registered, waiting at most the given timeout
+func shutdownTelemetryHandlers(timeout time.Duration) {
+	telemetryHandlers.Lock()
+	handlers := append([]*telemetryHandlerAsync{}, telemetryHandlers.handlers...)
+	telemetryHandlers.Unlock()
+
+	var wg sync.WaitGroup
+	for _, handler := range handlers {
+		wg.Add(1)
+		go func(handler *telemetryHandlerAsync) {
+			defer wg.Done()
+			handler.shutdown(timeout)
+		}(handler)
+	}
+	wg.Wait()
+}
+
+// deregisterTelemetryHandler removes the given handler from the handlers flushed by ShutdownTelemetry
+func deregisterTelemetryHandler(handler *telemetryHandlerAsync) {
+	telemetryHandlers.Lock()
+	defer telemetryHandlers.Unlock()
+	for idx, registeredHandler := range telemetryHandlers.handlers {
+		if registeredHandler == handler {
+			telemetryHandlers.handlers = append(telemetryHandlers.handlers[:idx], telemetryHandlers.handlers[idx+1:]...)
+			return
+		}
+	}
+}
+
+// telemetryHandlerAsync is a TelemetryHandler that queues the metrics submissions so the resource operations are not
+// blocked by the telemetry provider. The events queued are submitted in the background in batches by the wrapped
+// telemetryHandlerTimeoutSupport which makes sure each submission is configured with a timeout. The queue is bounded and
+// when it is full the oldest events are dropped
+type telemetryHandlerAsync struct {
+	handler       telemetryHandlerTimeoutSupport
+	queueSize     int
+	batchSize     int
+	flushInterval time.Duration
+
+	mutex   sync.Mutex
+	queue   []TelemetryEvent
+	closed  bool
+	dropped uint64
+	// droppedSubmitted is the number of dropped events already submitted to the telemetry provider, only accessed by the worker
+	droppedSubmitted uint64
+
+	notify   chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// newTelemetryHandlerAsync returns a telemetryHandlerAsync with the worker submitting the events already started. The
+// handler is registered so it gets flushed when ShutdownTelemetry is called
+func newTelemetryHandlerAsync(handler telemetryHandlerTimeoutSupport, queueSize, batchSize int, flushInterval time.Duration) *telemetryHandlerAsync {
+	t := &telemetryHandlerAsync{
+		handler:       handler,
+		queueSize:     queueSize,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		notify:        make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go t.run()
+	telemetryHandlers.Lock()
+	telemetryHandlers.handlers = append(telemetryHandlers.handlers, t)
+	telemetryHandlers.Unlock()
+	return t
+}
+
+func (t *telemetryHandlerAsync) SubmitPluginExecutionMetrics() {
+	t.enqueue(TelemetryEvent{Type: TelemetryEventPluginExecution, OpenAPIPluginVersion: t.handler.openAPIVersion, ProviderName: t.handler.providerName})
+}
+
+func (t *telemetryHandlerAsync) SubmitResourceExecutionMetrics(resourceName string, tfOperation TelemetryResourceOperation) {
+	t.enqueue(TelemetryEvent{Type: TelemetryEventResourceExecution, ProviderName: t.handler.providerName, ResourceName: resourceName, TFOperation: tfOperation})
+}
+
+func (t *telemetryHandlerAsync) SubmitResourceOperationMetrics(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome) {
+	t.enqueue(TelemetryEvent{Type: TelemetryEventResourceOperation, ProviderName: t.handler.providerName, ResourceName: resourceName, TFOperation: tfOperation, Outcome: outcome})
+}
+
+// droppedEvents returns the number of events dropped because the queue was full or the handler was already shut down
+func (t *telemetryHandlerAsync) droppedEvents() uint64 {
+	return atomic.LoadUint64(&t.dropped)
+}
+
+func (t *telemetryHandlerAsync) enqueue(event TelemetryEvent) {
+	t.mutex.Lock()
+	if t.closed {
+		t.mutex.Unlock()
+		atomic.AddUint64(&t.dropped, 1)
+		log.Printf("[WARN] telemetry handler already shut down, dropping '%s' event", event.Type)
+		return
+	}
+	if len(t.queue) >= t.queueSize {
+		dropped := t.queue[0]
+		t.queue = t.queue[1:]
+		atomic.AddUint64(&t.dropped, 1)
+		log.Printf("[WARN] telemetry queue is full (%d events), dropping oldest '%s' event", t.queueSize, dropped.Type)
+	}
+	t.queue = append(t.queue, event)
+	t.mutex.Unlock()
+
+	select {
+	case t.notify <- struct{}{}:
+	default:
+	}
+}
+
+// dequeue removes and returns up to batchSize events from the queue. If full is true, events are only returned if there
+// are enough events queued to fill a batch
+func (t *telemetryHandlerAsync) dequeue(full bool) []TelemetryEvent {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.queue) == 0 || (full && len(t.queue) < t.batchSize) {
+		return nil
+	}
+	n := len(t.queue)
+	if n > t.batchSize {
+		n = t.batchSize
+	}
+	batch := make([]TelemetryEvent, n)
+	copy(batch, t.queue[:n])
+	t.queue = t.queue[n:]
+	return batch
+}
+
+func (t *telemetryHandlerAsync) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(t.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.notify:
+			t.flush(true)
+		case <-ticker.C:
+			t.flush(false)
+		case <-t.stop:
+			t.flush(false)
+			return
+		}
+	}
+}
+
+// flush submits the events queued in batches followed by the number of events dropped since the last flush. If full is
+// true, only full batches are submitted
+func (t *telemetryHandlerAsync) flush(full bool) {
+	for batch := t.dequeue(full); batch != nil; batch = t.dequeue(full) {
+		t.submitBatch(batch)
+	}
+	t.submitDroppedEvents()
+}
+
+// submitDroppedEvents submits the number of events dropped that have not been submitted yet (if any)
+func (t *telemetryHandlerAsync) submitDroppedEvents() {
+	dropped := t.droppedEvents()
+	if dropped == t.droppedSubmitted {
+		return
+	}
+	t.handler.SubmitDroppedEventsMetrics(int64(dropped - t.droppedSubmitted))
+	t.droppedSubmitted = dropped
+}
+
+// submitBatch submits the given events in one go if the telemetry provider supports batches, otherwise each event is
+// submitted separately
+func (t *telemetryHandlerAsync) submitBatch(events []TelemetryEvent) {
+	if batchProvider, ok := t.handler.telemetryProvider.(TelemetryProviderBatch); ok {
+		telemetryConfig := t.handler.telemetryProvider.GetTelemetryProviderConfiguration(t.handler.data)
+		t.handler.submitMetric("SubmitTelemetryEvents", func() error {
+			return batchProvider.SubmitTelemetryEvents(events, telemetryConfig)
+		})
+		return
+	}
+	for _, event := range events {
+		switch event.Type {
+		case TelemetryEventPluginExecution:
+			t.handler.SubmitPluginExecutionMetrics()
+		case TelemetryEventResourceExecution:
+			t.handler.SubmitResourceExecutionMetrics(event.ResourceName, event.TFOperation)
+		case TelemetryEventResourceOperation:
+			t.handler.SubmitResourceOperationMetrics(event.ResourceName, event.TFOperation, event.Outcome)
+		}
+	}
+}
+
+// shutdown stops accepting new events and waits at most the given timeout for the events queued to be submitted. The
+// handler is deregistered so it is not kept around once shut down
+func (t *telemetryHandlerAsync) shutdown(timeout time.Duration) {
+	t.stopOnce.Do(func() {
+		t.mutex.Lock()
+		t.closed = true
+		t.mutex.Unlock()
+		close(t.stop)
+		deregisterTelemetryHandler(t)
+	})
+	select {
+	case <-t.done:
+	case <-time.After(timeout):
+		t.mutex.Lock()
+		pending := len(t.queue)
+		t.mutex.Unlock()
+		log.Printf("[WARN] telemetry events were not submitted within the expected time %s, %d events left in the queue", timeout, pending)
+	}
+	if dropped := t.droppedEvents(); dropped > 0 {
+		log.Printf("[WARN] telemetry handler dropped %d events", dropped)
+	}
+}
@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"bytes"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// telemetryProviderBatchStub is a telemetry provider supporting batches that records the batches received
+type telemetryProviderBatchStub struct {
+	telemetryProviderStub
+	mutex          sync.Mutex
+	batches        [][]TelemetryEvent
+	blockSubmitter chan struct{}
+}
+
+func (t *telemetryProviderBatchStub) SubmitTelemetryEvents(events []TelemetryEvent, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	if t.blockSubmitter != nil {
+		<-t.blockSubmitter
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.batches = append(t.batches, events)
+	return nil
+}
+
+func (t *telemetryProviderBatchStub) getBatches() [][]TelemetryEvent {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.batches
+}
+
+func newTestTelemetryHandlerAsync(telemetryProvider TelemetryProvider, queueSize, batchSize int, flushInterval time.Duration) *telemetryHandlerAsync {
+	return newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{
+		timeout:           1,
+		providerName:      "providerName",
+		openAPIVersion:    "0.25.0",
+		telemetryProvider: telemetryProvider,
+	}, queueSize, batchSize, flushInterval)
+}
+
+func TestTelemetryHandlerAsync_SubmitsEventsWithTelemetryProviderNotSupportingBatches(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	handler := newTestTelemetryHandlerAsync(stub, 10, 5, time.Hour)
+
+	handler.SubmitPluginExecutionMetrics()
+	handler.SubmitResourceExecutionMetrics("resourceName", TelemetryResourceOperationCreate)
+	handler.SubmitResourceOperationMetrics("resourceName", TelemetryResourceOperationCreate, TelemetryResourceOperationOutcome{StatusCode: 201})
+	handler.shutdown(time.Second)
+
+	assert.Equal(t, "0.25.0", stub.openAPIPluginVersionReceived)
+	assert.Equal(t, "providerName", stub.providerNameReceived)
+	assert.Equal(t, "resourceName", stub.resourceNameReceived)
+	assert.Equal(t, TelemetryResourceOperationCreate, stub.tfOperationReceived)
+	require.NotNil(t, stub.outcomeReceived)
+	assert.Equal(t, 201, stub.outcomeReceived.StatusCode)
+}
+
+func TestTelemetryHandlerAsync_SubmitsEventsInBatches(t *testing.T) {
+	stub := &telemetryProviderBatchStub{}
+	handler := newTestTelemetryHandlerAsync(stub, 10, 2, time.Hour)
+
+	handler.SubmitPluginExecutionMetrics()
+	handler.SubmitResourceExecutionMetrics("resourceName", TelemetryResourceOperationCreate)
+	handler.SubmitResourceOperationMetrics("resourceName", TelemetryResourceOperationCreate, TelemetryResourceOperationOutcome{StatusCode: 201})
+	handler.shutdown(time.Second)
+
+	batches := stub.getBatches()
+	require.Len(t, batches, 2)
+	assert.Equal(t, []TelemetryEvent{
+		{Type: TelemetryEventPluginExecution, OpenAPIPluginVersion: "0.25.0", ProviderName: "providerName"},
+		{Type: TelemetryEventResourceExecution, ProviderName: "providerName", ResourceName: "resourceName", TFOperation: TelemetryResourceOperationCreate},
+	}, batches[0])
+	assert.Equal(t, []TelemetryEvent{
+		{Type: TelemetryEventResourceOperation, ProviderName: "providerName", ResourceName: "resourceName", TFOperation: TelemetryResourceOperationCreate, Outcome: TelemetryResourceOperationOutcome{StatusCode: 201}},
+	}, batches[1])
+}
+
+func TestTelemetryHandlerAsync_FlushesEventsPeriodically(t *testing.T) {
+	stub := &telemetryProviderBatchStub{}
+	handler := newTestTelemetryHandlerAsync(stub, 10, 5, 10*time.Millisecond)
+	defer handler.shutdown(time.Second)
+
+	handler.SubmitResourceExecutionMetrics("resourceName", TelemetryResourceOperationRead)
+	for i := 0; i < 100 && len(stub.getBatches()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	batches := stub.getBatches()
+	require.Len(t, batches, 1)
+	assert.Len(t, batches[0], 1)
+}
+
+func TestTelemetryHandlerAsync_DropsOldestEventsWhenQueueIsFull(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	// The handler is created without the worker so the events stay in the queue
+	handler := &telemetryHandlerAsync{
+		handler:   telemetryHandlerTimeoutSupport{providerName: "providerName"},
+		queueSize: 2,
+		batchSize: 2,
+		notify:    make(chan struct{}, 1),
+	}
+	handler.SubmitResourceExecutionMetrics("resource1", TelemetryResourceOperationCreate)
+	handler.SubmitResourceExecutionMetrics("resource2", TelemetryResourceOperationCreate)
+	handler.SubmitResourceExecutionMetrics("resource3", TelemetryResourceOperationCreate)
+
+	assert.Equal(t, uint64(1), handler.droppedEvents())
+	require.Len(t, handler.queue, 2)
+	assert.Equal(t, "resource2", handler.queue[0].ResourceName)
+	assert.Equal(t, "resource3", handler.queue[1].ResourceName)
+	assert.Contains(t, buf.String(), "[WARN] telemetry queue is full (2 events), dropping oldest 'resource_execution' event")
+}
+
+func TestTelemetryHandlerAsync_SubmitsDroppedEventsOnFlush(t *testing.T) {
+	stub := &telemetryProviderBatchStub{}
+	handler := newTestTelemetryHandlerAsync(stub, 2, 10, time.Hour)
+
+	handler.SubmitResourceExecutionMetrics("resource1", TelemetryResourceOperationCreate)
+	handler.SubmitResourceExecutionMetrics("resource2", TelemetryResourceOperationCreate)
+	handler.SubmitResourceExecutionMetrics("resource3", TelemetryResourceOperationCreate)
+	handler.SubmitResourceExecutionMetrics("resource4", TelemetryResourceOperationCreate)
+	handler.shutdown(time.Second)
+
+	assert.Equal(t, uint64(2), handler.droppedEvents())
+	assert.Equal(t, int64(2), stub.droppedEventsReceived)
+	assert.Equal(t, "providerName", stub.providerNameReceived)
+	// Flushing again does not submit the dropped events already submitted
+	handler.flush(false)
+	assert.Equal(t, int64(2), stub.droppedEventsReceived)
+}
+
+func TestTelemetryHandlerAsync_DropsEventsAfterShutdown(t *testing.T) {
+	stub := &telemetryProviderBatchStub{}
+	handler := newTestTelemetryHandlerAsync(stub, 10, 5, time.Hour)
+	handler.shutdown(time.Second)
+
+	handler.SubmitPluginExecutionMetrics()
+	assert.Equal(t, uint64(1), handler.droppedEvents())
+	assert.Empty(t, stub.getBatches())
+}
+
+func TestTelemetryHandlerAsync_ShutdownTimesOut(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	stub := &telemetryProviderBatchStub{blockSubmitter: make(chan struct{})}
+	defer close(stub.blockSubmitter)
+	handler := newTestTelemetryHandlerAsync(stub, 10, 5, time.Hour)
+
+	handler.SubmitPluginExecutionMetrics()
+	start := time.Now()
+	handler.shutdown(50 * time.Millisecond)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Contains(t, buf.String(), "[WARN] telemetry events were not submitted within the expected time 50ms")
+}
+
+func TestShutdownTelemetryHandlers(t *testing.T) {
+	stub := &telemetryProviderBatchStub{}
+	handler := newTestTelemetryHandlerAsync(stub, 10, 5, time.Hour)
+	handler.SubmitPluginExecutionMetrics()
+
+	shutdownTelemetryHandlers(time.Second)
+
+	assert.Len(t, stub.getBatches(), 1)
+	telemetryHandlers.Lock()
+	defer telemetryHandlers.Unlock()
+	assert.NotContains(t, telemetryHandlers.handlers, handler)
+}
+
+func TestShutdownTelemetryHandlers_ShutsDownHandlersConcurrently(t *testing.T) {
+	stub := &telemetryProviderBatchStub{blockSubmitter: make(chan struct{})}
+	defer close(stub.blockSubmitter)
+	handlers := []*telemetryHandlerAsync{
+		newTestTelemetryHandlerAsync(stub, 10, 5, time.Hour),
+		newTestTelemetryHandlerAsync(stub, 10, 5, time.Hour),
+	}
+	for _, handler := range handlers {
+		handler.SubmitPluginExecutionMetrics()
+	}
+
+	start := time.Now()
+	shutdownTelemetryHandlers(100 * time.Millisecond)
+	assert.True(t, time.Since(start) < 200*time.Millisecond)
+}
+
+func TestTelemetryHandlerAsync_ShutdownDeregistersHandler(t *testing.T) {
+	handler := newTestTelemetryHandlerAsync(&telemetryProviderBatchStub{}, 10, 5, time.Hour)
+	telemetryHandlers.Lock()
+	assert.Contains(t, telemetryHandlers.handlers, handler)
+	telemetryHandlers.Unlock()
+
+	handler.shutdown(time.Second)
+
+	telemetryHandlers.Lock()
+	defer telemetryHandlers.Unlock()
+	assert.NotContains(t, telemetryHandlers.handlers, handler)
+}
+
+func TestTelemetryShutdownTimeout(t *testing.T) {
+	// the shutdown must allow for at least one submission to complete
+	assert.True(t, telemetryShutdownTimeout >= telemetryTimeout)
+}
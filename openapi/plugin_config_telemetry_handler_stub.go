@@ -3,6 +3,7 @@ package openapi
 type telemetryHandlerStub struct {
 	submitPluginExecutionMetricsFunc   func()
 	submitResourceExecutionMetricsFunc func(resourceName string, tfOperation TelemetryResourceOperation)
+	submitResourceOperationMetricsFunc func(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome)
 }
 
 func (t *telemetryHandlerStub) SubmitPluginExecutionMetrics() {
@@ -12,3 +13,9 @@ func (t *telemetryHandlerStub) SubmitPluginExecutionMetrics() {
 func (t *telemetryHandlerStub) SubmitResourceExecutionMetrics(resourceName string, tfOperation TelemetryResourceOperation) {
 	t.submitResourceExecutionMetricsFunc(resourceName, tfOperation)
 }
+
+func (t *telemetryHandlerStub) SubmitResourceOperationMetrics(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome) {
+	if t.submitResourceOperationMetricsFunc != nil {
+		t.submitResourceOperationMetricsFunc(resourceName, tfOperation, outcome)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"log"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -144,3 +145,84 @@ func TestSubmitTelemetryMetric_EmptyResourceName(t *testing.T) {
 	submitTelemetryMetric(clientOpenAPI, TelemetryResourceOperationCreate, "", "prefix_")
 	assert.False(t, submitResourceExecutionMetricsFuncCalled)
 }
+
+func TestSubmitResourceOperationMetrics(t *testing.T) {
+	expectedOutcome := TelemetryResourceOperationOutcome{Duration: 150 * time.Millisecond, StatusCode: 500, Err: errors.New("some error")}
+	stub := &telemetryProviderStub{}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:      "providerName",
+		timeout:           1,
+		openAPIVersion:    "0.25.0",
+		telemetryProvider: stub,
+	}
+	ths.SubmitResourceOperationMetrics("resourceName", TelemetryResourceOperationCreate, expectedOutcome)
+	assert.Equal(t, ths.providerName, stub.providerNameReceived)
+	assert.Equal(t, "resourceName", stub.resourceNameReceived)
+	assert.Equal(t, TelemetryResourceOperationCreate, stub.tfOperationReceived)
+	assert.Equal(t, &expectedOutcome, stub.outcomeReceived)
+}
+
+func TestSubmitResourceOperationMetrics_TelemetryProviderDoesNotSupportOperationMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:      "providerName",
+		timeout:           1,
+		openAPIVersion:    "0.25.0",
+		telemetryProvider: TelemetryProviderGraphite{Host: "localhost", Port: 8125},
+	}
+	ths.SubmitResourceOperationMetrics("resourceName", TelemetryResourceOperationCreate, TelemetryResourceOperationOutcome{})
+	assert.Contains(t, buf.String(), "[DEBUG] Telemetry provider does not support operation metrics")
+}
+
+func TestSubmitDroppedEventsMetrics(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:      "providerName",
+		timeout:           1,
+		openAPIVersion:    "0.25.0",
+		telemetryProvider: stub,
+	}
+	ths.SubmitDroppedEventsMetrics(5)
+	assert.Equal(t, ths.providerName, stub.providerNameReceived)
+	assert.Equal(t, int64(5), stub.droppedEventsReceived)
+}
+
+func TestTelemetryOperationRecorder(t *testing.T) {
+	var resourceNameReceived string
+	var tfOperationReceived TelemetryResourceOperation
+	var outcomeReceived TelemetryResourceOperationOutcome
+	clientOpenAPI := &clientOpenAPIStub{
+		telemetryHandler: &telemetryHandlerStub{
+			submitResourceOperationMetricsFunc: func(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome) {
+				resourceNameReceived = resourceName
+				tfOperationReceived = tfOperation
+				outcomeReceived = outcome
+			},
+		},
+	}
+	recorder := newTelemetryOperationRecorderDataSource(clientOpenAPI, TelemetryResourceOperationRead, "resourceName")
+	recorder.recordResponse(nil)
+	recorder.recordResponse(&http.Response{StatusCode: http.StatusNotFound})
+	expectedErr := errors.New("some error")
+	recorder.submit(expectedErr)
+	assert.Equal(t, "data_resourceName", resourceNameReceived)
+	assert.Equal(t, TelemetryResourceOperationRead, tfOperationReceived)
+	assert.Equal(t, http.StatusNotFound, outcomeReceived.StatusCode)
+	assert.Equal(t, expectedErr, outcomeReceived.Err)
+	assert.True(t, outcomeReceived.Failed())
+	assert.True(t, outcomeReceived.Duration >= 0)
+}
+
+func TestTelemetryOperationRecorder_EmptyResourceName(t *testing.T) {
+	var submitResourceOperationMetricsFuncCalled bool
+	clientOpenAPI := &clientOpenAPIStub{
+		telemetryHandler: &telemetryHandlerStub{
+			submitResourceOperationMetricsFunc: func(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome) {
+				submitResourceOperationMetricsFuncCalled = true
+			},
+		},
+	}
+	newTelemetryOperationRecorder(clientOpenAPI, TelemetryResourceOperationCreate, "", "").submit(nil)
+	assert.False(t, submitResourceOperationMetricsFuncCalled)
+}
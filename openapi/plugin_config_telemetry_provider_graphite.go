@@ -61,6 +61,23 @@ func (g TelemetryProviderGraphite) IncServiceProviderResourceTotalRunsCounter(pr
 	return nil
 }
 
+// IncTelemetryDroppedEventsCounter will increment the counter 'statsd.<prefix>.terraform.telemetry.dropped_events' metric by the number
+// of events dropped and appends a tag containing the 'provider_name'
+func (g TelemetryProviderGraphite) IncTelemetryDroppedEventsCounter(providerName string, droppedEvents int64, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	tags := []string{"provider_name:" + providerName}
+	metricName := "terraform.telemetry.dropped_events"
+	log.Printf("[INFO] graphite metric to be submitted: %s", metricName)
+	c, err := g.getGraphiteClient()
+	if err != nil {
+		return err
+	}
+	if err := c.Count(g.buildMetricName(metricName), droppedEvents, tags, 1.0); err != nil {
+		return err
+	}
+	log.Printf("[INFO] graphite metric successfully submitted: %s (tags: %s)", metricName, tags)
+	return nil
+}
+
 // GetTelemetryProviderConfiguration returns nil since Graphite does not need any TelemetryProviderConfiguration at the moment
 func (g TelemetryProviderGraphite) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
 	return nil
@@ -115,6 +115,29 @@ func TestTelemetryProviderGraphite_IncServiceProviderResourceTotalRunsCounter_Ba
 	assert.Equal(t, expectedError, err)
 }
 
+func TestTelemetryProviderGraphite_IncTelemetryDroppedEventsCounter(t *testing.T) {
+	expectedLogMetricToSubmit := "[INFO] graphite metric to be submitted: terraform.telemetry.dropped_events"
+	expectedLogMetricSuccess := "[INFO] graphite metric successfully submitted: terraform.telemetry.dropped_events (tags: [provider_name:myProviderName])"
+	expectedMetric := "myPrefixName.terraform.telemetry.dropped_events:5|c|#provider_name:myProviderName"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	telemetryPortInt, err := strconv.Atoi(telemetryPort)
+	tpg := TelemetryProviderGraphite{
+		Host:   telemetryHost,
+		Port:   telemetryPortInt,
+		Prefix: "myPrefixName",
+	}
+	err = tpg.IncTelemetryDroppedEventsCounter("myProviderName", 5, nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
+
 func TestTelemetryProviderGraphite_GetTelemetryProviderConfiguration(t *testing.T) {
 	tpg := TelemetryProviderGraphite{}
 	telemetryConfiguration := tpg.GetTelemetryProviderConfiguration(nil)
@@ -43,6 +43,12 @@ type telemetryMetric struct {
 	Tags       []string   `json:"tags"`
 }
 
+// telemetryMetricWithValue is a telemetryMetric that increases the counter by the given value instead of 1
+type telemetryMetricWithValue struct {
+	telemetryMetric
+	Value int64 `json:"value"`
+}
+
 func createNewCounterMetric(prefix, metricName string, tags []string) telemetryMetric {
 	if prefix != "" {
 		metricName = fmt.Sprintf("%s.%s", prefix, metricName)
@@ -66,10 +72,7 @@ func (g TelemetryProviderHTTPEndpoint) Validate() error {
 // IncOpenAPIPluginVersionTotalRunsCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.openapi_plugin_version.total_runs' including
 // any other tag present in the TelemetryProviderConfiguration.
 func (g TelemetryProviderHTTPEndpoint) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
-	version := strings.Replace(openAPIPluginVersion, ".", "_", -1)
-	tags := []string{"openapi_plugin_version:" + version}
-	metricName := "terraform.openapi_plugin_version.total_runs"
-	metric := createNewCounterMetric(g.Prefix, metricName, tags)
+	metric := g.createOpenAPIPluginVersionTotalRunsMetric(openAPIPluginVersion)
 	if err := g.submitMetric(metric, telemetryProviderConfiguration); err != nil {
 		return err
 	}
@@ -79,15 +82,54 @@ func (g TelemetryProviderHTTPEndpoint) IncOpenAPIPluginVersionTotalRunsCounter(o
 // IncServiceProviderResourceTotalRunsCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.provider'.
 // In addition, it will send tags with the provider name, resource name, and terrraform operation called.
 func (g TelemetryProviderHTTPEndpoint) IncServiceProviderResourceTotalRunsCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
-	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation)}
-	metricName := "terraform.provider"
-	metric := createNewCounterMetric(g.Prefix, metricName, tags)
+	metric := g.createServiceProviderResourceTotalRunsMetric(providerName, resourceName, tfOperation)
 	if err := g.submitMetric(metric, telemetryProviderConfiguration); err != nil {
 		return err
 	}
 	return nil
 }
 
+// SubmitTelemetryEvents submits the counter metrics corresponding to the given events in one single POST request containing
+// a JSON array of metrics. Resource operation events are ignored as the http endpoint only supports counters.
+func (g TelemetryProviderHTTPEndpoint) SubmitTelemetryEvents(events []TelemetryEvent, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	var metrics []telemetryMetric
+	for _, event := range events {
+		switch event.Type {
+		case TelemetryEventPluginExecution:
+			metrics = append(metrics, g.createOpenAPIPluginVersionTotalRunsMetric(event.OpenAPIPluginVersion))
+		case TelemetryEventResourceExecution:
+			metrics = append(metrics, g.createServiceProviderResourceTotalRunsMetric(event.ProviderName, event.ResourceName, event.TFOperation))
+		}
+	}
+	if len(metrics) == 0 {
+		return nil
+	}
+	return g.submitMetrics(metrics, telemetryProviderConfiguration)
+}
+
+// IncTelemetryDroppedEventsCounter will submit an increment by the number of events dropped the metric type counter
+// '<prefix>.terraform.telemetry.dropped_events' including a tag with the provider name.
+func (g TelemetryProviderHTTPEndpoint) IncTelemetryDroppedEventsCounter(providerName string, droppedEvents int64, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	metric := telemetryMetricWithValue{
+		telemetryMetric: createNewCounterMetric(g.Prefix, "terraform.telemetry.dropped_events", []string{"provider_name:" + providerName}),
+		Value:           droppedEvents,
+	}
+	return g.submitMetricPayload(metric.MetricName, metric, telemetryProviderConfiguration)
+}
+
+func (g TelemetryProviderHTTPEndpoint) createOpenAPIPluginVersionTotalRunsMetric(openAPIPluginVersion string) telemetryMetric {
+	version := strings.Replace(openAPIPluginVersion, ".", "_", -1)
+	tags := []string{"openapi_plugin_version:" + version}
+	metricName := "terraform.openapi_plugin_version.total_runs"
+	return createNewCounterMetric(g.Prefix, metricName, tags)
+}
+
+func (g TelemetryProviderHTTPEndpoint) createServiceProviderResourceTotalRunsMetric(providerName, resourceName string, tfOperation TelemetryResourceOperation) telemetryMetric {
+	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation)}
+	metricName := "terraform.provider"
+	return createNewCounterMetric(g.Prefix, metricName, tags)
+}
+
 // GetTelemetryProviderConfiguration returns a telemetryProviderConfigurationHTTPEndpoint loaded with headers mapping to
 // the plugin configuration schema properties that match the ones specified in the TelemetryProviderHTTPEndpoint ProviderSchemaProperties values
 func (g TelemetryProviderHTTPEndpoint) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
@@ -104,20 +146,58 @@ func (g TelemetryProviderHTTPEndpoint) GetTelemetryProviderConfiguration(data *s
 }
 
 func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetric, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	return g.submitMetricPayload(metric.MetricName, metric, telemetryProviderConfiguration)
+}
+
+func (g TelemetryProviderHTTPEndpoint) submitMetricPayload(metricName string, metric interface{}, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	telemetryConfiguration, err := g.getTelemetryConfiguration(telemetryProviderConfiguration)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] http endpoint metric to be submitted: %s", metricName)
+	req, err := g.createNewRequestWithPayload(metric, telemetryConfiguration)
+	if err != nil {
+		return err
+	}
+	if err := g.doRequest(req); err != nil {
+		return err
+	}
+	log.Printf("[INFO] http endpoint metric successfully submitted: %v", metric)
+	return nil
+}
+
+func (g TelemetryProviderHTTPEndpoint) submitMetrics(metrics []telemetryMetric, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	telemetryConfiguration, err := g.getTelemetryConfiguration(telemetryProviderConfiguration)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] http endpoint metrics to be submitted: %d", len(metrics))
+	req, err := g.createNewRequestWithPayload(metrics, telemetryConfiguration)
+	if err != nil {
+		return err
+	}
+	if err := g.doRequest(req); err != nil {
+		return err
+	}
+	log.Printf("[INFO] http endpoint metrics successfully submitted: %d", len(metrics))
+	return nil
+}
+
+func (g TelemetryProviderHTTPEndpoint) getTelemetryConfiguration(telemetryProviderConfiguration TelemetryProviderConfiguration) (*telemetryProviderConfigurationHTTPEndpoint, error) {
 	var telemetryConfiguration telemetryProviderConfigurationHTTPEndpoint
 	if telemetryProviderConfiguration != nil {
 		var ok bool
 		telemetryConfiguration, ok = telemetryProviderConfiguration.(telemetryProviderConfigurationHTTPEndpoint)
 		if !ok {
-			return fmt.Errorf("telemetryProviderConfiguration object not the expected one: telemetryProviderConfigurationHTTPEndpoint")
+			return nil, fmt.Errorf("telemetryProviderConfiguration object not the expected one: telemetryProviderConfigurationHTTPEndpoint")
 		}
 	}
+	return &telemetryConfiguration, nil
+}
 
-	log.Printf("[INFO] http endpoint metric to be submitted: %s", metric.MetricName)
-	req, err := g.createNewRequest(metric, &telemetryConfiguration)
-	if err != nil {
-		return err
-	}
+func (g TelemetryProviderHTTPEndpoint) doRequest(req *http.Request) error {
 	c := http.Client{}
 	resp, err := c.Do(req)
 	if err != nil {
@@ -126,14 +206,17 @@ func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetric, tele
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
 		return fmt.Errorf("response returned from POST '%s' returned a non expected status code %d", g.URL, resp.StatusCode)
 	}
-	log.Printf("[INFO] http endpoint metric successfully submitted: %s", metric)
 	return nil
 }
 
 func (g TelemetryProviderHTTPEndpoint) createNewRequest(metric telemetryMetric, telemetryProviderConfiguration *telemetryProviderConfigurationHTTPEndpoint) (*http.Request, error) {
+	return g.createNewRequestWithPayload(metric, telemetryProviderConfiguration)
+}
+
+func (g TelemetryProviderHTTPEndpoint) createNewRequestWithPayload(payload interface{}, telemetryProviderConfiguration *telemetryProviderConfigurationHTTPEndpoint) (*http.Request, error) {
 	var body []byte
 	var err error
-	body, err = json.Marshal(metric)
+	body, err = json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
@@ -334,6 +334,68 @@ func TestTelemetryProviderHttpEndpointIncServiceProviderResourceTotalRunsCounter
 	}
 }
 
+func TestTelemetryProviderHttpEndpointIncTelemetryDroppedEventsCounter(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		reqBody, err := ioutil.ReadAll(req.Body)
+		assert.Nil(t, err)
+		telemetryMetric := telemetryMetricWithValue{}
+		err = json.Unmarshal(reqBody, &telemetryMetric)
+		assert.Nil(t, err)
+		assert.Equal(t, metricTypeCounter, telemetryMetric.MetricType)
+		assert.Equal(t, "prefix.terraform.telemetry.dropped_events", telemetryMetric.MetricName)
+		assert.Equal(t, []string{"provider_name:cdn"}, telemetryMetric.Tags)
+		assert.Equal(t, int64(5), telemetryMetric.Value)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	tph := TelemetryProviderHTTPEndpoint{
+		URL:    fmt.Sprintf("%s/v1/metrics", api.URL),
+		Prefix: "prefix",
+	}
+	err := tph.IncTelemetryDroppedEventsCounter("cdn", 5, nil)
+	assert.NoError(t, err)
+}
+
+func TestTelemetryProviderHttpEndpointSubmitTelemetryEvents(t *testing.T) {
+	var requestsReceived int
+	var metricsReceived []telemetryMetric
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requestsReceived++
+		reqBody, err := ioutil.ReadAll(req.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, "some_id", req.Header.Get("billing_id"))
+		err = json.Unmarshal(reqBody, &metricsReceived)
+		assert.Nil(t, err)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	tph := TelemetryProviderHTTPEndpoint{
+		URL: fmt.Sprintf("%s/v1/metrics", api.URL),
+	}
+	events := []TelemetryEvent{
+		{Type: TelemetryEventPluginExecution, OpenAPIPluginVersion: "0.25.0"},
+		{Type: TelemetryEventResourceExecution, ProviderName: "cdn", ResourceName: "cdn_resource", TFOperation: TelemetryResourceOperationCreate},
+		{Type: TelemetryEventResourceOperation, ProviderName: "cdn", ResourceName: "cdn_resource", TFOperation: TelemetryResourceOperationCreate},
+	}
+	err := tph.SubmitTelemetryEvents(events, telemetryProviderConfigurationHTTPEndpoint{Headers: map[string]string{"billing_id": "some_id"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requestsReceived)
+	assert.Equal(t, []telemetryMetric{
+		{MetricType: metricTypeCounter, MetricName: "terraform.openapi_plugin_version.total_runs", Tags: []string{"openapi_plugin_version:0_25_0"}},
+		{MetricType: metricTypeCounter, MetricName: "terraform.provider", Tags: []string{"provider_name:cdn", "resource_name:cdn_resource", "terraform_operation:create"}},
+	}, metricsReceived)
+
+	// Resource operation events are not supported by the http endpoint so no request is sent
+	err = tph.SubmitTelemetryEvents(events[2:], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requestsReceived)
+
+	err = tph.SubmitTelemetryEvents(events, struct{}{})
+	assert.EqualError(t, err, "telemetryProviderConfiguration object not the expected one: telemetryProviderConfigurationHTTPEndpoint")
+}
+
 func TestGetTelemetryProviderConfiguration(t *testing.T) {
 	tp := TelemetryProviderHTTPEndpoint{
 		ProviderSchemaProperties: []string{"prop_name"},
@@ -0,0 +1,174 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/asaskevich/govalidator"
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"log"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	otlpMetricsPath          = "/v1/metrics"
+	otlpProtobufMediaType    = "application/x-protobuf"
+	otlpServiceName          = "terraform-provider-openapi"
+	otlpInstrumentationScope = "github.com/dikhan/terraform-provider-openapi"
+)
+
+// otlpDurationExplicitBounds are the bucket bounds (in milliseconds) used for the resource operation duration histogram
+var otlpDurationExplicitBounds = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000}
+
+// TelemetryProviderOTLP defines the configuration for an OpenTelemetry collector receiving metrics via OTLP (HTTP/protobuf).
+// This struct also implements the TelemetryProvider and TelemetryProviderOperationMetrics interfaces and ships metrics to the
+// following namespace by default <prefix>.terraform.* where '<prefix>' can be configured.
+type TelemetryProviderOTLP struct {
+	// Endpoint describes the OTLP HTTP endpoint of the collector (eg: http://localhost:4318). The '/v1/metrics' path is appended
+	// unless the endpoint already contains it
+	Endpoint string `yaml:"endpoint"`
+	// Prefix enables to append a prefix to the metrics pushed to the collector
+	Prefix string `yaml:"prefix,omitempty"`
+	// Headers defines static headers to be sent along with the metrics (eg: collector authentication headers)
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// ProviderSchemaProperties defines what specific provider configuration properties and their values that will be injected into
+	// metric API request headers. Values must match a real property name in provider schema configuration.
+	ProviderSchemaProperties []string `yaml:"provider_schema_properties,omitempty"`
+}
+
+// telemetryProviderConfigurationOTLP defines the specific telemetry configuration for the OTLP telemetry provider. This
+// struct is populated inside the GetTelemetryProviderConfiguration method given the resource data received.
+type telemetryProviderConfigurationOTLP struct {
+	Headers map[string]string
+}
+
+// Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider registration. If this
+// method returns an error the error will be logged but the telemetry will be disabled. Otherwise, the telemetry will be enabled
+// and the corresponding metrics will be shipped to the OTLP collector
+func (o TelemetryProviderOTLP) Validate() error {
+	if o.Endpoint == "" {
+		return errors.New("otlp telemetry configuration is missing a value for the 'endpoint property'")
+	}
+	if !govalidator.IsURL(o.Endpoint) {
+		return fmt.Errorf("otlp telemetry configuration does not have a valid endpoint '%s'", o.Endpoint)
+	}
+	return nil
+}
+
+// IncOpenAPIPluginVersionTotalRunsCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.openapi_plugin_version.total_runs'
+// with an attribute containing the 'openapi_plugin_version' used.
+func (o TelemetryProviderOTLP) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	pluginVersion := strings.Replace(openAPIPluginVersion, ".", "_", -1)
+	attributes := []otlpAttribute{{key: "openapi_plugin_version", value: pluginVersion}}
+	metric := encodeOTLPCounter(o.metricName("terraform.openapi_plugin_version.total_runs"), "1", 1, attributes, uint64(time.Now().UnixNano()))
+	return o.submitMetrics(telemetryProviderConfiguration, metric)
+}
+
+// IncServiceProviderResourceTotalRunsCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.provider'
+// with attributes containing the 'provider_name', 'resource_name', and 'terraform_operation' called.
+func (o TelemetryProviderOTLP) IncServiceProviderResourceTotalRunsCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	attributes := []otlpAttribute{{key: "provider_name", value: providerName}, {key: "resource_name", value: resourceName}, {key: "terraform_operation", value: string(tfOperation)}}
+	metric := encodeOTLPCounter(o.metricName("terraform.provider"), "1", 1, attributes, uint64(time.Now().UnixNano()))
+	return o.submitMetrics(telemetryProviderConfiguration, metric)
+}
+
+// SubmitServiceProviderResourceOperationMetrics will submit the resource operation duration (in milliseconds) to the histogram
+// '<prefix>.terraform.provider.operation.duration' and, if the operation failed, an increment to 1 the metric type counter
+// '<prefix>.terraform.provider.operation.errors'. Both metrics contain the 'provider_name', 'resource_name', 'terraform_operation'
+// and 'http_status_code' (if a response was received) attributes.
+func (o TelemetryProviderOTLP) SubmitServiceProviderResourceOperationMetrics(providerName, resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	attributes := []otlpAttribute{{key: "provider_name", value: providerName}, {key: "resource_name", value: resourceName}, {key: "terraform_operation", value: string(tfOperation)}}
+	if outcome.StatusCode > 0 {
+		attributes = append(attributes, otlpAttribute{key: "http_status_code", value: int64(outcome.StatusCode)})
+	}
+	now := time.Now()
+	durationMs := float64(outcome.Duration) / float64(time.Millisecond)
+	metrics := []*protobufEncoder{
+		encodeOTLPHistogram(o.metricName("terraform.provider.operation.duration"), "ms", durationMs, otlpDurationExplicitBounds, attributes, uint64(now.Add(-outcome.Duration).UnixNano()), uint64(now.UnixNano())),
+	}
+	if outcome.Failed() {
+		metrics = append(metrics, encodeOTLPCounter(o.metricName("terraform.provider.operation.errors"), "1", 1, attributes, uint64(now.UnixNano())))
+	}
+	return o.submitMetrics(telemetryProviderConfiguration, metrics...)
+}
+
+// IncTelemetryDroppedEventsCounter will submit an increment by the number of events dropped the metric type counter
+// '<prefix>.terraform.telemetry.dropped_events' with an attribute containing the 'provider_name'.
+func (o TelemetryProviderOTLP) IncTelemetryDroppedEventsCounter(providerName string, droppedEvents int64, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	attributes := []otlpAttribute{{key: "provider_name", value: providerName}}
+	metric := encodeOTLPCounter(o.metricName("terraform.telemetry.dropped_events"), "1", droppedEvents, attributes, uint64(time.Now().UnixNano()))
+	return o.submitMetrics(telemetryProviderConfiguration, metric)
+}
+
+// GetTelemetryProviderConfiguration returns a telemetryProviderConfigurationOTLP loaded with headers mapping to
+// the plugin configuration schema properties that match the ones specified in the TelemetryProviderOTLP ProviderSchemaProperties values
+func (o TelemetryProviderOTLP) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
+	tpConfig := telemetryProviderConfigurationOTLP{
+		Headers: map[string]string{},
+	}
+	for _, propSchemaName := range o.ProviderSchemaProperties {
+		propSchemaValue := data.Get(propSchemaName)
+		if propSchemaValue != nil {
+			tpConfig.Headers[propSchemaName] = propSchemaValue.(string)
+		}
+	}
+	return tpConfig
+}
+
+func (o TelemetryProviderOTLP) metricName(metricName string) string {
+	if o.Prefix != "" {
+		return fmt.Sprintf("%s.%s", o.Prefix, metricName)
+	}
+	return metricName
+}
+
+func (o TelemetryProviderOTLP) getMetricsURL() string {
+	endpoint := strings.TrimSuffix(o.Endpoint, "/")
+	if strings.HasSuffix(endpoint, otlpMetricsPath) {
+		return endpoint
+	}
+	return endpoint + otlpMetricsPath
+}
+
+func (o TelemetryProviderOTLP) submitMetrics(telemetryProviderConfiguration TelemetryProviderConfiguration, metrics ...*protobufEncoder) error {
+	var telemetryConfiguration telemetryProviderConfigurationOTLP
+	if telemetryProviderConfiguration != nil {
+		var ok bool
+		telemetryConfiguration, ok = telemetryProviderConfiguration.(telemetryProviderConfigurationOTLP)
+		if !ok {
+			return fmt.Errorf("telemetryProviderConfiguration object not the expected one: telemetryProviderConfigurationOTLP")
+		}
+	}
+	resourceAttributes := []otlpAttribute{{key: "service.name", value: otlpServiceName}, {key: "service.version", value: version.Version}}
+	body := encodeOTLPExportMetricsServiceRequest(resourceAttributes, otlpInstrumentationScope, version.Version, metrics)
+
+	metricsURL := o.getMetricsURL()
+	log.Printf("[INFO] otlp metrics to be submitted to %s (%d metrics)", metricsURL, len(metrics))
+	req, err := http.NewRequest(http.MethodPost, metricsURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(contentType, otlpProtobufMediaType)
+	req.Header.Set(userAgentHeader, version.BuildUserAgent(runtime.GOOS, runtime.GOARCH))
+	for headerName, headerValue := range o.Headers {
+		req.Header.Set(headerName, headerValue)
+	}
+	for schemaPropertyName, schemaPropertyValue := range telemetryConfiguration.Headers {
+		req.Header.Set(schemaPropertyName, schemaPropertyValue)
+	}
+	c := http.Client{}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("request POST %s failed. Response Error: '%s'", metricsURL, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("response returned from POST '%s' returned a non expected status code %d", metricsURL, resp.StatusCode)
+	}
+	log.Printf("[INFO] otlp metrics successfully submitted to %s", metricsURL)
+	return nil
+}
@@ -0,0 +1,264 @@
+package openapi
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// The OTLP metrics and traces payloads are encoded using the protocol buffers wire format. Only the subset of the OTLP
+// metrics data model (opentelemetry/proto/collector/metrics/v1/metrics_service.proto) used by the OTLP telemetry provider
+// and the subset of the OTLP traces data model (opentelemetry/proto/collector/trace/v1/trace_service.proto) used by the
+// tracer are supported, which avoids pulling in the OpenTelemetry generated protobuf definitions
+
+const (
+	protobufWireTypeVarint  = 0
+	protobufWireTypeFixed64 = 1
+	protobufWireTypeBytes   = 2
+)
+
+// otlpAggregationTemporalityDelta is the OTLP aggregation temporality used for all the metrics submitted. Each plugin
+// execution reports its own measurements so the collector is expected to aggregate them
+const otlpAggregationTemporalityDelta = 1
+
+// otlpStatusCodeOk and otlpStatusCodeError are the OTLP span status codes
+const (
+	otlpStatusCodeOk    = 1
+	otlpStatusCodeError = 2
+)
+
+// protobufEncoder is a minimal protocol buffers wire format encoder
+type protobufEncoder struct {
+	buf []byte
+}
+
+func (e *protobufEncoder) bytes() []byte {
+	return e.buf
+}
+
+func (e *protobufEncoder) appendTag(field int, wireType int) {
+	e.appendVarint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (e *protobufEncoder) appendVarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	e.buf = append(e.buf, b[:n]...)
+}
+
+func (e *protobufEncoder) appendFixed64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *protobufEncoder) varintField(field int, v uint64) {
+	e.appendTag(field, protobufWireTypeVarint)
+	e.appendVarint(v)
+}
+
+func (e *protobufEncoder) fixed64Field(field int, v uint64) {
+	e.appendTag(field, protobufWireTypeFixed64)
+	e.appendFixed64(v)
+}
+
+func (e *protobufEncoder) doubleField(field int, v float64) {
+	e.fixed64Field(field, math.Float64bits(v))
+}
+
+func (e *protobufEncoder) bytesField(field int, v []byte) {
+	e.appendTag(field, protobufWireTypeBytes)
+	e.appendVarint(uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+// stringField encodes the given string value; empty strings are skipped as they are the proto3 default value
+func (e *protobufEncoder) stringField(field int, v string) {
+	if v == "" {
+		return
+	}
+	e.bytesField(field, []byte(v))
+}
+
+func (e *protobufEncoder) messageField(field int, msg *protobufEncoder) {
+	e.bytesField(field, msg.bytes())
+}
+
+func (e *protobufEncoder) packedFixed64Field(field int, values []uint64) {
+	packed := &protobufEncoder{}
+	for _, v := range values {
+		packed.appendFixed64(v)
+	}
+	e.bytesField(field, packed.bytes())
+}
+
+func (e *protobufEncoder) packedDoubleField(field int, values []float64) {
+	packed := &protobufEncoder{}
+	for _, v := range values {
+		packed.appendFixed64(math.Float64bits(v))
+	}
+	e.bytesField(field, packed.bytes())
+}
+
+// otlpAttribute represents an OTLP KeyValue attribute. Only string and int64 values are supported
+type otlpAttribute struct {
+	key   string
+	value interface{}
+}
+
+// encodeOTLPAttribute encodes the attribute as an OTLP KeyValue message
+func encodeOTLPAttribute(attribute otlpAttribute) *protobufEncoder {
+	anyValue := &protobufEncoder{}
+	switch v := attribute.value.(type) {
+	case int64:
+		anyValue.varintField(3, uint64(v)) // int_value
+	case int:
+		anyValue.varintField(3, uint64(int64(v))) // int_value
+	case string:
+		anyValue.bytesField(1, []byte(v)) // string_value
+	}
+	keyValue := &protobufEncoder{}
+	keyValue.stringField(1, attribute.key)
+	keyValue.messageField(2, anyValue)
+	return keyValue
+}
+
+func appendOTLPAttributes(e *protobufEncoder, field int, attributes []otlpAttribute) {
+	for _, attribute := range attributes {
+		e.messageField(field, encodeOTLPAttribute(attribute))
+	}
+}
+
+// encodeOTLPCounter encodes a monotonic OTLP Sum metric containing a single data point with the given value
+func encodeOTLPCounter(name, unit string, value int64, attributes []otlpAttribute, timeUnixNano uint64) *protobufEncoder {
+	dataPoint := &protobufEncoder{}
+	dataPoint.fixed64Field(2, timeUnixNano)  // start_time_unix_nano
+	dataPoint.fixed64Field(3, timeUnixNano)  // time_unix_nano
+	dataPoint.fixed64Field(6, uint64(value)) // as_int
+	appendOTLPAttributes(dataPoint, 7, attributes)
+
+	sum := &protobufEncoder{}
+	sum.messageField(1, dataPoint)
+	sum.varintField(2, otlpAggregationTemporalityDelta)
+	sum.varintField(3, 1) // is_monotonic
+
+	metric := &protobufEncoder{}
+	metric.stringField(1, name)
+	metric.stringField(3, unit)
+	metric.messageField(7, sum)
+	return metric
+}
+
+// encodeOTLPHistogram encodes an OTLP Histogram metric containing a single data point with one observation of the given
+// value, which is counted in the corresponding bucket of the explicit bounds provided
+func encodeOTLPHistogram(name, unit string, value float64, explicitBounds []float64, attributes []otlpAttribute, startTimeUnixNano, timeUnixNano uint64) *protobufEncoder {
+	bucketCounts := make([]uint64, len(explicitBounds)+1)
+	bucketCounts[otlpHistogramBucketIndex(value, explicitBounds)] = 1
+
+	dataPoint := &protobufEncoder{}
+	dataPoint.fixed64Field(2, startTimeUnixNano) // start_time_unix_nano
+	dataPoint.fixed64Field(3, timeUnixNano)      // time_unix_nano
+	dataPoint.fixed64Field(4, 1)                 // count
+	dataPoint.doubleField(5, value)              // sum
+	dataPoint.packedFixed64Field(6, bucketCounts)
+	dataPoint.packedDoubleField(7, explicitBounds)
+	appendOTLPAttributes(dataPoint, 9, attributes)
+	dataPoint.doubleField(11, value) // min
+	dataPoint.doubleField(12, value) // max
+
+	histogram := &protobufEncoder{}
+	histogram.messageField(1, dataPoint)
+	histogram.varintField(2, otlpAggregationTemporalityDelta)
+
+	metric := &protobufEncoder{}
+	metric.stringField(1, name)
+	metric.stringField(3, unit)
+	metric.messageField(9, histogram)
+	return metric
+}
+
+// otlpHistogramBucketIndex returns the index of the bucket the value falls into. Buckets are upper bound inclusive and the
+// last bucket holds the values greater than the last explicit bound
+func otlpHistogramBucketIndex(value float64, explicitBounds []float64) int {
+	for idx, bound := range explicitBounds {
+		if value <= bound {
+			return idx
+		}
+	}
+	return len(explicitBounds)
+}
+
+// encodeOTLPExportMetricsServiceRequest encodes the given metrics as an OTLP ExportMetricsServiceRequest containing one
+// resource (described by the resource attributes) and one instrumentation scope
+func encodeOTLPExportMetricsServiceRequest(resourceAttributes []otlpAttribute, scopeName, scopeVersion string, metrics []*protobufEncoder) []byte {
+	resource := &protobufEncoder{}
+	appendOTLPAttributes(resource, 1, resourceAttributes)
+
+	scope := &protobufEncoder{}
+	scope.stringField(1, scopeName)
+	scope.stringField(2, scopeVersion)
+
+	scopeMetrics := &protobufEncoder{}
+	scopeMetrics.messageField(1, scope)
+	for _, metric := range metrics {
+		scopeMetrics.messageField(2, metric)
+	}
+
+	resourceMetrics := &protobufEncoder{}
+	resourceMetrics.messageField(1, resource)
+	resourceMetrics.messageField(2, scopeMetrics)
+
+	request := &protobufEncoder{}
+	request.messageField(1, resourceMetrics)
+	return request.bytes()
+}
+
+// encodeOTLPSpan encodes the given span as an OTLP Span message. Spans finished with an error are reported with the error
+// status code and the error as the status message
+func encodeOTLPSpan(span *traceSpan) *protobufEncoder {
+	status := &protobufEncoder{}
+	if span.err != nil {
+		status.stringField(2, span.err.Error()) // message
+		status.varintField(3, otlpStatusCodeError)
+	} else {
+		status.varintField(3, otlpStatusCodeOk)
+	}
+
+	e := &protobufEncoder{}
+	e.bytesField(1, span.traceID)
+	e.bytesField(2, span.spanID)
+	if !span.isRoot() {
+		e.bytesField(4, span.parentSpanID)
+	}
+	e.stringField(5, span.name)
+	e.varintField(6, uint64(span.kind))
+	e.fixed64Field(7, uint64(span.startTime.UnixNano())) // start_time_unix_nano
+	e.fixed64Field(8, uint64(span.endTime.UnixNano()))   // end_time_unix_nano
+	appendOTLPAttributes(e, 9, span.attributes)
+	e.messageField(15, status)
+	return e
+}
+
+// encodeOTLPExportTraceServiceRequest encodes the given spans as an OTLP ExportTraceServiceRequest containing one resource
+// (described by the resource attributes) and one instrumentation scope
+func encodeOTLPExportTraceServiceRequest(resourceAttributes []otlpAttribute, scopeName, scopeVersion string, spans []*traceSpan) []byte {
+	resource := &protobufEncoder{}
+	appendOTLPAttributes(resource, 1, resourceAttributes)
+
+	scope := &protobufEncoder{}
+	scope.stringField(1, scopeName)
+	scope.stringField(2, scopeVersion)
+
+	scopeSpans := &protobufEncoder{}
+	scopeSpans.messageField(1, scope)
+	for _, span := range spans {
+		scopeSpans.messageField(2, encodeOTLPSpan(span))
+	}
+
+	resourceSpans := &protobufEncoder{}
+	resourceSpans.messageField(1, resource)
+	resourceSpans.messageField(2, scopeSpans)
+
+	request := &protobufEncoder{}
+	request.messageField(1, resourceSpans)
+	return request.bytes()
+}
@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// protobufTestField is a raw protocol buffers field decoded by decodeProtobufTestMessage
+type protobufTestField struct {
+	varint  uint64
+	fixed64 uint64
+	bytes   []byte
+}
+
+// decodeProtobufTestMessage decodes the given protocol buffers message into its raw fields keyed by field number
+func decodeProtobufTestMessage(b []byte) (map[int][]protobufTestField, error) {
+	fields := map[int][]protobufTestField{}
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid tag")
+		}
+		b = b[n:]
+		field := protobufTestField{}
+		switch tag & 0x7 {
+		case protobufWireTypeVarint:
+			field.varint, n = binary.Uvarint(b)
+			if n <= 0 {
+				return nil, fmt.Errorf("invalid varint")
+			}
+			b = b[n:]
+		case protobufWireTypeFixed64:
+			if len(b) < 8 {
+				return nil, fmt.Errorf("invalid fixed64")
+			}
+			field.fixed64 = binary.LittleEndian.Uint64(b)
+			b = b[8:]
+		case protobufWireTypeBytes:
+			length, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < length {
+				return nil, fmt.Errorf("invalid length delimited field")
+			}
+			field.bytes = b[n : n+int(length)]
+			b = b[n+int(length):]
+		default:
+			return nil, fmt.Errorf("unsupported wire type %d", tag&0x7)
+		}
+		fields[int(tag>>3)] = append(fields[int(tag>>3)], field)
+	}
+	return fields, nil
+}
+
+// otlpTestMetric is the flattened representation of an OTLP metric containing a single data point
+type otlpTestMetric struct {
+	name         string
+	unit         string
+	kind         string
+	temporality  uint64
+	attributes   map[string]interface{}
+	intValue     int64
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+}
+
+// otlpTestRequest is the flattened representation of an OTLP ExportMetricsServiceRequest
+type otlpTestRequest struct {
+	resourceAttributes map[string]interface{}
+	scopeName          string
+	metrics            []otlpTestMetric
+}
+
+func decodeOTLPTestAttributes(t *testing.T, fields []protobufTestField) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for _, field := range fields {
+		keyValue, err := decodeProtobufTestMessage(field.bytes)
+		require.NoError(t, err)
+		anyValue, err := decodeProtobufTestMessage(keyValue[2][0].bytes)
+		require.NoError(t, err)
+		key := string(keyValue[1][0].bytes)
+		if v, ok := anyValue[1]; ok {
+			attributes[key] = string(v[0].bytes)
+		}
+		if v, ok := anyValue[3]; ok {
+			attributes[key] = int64(v[0].varint)
+		}
+	}
+	return attributes
+}
+
+// decodeOTLPTestRequest decodes the given OTLP ExportMetricsServiceRequest payload
+func decodeOTLPTestRequest(t *testing.T, body []byte) otlpTestRequest {
+	request, err := decodeProtobufTestMessage(body)
+	require.NoError(t, err)
+	require.Len(t, request[1], 1)
+	resourceMetrics, err := decodeProtobufTestMessage(request[1][0].bytes)
+	require.NoError(t, err)
+	resource, err := decodeProtobufTestMessage(resourceMetrics[1][0].bytes)
+	require.NoError(t, err)
+	scopeMetrics, err := decodeProtobufTestMessage(resourceMetrics[2][0].bytes)
+	require.NoError(t, err)
+	scope, err := decodeProtobufTestMessage(scopeMetrics[1][0].bytes)
+	require.NoError(t, err)
+
+	otlpRequest := otlpTestRequest{
+		resourceAttributes: decodeOTLPTestAttributes(t, resource[1]),
+		scopeName:          string(scope[1][0].bytes),
+	}
+	for _, metricField := range scopeMetrics[2] {
+		metric, err := decodeProtobufTestMessage(metricField.bytes)
+		require.NoError(t, err)
+		testMetric := otlpTestMetric{name: string(metric[1][0].bytes)}
+		if unit, ok := metric[3]; ok {
+			testMetric.unit = string(unit[0].bytes)
+		}
+		if sumField, ok := metric[7]; ok {
+			sum, err := decodeProtobufTestMessage(sumField[0].bytes)
+			require.NoError(t, err)
+			dataPoint, err := decodeProtobufTestMessage(sum[1][0].bytes)
+			require.NoError(t, err)
+			testMetric.kind = "sum"
+			testMetric.temporality = sum[2][0].varint
+			testMetric.intValue = int64(dataPoint[6][0].fixed64)
+			testMetric.attributes = decodeOTLPTestAttributes(t, dataPoint[7])
+		}
+		if histogramField, ok := metric[9]; ok {
+			histogram, err := decodeProtobufTestMessage(histogramField[0].bytes)
+			require.NoError(t, err)
+			dataPoint, err := decodeProtobufTestMessage(histogram[1][0].bytes)
+			require.NoError(t, err)
+			testMetric.kind = "histogram"
+			testMetric.temporality = histogram[2][0].varint
+			testMetric.count = dataPoint[4][0].fixed64
+			testMetric.sum = math.Float64frombits(dataPoint[5][0].fixed64)
+			packedBucketCounts := dataPoint[6][0].bytes
+			for i := 0; i < len(packedBucketCounts); i += 8 {
+				testMetric.bucketCounts = append(testMetric.bucketCounts, binary.LittleEndian.Uint64(packedBucketCounts[i:]))
+			}
+			testMetric.attributes = decodeOTLPTestAttributes(t, dataPoint[9])
+		}
+		otlpRequest.metrics = append(otlpRequest.metrics, testMetric)
+	}
+	return otlpRequest
+}
+
+func TestProtobufEncoder(t *testing.T) {
+	e := &protobufEncoder{}
+	e.varintField(1, 300)
+	e.stringField(2, "hello")
+	e.stringField(3, "") // empty strings are skipped
+	e.doubleField(4, 1.5)
+	e.fixed64Field(5, 7)
+	assert.Equal(t, []byte{0x08, 0xac, 0x02, 0x12, 0x05, 'h', 'e', 'l', 'l', 'o', 0x21, 0, 0, 0, 0, 0, 0, 0xf8, 0x3f, 0x29, 7, 0, 0, 0, 0, 0, 0, 0}, e.bytes())
+
+	fields, err := decodeProtobufTestMessage(e.bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(300), fields[1][0].varint)
+	assert.Equal(t, "hello", string(fields[2][0].bytes))
+	assert.NotContains(t, fields, 3)
+	assert.Equal(t, 1.5, math.Float64frombits(fields[4][0].fixed64))
+	assert.Equal(t, uint64(7), fields[5][0].fixed64)
+}
+
+func TestOTLPHistogramBucketIndex(t *testing.T) {
+	bounds := []float64{10, 100}
+	assert.Equal(t, 0, otlpHistogramBucketIndex(5, bounds))
+	assert.Equal(t, 0, otlpHistogramBucketIndex(10, bounds))
+	assert.Equal(t, 1, otlpHistogramBucketIndex(50, bounds))
+	assert.Equal(t, 2, otlpHistogramBucketIndex(500, bounds))
+}
+
+func TestEncodeOTLPExportMetricsServiceRequest(t *testing.T) {
+	attributes := []otlpAttribute{{key: "resource_name", value: "cdn_v1"}, {key: "http_status_code", value: int64(201)}}
+	metrics := []*protobufEncoder{
+		encodeOTLPCounter("terraform.provider", "1", 1, attributes, 1000),
+		encodeOTLPHistogram("terraform.provider.operation.duration", "ms", 42, []float64{10, 100}, attributes, 500, 1000),
+	}
+	body := encodeOTLPExportMetricsServiceRequest([]otlpAttribute{{key: "service.name", value: "my-service"}}, "my-scope", "1.0.0", metrics)
+
+	request := decodeOTLPTestRequest(t, body)
+	assert.Equal(t, map[string]interface{}{"service.name": "my-service"}, request.resourceAttributes)
+	assert.Equal(t, "my-scope", request.scopeName)
+	assert.Len(t, request.metrics, 2)
+
+	counter := request.metrics[0]
+	assert.Equal(t, "terraform.provider", counter.name)
+	assert.Equal(t, "sum", counter.kind)
+	assert.Equal(t, uint64(otlpAggregationTemporalityDelta), counter.temporality)
+	assert.Equal(t, int64(1), counter.intValue)
+	assert.Equal(t, map[string]interface{}{"resource_name": "cdn_v1", "http_status_code": int64(201)}, counter.attributes)
+
+	histogram := request.metrics[1]
+	assert.Equal(t, "terraform.provider.operation.duration", histogram.name)
+	assert.Equal(t, "ms", histogram.unit)
+	assert.Equal(t, "histogram", histogram.kind)
+	assert.Equal(t, uint64(otlpAggregationTemporalityDelta), histogram.temporality)
+	assert.Equal(t, uint64(1), histogram.count)
+	assert.Equal(t, float64(42), histogram.sum)
+	assert.Equal(t, []uint64{0, 1, 0}, histogram.bucketCounts)
+	assert.Equal(t, map[string]interface{}{"resource_name": "cdn_v1", "http_status_code": int64(201)}, histogram.attributes)
+}
@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// otlpReceiverStub is a local OTLP (HTTP/protobuf) receiver that records the requests received
+type otlpReceiverStub struct {
+	server         *httptest.Server
+	responseStatus int
+	mutex          sync.Mutex
+	paths          []string
+	headers        []http.Header
+	bodies         [][]byte
+}
+
+func newOTLPReceiverStub(responseStatus int) *otlpReceiverStub {
+	receiver := &otlpReceiverStub{responseStatus: responseStatus}
+	receiver.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		receiver.mutex.Lock()
+		receiver.paths = append(receiver.paths, req.URL.Path)
+		receiver.headers = append(receiver.headers, req.Header)
+		receiver.bodies = append(receiver.bodies, body)
+		receiver.mutex.Unlock()
+		rw.WriteHeader(receiver.responseStatus)
+	}))
+	return receiver
+}
+
+func (r *otlpReceiverStub) close() {
+	r.server.Close()
+}
+
+func TestTelemetryProviderOTLP_Validate(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		endpoint    string
+		expectedErr error
+	}{
+		{testName: "happy path", endpoint: "http://localhost:4318", expectedErr: nil},
+		{testName: "endpoint is missing", endpoint: "", expectedErr: errors.New("otlp telemetry configuration is missing a value for the 'endpoint property'")},
+		{testName: "endpoint is not valid", endpoint: "not valid", expectedErr: errors.New("otlp telemetry configuration does not have a valid endpoint 'not valid'")},
+	}
+	for _, tc := range testCases {
+		tp := TelemetryProviderOTLP{Endpoint: tc.endpoint}
+		err := tp.Validate()
+		assert.Equal(t, tc.expectedErr, err, tc.testName)
+	}
+}
+
+func TestTelemetryProviderOTLP_GetMetricsURL(t *testing.T) {
+	assert.Equal(t, "http://localhost:4318/v1/metrics", TelemetryProviderOTLP{Endpoint: "http://localhost:4318"}.getMetricsURL())
+	assert.Equal(t, "http://localhost:4318/v1/metrics", TelemetryProviderOTLP{Endpoint: "http://localhost:4318/"}.getMetricsURL())
+	assert.Equal(t, "http://localhost:4318/v1/metrics", TelemetryProviderOTLP{Endpoint: "http://localhost:4318/v1/metrics"}.getMetricsURL())
+}
+
+func TestTelemetryProviderOTLP_IncOpenAPIPluginVersionTotalRunsCounter(t *testing.T) {
+	receiver := newOTLPReceiverStub(http.StatusOK)
+	defer receiver.close()
+	tp := TelemetryProviderOTLP{
+		Endpoint: receiver.server.URL,
+		Prefix:   "prefix",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}
+	err := tp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", telemetryProviderConfigurationOTLP{Headers: map[string]string{"billing_id": "some_id"}})
+	require.NoError(t, err)
+
+	require.Len(t, receiver.bodies, 1)
+	assert.Equal(t, "/v1/metrics", receiver.paths[0])
+	assert.Equal(t, "application/x-protobuf", receiver.headers[0].Get(contentType))
+	assert.Contains(t, receiver.headers[0].Get(userAgentHeader), "OpenAPI Terraform Provider")
+	assert.Equal(t, "Bearer token", receiver.headers[0].Get("Authorization"))
+	assert.Equal(t, "some_id", receiver.headers[0].Get("billing_id"))
+
+	request := decodeOTLPTestRequest(t, receiver.bodies[0])
+	assert.Equal(t, map[string]interface{}{"service.name": "terraform-provider-openapi", "service.version": version.Version}, request.resourceAttributes)
+	require.Len(t, request.metrics, 1)
+	assert.Equal(t, "prefix.terraform.openapi_plugin_version.total_runs", request.metrics[0].name)
+	assert.Equal(t, "sum", request.metrics[0].kind)
+	assert.Equal(t, int64(1), request.metrics[0].intValue)
+	assert.Equal(t, map[string]interface{}{"openapi_plugin_version": "0_25_0"}, request.metrics[0].attributes)
+}
+
+func TestTelemetryProviderOTLP_IncServiceProviderResourceTotalRunsCounter(t *testing.T) {
+	receiver := newOTLPReceiverStub(http.StatusOK)
+	defer receiver.close()
+	tp := TelemetryProviderOTLP{Endpoint: receiver.server.URL}
+	err := tp.IncServiceProviderResourceTotalRunsCounter("providerName", "resourceName", TelemetryResourceOperationCreate, nil)
+	require.NoError(t, err)
+
+	require.Len(t, receiver.bodies, 1)
+	request := decodeOTLPTestRequest(t, receiver.bodies[0])
+	require.Len(t, request.metrics, 1)
+	assert.Equal(t, "terraform.provider", request.metrics[0].name)
+	assert.Equal(t, int64(1), request.metrics[0].intValue)
+	assert.Equal(t, map[string]interface{}{"provider_name": "providerName", "resource_name": "resourceName", "terraform_operation": "create"}, request.metrics[0].attributes)
+}
+
+func TestTelemetryProviderOTLP_IncTelemetryDroppedEventsCounter(t *testing.T) {
+	receiver := newOTLPReceiverStub(http.StatusOK)
+	defer receiver.close()
+	tp := TelemetryProviderOTLP{Endpoint: receiver.server.URL}
+	err := tp.IncTelemetryDroppedEventsCounter("providerName", 5, nil)
+	require.NoError(t, err)
+
+	require.Len(t, receiver.bodies, 1)
+	request := decodeOTLPTestRequest(t, receiver.bodies[0])
+	require.Len(t, request.metrics, 1)
+	assert.Equal(t, "terraform.telemetry.dropped_events", request.metrics[0].name)
+	assert.Equal(t, int64(5), request.metrics[0].intValue)
+	assert.Equal(t, map[string]interface{}{"provider_name": "providerName"}, request.metrics[0].attributes)
+}
+
+func TestTelemetryProviderOTLP_SubmitServiceProviderResourceOperationMetrics(t *testing.T) {
+	testCases := []struct {
+		testName            string
+		outcome             TelemetryResourceOperationOutcome
+		expectedMetricNames []string
+		expectedAttributes  map[string]interface{}
+	}{
+		{
+			testName:            "operation succeeded",
+			outcome:             TelemetryResourceOperationOutcome{Duration: 120 * time.Millisecond, StatusCode: http.StatusCreated},
+			expectedMetricNames: []string{"terraform.provider.operation.duration"},
+			expectedAttributes:  map[string]interface{}{"provider_name": "providerName", "resource_name": "resourceName", "terraform_operation": "create", "http_status_code": int64(201)},
+		},
+		{
+			testName:            "operation failed with a response from the API",
+			outcome:             TelemetryResourceOperationOutcome{Duration: 120 * time.Millisecond, StatusCode: http.StatusInternalServerError, Err: errors.New("some error")},
+			expectedMetricNames: []string{"terraform.provider.operation.duration", "terraform.provider.operation.errors"},
+			expectedAttributes:  map[string]interface{}{"provider_name": "providerName", "resource_name": "resourceName", "terraform_operation": "create", "http_status_code": int64(500)},
+		},
+		{
+			testName:            "operation failed without a response from the API",
+			outcome:             TelemetryResourceOperationOutcome{Duration: 120 * time.Millisecond, Err: errors.New("some error")},
+			expectedMetricNames: []string{"terraform.provider.operation.duration", "terraform.provider.operation.errors"},
+			expectedAttributes:  map[string]interface{}{"provider_name": "providerName", "resource_name": "resourceName", "terraform_operation": "create"},
+		},
+	}
+	for _, tc := range testCases {
+		receiver := newOTLPReceiverStub(http.StatusOK)
+		tp := TelemetryProviderOTLP{Endpoint: receiver.server.URL}
+		err := tp.SubmitServiceProviderResourceOperationMetrics("providerName", "resourceName", TelemetryResourceOperationCreate, tc.outcome, nil)
+		receiver.close()
+		require.NoError(t, err, tc.testName)
+
+		require.Len(t, receiver.bodies, 1, tc.testName)
+		request := decodeOTLPTestRequest(t, receiver.bodies[0])
+		var metricNames []string
+		for _, metric := range request.metrics {
+			metricNames = append(metricNames, metric.name)
+			assert.Equal(t, tc.expectedAttributes, metric.attributes, tc.testName)
+		}
+		assert.Equal(t, tc.expectedMetricNames, metricNames, tc.testName)
+		histogram := request.metrics[0]
+		assert.Equal(t, "histogram", histogram.kind, tc.testName)
+		assert.Equal(t, "ms", histogram.unit, tc.testName)
+		assert.Equal(t, uint64(1), histogram.count, tc.testName)
+		assert.Equal(t, float64(120), histogram.sum, tc.testName)
+	}
+}
+
+func TestTelemetryProviderOTLP_SubmitMetricsFailureScenarios(t *testing.T) {
+	receiver := newOTLPReceiverStub(http.StatusBadRequest)
+	defer receiver.close()
+	tp := TelemetryProviderOTLP{Endpoint: receiver.server.URL}
+
+	err := tp.IncServiceProviderResourceTotalRunsCounter("providerName", "resourceName", TelemetryResourceOperationCreate, nil)
+	assert.EqualError(t, err, "response returned from POST '"+receiver.server.URL+"/v1/metrics' returned a non expected status code 400")
+
+	err = tp.IncServiceProviderResourceTotalRunsCounter("providerName", "resourceName", TelemetryResourceOperationCreate, struct{}{})
+	assert.EqualError(t, err, "telemetryProviderConfiguration object not the expected one: telemetryProviderConfigurationOTLP")
+}
+
+func TestTelemetryProviderOTLP_GetTelemetryProviderConfiguration(t *testing.T) {
+	testSchema := newTestSchema(newStringSchemaDefinitionPropertyWithDefaults("billing_id", "", true, false, "some_id"))
+	tp := TelemetryProviderOTLP{ProviderSchemaProperties: []string{"billing_id"}}
+	telemetryConfig := tp.GetTelemetryProviderConfiguration(testSchema.getResourceData(t))
+	assert.Equal(t, telemetryProviderConfigurationOTLP{Headers: map[string]string{"billing_id": "some_id"}}, telemetryConfig)
+}
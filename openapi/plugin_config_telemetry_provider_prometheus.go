@@ -0,0 +1,243 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/asaskevich/govalidator"
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	prometheusDefaultJob          = "terraform_provider_openapi"
+	prometheusTextfileExtension   = ".prom"
+	prometheusExpositionMediaType = "text/plain; version=0.0.4"
+)
+
+// prometheusDurationBuckets are the histogram buckets upper bounds (in seconds) used for the resource operation durations
+var prometheusDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+// prometheusProcessRegistry holds the metrics submitted during the plugin execution that are pushed to the Pushgateway
+var prometheusProcessRegistry = newPrometheusMetricsRegistry()
+
+// prometheusTextfileMutex makes sure the textfile updates done concurrently by the plugin (eg: resources provisioned in
+// parallel) do not overwrite each other
+var prometheusTextfileMutex sync.Mutex
+
+// TelemetryProviderPrometheus defines the configuration for Prometheus. This struct also implements the TelemetryProvider and
+// TelemetryProviderOperationMetrics interfaces and writes the metrics in the Prometheus exposition format to a node exporter
+// textfile and/or pushes them to a Pushgateway. Metrics are named <prefix>_terraform_* where '<prefix>' can be configured.
+type TelemetryProviderPrometheus struct {
+	// TextfilePath describes the path of the file (with .prom extension) where the metrics are written to, to be collected
+	// by the node exporter textfile collector. Metrics already present in the file are accumulated across executions
+	TextfilePath string `yaml:"textfile_path,omitempty"`
+	// PushgatewayURL describes the Pushgateway URL where the metrics are pushed to (eg: http://pushgateway:9091)
+	PushgatewayURL string `yaml:"pushgateway_url,omitempty"`
+	// Job defines the job name used to group the metrics pushed to the Pushgateway. Defaults to 'terraform_provider_openapi'
+	Job string `yaml:"job,omitempty"`
+	// Prefix enables to append a prefix to the metrics names
+	Prefix string `yaml:"prefix,omitempty"`
+
+	// registry overrides the registry that holds the metrics pushed to the Pushgateway (used for testing purposes)
+	registry *prometheusMetricsRegistry
+}
+
+// Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider registration. If this
+// method returns an error the error will be logged but the telemetry will be disabled. Otherwise, the telemetry will be enabled
+// and the corresponding metrics will be written to the textfile and/or pushed to the Pushgateway
+func (p TelemetryProviderPrometheus) Validate() error {
+	if p.TextfilePath == "" && p.PushgatewayURL == "" {
+		return errors.New("prometheus telemetry configuration is missing a value for the 'textfile_path' or 'pushgateway_url' properties")
+	}
+	if p.TextfilePath != "" && filepath.Ext(p.TextfilePath) != prometheusTextfileExtension {
+		return fmt.Errorf("prometheus telemetry configuration 'textfile_path' must have the '%s' extension: '%s'", prometheusTextfileExtension, p.TextfilePath)
+	}
+	if p.PushgatewayURL != "" && !govalidator.IsURL(p.PushgatewayURL) {
+		return fmt.Errorf("prometheus telemetry configuration does not have a valid 'pushgateway_url' '%s'", p.PushgatewayURL)
+	}
+	return nil
+}
+
+// IncOpenAPIPluginVersionTotalRunsCounter will increment by 1 the counter '<prefix>_terraform_openapi_plugin_version_total_runs'
+// with a label containing the 'openapi_plugin_version' used.
+func (p TelemetryProviderPrometheus) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	pluginVersion := strings.Replace(openAPIPluginVersion, ".", "_", -1)
+	metrics := newPrometheusMetricsRegistry()
+	metrics.incCounter(p.metricName("terraform_openapi_plugin_version_total_runs"), "Total number of OpenAPI Terraform plugin executions",
+		[]prometheusLabel{{name: "openapi_plugin_version", value: pluginVersion}}, 1)
+	return p.submitMetrics(metrics)
+}
+
+// IncServiceProviderResourceTotalRunsCounter will increment by 1 the counter '<prefix>_terraform_provider_operations_total'
+// with labels containing the 'provider_name', 'resource_name', and 'terraform_operation' called.
+func (p TelemetryProviderPrometheus) IncServiceProviderResourceTotalRunsCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	metrics := newPrometheusMetricsRegistry()
+	metrics.incCounter(p.metricName("terraform_provider_operations_total"), "Total number of resource operations executed",
+		p.operationLabels(providerName, resourceName, tfOperation), 1)
+	return p.submitMetrics(metrics)
+}
+
+// SubmitServiceProviderResourceOperationMetrics will record the resource operation duration in the histogram
+// '<prefix>_terraform_provider_operation_duration_seconds', increment the counter '<prefix>_terraform_provider_operation_http_responses_total'
+// for the HTTP status code received (if any) and, if the operation failed, increment the counter '<prefix>_terraform_provider_operation_errors_total'.
+func (p TelemetryProviderPrometheus) SubmitServiceProviderResourceOperationMetrics(providerName, resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	labels := p.operationLabels(providerName, resourceName, tfOperation)
+	metrics := newPrometheusMetricsRegistry()
+	metrics.observeHistogram(p.metricName("terraform_provider_operation_duration_seconds"), "Duration of the resource operations in seconds",
+		labels, prometheusDurationBuckets, outcome.Duration.Seconds())
+	if outcome.StatusCode > 0 {
+		statusLabels := append(append([]prometheusLabel{}, labels...), prometheusLabel{name: "http_status_code", value: strconv.Itoa(outcome.StatusCode)})
+		metrics.incCounter(p.metricName("terraform_provider_operation_http_responses_total"), "Total number of HTTP responses received by status code",
+			statusLabels, 1)
+	}
+	if outcome.Failed() {
+		metrics.incCounter(p.metricName("terraform_provider_operation_errors_total"), "Total number of resource operations failed",
+			labels, 1)
+	}
+	return p.submitMetrics(metrics)
+}
+
+// IncTelemetryDroppedEventsCounter will increment by the number of events dropped the counter '<prefix>_terraform_telemetry_dropped_events_total'
+// with a label containing the 'provider_name'.
+func (p TelemetryProviderPrometheus) IncTelemetryDroppedEventsCounter(providerName string, droppedEvents int64, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	metrics := newPrometheusMetricsRegistry()
+	metrics.incCounter(p.metricName("terraform_telemetry_dropped_events_total"), "Total number of telemetry events dropped because the telemetry queue was full",
+		[]prometheusLabel{{name: "provider_name", value: providerName}}, float64(droppedEvents))
+	return p.submitMetrics(metrics)
+}
+
+// GetTelemetryProviderConfiguration returns nil as the Prometheus telemetry provider does not need any specific configuration
+// from the provider's resource data
+func (p TelemetryProviderPrometheus) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
+	return nil
+}
+
+func (p TelemetryProviderPrometheus) operationLabels(providerName, resourceName string, tfOperation TelemetryResourceOperation) []prometheusLabel {
+	return []prometheusLabel{{name: "provider_name", value: providerName}, {name: "resource_name", value: resourceName}, {name: "terraform_operation", value: string(tfOperation)}}
+}
+
+func (p TelemetryProviderPrometheus) metricName(metricName string) string {
+	if p.Prefix != "" {
+		return fmt.Sprintf("%s_%s", p.Prefix, metricName)
+	}
+	return metricName
+}
+
+func (p TelemetryProviderPrometheus) getRegistry() *prometheusMetricsRegistry {
+	if p.registry != nil {
+		return p.registry
+	}
+	return prometheusProcessRegistry
+}
+
+func (p TelemetryProviderPrometheus) getJob() string {
+	if p.Job != "" {
+		return p.Job
+	}
+	return prometheusDefaultJob
+}
+
+// submitMetrics writes the given metrics into the textfile and/or pushes them to the Pushgateway depending on what is configured
+func (p TelemetryProviderPrometheus) submitMetrics(metrics *prometheusMetricsRegistry) error {
+	var errs []string
+	if p.TextfilePath != "" {
+		if err := p.writeTextfile(metrics); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if p.PushgatewayURL != "" {
+		if err := p.push(metrics); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// writeTextfile adds the given metrics to the ones already present in the textfile. The file is replaced atomically so the
+// node exporter never reads a partially written file
+func (p TelemetryProviderPrometheus) writeTextfile(metrics *prometheusMetricsRegistry) error {
+	prometheusTextfileMutex.Lock()
+	defer prometheusTextfileMutex.Unlock()
+
+	textfileMetrics := newPrometheusMetricsRegistry()
+	content, err := ioutil.ReadFile(p.TextfilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read prometheus textfile '%s': %s", p.TextfilePath, err)
+	}
+	if err == nil {
+		textfileMetrics, err = parsePrometheusMetricsRegistry(bytes.NewReader(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse prometheus textfile '%s': %s", p.TextfilePath, err)
+		}
+	}
+	textfileMetrics.merge(metrics)
+
+	var buf bytes.Buffer
+	if err := textfileMetrics.write(&buf); err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile(filepath.Dir(p.TextfilePath), filepath.Base(p.TextfilePath)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write prometheus textfile '%s': %s", p.TextfilePath, err)
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(buf.Bytes()); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write prometheus textfile '%s': %s", p.TextfilePath, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write prometheus textfile '%s': %s", p.TextfilePath, err)
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write prometheus textfile '%s': %s", p.TextfilePath, err)
+	}
+	if err := os.Rename(tmpFile.Name(), p.TextfilePath); err != nil {
+		return fmt.Errorf("failed to write prometheus textfile '%s': %s", p.TextfilePath, err)
+	}
+	log.Printf("[INFO] prometheus metrics successfully written to %s", p.TextfilePath)
+	return nil
+}
+
+// push adds the given metrics to the ones submitted during the plugin execution and pushes all of them to the Pushgateway.
+// Metrics are pushed using POST so only the metrics with the same names are replaced in the job group
+func (p TelemetryProviderPrometheus) push(metrics *prometheusMetricsRegistry) error {
+	registry := p.getRegistry()
+	registry.merge(metrics)
+	var buf bytes.Buffer
+	if err := registry.write(&buf); err != nil {
+		return err
+	}
+	pushURL := fmt.Sprintf("%s/metrics/job/%s", strings.TrimSuffix(p.PushgatewayURL, "/"), url.PathEscape(p.getJob()))
+	req, err := http.NewRequest(http.MethodPost, pushURL, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(contentType, prometheusExpositionMediaType)
+	req.Header.Set(userAgentHeader, version.BuildUserAgent(runtime.GOOS, runtime.GOARCH))
+	c := http.Client{Timeout: telemetryTimeout * time.Second}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("request POST %s failed. Response Error: '%s'", pushURL, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("response returned from POST '%s' returned a non expected status code %d", pushURL, resp.StatusCode)
+	}
+	log.Printf("[INFO] prometheus metrics successfully pushed to %s", pushURL)
+	return nil
+}
@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type prometheusMetricType string
+
+const (
+	prometheusMetricTypeCounter   prometheusMetricType = "counter"
+	prometheusMetricTypeHistogram prometheusMetricType = "histogram"
+)
+
+// prometheusLabel represents a Prometheus label name/value pair
+type prometheusLabel struct {
+	name  string
+	value string
+}
+
+// prometheusMetricFamily holds the samples of a metric family. Samples are keyed by their series (metric name including
+// the labels, eg: my_metric{label="value"}) and kept in the order they were added
+type prometheusMetricFamily struct {
+	name    string
+	help    string
+	kind    prometheusMetricType
+	series  []string
+	samples map[string]float64
+}
+
+func (f *prometheusMetricFamily) add(series string, value float64) {
+	if _, exists := f.samples[series]; !exists {
+		f.series = append(f.series, series)
+	}
+	f.samples[series] += value
+}
+
+// prometheusMetricsRegistry keeps track of the metrics to be exposed in the Prometheus exposition format. All the samples
+// are cumulative (counters and histogram buckets, sums and counts) so registries can be merged by adding their values
+type prometheusMetricsRegistry struct {
+	mutex    sync.Mutex
+	families map[string]*prometheusMetricFamily
+}
+
+func newPrometheusMetricsRegistry() *prometheusMetricsRegistry {
+	return &prometheusMetricsRegistry{families: map[string]*prometheusMetricFamily{}}
+}
+
+func (r *prometheusMetricsRegistry) getFamily(name, help string, kind prometheusMetricType) *prometheusMetricFamily {
+	family, exists := r.families[name]
+	if !exists {
+		family = &prometheusMetricFamily{name: name, help: help, kind: kind, samples: map[string]float64{}}
+		r.families[name] = family
+	}
+	if family.help == "" {
+		family.help = help
+	}
+	return family
+}
+
+// incCounter increments by the given value the counter with the given labels
+func (r *prometheusMetricsRegistry) incCounter(name, help string, labels []prometheusLabel, value float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.getFamily(name, help, prometheusMetricTypeCounter).add(formatPrometheusSeries(name, labels), value)
+}
+
+// observeHistogram records the given observation in the histogram with the given labels and buckets upper bounds
+func (r *prometheusMetricsRegistry) observeHistogram(name, help string, labels []prometheusLabel, buckets []float64, value float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	family := r.getFamily(name, help, prometheusMetricTypeHistogram)
+	for _, bucket := range buckets {
+		observed := 0.0
+		if value <= bucket {
+			observed = 1
+		}
+		bucketLabels := append(append([]prometheusLabel{}, labels...), prometheusLabel{name: "le", value: strconv.FormatFloat(bucket, 'g', -1, 64)})
+		family.add(formatPrometheusSeries(name+"_bucket", bucketLabels), observed)
+	}
+	family.add(formatPrometheusSeries(name+"_bucket", append(append([]prometheusLabel{}, labels...), prometheusLabel{name: "le", value: "+Inf"})), 1)
+	family.add(formatPrometheusSeries(name+"_sum", labels), value)
+	family.add(formatPrometheusSeries(name+"_count", labels), 1)
+}
+
+// merge adds all the samples of the given registry into this registry
+func (r *prometheusMetricsRegistry) merge(other *prometheusMetricsRegistry) {
+	other.mutex.Lock()
+	defer other.mutex.Unlock()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, name := range other.familyNames() {
+		otherFamily := other.families[name]
+		family := r.getFamily(otherFamily.name, otherFamily.help, otherFamily.kind)
+		for _, series := range otherFamily.series {
+			family.add(series, otherFamily.samples[series])
+		}
+	}
+}
+
+func (r *prometheusMetricsRegistry) familyNames() []string {
+	var names []string
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// write writes the registry metrics in the Prometheus text exposition format
+func (r *prometheusMetricsRegistry) write(w io.Writer) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, name := range r.familyNames() {
+		family := r.families[name]
+		if family.help != "" {
+			if _, err := fmt.Fprintf(w, "# HELP %s %s\n", family.name, family.help); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "# TYPE %s %s\n", family.name, family.kind); err != nil {
+			return err
+		}
+		for _, series := range family.series {
+			if _, err := fmt.Fprintf(w, "%s %s\n", series, strconv.FormatFloat(family.samples[series], 'g', -1, 64)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parsePrometheusMetricsRegistry parses metrics in the Prometheus text exposition format as written by the registry. Samples
+// are assigned to the metric family declared by the preceding TYPE comment
+func parsePrometheusMetricsRegistry(reader io.Reader) (*prometheusMetricsRegistry, error) {
+	registry := newPrometheusMetricsRegistry()
+	var family *prometheusMetricFamily
+	helps := map[string]string{}
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			fields := strings.SplitN(line, " ", 4)
+			if len(fields) >= 3 && fields[1] == "HELP" {
+				if len(fields) == 4 {
+					helps[fields[2]] = fields[3]
+				}
+			}
+			if len(fields) == 4 && fields[1] == "TYPE" {
+				family = registry.getFamily(fields[2], helps[fields[2]], prometheusMetricType(fields[3]))
+			}
+			continue
+		}
+		idx := strings.LastIndex(line, " ")
+		if idx < 0 || family == nil {
+			return nil, fmt.Errorf("invalid prometheus metric line %d: '%s'", lineNumber, line)
+		}
+		value, err := strconv.ParseFloat(line[idx+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prometheus metric value in line %d: '%s'", lineNumber, line)
+		}
+		family.add(line[:idx], value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// formatPrometheusSeries returns the series for the given metric name and labels (eg: my_metric{label="value"})
+func formatPrometheusSeries(name string, labels []prometheusLabel) string {
+	if len(labels) == 0 {
+		return name
+	}
+	var formattedLabels []string
+	for _, label := range labels {
+		formattedLabels = append(formattedLabels, fmt.Sprintf(`%s="%s"`, label.name, escapePrometheusLabelValue(label.value)))
+	}
+	return fmt.Sprintf("%s{%s}", name, strings.Join(formattedLabels, ","))
+}
+
+func escapePrometheusLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusMetricsRegistryIncCounter(t *testing.T) {
+	registry := newPrometheusMetricsRegistry()
+	labels := []prometheusLabel{{name: "resource_name", value: "cdn_v1"}}
+	registry.incCounter("operations_total", "Total operations", labels, 1)
+	registry.incCounter("operations_total", "Total operations", labels, 1)
+	registry.incCounter("operations_total", "Total operations", []prometheusLabel{{name: "resource_name", value: `lb"v1`}}, 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, registry.write(&buf))
+	assert.Equal(t, `# HELP operations_total Total operations
+# TYPE operations_total counter
+operations_total{resource_name="cdn_v1"} 2
+operations_total{resource_name="lb\"v1"} 1
+`, buf.String())
+}
+
+func TestPrometheusMetricsRegistryObserveHistogram(t *testing.T) {
+	registry := newPrometheusMetricsRegistry()
+	labels := []prometheusLabel{{name: "resource_name", value: "cdn_v1"}}
+	registry.observeHistogram("duration_seconds", "", labels, []float64{0.5, 1}, 0.75)
+
+	var buf bytes.Buffer
+	require.NoError(t, registry.write(&buf))
+	assert.Equal(t, `# TYPE duration_seconds histogram
+duration_seconds_bucket{resource_name="cdn_v1",le="0.5"} 0
+duration_seconds_bucket{resource_name="cdn_v1",le="1"} 1
+duration_seconds_bucket{resource_name="cdn_v1",le="+Inf"} 1
+duration_seconds_sum{resource_name="cdn_v1"} 0.75
+duration_seconds_count{resource_name="cdn_v1"} 1
+`, buf.String())
+}
+
+func TestParsePrometheusMetricsRegistry(t *testing.T) {
+	existingMetrics := `# HELP operations_total Total operations
+# TYPE operations_total counter
+operations_total{resource_name="cdn_v1"} 2
+
+# TYPE duration_seconds histogram
+duration_seconds_bucket{le="1"} 1
+duration_seconds_bucket{le="+Inf"} 1
+duration_seconds_sum 0.5
+duration_seconds_count 1
+`
+	registry, err := parsePrometheusMetricsRegistry(strings.NewReader(existingMetrics))
+	require.NoError(t, err)
+
+	newMetrics := newPrometheusMetricsRegistry()
+	newMetrics.incCounter("operations_total", "Total operations", []prometheusLabel{{name: "resource_name", value: "cdn_v1"}}, 1)
+	newMetrics.observeHistogram("duration_seconds", "", nil, []float64{1}, 2)
+	newMetrics.incCounter("errors_total", "Total errors", nil, 1)
+	registry.merge(newMetrics)
+
+	var buf bytes.Buffer
+	require.NoError(t, registry.write(&buf))
+	assert.Equal(t, `# TYPE duration_seconds histogram
+duration_seconds_bucket{le="1"} 1
+duration_seconds_bucket{le="+Inf"} 2
+duration_seconds_sum 2.5
+duration_seconds_count 2
+# HELP errors_total Total errors
+# TYPE errors_total counter
+errors_total 1
+# HELP operations_total Total operations
+# TYPE operations_total counter
+operations_total{resource_name="cdn_v1"} 3
+`, buf.String())
+}
+
+func TestParsePrometheusMetricsRegistry_InvalidContent(t *testing.T) {
+	_, err := parsePrometheusMetricsRegistry(strings.NewReader("operations_total 1\n"))
+	assert.EqualError(t, err, "invalid prometheus metric line 1: 'operations_total 1'")
+
+	_, err = parsePrometheusMetricsRegistry(strings.NewReader("# TYPE operations_total counter\noperations_total one\n"))
+	assert.EqualError(t, err, "invalid prometheus metric value in line 2: 'operations_total one'")
+}
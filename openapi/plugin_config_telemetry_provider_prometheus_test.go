@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelemetryProviderPrometheus_Validate(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		tp          TelemetryProviderPrometheus
+		expectedErr error
+	}{
+		{testName: "textfile configured", tp: TelemetryProviderPrometheus{TextfilePath: "/var/lib/node_exporter/textfile/openapi.prom"}, expectedErr: nil},
+		{testName: "pushgateway configured", tp: TelemetryProviderPrometheus{PushgatewayURL: "http://pushgateway:9091"}, expectedErr: nil},
+		{testName: "textfile and pushgateway configured", tp: TelemetryProviderPrometheus{TextfilePath: "openapi.prom", PushgatewayURL: "http://pushgateway:9091"}, expectedErr: nil},
+		{testName: "nothing configured", tp: TelemetryProviderPrometheus{}, expectedErr: errors.New("prometheus telemetry configuration is missing a value for the 'textfile_path' or 'pushgateway_url' properties")},
+		{testName: "textfile with wrong extension", tp: TelemetryProviderPrometheus{TextfilePath: "openapi.txt"}, expectedErr: errors.New("prometheus telemetry configuration 'textfile_path' must have the '.prom' extension: 'openapi.txt'")},
+		{testName: "pushgateway url not valid", tp: TelemetryProviderPrometheus{PushgatewayURL: "not valid"}, expectedErr: errors.New("prometheus telemetry configuration does not have a valid 'pushgateway_url' 'not valid'")},
+	}
+	for _, tc := range testCases {
+		err := tc.tp.Validate()
+		assert.Equal(t, tc.expectedErr, err, tc.testName)
+	}
+}
+
+func TestTelemetryProviderPrometheus_Textfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	textfilePath := filepath.Join(dir, "openapi.prom")
+	tp := TelemetryProviderPrometheus{TextfilePath: textfilePath, Prefix: "openapi"}
+
+	require.NoError(t, tp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil))
+	require.NoError(t, tp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil))
+	require.NoError(t, tp.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationCreate, nil))
+	require.NoError(t, tp.SubmitServiceProviderResourceOperationMetrics("cdn", "cdn_v1", TelemetryResourceOperationCreate, TelemetryResourceOperationOutcome{Duration: 200 * time.Millisecond, StatusCode: http.StatusInternalServerError, Err: errors.New("some error")}, nil))
+	require.NoError(t, tp.IncTelemetryDroppedEventsCounter("cdn", 5, nil))
+
+	content, err := ioutil.ReadFile(textfilePath)
+	require.NoError(t, err)
+	metrics := string(content)
+	assert.Contains(t, metrics, "# TYPE openapi_terraform_openapi_plugin_version_total_runs counter\nopenapi_terraform_openapi_plugin_version_total_runs{openapi_plugin_version=\"0_25_0\"} 2\n")
+	assert.Contains(t, metrics, "openapi_terraform_provider_operations_total{provider_name=\"cdn\",resource_name=\"cdn_v1\",terraform_operation=\"create\"} 1\n")
+	assert.Contains(t, metrics, "openapi_terraform_provider_operation_http_responses_total{provider_name=\"cdn\",resource_name=\"cdn_v1\",terraform_operation=\"create\",http_status_code=\"500\"} 1\n")
+	assert.Contains(t, metrics, "openapi_terraform_provider_operation_errors_total{provider_name=\"cdn\",resource_name=\"cdn_v1\",terraform_operation=\"create\"} 1\n")
+	assert.Contains(t, metrics, "openapi_terraform_provider_operation_duration_seconds_bucket{provider_name=\"cdn\",resource_name=\"cdn_v1\",terraform_operation=\"create\",le=\"0.25\"} 1\n")
+	assert.Contains(t, metrics, "openapi_terraform_provider_operation_duration_seconds_count{provider_name=\"cdn\",resource_name=\"cdn_v1\",terraform_operation=\"create\"} 1\n")
+	assert.Contains(t, metrics, "openapi_terraform_telemetry_dropped_events_total{provider_name=\"cdn\"} 5\n")
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1, "temporary files should be cleaned up")
+}
+
+func TestTelemetryProviderPrometheus_Textfile_InvalidContent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	textfilePath := filepath.Join(dir, "openapi.prom")
+	require.NoError(t, ioutil.WriteFile(textfilePath, []byte("not valid\n"), 0644))
+	tp := TelemetryProviderPrometheus{TextfilePath: textfilePath}
+
+	err = tp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil)
+	assert.EqualError(t, err, "failed to parse prometheus textfile '"+textfilePath+"': invalid prometheus metric line 1: 'not valid'")
+}
+
+func TestTelemetryProviderPrometheus_Pushgateway(t *testing.T) {
+	var pathsReceived []string
+	var bodiesReceived []string
+	var contentTypeReceived string
+	pushgateway := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		body, _ := ioutil.ReadAll(req.Body)
+		pathsReceived = append(pathsReceived, req.URL.Path)
+		bodiesReceived = append(bodiesReceived, string(body))
+		contentTypeReceived = req.Header.Get(contentType)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer pushgateway.Close()
+	tp := TelemetryProviderPrometheus{PushgatewayURL: pushgateway.URL, Job: "my_job", registry: newPrometheusMetricsRegistry()}
+
+	require.NoError(t, tp.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationCreate, nil))
+	require.NoError(t, tp.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationCreate, nil))
+
+	assert.Equal(t, []string{"/metrics/job/my_job", "/metrics/job/my_job"}, pathsReceived)
+	assert.Equal(t, "text/plain; version=0.0.4", contentTypeReceived)
+	// the metrics submitted during the plugin execution are accumulated and pushed together
+	assert.Contains(t, bodiesReceived[1], "terraform_provider_operations_total{provider_name=\"cdn\",resource_name=\"cdn_v1\",terraform_operation=\"create\"} 2\n")
+}
+
+func TestTelemetryProviderPrometheus_Pushgateway_NonExpectedStatusCode(t *testing.T) {
+	pushgateway := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+	}))
+	defer pushgateway.Close()
+	tp := TelemetryProviderPrometheus{PushgatewayURL: pushgateway.URL, registry: newPrometheusMetricsRegistry()}
+
+	err := tp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil)
+	assert.EqualError(t, err, "response returned from POST '"+pushgateway.URL+"/metrics/job/terraform_provider_openapi' returned a non expected status code 400")
+}
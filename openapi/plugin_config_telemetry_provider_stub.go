@@ -9,6 +9,8 @@ type telemetryProviderStub struct {
 	providerNameReceived         string
 	resourceNameReceived         string
 	tfOperationReceived          TelemetryResourceOperation
+	outcomeReceived              *TelemetryResourceOperationOutcome
+	droppedEventsReceived        int64
 	telemetryProviderConfig      TelemetryProviderConfiguration
 }
 
@@ -34,6 +36,20 @@ func (t *telemetryProviderStub) IncServiceProviderResourceTotalRunsCounter(provi
 	return nil
 }
 
+func (t *telemetryProviderStub) SubmitServiceProviderResourceOperationMetrics(providerName, resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	t.providerNameReceived = providerName
+	t.resourceNameReceived = resourceName
+	t.tfOperationReceived = tfOperation
+	t.outcomeReceived = &outcome
+	return nil
+}
+
+func (t *telemetryProviderStub) IncTelemetryDroppedEventsCounter(providerName string, droppedEvents int64, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	t.providerNameReceived = providerName
+	t.droppedEventsReceived += droppedEvents
+	return nil
+}
+
 func (t *telemetryProviderStub) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
 	return t.telemetryProviderConfig
 }
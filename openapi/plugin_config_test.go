@@ -9,6 +9,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const providerName = "test"
@@ -239,6 +242,70 @@ services:
 		})
 	})
 
+	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration file containing otlp telemetry configured and a service called 'test'", t, func() {
+		expectedEndpoint := "http://localhost:4318"
+		pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+      telemetry:
+        otlp:
+          endpoint: %s
+          prefix: openapi
+          headers:
+            Authorization: Bearer token
+      swagger-url: %s`, providerName, expectedEndpoint, otfVarSwaggerURLValue)
+		configReader := strings.NewReader(pluginConfig)
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: configReader,
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the serviceConfiguration contains the expected otlp telemetry configuration", func() {
+				expectedOTLPProvider := &TelemetryProviderOTLP{
+					Endpoint: expectedEndpoint,
+					Prefix:   "openapi",
+					Headers:  map[string]string{"Authorization": "Bearer token"},
+				}
+				So(serviceConfiguration.GetTelemetryConfiguration(), ShouldResemble, TelemetryProvider(expectedOTLPProvider))
+			})
+		})
+	})
+
+	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration file containing prometheus telemetry configured and a service called 'test'", t, func() {
+		pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+      telemetry:
+        prometheus:
+          textfile_path: /var/lib/node_exporter/textfile/openapi.prom
+          pushgateway_url: http://pushgateway:9091
+          job: my_job
+      swagger-url: %s`, providerName, otfVarSwaggerURLValue)
+		configReader := strings.NewReader(pluginConfig)
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: configReader,
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the serviceConfiguration contains the expected prometheus telemetry configuration", func() {
+				expectedPrometheusProvider := &TelemetryProviderPrometheus{
+					TextfilePath:   "/var/lib/node_exporter/textfile/openapi.prom",
+					PushgatewayURL: "http://pushgateway:9091",
+					Job:            "my_job",
+				}
+				So(serviceConfiguration.GetTelemetryConfiguration(), ShouldResemble, TelemetryProvider(expectedPrometheusProvider))
+			})
+		})
+	})
+
 	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration that DOES NOT contain a service called 'test'", t, func() {
 		pluginConfig := fmt.Sprintf(`version: '1'
 services:
@@ -321,6 +388,152 @@ services:
 		})
 	})
 
+	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration file in version 2 containing a service called 'test'", t, func() {
+		pluginConfig := fmt.Sprintf(`version: '2'
+services:
+    %s:
+      swagger:
+        url: %s
+        insecure_skip_verify: true
+      retry:
+        max_attempts: 3`, providerName, otfVarSwaggerURLValue)
+		configReader := strings.NewReader(pluginConfig)
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: configReader,
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the serviceConfiguration returned should be a version 2 service configuration", func() {
+				So(serviceConfiguration, ShouldHaveSameTypeAs, &ServiceConfigV2{})
+			})
+			Convey("And the serviceConfiguration returned should contain the swagger configuration", func() {
+				So(serviceConfiguration.GetSwaggerURL(), ShouldEqual, otfVarSwaggerURLValue)
+				So(serviceConfiguration.IsInsecureSkipVerifyEnabled(), ShouldBeTrue)
+			})
+			Convey("And the serviceConfiguration returned should contain the retry configuration", func() {
+				So(serviceConfiguration.GetRetryConfiguration(), ShouldResemble, &RetryConfig{MaxAttempts: 3})
+			})
+		})
+	})
+
+	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration file in version 2 containing an unknown property", t, func() {
+		pluginConfig := fmt.Sprintf(`version: '2'
+services:
+    %s:
+      swagger:
+        url: %s
+      swagger-url: %s`, providerName, otfVarSwaggerURLValue, otfVarSwaggerURLValue)
+		configReader := strings.NewReader(pluginConfig)
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: configReader,
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			_, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("And the error should mention the unknown property", func() {
+				So(err.Error(), should.ContainSubstring, "failed to unmarshall terraform-provider-openapi.yaml configuration file")
+				So(err.Error(), should.ContainSubstring, "field swagger-url not found in type openapi.ServiceConfigV2")
+			})
+		})
+	})
+
+	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration file in version 1 containing an unknown property", t, func() {
+		pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+      swagger-url: %s
+      unknown: value`, providerName, otfVarSwaggerURLValue)
+		configReader := strings.NewReader(pluginConfig)
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: configReader,
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be nil as version 1 ignores unknown properties", func() {
+				So(err, ShouldBeNil)
+				So(serviceConfiguration.GetSwaggerURL(), ShouldEqual, otfVarSwaggerURLValue)
+			})
+		})
+	})
+}
+
+func TestGetStaticServiceProviderConfiguration(t *testing.T) {
+	testCases := []struct {
+		name                string
+		pluginConfig        string
+		expectedRetryConfig *RetryConfig
+		expectedNil         bool
+		expectedErr         string
+	}{
+		{
+			name:        "plugin configuration file not present",
+			expectedNil: true,
+		},
+		{
+			name: "plugin configuration file containing the service without swagger settings",
+			pluginConfig: fmt.Sprintf(`version: '1'
+services:
+  %s:
+    retry:
+      max_attempts: 3`, providerName),
+			expectedRetryConfig: &RetryConfig{MaxAttempts: 3},
+		},
+		{
+			name: "plugin configuration file containing the service with swagger settings which are ignored",
+			pluginConfig: fmt.Sprintf(`version: '1'
+services:
+  %s:
+    swagger-url: http://some-api/swagger.yaml
+    retry:
+      max_attempts: 3`, providerName),
+			expectedRetryConfig: &RetryConfig{MaxAttempts: 3},
+		},
+		{
+			name: "plugin configuration file not containing the service",
+			pluginConfig: `version: '1'
+services:
+  other:
+    swagger-url: http://some-api/swagger.yaml`,
+			expectedNil: true,
+		},
+		{
+			name: "plugin configuration file containing the service with settings not valid",
+			pluginConfig: fmt.Sprintf(`version: '1'
+services:
+  %s:
+    retry:
+      max_attempts: -1`, providerName),
+			expectedErr: "service configuration for 'test' not valid: retry configuration 'max_attempts' value '-1' not valid, the value must not be negative",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pluginConfiguration := PluginConfiguration{ProviderName: providerName}
+			if tc.pluginConfig != "" {
+				pluginConfiguration.Configuration = strings.NewReader(tc.pluginConfig)
+			}
+			serviceConfiguration, err := pluginConfiguration.getStaticServiceConfiguration()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectedNil {
+				assert.Nil(t, serviceConfiguration)
+				return
+			}
+			require.NotNil(t, serviceConfiguration)
+			assert.Equal(t, tc.expectedRetryConfig, serviceConfiguration.GetRetryConfiguration())
+		})
+	}
 }
 
 func udpServer(metricChannel chan string) (net.PacketConn, string, string) {
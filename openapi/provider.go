@@ -3,8 +3,6 @@ package openapi
 import (
 	"net/http"
 
-	"crypto/tls"
-
 	"fmt"
 	"log"
 
@@ -38,7 +36,13 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 
 	log.Printf("[DEBUG] service configuration = %+v", serviceConfiguration)
 
-	openAPISpecAnalyser, err := CreateSpecAnalyser(specAnalyserV2, serviceConfiguration.GetSwaggerURL())
+	var openAPISpecAnalyser SpecAnalyser
+	var err error
+	if swaggerSpecs := serviceConfiguration.GetSwaggerSpecs(); len(swaggerSpecs) > 0 {
+		openAPISpecAnalyser, err = CreateCompositeSpecAnalyser(specAnalyserV2, swaggerSpecs)
+	} else {
+		openAPISpecAnalyser, err = CreateSpecAnalyserWithOverlays(specAnalyserV2, serviceConfiguration.GetSwaggerURL(), serviceConfiguration.GetSwaggerOverlays())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("plugin OpenAPI spec analyser error: %s", err)
 	}
@@ -69,14 +73,25 @@ func getServiceConfiguration(providerName string) (ServiceConfiguration, error)
 		return nil, err
 	}
 
-	if serviceConfiguration.IsInsecureSkipVerifyEnabled() {
-		tr := http.DefaultTransport.(*http.Transport)
-		tr.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
+	// The TLS configuration from the plugin configuration file is applied to the default transport so the swagger file
+	// (and any external refs) are retrieved using the same TLS settings as the ones used when calling the API
+	if serviceConfiguration.IsInsecureSkipVerifyEnabled() || !serviceConfiguration.GetTLSConfiguration().isEmpty() {
+		tlsConfig, err := serviceConfiguration.GetTLSConfiguration().buildTLSConfig(serviceConfiguration.IsInsecureSkipVerifyEnabled())
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure TLS for provider '%s': %s", providerName, err)
 		}
+		tr := http.DefaultTransport.(*http.Transport)
+		tr.TLSClientConfig = tlsConfig
+	}
+
+	if serviceConfiguration.IsInsecureSkipVerifyEnabled() {
 		log.Printf("[WARN] Provider '%s' is using insecure skip verify. Please make sure you trust the aforementioned server hosting the swagger file. Otherwise, it's highly recommended avoiding the use of OTF_INSECURE_SKIP_VERIFY env variable when executing this provider", providerName)
 	}
 
-	log.Printf("[INFO] Provider %s is using the following swagger file: %s", providerName, serviceConfiguration.GetSwaggerURL())
+	if swaggerSpecs := serviceConfiguration.GetSwaggerSpecs(); len(swaggerSpecs) > 0 {
+		log.Printf("[INFO] Provider %s is composed of the following swagger files: %+v", providerName, swaggerSpecs)
+	} else {
+		log.Printf("[INFO] Provider %s is using the following swagger file: %s", providerName, serviceConfiguration.GetSwaggerURL())
+	}
 	return serviceConfiguration, nil
 }
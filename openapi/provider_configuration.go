@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"github.com/dikhan/http_goclient"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -15,11 +16,15 @@ const providerPropertyEndPoints = "endpoints"
 // file. These headers may be sent as part of the HTTP calls if the resource requires them (as specified in the swagger doc)
 // - Endpoints contains the endpoints configured by the user, which effectively will override the default host set in the swagger file
 // - Region contains the region if user provided value for it (only supported for multi-region providers)
+// - TLS contains the TLS settings provided by the user (e,g: CA bundle, client cert/key for mutual TLS, min TLS version)
+// - RateLimit contains the client side rate limit settings provided by the user
 type providerConfiguration struct {
 	Headers                   map[string]string
 	SecuritySchemaDefinitions map[string]specAPIKeyAuthenticator
 	Endpoints                 map[string]string
 	Region                    string
+	TLS                       *TLSConfig
+	RateLimit                 *RateLimitConfig
 }
 
 // createProviderConfig returns a providerConfiguration populated with the values provided by the user in the provider's terraform
@@ -68,6 +73,9 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 		providerConfiguration.Endpoints = providerConfigurationEndPoints.configureEndpoints(data)
 	}
 
+	providerConfiguration.TLS = configureTLS(data)
+	providerConfiguration.RateLimit = configureRateLimit(data)
+
 	return providerConfiguration, nil
 }
 
@@ -76,6 +84,17 @@ func (p *providerConfiguration) getAuthenticatorFor(s SpecSecurityScheme) specAP
 	return p.SecuritySchemaDefinitions[securitySchemeConfigName]
 }
 
+// configureAuthenticatorsHTTPClient makes sure the authenticators that perform HTTP calls on their own (e,g: refresh token
+// authenticator) use the given http client, so the same TLS settings apply to them too
+func (p *providerConfiguration) configureAuthenticatorsHTTPClient(httpClient http_goclient.HttpClientIface) {
+	for name, authenticator := range p.SecuritySchemaDefinitions {
+		if refreshTokenAuthenticator, ok := authenticator.(apiRefreshTokenAuthenticator); ok {
+			refreshTokenAuthenticator.httpClient = httpClient
+			p.SecuritySchemaDefinitions[name] = refreshTokenAuthenticator
+		}
+	}
+}
+
 func (p *providerConfiguration) getHeaderValueFor(s SpecHeaderParam) string {
 	headerConfigName := s.GetHeaderTerraformConfigurationName()
 	return p.Headers[headerConfigName]
@@ -131,7 +131,7 @@ func TestEndpointsValidateFunc(t *testing.T) {
 //				headers: SpecHeaderParameters{},
 //				security: &specSecurityStub{
 //					securityDefinitions:   &SpecSecurityDefinitions{},
-//					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+//					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{}),
 //				},
 //			},
 //		}
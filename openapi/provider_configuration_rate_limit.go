@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const providerPropertyRateLimitRequestsPerSecond = "rate_limit_requests_per_second"
+const providerPropertyRateLimitBurst = "rate_limit_burst"
+
+// rateLimitSchema returns the provider's schema properties that enable users to configure the client side rate limit
+// applied to the API requests. Values provided in the provider's terraform configuration take preference over the ones
+// set in the plugin configuration file and the OpenAPI document
+func rateLimitSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		providerPropertyRateLimitRequestsPerSecond: {
+			Type:         schema.TypeFloat,
+			Optional:     true,
+			Description:  "Max number of API requests per second performed against each API host",
+			ValidateFunc: validateRateLimitProperty,
+		},
+		providerPropertyRateLimitBurst: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "Max number of API requests that can be performed at once against each API host",
+			ValidateFunc: validateRateLimitProperty,
+		},
+	}
+}
+
+// validateRateLimitProperty validates the rate limit values provided in the provider's terraform configuration following
+// the same rules as RateLimitConfig.Validate. Zero is rejected too as it would silently disable the rate limit; the
+// property must be omitted instead
+func validateRateLimitProperty(value interface{}, key string) (warns []string, errs []error) {
+	rateLimit := &RateLimitConfig{}
+	isZero := false
+	switch v := value.(type) {
+	case float64:
+		rateLimit.RequestsPerSecond = v
+		isZero = v == 0
+	case int:
+		rateLimit.Burst = v
+		isZero = v == 0
+	}
+	if rateLimit.Validate() != nil || isZero {
+		return nil, []error{fmt.Errorf("property '%s' value '%v' not valid, the value must be greater than zero", key, value)}
+	}
+	return nil, nil
+}
+
+// configureRateLimit returns a RateLimitConfig populated with the rate limit values provided by the user in the provider's
+// terraform configuration
+func configureRateLimit(data *schema.ResourceData) *RateLimitConfig {
+	rateLimit := &RateLimitConfig{}
+	if value := data.Get(providerPropertyRateLimitRequestsPerSecond); value != nil {
+		rateLimit.RequestsPerSecond = value.(float64)
+	}
+	if value := data.Get(providerPropertyRateLimitBurst); value != nil {
+		rateLimit.Burst = value.(int)
+	}
+	return rateLimit
+}
@@ -0,0 +1,62 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureRateLimit(t *testing.T) {
+	Convey("Given a provider schema data containing the rate limit properties", t, func() {
+		data := schema.TestResourceDataRaw(t, rateLimitSchema(), map[string]interface{}{
+			providerPropertyRateLimitRequestsPerSecond: 2.5,
+			providerPropertyRateLimitBurst:             5,
+		})
+		Convey("When configureRateLimit is called", func() {
+			rateLimit := configureRateLimit(data)
+			Convey("Then the rate limit returned should contain the expected values", func() {
+				So(rateLimit, ShouldResemble, &RateLimitConfig{RequestsPerSecond: 2.5, Burst: 5})
+			})
+		})
+	})
+	Convey("Given a provider schema data that does not contain the rate limit properties", t, func() {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+		Convey("When configureRateLimit is called", func() {
+			rateLimit := configureRateLimit(data)
+			Convey("Then the rate limit returned should be empty", func() {
+				So(rateLimit.isEmpty(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestValidateRateLimitProperty(t *testing.T) {
+	testCases := []struct {
+		name          string
+		key           string
+		value         interface{}
+		expectedError string
+	}{
+		{name: "valid requests per second", key: providerPropertyRateLimitRequestsPerSecond, value: 0.5},
+		{name: "valid burst", key: providerPropertyRateLimitBurst, value: 10},
+		{name: "negative requests per second", key: providerPropertyRateLimitRequestsPerSecond, value: -1.5, expectedError: "property 'rate_limit_requests_per_second' value '-1.5' not valid, the value must be greater than zero"},
+		{name: "zero requests per second", key: providerPropertyRateLimitRequestsPerSecond, value: 0.0, expectedError: "property 'rate_limit_requests_per_second' value '0' not valid, the value must be greater than zero"},
+		{name: "negative burst", key: providerPropertyRateLimitBurst, value: -2, expectedError: "property 'rate_limit_burst' value '-2' not valid, the value must be greater than zero"},
+		{name: "zero burst", key: providerPropertyRateLimitBurst, value: 0, expectedError: "property 'rate_limit_burst' value '0' not valid, the value must be greater than zero"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			warns, errs := rateLimitSchema()[tc.key].ValidateFunc(tc.value, tc.key)
+			assert.Empty(t, warns)
+			if tc.expectedError == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.EqualError(t, errs[0], tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// operationSecurityRequirements contains the security requirements of a resource operation that override the global ones
+type operationSecurityRequirements struct {
+	// operation describes the operation the security requirements belong to, e,g: POST cdns_v1
+	operation            string
+	securityRequirements SpecSecurityRequirements
+}
+
+// getOperationsSecurityRequirements returns the security requirements of the resources and data sources operations that
+// override the global security requirements. Ignored resources are not taken into account
+func getOperationsSecurityRequirements(specAnalyser SpecAnalyser) ([]operationSecurityRequirements, error) {
+	resources, err := specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	var operationsSecurityRequirements []operationSecurityRequirements
+	for _, resource := range append(resources, specAnalyser.GetTerraformCompliantDataSources()...) {
+		if resource.shouldIgnoreResource() {
+			continue
+		}
+		for _, operation := range getResourceMethodOperations(resource) {
+			if len(operation.operation.SecurityRequirements) == 0 {
+				continue
+			}
+			operationsSecurityRequirements = append(operationsSecurityRequirements, operationSecurityRequirements{
+				operation:            fmt.Sprintf("%s %s", operation.method, resource.getResourceName()),
+				securityRequirements: operation.operation.SecurityRequirements,
+			})
+		}
+	}
+	return operationsSecurityRequirements, nil
+}
+
+// resourceMethodOperation contains a resource operation along with the method it is performed with (LIST for the list
+// operation used by data sources)
+type resourceMethodOperation struct {
+	method    string
+	operation *specResourceOperation
+}
+
+// getResourceMethodOperations returns the operations defined for the given resource along with their methods
+func getResourceMethodOperations(resource SpecResource) []resourceMethodOperation {
+	operations := resource.getResourceOperations()
+	var methodOperations []resourceMethodOperation
+	for _, operation := range []resourceMethodOperation{{http.MethodPost, operations.Post}, {http.MethodGet, operations.Get}, {"LIST", operations.List}, {http.MethodPut, operations.Put}, {http.MethodDelete, operations.Delete}} {
+		if operation.operation != nil {
+			methodOperations = append(methodOperations, operation)
+		}
+	}
+	return methodOperations
+}
+
+// createSecurityDefinitionValidateFunc returns the ValidateFunc of the provider property of the given security definition,
+// which raises plan time warnings about the global and operation level security requirements using the security
+// definition that can not be used:
+// - the property is configured with an empty value
+// - the security requirements refer to security schemes that are not defined as apiKey security definitions
+// Terraform validates the provider properties one at a time (and only the ones configured), hence the security requirements
+// that can not be satisfied because other properties are not configured are reported when planning the resources using
+// them (see validateResourceSecurityRequirements)
+func createSecurityDefinitionValidateFunc(securityDefinition SpecSecurityDefinition, securityDefinitions *SpecSecurityDefinitions, securityRequirements SpecSecurityRequirements) schema.SchemaValidateFunc {
+	var usableSecurityRequirements, unusableSecurityRequirements SpecSecurityRequirements
+	var undefinedSecuritySchemes []string
+	for _, securitySchemes := range securityRequirements {
+		if !securitySchemes.securitySchemeExists(securityDefinition) {
+			continue
+		}
+		undefined := getUndefinedSecuritySchemes(securitySchemes, securityDefinitions)
+		if len(undefined) == 0 {
+			usableSecurityRequirements = append(usableSecurityRequirements, securitySchemes)
+			continue
+		}
+		unusableSecurityRequirements = append(unusableSecurityRequirements, securitySchemes)
+		undefinedSecuritySchemes = append(undefinedSecuritySchemes, undefined...)
+	}
+	return func(value interface{}, key string) (warns []string, errs []error) {
+		if len(usableSecurityRequirements) == 0 && len(unusableSecurityRequirements) > 0 {
+			warns = append(warns, fmt.Sprintf("property '%s' has no effect: the security requirements using it (%s) refer to security schemes that are not defined in the swagger document (%s)", key, unusableSecurityRequirements, strings.Join(undefinedSecuritySchemes, ", ")))
+		}
+		if v, ok := value.(string); ok && v == "" && len(usableSecurityRequirements) > 0 {
+			warns = append(warns, fmt.Sprintf("property '%s' is configured with an empty value, hence the security requirements using it (%s) can not be used", key, usableSecurityRequirements))
+		}
+		return warns, nil
+	}
+}
+
+// getUndefinedSecuritySchemes returns the names of the security schemes that do not have a corresponding security definition
+func getUndefinedSecuritySchemes(securitySchemes SpecSecuritySchemes, securityDefinitions *SpecSecurityDefinitions) []string {
+	var undefined []string
+	for _, securityScheme := range securitySchemes {
+		if securityDefinitions == nil || securityDefinitions.findSecurityDefinitionFor(securityScheme.Name) == nil {
+			undefined = append(undefined, securityScheme.Name)
+		}
+	}
+	return undefined
+}
+
+// logUnusableSecurityRequirements logs a warning for each global security requirement alternative that can not be used
+// with the given provider configuration. The alternatives are informative only as long as one of them can be used; the
+// operations whose security requirements can not be satisfied at all are reported by validateResourceSecurityRequirements
+func logUnusableSecurityRequirements(globalSecurityRequirements SpecSecurityRequirements, config providerConfiguration) {
+	auth := apiAuth{}
+	for _, unusableSecurityRequirement := range auth.getUnusableSecurityRequirements(globalSecurityRequirements, config) {
+		log.Printf("[WARN] global security requirement %s can not be used with the current provider configuration", unusableSecurityRequirement)
+	}
+}
+
+// securityRequirementsClientOpenAPI is implemented by the ClientOpenAPI implementations that can check whether the security
+// requirements of the resource operations can be satisfied with the provider configuration
+type securityRequirementsClientOpenAPI interface {
+	// validateSecurityRequirements returns an error if the security requirements of any of the resource operations can
+	// not be satisfied
+	validateSecurityRequirements(resource SpecResource) error
+}
+
+func (o ProviderClient) validateSecurityRequirements(resource SpecResource) error {
+	auth, ok := o.apiAuthenticator.(apiAuth)
+	if !ok {
+		return nil
+	}
+	return validateResourceSecurityRequirements(auth, resource, o.providerConfiguration)
+}
+
+// validateResourceSecurityRequirements returns an error listing the resource operations (falling back to the global security
+// requirements if the operation does not override them) whose security requirements can not be satisfied with the given
+// provider configuration, in which case the API requests performed for the operations would fail. The list operation is
+// not taken into account since it is only used by data sources
+func validateResourceSecurityRequirements(auth apiAuth, resource SpecResource, config providerConfiguration) error {
+	var errs []string
+	for _, operation := range getResourceMethodOperations(resource) {
+		if operation.method == "LIST" {
+			continue
+		}
+		operationName := fmt.Sprintf("%s %s", operation.method, resource.getResourceName())
+		if required, securityRequirements := auth.authRequired(operationName, operation.operation.SecurityRequirements); required {
+			if _, err := auth.selectAuthenticators(securityRequirements, config); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", operationName, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("the security requirements of the following operations can not be satisfied with the current provider configuration, the API requests will fail: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
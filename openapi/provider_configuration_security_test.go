@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOperationsSecurityRequirements(t *testing.T) {
+	apiKeyRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}}
+	appIDRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "app_id"}}}
+	resource := newSpecStubResourceWithOperations("cdns_v1", "/v1/cdns", false, nil, &specResourceOperation{SecurityRequirements: apiKeyRequirements}, &specResourceOperation{}, nil, &specResourceOperation{SecurityRequirements: appIDRequirements})
+	ignoredResource := newSpecStubResourceWithOperations("ignored_v1", "/v1/ignored", true, nil, &specResourceOperation{SecurityRequirements: apiKeyRequirements}, nil, nil, nil)
+	dataSource := &specStubResource{name: "lbs_v1", resourceListOperation: &specResourceOperation{SecurityRequirements: appIDRequirements}}
+	specAnalyser := &specAnalyserStub{
+		resources:   []SpecResource{resource, ignoredResource},
+		dataSources: []SpecResource{dataSource},
+	}
+	operationsSecurityRequirements, err := getOperationsSecurityRequirements(specAnalyser)
+	require.NoError(t, err)
+	assert.Equal(t, []operationSecurityRequirements{
+		{operation: "POST cdns_v1", securityRequirements: apiKeyRequirements},
+		{operation: "DELETE cdns_v1", securityRequirements: appIDRequirements},
+		{operation: "LIST lbs_v1", securityRequirements: appIDRequirements},
+	}, operationsSecurityRequirements)
+}
+
+func TestCreateSecurityDefinitionValidateFunc(t *testing.T) {
+	securityDefinitions := &SpecSecurityDefinitions{
+		newAPIKeyHeaderSecurityDefinition("api_key", "X-API-KEY"),
+		newAPIKeyHeaderSecurityDefinition("app_id", "X-APP-ID"),
+	}
+	testCases := []struct {
+		name                 string
+		securityRequirements SpecSecurityRequirements
+		value                interface{}
+		expectedWarnings     []string
+	}{
+		{
+			name: "security definition used by usable security requirements and configured with a value",
+			securityRequirements: SpecSecurityRequirements{
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "app_id"}},
+			},
+			value: "someValue",
+		},
+		{
+			name: "security definition used by usable security requirements and configured with an empty value",
+			securityRequirements: SpecSecurityRequirements{
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "app_id"}},
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "app_id"}},
+			},
+			value:            "",
+			expectedWarnings: []string{"property 'api_key' is configured with an empty value, hence the security requirements using it ([api_key AND app_id]) can not be used"},
+		},
+		{
+			name: "security definition only used by operation security requirements referring to undefined security schemes",
+			securityRequirements: SpecSecurityRequirements{
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "app_id"}},
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "oauth"}},
+			},
+			value:            "someValue",
+			expectedWarnings: []string{"property 'api_key' has no effect: the security requirements using it ([api_key AND oauth]) refer to security schemes that are not defined in the swagger document (oauth)"},
+		},
+		{
+			name: "security definition used by usable and unusable security requirements",
+			securityRequirements: SpecSecurityRequirements{
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}},
+				SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "oauth"}},
+			},
+			value: "someValue",
+		},
+		{
+			name:  "security definition not used by any security requirement",
+			value: "someValue",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validateFunc := createSecurityDefinitionValidateFunc((*securityDefinitions)[0], securityDefinitions, tc.securityRequirements)
+			warns, errs := validateFunc(tc.value, "api_key")
+			assert.Equal(t, tc.expectedWarnings, warns)
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func TestLogUnusableSecurityRequirements(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	providerConfig := providerConfiguration{
+		SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+			"api_key": apiKeyHeaderAuthenticator{apiKey: apiKey{name: "X-API-KEY", value: "someValue"}},
+			"app_id":  apiKeyHeaderAuthenticator{apiKey: apiKey{name: "X-APP-ID", value: ""}, terraformConfigurationName: "app_id"},
+		},
+	}
+	globalSecurityRequirements := SpecSecurityRequirements{
+		SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}},
+		SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "app_id"}},
+	}
+	logUnusableSecurityRequirements(globalSecurityRequirements, providerConfig)
+	assert.Contains(t, buf.String(), "[WARN] global security requirement [api_key AND app_id]: required security definition 'app_id' is missing the value")
+	assert.NotContains(t, buf.String(), "global security requirement [api_key]:")
+}
+
+func TestValidateResourceSecurityRequirements(t *testing.T) {
+	providerConfig := providerConfiguration{
+		SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+			"api_key": apiKeyHeaderAuthenticator{apiKey: apiKey{name: "X-API-KEY", value: "someValue"}},
+			"app_id":  apiKeyHeaderAuthenticator{apiKey: apiKey{name: "X-APP-ID", value: ""}, terraformConfigurationName: "app_id"},
+		},
+	}
+	apiKeyRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}}
+	appIDRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "app_id"}}}
+	testCases := []struct {
+		name                       string
+		globalSecurityRequirements SpecSecurityRequirements
+		resource                   SpecResource
+		expectedErr                string
+	}{
+		{
+			name:                       "resource operations using satisfied global security requirements",
+			globalSecurityRequirements: apiKeyRequirements,
+			resource:                   newSpecStubResourceWithOperations("cdns_v1", "/v1/cdns", false, nil, &specResourceOperation{}, &specResourceOperation{}, nil, &specResourceOperation{}),
+		},
+		{
+			name:                       "resource operations overriding the global security requirements with satisfied ones",
+			globalSecurityRequirements: appIDRequirements,
+			resource:                   newSpecStubResourceWithOperations("cdns_v1", "/v1/cdns", false, nil, &specResourceOperation{SecurityRequirements: apiKeyRequirements}, &specResourceOperation{SecurityRequirements: apiKeyRequirements}, nil, &specResourceOperation{SecurityRequirements: apiKeyRequirements}),
+		},
+		{
+			name:                       "resource operations using unsatisfied global and operation security requirements",
+			globalSecurityRequirements: appIDRequirements,
+			resource:                   newSpecStubResourceWithOperations("cdns_v1", "/v1/cdns", false, nil, &specResourceOperation{SecurityRequirements: apiKeyRequirements}, &specResourceOperation{}, nil, &specResourceOperation{SecurityRequirements: appIDRequirements}),
+			expectedErr:                "the security requirements of the following operations can not be satisfied with the current provider configuration, the API requests will fail: PUT cdns_v1: required security definition 'app_id' is missing the value. Please make sure the property 'app_id' is configured with a value in the provider's terraform configuration; DELETE cdns_v1: required security definition 'app_id' is missing the value. Please make sure the property 'app_id' is configured with a value in the provider's terraform configuration",
+		},
+		{
+			name:     "resource list operation using unsatisfied security requirements",
+			resource: &specStubResource{name: "cdns_v1", resourceListOperation: &specResourceOperation{SecurityRequirements: appIDRequirements}},
+		},
+		{
+			name:     "resource without security requirements",
+			resource: newSpecStubResourceWithOperations("cdns_v1", "/v1/cdns", false, nil, &specResourceOperation{}, &specResourceOperation{}, nil, &specResourceOperation{}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := apiAuth{globalSecurityRequirements: &tc.globalSecurityRequirements}
+			err := validateResourceSecurityRequirements(auth, tc.resource, providerConfig)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestProviderClientValidateSecurityRequirements(t *testing.T) {
+	globalSecurityRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "app_id"}}}
+	resource := newSpecStubResourceWithOperations("cdns_v1", "/v1/cdns", false, nil, &specResourceOperation{}, nil, nil, nil)
+	providerClient := ProviderClient{
+		apiAuthenticator: newAPIAuthenticator(&globalSecurityRequirements),
+		providerConfiguration: providerConfiguration{
+			SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+				"app_id": apiKeyHeaderAuthenticator{apiKey: apiKey{name: "X-APP-ID", value: ""}, terraformConfigurationName: "app_id"},
+			},
+		},
+	}
+	err := providerClient.validateSecurityRequirements(resource)
+	assert.EqualError(t, err, "the security requirements of the following operations can not be satisfied with the current provider configuration, the API requests will fail: POST cdns_v1: required security definition 'app_id' is missing the value. Please make sure the property 'app_id' is configured with a value in the provider's terraform configuration")
+}
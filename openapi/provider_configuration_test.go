@@ -20,7 +20,7 @@ func TestNewProviderConfiguration(t *testing.T) {
 					newAPIKeyHeaderSecurityDefinition(stringProperty.getTerraformCompliantPropertyName(), "someHeaderSecDefName"),
 					newAPIKeyQuerySecurityDefinition(stringWithPreferredNameProperty.getTerraformCompliantPropertyName(), "someQuerySecDefName"),
 				},
-				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+				globalSecurityRequirements: createSecurityRequirements([]map[string][]string{}),
 			},
 		}
 
@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const providerPropertyTLSCABundle = "tls_ca_bundle"
+const providerPropertyTLSClientCert = "tls_client_cert"
+const providerPropertyTLSClientKey = "tls_client_key"
+const providerPropertyTLSMinVersion = "tls_min_version"
+
+// tlsSchema returns the provider's schema properties that enable users to configure the TLS settings used when connecting
+// to the API. Values provided in the provider's terraform configuration take preference over the ones set in the plugin
+// configuration file
+func tlsSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		providerPropertyTLSCABundle:   terraformutils.CreateStringSchemaProperty(providerPropertyTLSCABundle, false, ""),
+		providerPropertyTLSClientCert: terraformutils.CreateStringSchemaProperty(providerPropertyTLSClientCert, false, ""),
+		providerPropertyTLSClientKey:  terraformutils.CreateStringSchemaProperty(providerPropertyTLSClientKey, false, ""),
+		providerPropertyTLSMinVersion: terraformutils.CreateStringSchemaProperty(providerPropertyTLSMinVersion, false, ""),
+	}
+	s[providerPropertyTLSCABundle].Description = "CA certificates (path to PEM file or PEM content) trusted in addition to the system CAs when connecting to the API"
+	s[providerPropertyTLSClientCert].Description = "Client certificate (path to PEM file or PEM content) used when the API requires mutual TLS"
+	s[providerPropertyTLSClientKey].Description = "Client private key (path to PEM file or PEM content) matching the client certificate"
+	s[providerPropertyTLSClientKey].Sensitive = true
+	s[providerPropertyTLSMinVersion].Description = "Minimum TLS version accepted when connecting to the API. Supported values: 1.0, 1.1, 1.2, 1.3"
+	s[providerPropertyTLSMinVersion].ValidateFunc = func(value interface{}, key string) ([]string, []error) {
+		userValue := value.(string)
+		if _, exists := tlsVersions[userValue]; !exists {
+			return nil, []error{fmt.Errorf("property %s value %s is not valid, please make sure the value is one of [1.0 1.1 1.2 1.3]", key, userValue)}
+		}
+		return nil, nil
+	}
+	return s
+}
+
+// configureTLS returns a TLSConfig populated with the TLS values provided by the user in the provider's terraform configuration
+func configureTLS(data *schema.ResourceData) *TLSConfig {
+	return &TLSConfig{
+		CABundle:   getProviderStringProperty(data, providerPropertyTLSCABundle),
+		ClientCert: getProviderStringProperty(data, providerPropertyTLSClientCert),
+		ClientKey:  getProviderStringProperty(data, providerPropertyTLSClientKey),
+		MinVersion: getProviderStringProperty(data, providerPropertyTLSMinVersion),
+	}
+}
+
+func getProviderStringProperty(data *schema.ResourceData, propertyName string) string {
+	if value := data.Get(propertyName); value != nil {
+		return value.(string)
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTLSSchema(t *testing.T) {
+	Convey("Given the tls schema", t, func() {
+		s := tlsSchema()
+		Convey("Then the schema should contain the expected optional tls properties", func() {
+			So(s, ShouldContainKey, providerPropertyTLSCABundle)
+			So(s, ShouldContainKey, providerPropertyTLSClientCert)
+			So(s, ShouldContainKey, providerPropertyTLSClientKey)
+			So(s, ShouldContainKey, providerPropertyTLSMinVersion)
+			for _, property := range s {
+				So(property.Optional, ShouldBeTrue)
+				So(property.Type, ShouldEqual, schema.TypeString)
+			}
+		})
+		Convey("And the client key property should be sensitive", func() {
+			So(s[providerPropertyTLSClientKey].Sensitive, ShouldBeTrue)
+		})
+		Convey("And the min version validate func should accept supported values", func() {
+			_, errs := s[providerPropertyTLSMinVersion].ValidateFunc("1.2", providerPropertyTLSMinVersion)
+			So(errs, ShouldBeEmpty)
+		})
+		Convey("And the min version validate func should reject unsupported values", func() {
+			_, errs := s[providerPropertyTLSMinVersion].ValidateFunc("2.0", providerPropertyTLSMinVersion)
+			So(errs, ShouldNotBeEmpty)
+			So(errs[0].Error(), ShouldEqual, "property tls_min_version value 2.0 is not valid, please make sure the value is one of [1.0 1.1 1.2 1.3]")
+		})
+	})
+}
+
+func TestConfigureTLS(t *testing.T) {
+	Convey("Given a provider schema data containing the tls properties", t, func() {
+		data := schema.TestResourceDataRaw(t, tlsSchema(), map[string]interface{}{
+			providerPropertyTLSCABundle:   "ca.pem",
+			providerPropertyTLSClientCert: "cert.pem",
+			providerPropertyTLSClientKey:  "key.pem",
+			providerPropertyTLSMinVersion: "1.3",
+		})
+		Convey("When configureTLS is called", func() {
+			tlsConfig := configureTLS(data)
+			Convey("Then the tls config returned should contain the expected values", func() {
+				So(tlsConfig, ShouldResemble, &TLSConfig{CABundle: "ca.pem", ClientCert: "cert.pem", ClientKey: "key.pem", MinVersion: "1.3"})
+			})
+		})
+	})
+	Convey("Given a provider schema data that does not contain the tls properties", t, func() {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+		Convey("When configureTLS is called", func() {
+			tlsConfig := configureTLS(data)
+			Convey("Then the tls config returned should be empty", func() {
+				So(tlsConfig.isEmpty(), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -84,6 +84,8 @@ func (p providerFactory) createProvider() (*schema.Provider, error) {
 // - api key auth which will be used as the authentication mechanism when making http requests to the service provider
 // - specific headers used in operations
 // - endpoints override in case the user wants to point the resource to a different API (e,g: staging environment endpoint)
+// - tls settings used when connecting to the API (e,g: CA bundle, client certificate for mutual TLS, etc)
+// - client side rate limit applied to the API requests
 func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfiguration SpecBackendConfiguration, providerConfigurationEndPoints *providerConfigurationEndPoints) (map[string]*schema.Schema, error) {
 	s := map[string]*schema.Schema{}
 
@@ -99,11 +101,23 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 	}
 
 	// Override security definitions to required if they are global security schemes
-	globalSecuritySchemes, err := p.specAnalyser.GetSecurity().GetGlobalSecuritySchemes()
+	globalSecurityRequirements, err := p.specAnalyser.GetSecurity().GetGlobalSecurityRequirements()
 	if err != nil {
 		return nil, err
 	}
 
+	// The operation security requirements are used to warn at plan time about the security definitions configured
+	// that can not be used. The operations whose security requirements can not be satisfied are reported when planning
+	// the corresponding resources (see resourceFactory.validateSecurityRequirements)
+	operationsSecurityRequirements, err := getOperationsSecurityRequirements(p.specAnalyser)
+	if err != nil {
+		return nil, err
+	}
+	securityRequirements := append(SpecSecurityRequirements{}, globalSecurityRequirements...)
+	for _, operation := range operationsSecurityRequirements {
+		securityRequirements = append(securityRequirements, operation.securityRequirements...)
+	}
+
 	// Add all security definitions as optional properties
 	securityDefinitions, err := p.specAnalyser.GetSecurity().GetAPIKeySecurityDefinitions()
 	if err != nil {
@@ -112,10 +126,11 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 	for _, securityDefinition := range *securityDefinitions {
 		secDefName := securityDefinition.getTerraformConfigurationName()
 		required := false
-		if globalSecuritySchemes.securitySchemeExists(securityDefinition) {
+		if globalSecurityRequirements.securitySchemeRequired(securityDefinition) {
 			required = true
 		}
 		p.configureProviderPropertyFromPluginConfig(s, secDefName, required)
+		s[secDefName].ValidateFunc = createSecurityDefinitionValidateFunc(securityDefinition, securityDefinitions, securityRequirements)
 	}
 
 	headers, err := p.specAnalyser.GetAllHeaderParameters()
@@ -135,6 +150,14 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 		}
 	}
 
+	for tlsPropertyName, tlsProperty := range tlsSchema() {
+		s[tlsPropertyName] = tlsProperty
+	}
+
+	for rateLimitPropertyName, rateLimitProperty := range rateLimitSchema() {
+		s[rateLimitPropertyName] = rateLimitProperty
+	}
+
 	return s, nil
 }
 
@@ -234,6 +257,10 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 		}
 
 		r := newResourceFactory(openAPIResource)
+		r.pollPolicyOverride, err = p.serviceConfiguration.GetPollingConfiguration().getPollPolicy(openAPIResource.getResourceName())
+		if err != nil {
+			return nil, nil, err
+		}
 		d := newDataSourceInstanceFactory(openAPIResource)
 		fullDataSourceInstanceName, _ := p.getProviderResourceName(d.getDataSourceInstanceName())
 
@@ -262,15 +289,33 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 
 func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBackendConfiguration, providerConfigurationEndPoints *providerConfigurationEndPoints) schema.ConfigureFunc {
 	return func(data *schema.ResourceData) (interface{}, error) {
-		globalSecuritySchemes, err := p.specAnalyser.GetSecurity().GetGlobalSecuritySchemes()
+		globalSecurityRequirements, err := p.specAnalyser.GetSecurity().GetGlobalSecurityRequirements()
 		if err != nil {
 			return nil, err
 		}
-		authenticator := newAPIAuthenticator(&globalSecuritySchemes)
+		authenticator := newAPIAuthenticator(&globalSecurityRequirements)
 		config, err := p.createProviderConfig(data, providerConfigurationEndPoints)
 		if err != nil {
 			return nil, err
 		}
+		logUnusableSecurityRequirements(globalSecurityRequirements, *config)
+		httpClient, err := p.createHTTPClient(config)
+		if err != nil {
+			return nil, err
+		}
+		config.configureAuthenticatorsHTTPClient(httpClient)
+		retryPolicy, err := p.serviceConfiguration.GetRetryConfiguration().getRetryPolicy()
+		if err != nil {
+			return nil, err
+		}
+		rateLimiter, err := p.createRateLimiter(openAPIBackendConfiguration, config)
+		if err != nil {
+			return nil, err
+		}
+		tracer, err := p.serviceConfiguration.GetTracingConfiguration().newTracer(p.name)
+		if err != nil {
+			return nil, err
+		}
 		telemetryHandler := p.GetTelemetryHandler(data)
 		if telemetryHandler != nil {
 			telemetryHandler.SubmitPluginExecutionMetrics()
@@ -278,15 +323,47 @@ func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBacke
 		openAPIClient := &ProviderClient{
 			openAPIBackendConfiguration: openAPIBackendConfiguration,
 			apiAuthenticator:            authenticator,
-			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			httpClient:                  httpClient,
 			providerConfiguration:       *config,
 			telemetryHandler:            telemetryHandler,
+			retryPolicy:                 retryPolicy,
+			rateLimiter:                 rateLimiter,
+			requestDoer:                 httpClient.HttpClient,
+			tracer:                      tracer,
 		}
 		return openAPIClient, nil
 	}
 }
 
-// GetTelemetryHandler returns a handler containing validated telemetry providers
+// createHTTPClient returns the http client used to communicate with the API. The TLS settings provided in the provider's
+// terraform configuration take preference over the ones configured in the plugin configuration file. If no TLS settings
+// are configured the client will use the default transport
+func (p providerFactory) createHTTPClient(config *providerConfiguration) (*http_goclient.HttpClient, error) {
+	tlsConfig := p.serviceConfiguration.GetTLSConfiguration().merge(config.TLS)
+	if tlsConfig.isEmpty() {
+		return &http_goclient.HttpClient{HttpClient: &http.Client{}}, nil
+	}
+	clientTLSConfig, err := tlsConfig.buildTLSConfig(p.serviceConfiguration.IsInsecureSkipVerifyEnabled())
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the provider's TLS settings: %s", err)
+	}
+	return &http_goclient.HttpClient{HttpClient: &http.Client{Transport: newHTTPTransport(clientTLSConfig)}}, nil
+}
+
+// createRateLimiter returns the rate limiter applied to the API requests. The rate limit values provided in the provider's
+// terraform configuration take preference over the ones configured in the plugin configuration file, which in turn take
+// preference over the ones in the OpenAPI document. If the rate limit is not configured nil is returned
+func (p providerFactory) createRateLimiter(openAPIBackendConfiguration SpecBackendConfiguration, config *providerConfiguration) (*hostRateLimiter, error) {
+	specRateLimit, err := openAPIBackendConfiguration.getRateLimit()
+	if err != nil {
+		return nil, err
+	}
+	rateLimit := specRateLimit.merge(p.serviceConfiguration.GetRateLimitConfiguration()).merge(config.RateLimit)
+	return newHostRateLimiter(rateLimit), nil
+}
+
+// GetTelemetryHandler returns a handler containing validated telemetry providers. Metrics are submitted asynchronously
+// in batches so the resource operations are not blocked by the telemetry provider
 func (p providerFactory) GetTelemetryHandler(data *schema.ResourceData) TelemetryHandler {
 	telemetryProvider := p.serviceConfiguration.GetTelemetryConfiguration()
 	if telemetryProvider != nil {
@@ -296,13 +373,17 @@ func (p providerFactory) GetTelemetryHandler(data *schema.ResourceData) Telemetr
 			return nil
 		}
 	}
-	return telemetryHandlerTimeoutSupport{
+	telemetryHandler := telemetryHandlerTimeoutSupport{
 		timeout:           telemetryTimeout,
 		providerName:      p.name,
 		openAPIVersion:    version.Version,
 		telemetryProvider: telemetryProvider,
 		data:              data,
 	}
+	if telemetryProvider == nil {
+		return telemetryHandler
+	}
+	return newTelemetryHandlerAsync(telemetryHandler, telemetryQueueSize, telemetryBatchSize, telemetryFlushInterval)
 }
 
 // createProviderConfig returns a providerConfiguration populated with:
@@ -124,7 +124,7 @@ func TestCreateProvider(t *testing.T) {
 					securityDefinitions: &SpecSecurityDefinitions{
 						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -179,7 +179,7 @@ func TestCreateProvider(t *testing.T) {
 					securityDefinitions: &SpecSecurityDefinitions{
 						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -278,7 +278,7 @@ func TestCreateProvider(t *testing.T) {
 					securityDefinitions: &SpecSecurityDefinitions{
 						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -325,7 +325,7 @@ func TestCreateProvider(t *testing.T) {
 					securityDefinitions: &SpecSecurityDefinitions{
 						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -402,7 +402,7 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 					securityDefinitions: &SpecSecurityDefinitions{
 						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -424,6 +424,11 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 			Convey("And the provider schema default function should not be nil", func() {
 				So(providerSchema[apiKeyAuthProperty.Name].DefaultFunc, ShouldNotBeNil)
 			})
+			Convey("And the provider schema security property should warn at plan time if it is configured with an empty value", func() {
+				warns, errs := providerSchema[apiKeyAuthProperty.Name].ValidateFunc("", apiKeyAuthProperty.Name)
+				So(errs, ShouldBeEmpty)
+				So(warns, ShouldResemble, []string{"property 'apikey_auth' is configured with an empty value, hence the security requirements using it ([apikey_auth]) can not be used"})
+			})
 			Convey("And the provider schema properties commands should have been executed", func() {
 				So(serviceConfig.SchemaConfiguration[0].ExecuteCommandCalled, ShouldBeTrue)
 				So(serviceConfig.SchemaConfiguration[1].ExecuteCommandCalled, ShouldBeTrue)
@@ -460,7 +465,7 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 					securityDefinitions: &SpecSecurityDefinitions{
 						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -497,7 +502,7 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 						newAPIKeyHeaderSecurityDefinition(globalSecurityDefinitionName, authorizationHeader),
 						newAPIKeyHeaderSecurityDefinition(otherSecurityDefinitionName, "Authorization2"),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							globalSecurityDefinitionName: []string{""},
 						},
@@ -535,8 +540,8 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 			specAnalyser: &specAnalyserStub{
 				headers: SpecHeaderParameters{},
 				security: &specSecurityStub{
-					securityDefinitions:   &SpecSecurityDefinitions{},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+					securityDefinitions:        &SpecSecurityDefinitions{},
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{}),
 				},
 			},
 			serviceConfiguration: &ServiceConfigStub{},
@@ -573,8 +578,8 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 				resources: []SpecResource{resource},
 				headers:   SpecHeaderParameters{},
 				security: &specSecurityStub{
-					securityDefinitions:   &SpecSecurityDefinitions{},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+					securityDefinitions:        &SpecSecurityDefinitions{},
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{}),
 				},
 			},
 			serviceConfiguration: &ServiceConfigStub{},
@@ -598,8 +603,8 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 				resources: []SpecResource{},
 				headers:   SpecHeaderParameters{},
 				security: &specSecurityStub{
-					securityDefinitions:   &SpecSecurityDefinitions{},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+					securityDefinitions:        &SpecSecurityDefinitions{},
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{}),
 				},
 			},
 			serviceConfiguration: &ServiceConfigStub{},
@@ -669,7 +674,7 @@ func TestConfigureProviderPropertyFromPluginConfig(t *testing.T) {
 					securityDefinitions: &SpecSecurityDefinitions{
 						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -715,7 +720,7 @@ func TestConfigureProvider(t *testing.T) {
 					securityDefinitions: &SpecSecurityDefinitions{
 						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -736,6 +741,8 @@ func TestConfigureProvider(t *testing.T) {
 			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
 			client, err := configureFunc(testProviderSchema.getResourceData(t))
 			providerClient := client.(*ProviderClient)
+			// Metrics are submitted asynchronously so the handlers are flushed to make sure the metrics get submitted
+			shutdownTelemetryHandlers(time.Second)
 			Convey("Then error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -774,7 +781,7 @@ func TestConfigureProvider(t *testing.T) {
 					securityDefinitions: &SpecSecurityDefinitions{
 						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
 					},
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -795,6 +802,8 @@ func TestConfigureProvider(t *testing.T) {
 			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
 			client, err := configureFunc(testProviderSchema.getResourceData(t))
 			providerClient := client.(*ProviderClient)
+			// Metrics are submitted asynchronously so the handlers are flushed to make sure the metrics get submitted
+			shutdownTelemetryHandlers(time.Second)
 			Convey("Then error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -805,9 +814,11 @@ func TestConfigureProvider(t *testing.T) {
 				So(httpMetricsSubmitted, ShouldBeTrue)
 				So(headersReceived.Get("header_name"), ShouldEqual, "someHeaderValue")
 
-				tm := telemetryMetric{}
-				err = json.Unmarshal(metricsReceived, &tm)
+				var metrics []telemetryMetric
+				err = json.Unmarshal(metricsReceived, &metrics)
 				So(err, ShouldBeNil)
+				So(metrics, ShouldHaveLength, 1)
+				tm := metrics[0]
 				So(tm.MetricType, ShouldEqual, metricTypeCounter)
 				So(tm.MetricName, ShouldEqual, "openapi.terraform.openapi_plugin_version.total_runs")
 				So(tm.Tags, ShouldResemble, []string{"openapi_plugin_version:dev"})
@@ -852,7 +863,7 @@ func TestCreateProviderConfig(t *testing.T) {
 				},
 				security: &specSecurityStub{
 					securityDefinitions: &expectedSecurityDefinitions,
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthProperty.Name: []string{""},
 						},
@@ -895,7 +906,7 @@ func TestCreateProviderConfig(t *testing.T) {
 				},
 				security: &specSecurityStub{
 					securityDefinitions: &expectedSecurityDefinitions,
-					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{
 						{
 							apiKeyAuthPreferredNonCompliantNameProperty.Name: []string{""},
 						},
@@ -1046,8 +1057,9 @@ func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMap(t *testing.T
 
 	for _, tc := range testCases {
 		p := providerFactory{
-			name:         "provider",
-			specAnalyser: tc.specV2stub,
+			name:                 "provider",
+			specAnalyser:         tc.specV2stub,
+			serviceConfiguration: &ServiceConfigStub{},
 		}
 		resourceMap, dataSourceMap, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
 
@@ -1063,7 +1075,8 @@ func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMap(t *testing.T
 
 func TestCreateTerraformProviderDataSourceInstanceMap_ignore_resource(t *testing.T) {
 	p := providerFactory{
-		name: "provider",
+		name:                 "provider",
+		serviceConfiguration: &ServiceConfigStub{},
 		specAnalyser: &specAnalyserStub{
 			resources: []SpecResource{
 				newSpecStubResource("resource", "/v1/resource", true, &specSchemaDefinition{}),
@@ -1078,7 +1091,8 @@ func TestCreateTerraformProviderDataSourceInstanceMap_ignore_resource(t *testing
 
 func TestCreateTerraformProviderDataSourceInstanceMap_duplicate_resource(t *testing.T) {
 	p := providerFactory{
-		name: "provider",
+		name:                 "provider",
+		serviceConfiguration: &ServiceConfigStub{},
 		specAnalyser: &specAnalyserStub{
 			resources: []SpecResource{
 				newSpecStubResource("resource", "/v1/resource", false, &specSchemaDefinition{}),
@@ -1161,13 +1175,26 @@ func TestGetTelemetryHandler(t *testing.T) {
 	telemetryHandler := providerFactory.GetTelemetryHandler(expectedResourceData)
 
 	assert.NotNil(t, telemetryHandler)
-	assert.IsType(t, telemetryHandlerTimeoutSupport{}, telemetryHandler)
-	assert.Equal(t, expectedProviderName, telemetryHandler.(telemetryHandlerTimeoutSupport).providerName)
-	assert.Equal(t, version.Version, telemetryHandler.(telemetryHandlerTimeoutSupport).openAPIVersion)
-	assert.Equal(t, telemetryTimeout, telemetryHandler.(telemetryHandlerTimeoutSupport).timeout)
-	assert.Equal(t, expectedTelemetryProvider, telemetryHandler.(telemetryHandlerTimeoutSupport).telemetryProvider)
-	assert.Equal(t, expectedResourceData, telemetryHandler.(telemetryHandlerTimeoutSupport).data)
+	assert.IsType(t, &telemetryHandlerAsync{}, telemetryHandler)
+	asyncTelemetryHandler := telemetryHandler.(*telemetryHandlerAsync)
+	defer asyncTelemetryHandler.shutdown(time.Second)
+	assert.Equal(t, telemetryQueueSize, asyncTelemetryHandler.queueSize)
+	assert.Equal(t, telemetryBatchSize, asyncTelemetryHandler.batchSize)
+	assert.Equal(t, telemetryFlushInterval, asyncTelemetryHandler.flushInterval)
+	assert.Equal(t, expectedProviderName, asyncTelemetryHandler.handler.providerName)
+	assert.Equal(t, version.Version, asyncTelemetryHandler.handler.openAPIVersion)
+	assert.Equal(t, telemetryTimeout, asyncTelemetryHandler.handler.timeout)
+	assert.Equal(t, expectedTelemetryProvider, asyncTelemetryHandler.handler.telemetryProvider)
+	assert.Equal(t, expectedResourceData, asyncTelemetryHandler.handler.data)
+}
 
+func TestGetTelemetryHandlerTelemetryProviderNotConfigured(t *testing.T) {
+	providerFactory := providerFactory{
+		name:                 "provider_name",
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+	telemetryHandler := providerFactory.GetTelemetryHandler(&schema.ResourceData{})
+	assert.IsType(t, telemetryHandlerTimeoutSupport{}, telemetryHandler)
 }
 
 func TestGetTelemetryHandlerReturnsNilTelemetryProviderDueToTelemetryValidationError(t *testing.T) {
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// StaticProviderOpenAPI defines the OpenAPI Terraform Provider generated at build time (see ProviderGenerator). Unlike
+// ProviderOpenAPI, the swagger document is embedded in the provider; hence the provider schema can only change with a new
+// release of the provider. The schemas served are built from the embedded swagger document, the generated schemas are
+// only a drift check.
+type StaticProviderOpenAPI struct {
+	ProviderName string
+	// SwaggerURL defines where the swagger document was retrieved from when the provider was generated. It is only used
+	// to resolve the API host when the swagger document does not specify one
+	SwaggerURL string
+	// SwaggerDocument contains the swagger document (in JSON) the provider was generated from, with the overlays applied
+	SwaggerDocument []byte
+	// ProviderSchema, ResourcesSchema and DataSourcesSchema contain the generated schemas. They are not served, they are
+	// only a drift check: the provider fails to be created if the schemas built from the swagger document do not match them
+	ProviderSchema    map[string]*schema.Schema
+	ResourcesSchema   map[string]map[string]*schema.Schema
+	DataSourcesSchema map[string]map[string]*schema.Schema
+	// ServiceConfiguration (optional) defines the service configuration used by the provider (e,g: retry, polling,
+	// telemetry), usually loaded from the plugin configuration file with GetStaticServiceConfiguration. The swagger
+	// settings of the service configuration are ignored
+	ServiceConfiguration ServiceConfiguration
+}
+
+// GetStaticServiceConfiguration returns the service configuration of the given static provider from the plugin configuration
+// file (located at ~/.terraform.d/plugins or the OTF_VAR_<provider_name>_PLUGIN_CONFIGURATION_FILE env variable). Nil is
+// returned if the plugin configuration file is not present or it does not configure the provider, in which case the
+// default service configuration is used
+func GetStaticServiceConfiguration(providerName string) (ServiceConfiguration, error) {
+	pluginConfiguration, err := NewPluginConfiguration(providerName)
+	if err != nil {
+		return nil, err
+	}
+	return pluginConfiguration.getStaticServiceConfiguration()
+}
+
+// CreateSchemaProvider returns the terraform schema.Provider created from the embedded swagger document. The resources
+// and data sources operations are served by the same factories as the ones used by ProviderOpenAPI.
+func (p StaticProviderOpenAPI) CreateSchemaProvider() (*schema.Provider, error) {
+	provider, err := createStaticProvider(p.ProviderName, p.SwaggerURL, p.SwaggerDocument, p.ServiceConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("plugin terraform-provider-%s init error while creating schema provider: %s", p.ProviderName, err)
+	}
+	if err := p.checkGeneratedSchemas(provider); err != nil {
+		return nil, fmt.Errorf("plugin terraform-provider-%s schema does not match the generated one, please regenerate the provider: %s", p.ProviderName, err)
+	}
+	return provider, nil
+}
+
+// checkGeneratedSchemas makes sure the schemas built from the embedded swagger document match the generated ones; which
+// might not be the case if the provider is rebuilt with a version of the OpenAPI library that builds the schemas differently
+func (p StaticProviderOpenAPI) checkGeneratedSchemas(provider *schema.Provider) error {
+	if err := compareTerraformSchemas("provider", p.ProviderSchema, provider.Schema); err != nil {
+		return err
+	}
+	if err := compareTerraformResourceSchemas("resource", p.ResourcesSchema, provider.ResourcesMap); err != nil {
+		return err
+	}
+	return compareTerraformResourceSchemas("data source", p.DataSourcesSchema, provider.DataSourcesMap)
+}
+
+// createStaticProvider creates the schema.Provider from the given swagger document (in JSON) using the provider factory
+func createStaticProvider(providerName, swaggerURL string, swaggerDocument []byte, serviceConfiguration ServiceConfiguration) (*schema.Provider, error) {
+	if serviceConfiguration == nil {
+		serviceConfiguration = &ServiceConfigV1{}
+	}
+	specAnalyser, err := newSpecAnalyserV2FromDocument(swaggerURL, swaggerDocument)
+	if err != nil {
+		return nil, err
+	}
+	providerFactory, err := newProviderFactory(providerName, specAnalyser, serviceConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] Provider %s is using the embedded swagger file generated from: %s", providerName, swaggerURL)
+	return providerFactory.createProvider()
+}
+
+func compareTerraformResourceSchemas(kind string, generated map[string]map[string]*schema.Schema, resources map[string]*schema.Resource) error {
+	for _, name := range sortedResourceNames(resources) {
+		if _, exists := generated[name]; !exists {
+			return fmt.Errorf("%s '%s' is not part of the generated schema", kind, name)
+		}
+	}
+	var generatedNames []string
+	for name := range generated {
+		generatedNames = append(generatedNames, name)
+	}
+	sort.Strings(generatedNames)
+	for _, name := range generatedNames {
+		resource, exists := resources[name]
+		if !exists {
+			return fmt.Errorf("generated %s '%s' is no longer exposed by the swagger document", kind, name)
+		}
+		if err := compareTerraformSchemas(fmt.Sprintf("%s '%s'", kind, name), generated[name], resource.Schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareTerraformSchemas compares the schemas based on their generated source code, thus the function fields
+// (e,g: ValidateFunc) are not taken into account
+func compareTerraformSchemas(kind string, generated, built map[string]*schema.Schema) error {
+	generatedSource, err := renderTerraformSchemaMap(generated)
+	if err != nil {
+		return err
+	}
+	builtSource, err := renderTerraformSchemaMap(built)
+	if err != nil {
+		return err
+	}
+	if generatedSource != builtSource {
+		return fmt.Errorf("%s schema has changed", kind)
+	}
+	return nil
+}
+
+func sortedResourceNames(resources map[string]*schema.Resource) []string {
+	var names []string
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
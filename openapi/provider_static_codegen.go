@@ -0,0 +1,258 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// generatedCodeHeader follows the Go convention (https://golang.org/s/generatedcode) so tools can tell the files apart
+const generatedCodeHeader = "// Code generated by generate-provider. DO NOT EDIT.\n\n"
+
+// ProviderGenerator generates the source code of a standalone terraform provider (Go package main) from a swagger
+// document. The generated provider embeds the swagger document (with the overlays applied) and it is served by
+// StaticProviderOpenAPI. The schemas of the provider, resources and data sources are generated too, but they are not
+// served: they are only a drift check, the provider serves the schemas built from the embedded swagger document at runtime
+// and fails to start if they do not match the generated ones. The generated code is deterministic so it can be committed
+// along with the provider, making the schema changes visible in the diff.
+type ProviderGenerator struct {
+	// ProviderName defines the name of the provider (terraform-provider-<name>)
+	ProviderName string
+	// SwaggerURL defines where the swagger document is located, either a URL or a path to a file stored in the disk
+	SwaggerURL string
+	// Overlays defines the JSON Patch or OpenAPI Overlay documents applied in order to the swagger document
+	Overlays []string
+}
+
+// Generate returns the source code of the provider files keyed by file name
+func (g ProviderGenerator) Generate() (map[string][]byte, error) {
+	document, err := GetEffectiveSwaggerDocument(g.SwaggerURL, g.Overlays)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := createStaticProvider(g.ProviderName, g.SwaggerURL, document, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	providerSchema, err := renderTerraformSchemaMap(provider.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the provider schema: %s", err)
+	}
+	resourcesSchema, err := renderTerraformResourceSchemas(provider.ResourcesMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the resources schema: %s", err)
+	}
+	dataSourcesSchema, err := renderTerraformResourceSchemas(provider.DataSourcesMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the data sources schema: %s", err)
+	}
+
+	sources := map[string]string{
+		"main.go":            generatedMainSource,
+		"provider.go":        fmt.Sprintf(generatedProviderSource, strconv.Quote(g.ProviderName), g.ProviderName),
+		"swagger.go":         fmt.Sprintf(generatedSwaggerSource, strconv.Quote(g.SwaggerURL), renderRawString(string(document))),
+		"provider_schema.go": fmt.Sprintf(generatedSchemaSource, "providerSchema contains the expected schema of the provider configuration", "providerSchema", providerSchema),
+		"resources.go":       fmt.Sprintf(generatedSchemaSource, "resourcesSchema contains the expected schemas of the resources keyed by resource name", "resourcesSchema", resourcesSchema),
+		"data_sources.go":    fmt.Sprintf(generatedSchemaSource, "dataSourcesSchema contains the expected schemas of the data sources keyed by data source name", "dataSourcesSchema", dataSourcesSchema),
+	}
+	files := map[string][]byte{}
+	for name, source := range sources {
+		formatted, err := format.Source([]byte(generatedCodeHeader + source))
+		if err != nil {
+			return nil, fmt.Errorf("failed to format the generated file '%s': %s", name, err)
+		}
+		files[name] = formatted
+	}
+	return files, nil
+}
+
+const generatedMainSource = `package main
+
+import (
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+	"github.com/hashicorp/terraform-plugin-sdk/plugin"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func main() {
+	plugin.Serve(
+		&plugin.ServeOpts{
+			ProviderFunc: func() terraform.ResourceProvider {
+				return Provider()
+			},
+		})
+
+	// Make sure the telemetry metrics still queued are submitted before the plugin exits
+	openapi.ShutdownTelemetry()
+}
+`
+
+const generatedProviderSource = `package main
+
+import (
+	"log"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const providerName = %s
+
+// Provider returns the terraform-provider-%s schema provider configured with the service configuration of the
+// plugin configuration file (if any)
+func Provider() *schema.Provider {
+	serviceConfiguration, err := openapi.GetStaticServiceConfiguration(providerName)
+	if err != nil {
+		log.Fatalf("[ERROR] There was an error loading the plugin configuration of the terraform provider: %%s", err)
+	}
+	p := openapi.StaticProviderOpenAPI{
+		ProviderName:         providerName,
+		SwaggerURL:           swaggerURL,
+		SwaggerDocument:      []byte(swaggerDocument),
+		ProviderSchema:       providerSchema,
+		ResourcesSchema:      resourcesSchema,
+		DataSourcesSchema:    dataSourcesSchema,
+		ServiceConfiguration: serviceConfiguration,
+	}
+	provider, err := p.CreateSchemaProvider()
+	if err != nil {
+		log.Fatalf("[ERROR] There was an error initialising the terraform provider: %%s", err)
+	}
+	return provider
+}
+`
+
+const generatedSwaggerSource = `package main
+
+// swaggerURL is where the swagger document was retrieved from when the provider was generated
+const swaggerURL = %s
+
+// swaggerDocument contains the swagger document the provider was generated from, with the overlays applied
+const swaggerDocument = %s
+`
+
+const generatedSchemaSource = `package main
+
+import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+// %s. It is only used to check that the schema built from
+// the embedded swagger document at runtime (which is the one served) has not drifted from the generated one
+var %s = %s
+`
+
+// renderRawString returns the Go raw string literal of the given value, backquotes are concatenated as interpreted strings
+func renderRawString(value string) string {
+	return "`" + strings.Replace(value, "`", "` + \"`\" + `", -1) + "`"
+}
+
+// renderTerraformResourceSchemas returns the Go source code of the resources schemas keyed by resource name
+func renderTerraformResourceSchemas(resources map[string]*schema.Resource) (string, error) {
+	var b bytes.Buffer
+	b.WriteString("map[string]map[string]*schema.Schema{\n")
+	for _, name := range sortedResourceNames(resources) {
+		fmt.Fprintf(&b, "%s: {\n", strconv.Quote(name))
+		if err := writeTerraformSchemaMapEntries(&b, resources[name].Schema); err != nil {
+			return "", fmt.Errorf("'%s' %s", name, err)
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+// renderTerraformSchemaMap returns the Go source code of the given schema. The function fields of the schema (e,g:
+// ValidateFunc, DefaultFunc) can not be rendered and therefore are not part of the source code
+func renderTerraformSchemaMap(s map[string]*schema.Schema) (string, error) {
+	var b bytes.Buffer
+	b.WriteString("map[string]*schema.Schema{\n")
+	if err := writeTerraformSchemaMapEntries(&b, s); err != nil {
+		return "", err
+	}
+	b.WriteString("}")
+	return b.String(), nil
+}
+
+func writeTerraformSchemaMapEntries(b *bytes.Buffer, s map[string]*schema.Schema) error {
+	var names []string
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(b, "%s: {\n", strconv.Quote(name))
+		if err := writeTerraformSchemaFields(b, s[name]); err != nil {
+			return fmt.Errorf("property '%s' %s", name, err)
+		}
+		b.WriteString("},\n")
+	}
+	return nil
+}
+
+func writeTerraformSchemaFields(b *bytes.Buffer, s *schema.Schema) error {
+	fmt.Fprintf(b, "Type: schema.%s,\n", s.Type)
+	if s.Description != "" {
+		fmt.Fprintf(b, "Description: %s,\n", strconv.Quote(s.Description))
+	}
+	if s.Deprecated != "" {
+		fmt.Fprintf(b, "Deprecated: %s,\n", strconv.Quote(s.Deprecated))
+	}
+	for _, field := range []struct {
+		name  string
+		value bool
+	}{{"Optional", s.Optional}, {"Required", s.Required}, {"Computed", s.Computed}, {"ForceNew", s.ForceNew}, {"Sensitive", s.Sensitive}} {
+		if field.value {
+			fmt.Fprintf(b, "%s: true,\n", field.name)
+		}
+	}
+	if s.Default != nil {
+		defaultValue, err := renderTerraformSchemaDefault(s.Default)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "Default: %s,\n", defaultValue)
+	}
+	if s.MaxItems != 0 {
+		fmt.Fprintf(b, "MaxItems: %d,\n", s.MaxItems)
+	}
+	if s.MinItems != 0 {
+		fmt.Fprintf(b, "MinItems: %d,\n", s.MinItems)
+	}
+	switch elem := s.Elem.(type) {
+	case nil:
+	case *schema.Schema:
+		b.WriteString("Elem: &schema.Schema{\n")
+		if err := writeTerraformSchemaFields(b, elem); err != nil {
+			return err
+		}
+		b.WriteString("},\n")
+	case *schema.Resource:
+		b.WriteString("Elem: &schema.Resource{\nSchema: map[string]*schema.Schema{\n")
+		if err := writeTerraformSchemaMapEntries(b, elem.Schema); err != nil {
+			return err
+		}
+		b.WriteString("},\n},\n")
+	default:
+		return fmt.Errorf("elem of type %T not supported", elem)
+	}
+	return nil
+}
+
+func renderTerraformSchemaDefault(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return fmt.Sprintf("float64(%s)", strconv.FormatFloat(v, 'g', -1, 64)), nil
+	}
+	return "", fmt.Errorf("default value of type %T not supported", value)
+}
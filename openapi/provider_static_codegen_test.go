@@ -0,0 +1,174 @@
+package openapi
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderGeneratorGenerate(t *testing.T) {
+	swaggerFile := initAPISpecFile(overlayTestSwagger)
+	defer os.Remove(swaggerFile.Name())
+	idOverlayFile := initAPISpecFile(`[{"op":"add","path":"/definitions/ContentDeliveryNetwork/properties/uuid/x-terraform-id","value":true}]`)
+	defer os.Remove(idOverlayFile.Name())
+
+	generator := ProviderGenerator{ProviderName: "example", SwaggerURL: swaggerFile.Name(), Overlays: []string{idOverlayFile.Name()}}
+	files, err := generator.Generate()
+	require.NoError(t, err)
+
+	var fileNames []string
+	for fileName, source := range files {
+		fileNames = append(fileNames, fileName)
+		assert.Contains(t, string(source), "// Code generated by generate-provider. DO NOT EDIT.")
+		_, err := parser.ParseFile(token.NewFileSet(), fileName, source, 0)
+		assert.NoError(t, err, fileName)
+	}
+	assert.ElementsMatch(t, []string{"main.go", "provider.go", "swagger.go", "provider_schema.go", "resources.go", "data_sources.go"}, fileNames)
+	assert.Contains(t, string(files["provider.go"]), `const providerName = "example"`)
+	assert.Contains(t, string(files["provider.go"]), `openapi.GetStaticServiceConfiguration(providerName)`, "the provider should use the plugin configuration")
+	assert.Contains(t, string(files["provider.go"]), `ServiceConfiguration: serviceConfiguration,`)
+	assert.Contains(t, string(files["swagger.go"]), `"x-terraform-id": true`, "the overlays should be applied to the embedded swagger document")
+	assert.Contains(t, string(files["resources.go"]), `
+	"example_cdns_v1": {
+		"label": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"size": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  float64(10),
+		},
+		"uuid": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+	},
+}`)
+	assert.Contains(t, string(files["data_sources.go"]), `"example_cdns_v1_instance": {`)
+
+	regenerated, err := generator.Generate()
+	require.NoError(t, err)
+	assert.Equal(t, files, regenerated, "the generated code should be deterministic")
+
+	assertGeneratedProviderBuilds(t, files)
+}
+
+// assertGeneratedProviderBuilds writes the generated files in a temporary package inside this module, so the generated
+// code is built against the current version of the openapi package, and makes sure the package builds and passes go vet
+func assertGeneratedProviderBuilds(t *testing.T, files map[string][]byte) {
+	if testing.Short() {
+		t.Skip("skipping the build of the generated provider in short mode")
+	}
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("skipping the build of the generated provider since the go binary is not available")
+	}
+	dir, err := ioutil.TempDir("..", "generated-provider")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for fileName, source := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, fileName), source, 0644))
+	}
+	for _, args := range [][]string{{"build", "-o", os.DevNull, "."}, {"vet", "."}} {
+		cmd := exec.Command(goBinary, args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		assert.NoError(t, err, "go %v failed: %s", args, output)
+	}
+}
+
+func TestProviderGeneratorGenerateErrors(t *testing.T) {
+	swaggerFile := initAPISpecFile(overlayTestSwagger)
+	defer os.Remove(swaggerFile.Name())
+
+	testCases := []struct {
+		name          string
+		generator     ProviderGenerator
+		expectedError string
+	}{
+		{
+			name:          "provider name not terraform compliant",
+			generator:     ProviderGenerator{ProviderName: "Example", SwaggerURL: swaggerFile.Name()},
+			expectedError: "provider name 'Example' not terraform name compliant, please consider renaming provider to 'example'",
+		},
+		{
+			name:          "swagger document does not exist",
+			generator:     ProviderGenerator{ProviderName: "example", SwaggerURL: "non-existing-swagger.yaml"},
+			expectedError: "failed to retrieve the OpenAPI document from 'non-existing-swagger.yaml'",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.generator.Generate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
+
+func TestRenderTerraformSchemaMap(t *testing.T) {
+	testCases := []struct {
+		name           string
+		schema         map[string]*schema.Schema
+		expectedSource string
+		expectedError  string
+	}{
+		{
+			name: "primitive properties with defaults and function fields",
+			schema: map[string]*schema.Schema{
+				"name":    {Type: schema.TypeString, Required: true, ForceNew: true, Description: "the \"name\""},
+				"enabled": {Type: schema.TypeBool, Optional: true, Default: true, ValidateFunc: func(interface{}, string) ([]string, []error) { return nil, nil }},
+				"size":    {Type: schema.TypeInt, Optional: true, Default: 2},
+				"ratio":   {Type: schema.TypeFloat, Computed: true, Sensitive: true, Default: 0.5},
+			},
+			expectedSource: "map[string]*schema.Schema{\n" +
+				"\"enabled\": {\nType: schema.TypeBool,\nOptional: true,\nDefault: true,\n},\n" +
+				"\"name\": {\nType: schema.TypeString,\nDescription: \"the \\\"name\\\"\",\nRequired: true,\nForceNew: true,\n},\n" +
+				"\"ratio\": {\nType: schema.TypeFloat,\nComputed: true,\nSensitive: true,\nDefault: float64(0.5),\n},\n" +
+				"\"size\": {\nType: schema.TypeInt,\nOptional: true,\nDefault: 2,\n},\n" +
+				"}",
+		},
+		{
+			name: "list properties",
+			schema: map[string]*schema.Schema{
+				"tags":   {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				"object": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: &schema.Resource{Schema: map[string]*schema.Schema{"key": {Type: schema.TypeString, Optional: true}}}},
+			},
+			expectedSource: "map[string]*schema.Schema{\n" +
+				"\"object\": {\nType: schema.TypeList,\nOptional: true,\nMaxItems: 1,\nElem: &schema.Resource{\nSchema: map[string]*schema.Schema{\n\"key\": {\nType: schema.TypeString,\nOptional: true,\n},\n},\n},\n},\n" +
+				"\"tags\": {\nType: schema.TypeList,\nOptional: true,\nElem: &schema.Schema{\nType: schema.TypeString,\n},\n},\n" +
+				"}",
+		},
+		{
+			name:          "default value not supported",
+			schema:        map[string]*schema.Schema{"values": {Type: schema.TypeList, Optional: true, Default: []string{"value"}}},
+			expectedError: "property 'values' default value of type []string not supported",
+		},
+		{
+			name:          "elem not supported",
+			schema:        map[string]*schema.Schema{"values": {Type: schema.TypeList, Optional: true, Elem: schema.TypeString}},
+			expectedError: "property 'values' elem of type schema.ValueType not supported",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			source, err := renderTerraformSchemaMap(tc.schema)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSource, source)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticProviderOpenAPICreateSchemaProvider(t *testing.T) {
+	swaggerFile := initAPISpecFile(overlayTestSwagger)
+	defer os.Remove(swaggerFile.Name())
+	idOverlayFile := initAPISpecFile(`[{"op":"add","path":"/definitions/ContentDeliveryNetwork/properties/uuid/x-terraform-id","value":true}]`)
+	defer os.Remove(idOverlayFile.Name())
+	document, err := GetEffectiveSwaggerDocument(swaggerFile.Name(), []string{idOverlayFile.Name()})
+	require.NoError(t, err)
+
+	// newStaticProvider returns a StaticProviderOpenAPI whose generated schemas are the ones built from the document
+	newStaticProvider := func(t *testing.T) StaticProviderOpenAPI {
+		provider, err := createStaticProvider("example", swaggerFile.Name(), document, nil)
+		require.NoError(t, err)
+		staticProvider := StaticProviderOpenAPI{
+			ProviderName:      "example",
+			SwaggerURL:        swaggerFile.Name(),
+			SwaggerDocument:   document,
+			ProviderSchema:    provider.Schema,
+			ResourcesSchema:   map[string]map[string]*schema.Schema{},
+			DataSourcesSchema: map[string]map[string]*schema.Schema{},
+		}
+		for name, resource := range provider.ResourcesMap {
+			staticProvider.ResourcesSchema[name] = resource.Schema
+		}
+		for name, dataSource := range provider.DataSourcesMap {
+			staticProvider.DataSourcesSchema[name] = dataSource.Schema
+		}
+		return staticProvider
+	}
+
+	testCases := []struct {
+		name          string
+		modify        func(p *StaticProviderOpenAPI)
+		expectedError string
+	}{
+		{
+			name:   "generated schemas match the swagger document",
+			modify: func(p *StaticProviderOpenAPI) {},
+		},
+		{
+			name: "service configuration provided",
+			modify: func(p *StaticProviderOpenAPI) {
+				p.ServiceConfiguration = &ServiceConfigV1{RetryConfig: &RetryConfig{MaxAttempts: 3}}
+			},
+		},
+		{
+			name: "generated resource property removed",
+			modify: func(p *StaticProviderOpenAPI) {
+				delete(p.ResourcesSchema["example_cdns_v1"], "size")
+			},
+			expectedError: "plugin terraform-provider-example schema does not match the generated one, please regenerate the provider: resource 'example_cdns_v1' schema has changed",
+		},
+		{
+			name: "generated resource missing",
+			modify: func(p *StaticProviderOpenAPI) {
+				delete(p.ResourcesSchema, "example_cdns_v1")
+			},
+			expectedError: "plugin terraform-provider-example schema does not match the generated one, please regenerate the provider: resource 'example_cdns_v1' is not part of the generated schema",
+		},
+		{
+			name: "generated data source no longer exposed",
+			modify: func(p *StaticProviderOpenAPI) {
+				p.DataSourcesSchema["example_users_v1"] = map[string]*schema.Schema{}
+			},
+			expectedError: "plugin terraform-provider-example schema does not match the generated one, please regenerate the provider: generated data source 'example_users_v1' is no longer exposed by the swagger document",
+		},
+		{
+			name: "generated provider property modified",
+			modify: func(p *StaticProviderOpenAPI) {
+				p.ProviderSchema[providerPropertyTLSClientKey] = &schema.Schema{Type: schema.TypeString, Optional: true}
+			},
+			expectedError: "plugin terraform-provider-example schema does not match the generated one, please regenerate the provider: provider schema has changed",
+		},
+		{
+			name: "swagger document not valid",
+			modify: func(p *StaticProviderOpenAPI) {
+				p.SwaggerDocument = []byte(`{"swagger":`)
+			},
+			expectedError: "plugin terraform-provider-example init error while creating schema provider: failed to analyse the OpenAPI document",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			staticProvider := newStaticProvider(t)
+			tc.modify(&staticProvider)
+			provider, err := staticProvider.CreateSchemaProvider()
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, provider.InternalValidate())
+			assert.Contains(t, provider.ResourcesMap, "example_cdns_v1")
+			assert.Contains(t, provider.DataSourcesMap, "example_cdns_v1_instance")
+		})
+	}
+}
+
+func TestGetStaticServiceConfiguration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "static")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	pluginConfigFile := filepath.Join(dir, OpenAPIPluginConfigurationFileName)
+	pluginConfigFileEnvVar := fmt.Sprintf(otfVarPluginConfigurationFile, "example")
+	os.Setenv(pluginConfigFileEnvVar, pluginConfigFile)
+	defer os.Unsetenv(pluginConfigFileEnvVar)
+
+	serviceConfiguration, err := GetStaticServiceConfiguration("example")
+	require.NoError(t, err)
+	assert.Nil(t, serviceConfiguration, "the default service configuration should be used if the plugin configuration file is not present")
+
+	require.NoError(t, ioutil.WriteFile(pluginConfigFile, []byte(`version: '1'
+services:
+  example:
+    retry:
+      max_attempts: 3
+    rate_limit:
+      requests_per_second: 10
+`), 0644))
+	serviceConfiguration, err = GetStaticServiceConfiguration("example")
+	require.NoError(t, err)
+	require.NotNil(t, serviceConfiguration)
+	assert.Equal(t, &RetryConfig{MaxAttempts: 3}, serviceConfiguration.GetRetryConfiguration())
+
+	swaggerFile := initAPISpecFile(overlayTestSwagger)
+	defer os.Remove(swaggerFile.Name())
+	provider, err := createStaticProvider("example", swaggerFile.Name(), []byte(overlayTestSwagger), serviceConfiguration)
+	require.NoError(t, err)
+	assert.NoError(t, provider.InternalValidate())
+}
@@ -5,39 +5,59 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"path"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
+	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
 type resourceFactory struct {
-	openAPIResource       SpecResource
-	defaultTimeout        time.Duration
-	defaultPollInterval   time.Duration
-	defaultPollMinTimeout time.Duration
-	defaultPollDelay      time.Duration
+	openAPIResource     SpecResource
+	defaultTimeout      time.Duration
+	defaultPollInterval time.Duration
+	defaultPollDelay    time.Duration
+	// pollPolicyOverride contains the polling cadence configured in the plugin configuration for the resource which takes
+	// preference over the one defined in the OpenAPI document; nil if not configured
+	pollPolicyOverride *specPollPolicy
 }
 
 // only applicable when remote resource no longer exists and GET operations return 404 NotFound
 const defaultDestroyStatus = "destroyed"
 
+// internal statuses used to track long running operations regardless of the actual statuses returned by the API
+const operationStatusPending = "pending"
+const operationStatusCompleted = "completed"
+
+// locationHeader is the response header containing the location of the resource created by a long running operation
+const locationHeader = "Location"
+
+// etagPropertyName is the name of the computed property where the ETag returned by the API is stored when the resource
+// supports optimistic concurrency control (PUT or DELETE operations declaring the If-Match header)
+const etagPropertyName = "etag"
+const etagHeader = "ETag"
+
 var defaultPollInterval = time.Duration(5 * time.Second)
-var defaultPollMinTimeout = time.Duration(10 * time.Second)
 var defaultPollDelay = time.Duration(1 * time.Second)
 var defaultTimeout = time.Duration(10 * time.Minute)
 
+// status codes considered successful when the operation does not declare any 2xx response in the spec
+var defaultCreateStatusCodes = []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}
+var defaultUpdateStatusCodes = []int{http.StatusOK, http.StatusAccepted}
+var defaultDeleteStatusCodes = []int{http.StatusNoContent, http.StatusOK, http.StatusAccepted}
+
 func newResourceFactory(openAPIResource SpecResource) resourceFactory {
 	return resourceFactory{
-		openAPIResource:       openAPIResource,
-		defaultPollDelay:      defaultPollDelay,
-		defaultPollInterval:   defaultPollInterval,
-		defaultPollMinTimeout: defaultPollMinTimeout,
-		defaultTimeout:        defaultTimeout,
+		openAPIResource:     openAPIResource,
+		defaultPollDelay:    defaultPollDelay,
+		defaultPollInterval: defaultPollInterval,
+		defaultTimeout:      defaultTimeout,
 	}
 }
 
@@ -53,13 +73,14 @@ func (r resourceFactory) createTerraformResource() (*schema.Resource, error) {
 		return nil, err
 	}
 	return &schema.Resource{
-		Schema:   s,
-		Create:   r.create,
-		Read:     r.read,
-		Delete:   r.delete,
-		Update:   r.update,
-		Importer: r.importer(),
-		Timeouts: timeouts,
+		Schema:        s,
+		Create:        r.create,
+		Read:          r.read,
+		Delete:        r.delete,
+		Update:        r.update,
+		Importer:      r.importer(),
+		Timeouts:      timeouts,
+		CustomizeDiff: r.validateSecurityRequirements,
 	}, nil
 }
 
@@ -84,10 +105,23 @@ func (r resourceFactory) createTerraformResourceSchema() (map[string]*schema.Sch
 		return nil, err
 	}
 	log.Printf("[DEBUG] resource '%s' schemaDefinition: %s", r.openAPIResource.getResourceName(), sPrettyPrint(schemaDefinition))
-	return schemaDefinition.createResourceSchema()
+	resourceSchema, err := schemaDefinition.createResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	if r.isETagSupported() {
+		if _, exists := resourceSchema[etagPropertyName]; !exists {
+			resourceSchema[etagPropertyName] = &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ETag of the resource as returned by the API, sent in the If-Match header on updates and deletes",
+			}
+		}
+	}
+	return resourceSchema, nil
 }
 
-func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error {
+func (r resourceFactory) create(data *schema.ResourceData, i interface{}) (err error) {
 	providerClient := i.(ClientOpenAPI)
 
 	if r.openAPIResource == nil {
@@ -96,6 +130,11 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 	resourceName := r.openAPIResource.getResourceName()
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationCreate, resourceName, "")
+	operationTelemetry := newTelemetryOperationRecorder(providerClient, TelemetryResourceOperationCreate, resourceName, "")
+	defer func() { operationTelemetry.submit(err) }()
+	providerClient, span := startOperationSpan(providerClient, TelemetryResourceOperationCreate, resourceName)
+	defer func() { span.end(err) }()
+	providerClient = withOperationDeadline(providerClient, data, schema.TimeoutCreate)
 
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
@@ -106,29 +145,58 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 	requestPayload := r.createPayloadFromLocalStateData(data)
 	responsePayload := map[string]interface{}{}
 
-	res, err := providerClient.Post(r.openAPIResource, requestPayload, &responsePayload, parentIDs...)
+	requestHeaders, err := r.createIdempotencyKeyHeader(operation)
+	if err != nil {
+		return err
+	}
+
+	res, err := providerClient.Post(r.openAPIResource, requestPayload, &responsePayload, requestHeaders, parentIDs...)
+	operationTelemetry.recordResponse(res)
 	if err != nil {
 		return err
 	}
-	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}); err != nil {
+	if err := checkHTTPStatusCode(r.openAPIResource, res, operation.getSuccessStatusCodes(defaultCreateStatusCodes)); err != nil {
 		return fmt.Errorf("[resource='%s'] POST %s failed: %s", r.openAPIResource.getResourceName(), resourcePath, err)
 	}
 
 	err = setStateID(r.openAPIResource, data, responsePayload)
 	if err != nil {
-		return err
+		// Long running operations might not return the resource identifier until the operation completes, in which case
+		// the identifier is resolved once the operation finishes (see handleOperationPolling)
+		if !isOperationPollingResponse(operation, res) {
+			return err
+		}
+		log.Printf("[INFO] [resource='%s'] POST %s response does not contain the resource identifier (%s), it will be resolved once the operation completes", r.openAPIResource.getResourceName(), resourcePath, err)
 	}
-	log.Printf("[INFO] Resource '%s' ID: %s", resourcePath, data.Id())
 
-	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutCreate)
+	pollResponse, err := r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res, schema.TimeoutCreate)
 	if err != nil {
 		return fmt.Errorf("polling mechanism failed after POST %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
+	log.Printf("[INFO] Resource '%s' ID: %s", resourcePath, data.Id())
+
+	if err := updateStateWithPayloadData(r.openAPIResource, responsePayload, data); err != nil {
+		return err
+	}
+	return r.updateStateWithETag(getETagResponse(res, pollResponse), data)
+}
 
-	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
+// createIdempotencyKeyHeader returns the headers containing a newly generated idempotency key if the operation is configured
+// with the x-terraform-idempotency-key-header extension; nil otherwise. The same key is sent on the request and any retries
+// so APIs supporting idempotency keys can deduplicate the requests
+func (r resourceFactory) createIdempotencyKeyHeader(operation *specResourceOperation) (map[string]string, error) {
+	if operation == nil || operation.idempotencyKeyHeader == "" {
+		return nil, nil
+	}
+	idempotencyKey, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("[resource='%s'] failed to generate the idempotency key: %s", r.openAPIResource.getResourceName(), err)
+	}
+	log.Printf("[DEBUG] [resource='%s'] sending idempotency key '%s' in header '%s'", r.openAPIResource.getResourceName(), idempotencyKey, operation.idempotencyKeyHeader)
+	return map[string]string{operation.idempotencyKeyHeader: idempotencyKey}, nil
 }
 
-func (r resourceFactory) read(data *schema.ResourceData, i interface{}) error {
+func (r resourceFactory) read(data *schema.ResourceData, i interface{}) (err error) {
 	openAPIClient := i.(ClientOpenAPI)
 
 	if r.openAPIResource == nil {
@@ -137,13 +205,19 @@ func (r resourceFactory) read(data *schema.ResourceData, i interface{}) error {
 	resourceName := r.openAPIResource.getResourceName()
 
 	submitTelemetryMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "")
+	operationTelemetry := newTelemetryOperationRecorder(openAPIClient, TelemetryResourceOperationRead, resourceName, "")
+	defer func() { operationTelemetry.submit(err) }()
+	openAPIClient, span := startOperationSpan(openAPIClient, TelemetryResourceOperationRead, resourceName)
+	defer func() { span.end(err) }()
+	openAPIClient = withOperationDeadline(openAPIClient, data, schema.TimeoutRead)
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
 		return err
 	}
 
-	remoteData, err := r.readRemote(data.Id(), openAPIClient, parentsIDs...)
+	remoteData, res, err := r.readRemoteWithResponse(data.Id(), openAPIClient, parentsIDs...)
+	operationTelemetry.recordResponse(res)
 
 	if err != nil {
 		if openapiErr, ok := err.(openapierr.Error); ok {
@@ -154,23 +228,81 @@ func (r resourceFactory) read(data *schema.ResourceData, i interface{}) error {
 		return fmt.Errorf("[resource='%s'] GET %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
 	}
 
-	return updateStateWithPayloadData(r.openAPIResource, remoteData, data)
+	if err := updateStateWithPayloadData(r.openAPIResource, remoteData, data); err != nil {
+		return err
+	}
+	return r.updateStateWithETag(res, data)
 }
 
 func (r resourceFactory) readRemote(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, error) {
+	remoteData, _, err := r.readRemoteWithResponse(id, providerClient, parentIDs...)
+	return remoteData, err
+}
+
+// readRemoteWithResponse performs the GET request for the given resource instance id and returns the response payload
+// along with the HTTP response so callers can read response headers (e,g: ETag). The HTTP response is also returned if the
+// response status code is not the expected one
+func (r resourceFactory) readRemoteWithResponse(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, *http.Response, error) {
 	var err error
 	responsePayload := map[string]interface{}{}
 	resp, err := providerClient.Get(r.openAPIResource, id, &responsePayload, parentIDs...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
 	log.Printf("[DEBUG] GET '%s' response payload: %#v", r.openAPIResource.getResourceName(), responsePayload)
-	return responsePayload, nil
+	return responsePayload, resp, nil
+}
+
+// isETagSupported returns true if the resource PUT or DELETE operations declare the If-Match header, in which case the ETag
+// returned by the API is stored in the state and sent back as a precondition
+func (r resourceFactory) isETagSupported() bool {
+	if r.openAPIResource == nil {
+		return false
+	}
+	operations := r.openAPIResource.getResourceOperations()
+	return (operations.Put != nil && operations.Put.ifMatchEnabled) || (operations.Delete != nil && operations.Delete.ifMatchEnabled)
+}
+
+// updateStateWithETag saves the ETag header returned in the response into the state if the resource supports ETags. The
+// state is left untouched if the response does not contain the ETag header
+func (r resourceFactory) updateStateWithETag(res *http.Response, resourceLocalData *schema.ResourceData) error {
+	if !r.isETagSupported() || res == nil {
+		return nil
+	}
+	etag := res.Header.Get(etagHeader)
+	if etag == "" {
+		return nil
+	}
+	return resourceLocalData.Set(etagPropertyName, etag)
+}
+
+// getETagResponse returns the response the ETag should be taken from: the final read performed by the polling mechanism
+// if any, since the resource version might have changed while the asynchronous operation was being processed; otherwise
+// the operation response
+func getETagResponse(operationResponse, pollResponse *http.Response) *http.Response {
+	if pollResponse != nil {
+		return pollResponse
+	}
+	return operationResponse
+}
+
+// createIfMatchHeader returns the headers containing the If-Match precondition populated with the ETag stored in the state
+// if the operation declares the If-Match header; nil otherwise or if there is no ETag stored in the state yet
+func (r resourceFactory) createIfMatchHeader(operation *specResourceOperation, resourceLocalData *schema.ResourceData) map[string]string {
+	if operation == nil || !operation.ifMatchEnabled {
+		return nil
+	}
+	etag, ok := resourceLocalData.Get(etagPropertyName).(string)
+	if !ok || etag == "" {
+		log.Printf("[WARN] [resource='%s'] no ETag stored in the state for '%s', the request will be sent without the If-Match precondition", r.openAPIResource.getResourceName(), resourceLocalData.Id())
+		return nil
+	}
+	return map[string]string{ifMatchHeader: etag}
 }
 
 func (r resourceFactory) getParentIDs(data *schema.ResourceData) ([]string, error) {
@@ -196,7 +328,7 @@ func (r resourceFactory) getParentIDs(data *schema.ResourceData) ([]string, erro
 	return []string{}, nil
 }
 
-func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error {
+func (r resourceFactory) update(data *schema.ResourceData, i interface{}) (err error) {
 	providerClient := i.(ClientOpenAPI)
 
 	if r.openAPIResource == nil {
@@ -205,6 +337,11 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 	resourceName := r.openAPIResource.getResourceName()
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationUpdate, resourceName, "")
+	operationTelemetry := newTelemetryOperationRecorder(providerClient, TelemetryResourceOperationUpdate, resourceName, "")
+	defer func() { operationTelemetry.submit(err) }()
+	providerClient, span := startOperationSpan(providerClient, TelemetryResourceOperationUpdate, resourceName)
+	defer func() { span.end(err) }()
+	providerClient = withOperationDeadline(providerClient, data, schema.TimeoutUpdate)
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
@@ -220,23 +357,28 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 	if err := r.checkImmutableFields(data, providerClient, parentsIDs...); err != nil {
 		return err
 	}
-	res, err := providerClient.Put(r.openAPIResource, data.Id(), requestPayload, &responsePayload, parentsIDs...)
+	requestHeaders := r.createIfMatchHeader(operation, data)
+	res, err := providerClient.Put(r.openAPIResource, data.Id(), requestPayload, &responsePayload, requestHeaders, parentsIDs...)
+	operationTelemetry.recordResponse(res)
 	if err != nil {
 		return err
 	}
-	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusAccepted}); err != nil {
+	if err := checkHTTPStatusCode(r.openAPIResource, res, operation.getSuccessStatusCodes(defaultUpdateStatusCodes)); err != nil {
 		return fmt.Errorf("[resource='%s'] UPDATE %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
 	}
 
-	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutUpdate)
+	pollResponse, err := r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res, schema.TimeoutUpdate)
 	if err != nil {
 		return fmt.Errorf("polling mechanism failed after PUT %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
 
-	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
+	if err := updateStateWithPayloadData(r.openAPIResource, responsePayload, data); err != nil {
+		return err
+	}
+	return r.updateStateWithETag(getETagResponse(res, pollResponse), data)
 }
 
-func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error {
+func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) (err error) {
 	providerClient := i.(ClientOpenAPI)
 
 	if r.openAPIResource == nil {
@@ -245,6 +387,11 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 	resourceName := r.openAPIResource.getResourceName()
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationDelete, resourceName, "")
+	operationTelemetry := newTelemetryOperationRecorder(providerClient, TelemetryResourceOperationDelete, resourceName, "")
+	defer func() { operationTelemetry.submit(err) }()
+	providerClient, span := startOperationSpan(providerClient, TelemetryResourceOperationDelete, resourceName)
+	defer func() { span.end(err) }()
+	providerClient = withOperationDeadline(providerClient, data, schema.TimeoutDelete)
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
@@ -255,11 +402,13 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 	if operation == nil {
 		return fmt.Errorf("[resource='%s'] resource does not support DELETE operation, check the swagger file exposed on '%s'", r.openAPIResource.getResourceName(), resourcePath)
 	}
-	res, err := providerClient.Delete(r.openAPIResource, data.Id(), parentsIDs...)
+	requestHeaders := r.createIfMatchHeader(operation, data)
+	res, err := providerClient.Delete(r.openAPIResource, data.Id(), requestHeaders, parentsIDs...)
+	operationTelemetry.recordResponse(res)
 	if err != nil {
 		return err
 	}
-	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusNoContent, http.StatusOK, http.StatusAccepted}); err != nil {
+	if err := checkHTTPStatusCode(r.openAPIResource, res, operation.getSuccessStatusCodes(defaultDeleteStatusCodes)); err != nil {
 		if openapiErr, ok := err.(openapierr.Error); ok {
 			if openapierr.NotFound == openapiErr.Code() {
 				return nil
@@ -268,7 +417,7 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 		return fmt.Errorf("[resource='%s'] DELETE %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
 	}
 
-	err = r.handlePollingIfConfigured(nil, data, providerClient, operation, res.StatusCode, schema.TimeoutDelete)
+	_, err = r.handlePollingIfConfigured(nil, data, providerClient, operation, res, schema.TimeoutDelete)
 	if err != nil {
 		return fmt.Errorf("polling mechanism failed after DELETE %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
@@ -276,6 +425,15 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 	return nil
 }
 
+// validateSecurityRequirements fails the plan of the resource if the security requirements of any of its operations can not
+// be satisfied with the provider configuration, since the API requests performed for them would fail otherwise
+func (r resourceFactory) validateSecurityRequirements(diff *schema.ResourceDiff, i interface{}) error {
+	if client, ok := i.(securityRequirementsClientOpenAPI); ok {
+		return client.validateSecurityRequirements(r.openAPIResource)
+	}
+	return nil
+}
+
 func (r resourceFactory) importer() *schema.ResourceImporter {
 	return &schema.ResourceImporter{
 		State: func(data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
@@ -313,18 +471,55 @@ func (r resourceFactory) importer() *schema.ResourceImporter {
 			}
 			// If the resources is NOT a sub-resource and just a top level resource then the array passed in will just contain
 			// 	the data object we get from terraform core without any updates.
-			err := r.read(data, i)
+			err := r.importCompositeIdentifier(data)
+			if err != nil {
+				return results, err
+			}
+			err = r.read(data, i)
 			return results, err
 		},
 	}
 }
 
-func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, responseStatusCode int, timeoutFor string) error {
-	response := operation.responses.getResponse(responseStatusCode)
-
-	if response == nil || !response.isPollingEnabled {
+// importCompositeIdentifier splits the ID of the resource being imported into the composite identifier values (e,g: zone:www:A)
+// and populates the corresponding properties. The ID is left untouched if the resource does not have a composite identifier
+func (r resourceFactory) importCompositeIdentifier(data *schema.ResourceData) error {
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return err
+	}
+	if !resourceSchema.isCompositeIdentifier() {
 		return nil
 	}
+	identifierValues, err := resourceSchema.getCompositeIdentifierValues(data.Id())
+	if err != nil {
+		return fmt.Errorf("can not import resource '%s': %s", r.openAPIResource.getResourceName(), err)
+	}
+	for propertyName, value := range identifierValues {
+		if err := data.Set(propertyName, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handlePollingIfConfigured waits for the asynchronous operation to complete if the response received is configured with
+// any of the polling mechanisms. The response of the final read performed once the operation completed is returned, nil if
+// no polling was configured or the resource was not read (e,g: DELETE operations)
+func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, res *http.Response, timeoutFor string) (*http.Response, error) {
+	response := operation.responses.getResponse(res.StatusCode)
+
+	if response == nil {
+		return nil, nil
+	}
+
+	if response.operationPolling != nil {
+		return r.handleOperationPolling(responsePayload, resourceLocalData, providerClient, response, res, timeoutFor)
+	}
+
+	if !response.isPollingEnabled {
+		return nil, nil
+	}
 
 	targetStatuses := response.pollTargetStatuses
 	pendingStatuses := response.pollPendingStatuses
@@ -345,36 +540,175 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 	log.Printf("[DEBUG] target statuses (%s); pending statuses (%s)", targetStatuses, pendingStatuses)
 	log.Printf("[INFO] Waiting for resource '%s' to reach a completion status (%s)", r.openAPIResource.getResourceName(), targetStatuses)
 
-	stateConf := &resource.StateChangeConf{
-		Pending:      pendingStatuses,
-		Target:       targetStatuses,
-		Refresh:      r.resourceStateRefreshFunc(resourceLocalData, providerClient),
-		Timeout:      resourceLocalData.Timeout(timeoutFor),
-		PollInterval: r.defaultPollInterval,
-		MinTimeout:   r.defaultPollMinTimeout,
-		Delay:        r.defaultPollDelay,
-	}
+	var lastReadResponse *http.Response
+	description := fmt.Sprintf("waiting for resource '%s' (%s) to reach a completion status (%s)", r.openAPIResource.getResourceName(), resourceLocalData.Id(), targetStatuses)
+	stateConf := r.getPollPolicy(response).createStateChangeConf(description, pendingStatuses, targetStatuses, r.resourceStateRefreshFunc(resourceLocalData, providerClient, response, &lastReadResponse), resourceLocalData.Timeout(timeoutFor))
 
 	// Wait, catching any errors
 	remoteData, err := stateConf.WaitForState()
 	if err != nil {
-		return fmt.Errorf("error waiting for resource to reach a completion status (%s) [valid pending statuses (%s)]: %s", targetStatuses, pendingStatuses, err)
+		return nil, fmt.Errorf("error waiting for resource to reach a completion status (%s) [valid pending statuses (%s)]: %s", targetStatuses, pendingStatuses, err)
 	}
 	if responsePayload != nil {
 		remoteDataCasted, ok := remoteData.(map[string]interface{})
 		if ok {
 			*responsePayload = remoteDataCasted
 		} else {
-			return fmt.Errorf("failed to convert remote data (%s) to map[string]interface{}", reflect.TypeOf(remoteData))
+			return nil, fmt.Errorf("failed to convert remote data (%s) to map[string]interface{}", reflect.TypeOf(remoteData))
 		}
 	}
+	return lastReadResponse, nil
+}
+
+// isOperationPollingResponse checks whether the response received for the given operation returns a long running operation
+func isOperationPollingResponse(operation *specResourceOperation, res *http.Response) bool {
+	response := operation.responses.getResponse(res.StatusCode)
+	return response != nil && response.operationPolling != nil
+}
+
+// handleOperationPolling polls the long running operation URL returned in the response header configured until the
+// operation reaches a terminal status. If the operation fails, the error details returned by the API are surfaced. If
+// the operation succeeds and responsePayload is not nil (create and update operations), the final resource is read and
+// stored in responsePayload and the response of the read is returned. If the resource ID is not known yet (the create response did not contain it), it is
+// resolved from the completed operation before reading the resource
+func (r resourceFactory) handleOperationPolling(responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, response *specResponse, res *http.Response, timeoutFor string) (*http.Response, error) {
+	operationPolling := response.operationPolling
+	operationURL, err := r.getOperationURL(operationPolling, res)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Waiting for operation '%s' of resource '%s' to reach a completion status (%s)", operationURL, r.openAPIResource.getResourceName(), operationPolling.completedStatuses)
+
+	description := fmt.Sprintf("waiting for operation '%s' of resource '%s' (%s) to reach a completion status (%s)", operationURL, r.openAPIResource.getResourceName(), resourceLocalData.Id(), operationPolling.completedStatuses)
+	stateConf := r.getPollPolicy(response).createStateChangeConf(description, []string{operationStatusPending}, []string{operationStatusCompleted}, r.operationStateRefreshFunc(operationURL, operationPolling, providerClient), resourceLocalData.Timeout(timeoutFor))
+
+	operationPayload, err := stateConf.WaitForState()
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for operation '%s' to reach a completion status (%s): %s", operationURL, operationPolling.completedStatuses, err)
+	}
+
+	if responsePayload == nil {
+		return nil, nil
+	}
+	if resourceLocalData.Id() == "" {
+		operationPayloadCasted, _ := operationPayload.(map[string]interface{})
+		if err := r.setStateIDFromOperation(resourceLocalData, operationPolling, operationPayloadCasted, res); err != nil {
+			return nil, fmt.Errorf("error on resolving the identifier of resource '%s' after operation '%s' completed: %s", r.openAPIResource.getResourceName(), operationURL, err)
+		}
+	}
+	parentIDs, err := r.getParentIDs(resourceLocalData)
+	if err != nil {
+		return nil, err
+	}
+	remoteData, readResponse, err := r.readRemoteWithResponse(resourceLocalData.Id(), providerClient, parentIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("error on retrieving resource '%s' (%s) after operation '%s' completed: %s", r.openAPIResource.getResourceName(), resourceLocalData.Id(), operationURL, err)
+	}
+	*responsePayload = remoteData
+	return readResponse, nil
+}
+
+// setStateIDFromOperation sets the ID of a resource whose create response did not contain the resource identifier. The
+// identifier is resolved from the result of the completed operation or, if the operation does not contain a result, from
+// the last path segment of the resource location returned in the Location header of the create response (unless the
+// Location header is the one pointing at the operation)
+func (r resourceFactory) setStateIDFromOperation(resourceLocalData *schema.ResourceData, operationPolling *specOperationPolling, operationPayload map[string]interface{}, res *http.Response) error {
+	if result := operationPolling.getResult(operationPayload); result != nil {
+		return setStateID(r.openAPIResource, resourceLocalData, result)
+	}
+	resourceLocation := res.Header.Get(locationHeader)
+	if resourceLocation == "" || strings.EqualFold(operationPolling.header, locationHeader) {
+		return fmt.Errorf("the operation does not contain the result field '%s' and the response is missing the '%s' header containing the resource location", operationPolling.resultField, locationHeader)
+	}
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return err
+	}
+	if resourceSchema.isCompositeIdentifier() {
+		return fmt.Errorf("the operation does not contain the result field '%s' and composite identifiers can not be resolved from the resource location '%s'", operationPolling.resultField, resourceLocation)
+	}
+	locationURL, err := url.Parse(resourceLocation)
+	if err != nil {
+		return fmt.Errorf("failed to parse the resource location '%s' returned in the '%s' header: %s", resourceLocation, locationHeader, err)
+	}
+	id := path.Base(strings.TrimSuffix(locationURL.Path, "/"))
+	if id == "" || id == "." || id == "/" {
+		return fmt.Errorf("the resource location '%s' returned in the '%s' header does not contain the resource identifier", resourceLocation, locationHeader)
+	}
+	resourceLocalData.SetId(id)
 	return nil
 }
 
-func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
+// getPollPolicy returns the poll policy resulting from overriding the default poll cadence with the one defined in the
+// response and then with the one configured in the plugin configuration
+func (r resourceFactory) getPollPolicy(response *specResponse) *specPollPolicy {
+	defaultPollPolicy := &specPollPolicy{delay: r.defaultPollDelay, interval: r.defaultPollInterval}
+	return defaultPollPolicy.merge(response.pollPolicy).merge(r.pollPolicyOverride)
+}
+
+// getOperationURL returns the operation URL contained in the response header configured. Relative URLs are resolved
+// against the request URL
+func (r resourceFactory) getOperationURL(operationPolling *specOperationPolling, res *http.Response) (string, error) {
+	operationURL := res.Header.Get(operationPolling.header)
+	if operationURL == "" {
+		return "", fmt.Errorf("response with status code (%d) is missing the '%s' header containing the operation URL", res.StatusCode, operationPolling.header)
+	}
+	if res.Request != nil && res.Request.URL != nil {
+		resolvedURL, err := res.Request.URL.Parse(operationURL)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse the operation URL '%s' returned in the '%s' header: %s", operationURL, operationPolling.header, err)
+		}
+		return resolvedURL.String(), nil
+	}
+	return operationURL, nil
+}
+
+// operationStateRefreshFunc returns a StateRefreshFunc that reads the long running operation and translates its status into
+// operationStatusPending or operationStatusCompleted. An error is returned if the operation reaches a failed status
+func (r resourceFactory) operationStateRefreshFunc(operationURL string, operationPolling *specOperationPolling, providerClient ClientOpenAPI) resource.StateRefreshFunc {
+	return func() (result interface{}, state string, err error) {
+		providerClient, span := startClientSpan(providerClient, "poll operation", otlpAttribute{key: "poll.operation_url", value: operationURL})
+		defer func() {
+			span.setAttribute("poll.state", state)
+			span.end(err)
+		}()
+		operationPayload := map[string]interface{}{}
+		res, err := providerClient.GetOperation(r.openAPIResource, operationURL, &operationPayload)
+		if err != nil {
+			return nil, "", fmt.Errorf("error on retrieving operation '%s': %s", operationURL, err)
+		}
+		if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK}); err != nil {
+			return nil, "", fmt.Errorf("error on retrieving operation '%s': %s", operationURL, err)
+		}
+		status, err := operationPolling.getStatus(operationPayload)
+		if err != nil {
+			return nil, "", fmt.Errorf("error on retrieving operation '%s' status: %s", operationURL, err)
+		}
+		log.Printf("[DEBUG] operation '%s' status: %s", operationURL, status)
+		if operationPolling.isFailed(status) {
+			return nil, "", fmt.Errorf("operation '%s' finished with status '%s': %s", operationURL, status, operationPolling.getErrorMessage(operationPayload))
+		}
+		if operationPolling.isCompleted(status) {
+			return operationPayload, operationStatusCompleted, nil
+		}
+		return operationPayload, operationStatusPending, nil
+	}
+}
 
-		remoteData, err := r.readRemote(resourceLocalData.Id(), providerClient)
+// resourceStateRefreshFunc returns a StateRefreshFunc that reads the resource and returns its status. If the response
+// passed in defines failure statuses and the resource reaches one of them, an error containing the error details returned
+// by the API is returned so the polling stops straight away. If lastReadResponse is not nil, it is set with the response
+// of the last successful read
+func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, response *specResponse, lastReadResponse **http.Response) resource.StateRefreshFunc {
+	return func() (result interface{}, state string, err error) {
+		providerClient, span := startClientSpan(providerClient, "poll resource", otlpAttribute{key: "terraform.resource_name", value: r.openAPIResource.getResourceName()})
+		defer func() {
+			span.setAttribute("poll.state", state)
+			span.end(err)
+		}()
+
+		remoteData, readResponse, err := r.readRemoteWithResponse(resourceLocalData.Id(), providerClient)
 		if err != nil {
 			if openapiErr, ok := err.(openapierr.Error); ok {
 				if openapierr.NotFound == openapiErr.Code() {
@@ -390,6 +724,12 @@ func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.Reso
 		}
 
 		log.Printf("[DEBUG] resource status '%s' (%s): %s", r.openAPIResource.getResourceName(), resourceLocalData.Id(), newStatus)
+		if response.isFailureStatus(newStatus) {
+			return nil, "", fmt.Errorf("resource '%s' (%s) reached the failure status '%s': %s", r.openAPIResource.getResourceName(), resourceLocalData.Id(), newStatus, getPayloadErrorDetail(remoteData, response.pollErrorField))
+		}
+		if lastReadResponse != nil {
+			*lastReadResponse = readResponse
+		}
 		return remoteData, newStatus, nil
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -78,6 +79,40 @@ func TestCreateTerraformResource(t *testing.T) {
 				err := schemaResource.Delete(resourceData, client)
 				So(err, ShouldBeNil)
 			})
+			Convey("And the customize diff function is invokable and returns nil error", func() {
+				err := schemaResource.CustomizeDiff(nil, client)
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestResourceFactoryValidateSecurityRequirements(t *testing.T) {
+	globalSecurityRequirements := SpecSecurityRequirements{SpecSecuritySchemes{SpecSecurityScheme{Name: "app_id"}}}
+	r := newResourceFactory(newSpecStubResourceWithOperations("cdns_v1", "/v1/cdns", false, nil, &specResourceOperation{}, nil, nil, nil))
+	Convey("Given a resource factory and a provider client configured without the security definition required by the resource operations", t, func() {
+		providerClient := &ProviderClient{
+			apiAuthenticator: newAPIAuthenticator(&globalSecurityRequirements),
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"app_id": apiKeyHeaderAuthenticator{apiKey: apiKey{name: "X-APP-ID", value: ""}, terraformConfigurationName: "app_id"},
+				},
+			},
+		}
+		Convey("When validateSecurityRequirements is called", func() {
+			err := r.validateSecurityRequirements(nil, providerClient)
+			Convey("Then the error returned should list the operations whose security requirements can not be satisfied", func() {
+				So(err.Error(), ShouldEqual, "the security requirements of the following operations can not be satisfied with the current provider configuration, the API requests will fail: POST cdns_v1: required security definition 'app_id' is missing the value. Please make sure the property 'app_id' is configured with a value in the provider's terraform configuration")
+			})
+		})
+	})
+	Convey("Given a resource factory and a client that does not support validating the security requirements", t, func() {
+		client := &clientOpenAPIStub{}
+		Convey("When validateSecurityRequirements is called", func() {
+			err := r.validateSecurityRequirements(nil, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
 		})
 	})
 }
@@ -148,6 +183,64 @@ func TestCreate(t *testing.T) {
 			})
 		})
 
+		Convey("When create is called with a resource which POST operation is configured with an idempotency key header", func() {
+			r.openAPIResource.(*specStubResource).resourcePostOperation.idempotencyKeyHeader = "Idempotency-Key"
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: "someID",
+				},
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the client should have received the idempotency key header with a generated key", func() {
+				So(client.headersReceived, ShouldContainKey, "Idempotency-Key")
+				So(client.headersReceived["Idempotency-Key"], ShouldNotBeEmpty)
+			})
+		})
+
+		Convey("When create is called with a resource which POST operation is not configured with an idempotency key header", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: "someID",
+				},
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be nil and the client should not have received any extra header", func() {
+				So(err, ShouldBeNil)
+				So(client.headersReceived, ShouldBeNil)
+			})
+		})
+
+		Convey("When create is called with a resource which POST operation declares a 207 response and the client returns 207", func() {
+			r.openAPIResource.(*specStubResource).resourcePostOperation.responses = specResponses{http.StatusMultiStatus: &specResponse{}}
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: "someID",
+				},
+				returnHTTPCode: http.StatusMultiStatus,
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When create is called with a resource which POST operation declares only a 201 response and the client returns 200", func() {
+			r.openAPIResource.(*specStubResource).resourcePostOperation.responses = specResponses{http.StatusCreated: &specResponse{}}
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: "someID",
+				},
+				returnHTTPCode: http.StatusOK,
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource failed: [resource='resourceName'] HTTP Response Status Code 200 not matching expected one [201] ()")
+			})
+		})
+
 		Convey("When update is called with resource data and a client returns a non expected http code", func() {
 			client := &clientOpenAPIStub{
 				responsePayload: map[string]interface{}{},
@@ -158,7 +251,7 @@ func TestCreate(t *testing.T) {
 				So(err, ShouldNotBeNil)
 			})
 			Convey("And the error returned should be", func() {
-				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200 201 202] ()")
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource failed: [resource='resourceName'] HTTP Response Status Code 500 - Internal Server Error")
 			})
 		})
 
@@ -211,6 +304,92 @@ func TestCreate(t *testing.T) {
 		})
 	})
 
+	Convey("Given a resource factory that has a create operation (post) returning a long running operation in the 202 response without the resource identifier", t, func() {
+		testSchema := newTestSchema(idProperty, stringProperty)
+		resourceData := testSchema.getResourceData(t)
+		postOperation := &specResourceOperation{
+			responses: specResponses{
+				http.StatusAccepted: &specResponse{
+					operationPolling: &specOperationPolling{
+						header:            "Operation-Location",
+						statusField:       "state",
+						completedStatuses: []string{"succeeded"},
+						failedStatuses:    []string{"failed"},
+						resultField:       "result",
+					},
+				},
+			},
+		}
+		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), postOperation, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+		r := newResourceFactory(specResource)
+		r.defaultPollDelay = time.Millisecond
+		r.defaultPollInterval = time.Millisecond
+		postResponseHeaders := http.Header{"Operation-Location": []string{"/v1/operations/op1"}}
+		client := &clientOpenAPIStub{
+			responsePayload: map[string]interface{}{
+				idProperty.Name:     "someID",
+				stringProperty.Name: "someExtraValueThatProvesResponseDataIsPersisted",
+			},
+			funcPost: func() (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusAccepted, Header: postResponseHeaders, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			},
+		}
+		Convey("When create is called and the completed operation contains the resource in its result", func() {
+			client.operationResponsePayloads = []map[string]interface{}{
+				{"state": "running"},
+				{"state": "succeeded", "result": map[string]interface{}{idProperty.Name: "someID"}},
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resource ID should be the one contained in the operation result", func() {
+				So(resourceData.Id(), ShouldEqual, "someID")
+				So(client.idReceived, ShouldEqual, "someID")
+			})
+			Convey("And resourceData should be populated with the values of the resource read once the operation completed", func() {
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, client.responsePayload[stringProperty.Name])
+			})
+		})
+		Convey("When create is called and the completed operation does not contain a result but the response contains the resource location", func() {
+			postResponseHeaders.Set("Location", "https://api.host.com/v1/resource/someID")
+			client.operationResponsePayloads = []map[string]interface{}{{"state": "succeeded"}}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resource ID should be the last path segment of the resource location", func() {
+				So(resourceData.Id(), ShouldEqual, "someID")
+				So(client.idReceived, ShouldEqual, "someID")
+			})
+		})
+		Convey("When create is called and neither the completed operation nor the response contain the resource identifier", func() {
+			client.operationResponsePayloads = []map[string]interface{}{{"state": "succeeded"}}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "polling mechanism failed after POST /v1/resource call with response status code (202): error on resolving the identifier of resource 'resourceName' after operation '/v1/operations/op1' completed: the operation does not contain the result field 'result' and the response is missing the 'Location' header containing the resource location")
+			})
+			Convey("And the resource ID should not be set", func() {
+				So(resourceData.Id(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a resource factory that has a create operation (post) whose response does not contain the resource identifier and does not return a long running operation", t, func() {
+		r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty)
+		Convey("When create is called", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					stringProperty.Name: "someValue",
+				},
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be the missing identifier one", func() {
+				So(err.Error(), ShouldEqual, "response object returned from the API is missing mandatory identifier property 'id'")
+			})
+		})
+	})
+
 	Convey("Given a resource factory where getResourcePath returns an error", t, func() {
 		r := resourceFactory{
 			openAPIResource: &specStubResource{
@@ -359,7 +538,7 @@ func TestReadRemote(t *testing.T) {
 				So(err, ShouldNotBeNil)
 			})
 			Convey("And the error returned should be", func() {
-				So(err.Error(), ShouldEqual, "[resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200] ()")
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] HTTP Response Status Code 500 - Internal Server Error")
 			})
 		})
 
@@ -480,7 +659,7 @@ func TestUpdate(t *testing.T) {
 			}
 			err := r.update(resourceData, client)
 			Convey("And the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "[resource='resourceName'] UPDATE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200 202] ()")
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] UPDATE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 500 - Internal Server Error")
 			})
 		})
 		Convey("When update is called with resource data and a client returns a non expected error", func() {
@@ -628,7 +807,7 @@ func TestDelete(t *testing.T) {
 				So(err, ShouldNotBeNil)
 			})
 			Convey("And the error returned should be", func() {
-				So(err.Error(), ShouldEqual, "[resource='resourceName'] DELETE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [204 200 202] ()")
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] DELETE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 500 - Internal Server Error")
 			})
 		})
 
@@ -710,6 +889,180 @@ func TestDelete(t *testing.T) {
 	})
 }
 
+func TestIsETagSupported(t *testing.T) {
+	Convey("Given a resource factory with a PUT operation that declares the If-Match header", t, func() {
+		r := newResourceFactory(newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, nil, &specResourceOperation{}, &specResourceOperation{ifMatchEnabled: true}, &specResourceOperation{}, &specResourceOperation{}))
+		Convey("When isETagSupported is called", func() {
+			supported := r.isETagSupported()
+			Convey("Then the result returned should be true", func() {
+				So(supported, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a resource factory with a DELETE operation that declares the If-Match header", t, func() {
+		r := newResourceFactory(newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, nil, &specResourceOperation{}, nil, &specResourceOperation{}, &specResourceOperation{ifMatchEnabled: true}))
+		Convey("When isETagSupported is called", func() {
+			supported := r.isETagSupported()
+			Convey("Then the result returned should be true", func() {
+				So(supported, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a resource factory with operations that do not declare the If-Match header", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty)
+		Convey("When isETagSupported is called", func() {
+			supported := r.isETagSupported()
+			Convey("Then the result returned should be false", func() {
+				So(supported, ShouldBeFalse)
+			})
+		})
+		Convey("When createTerraformResourceSchema is called", func() {
+			s, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should not contain the etag property", func() {
+				So(err, ShouldBeNil)
+				So(s, ShouldNotContainKey, etagPropertyName)
+			})
+		})
+	})
+}
+
+func TestResourceETag(t *testing.T) {
+	Convey("Given a resource factory with PUT and DELETE operations that declare the If-Match header", t, func() {
+		r, resourceData := testCreateResourceFactoryWithETag(t)
+		Convey("When createTerraformResourceSchema is called", func() {
+			s, err := r.createTerraformResourceSchema()
+			Convey("Then the schema returned should contain the computed etag property", func() {
+				So(err, ShouldBeNil)
+				So(s, ShouldContainKey, etagPropertyName)
+				So(s[etagPropertyName].Computed, ShouldBeTrue)
+			})
+		})
+		Convey("When create is called with a client that returns an ETag header", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{idProperty.Name: "someID"},
+				responseHeaders: http.Header{"Etag": []string{`"v1"`}},
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be nil and the ETag should be stored in the state", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Get(etagPropertyName), ShouldEqual, `"v1"`)
+			})
+		})
+		Convey("When read is called with a client that returns an ETag header", func() {
+			resourceData.SetId("someID")
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{idProperty.Name: "someID"},
+				responseHeaders: http.Header{"Etag": []string{`"v2"`}},
+			}
+			err := r.read(resourceData, client)
+			Convey("Then the error returned should be nil and the ETag should be stored in the state", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Get(etagPropertyName), ShouldEqual, `"v2"`)
+			})
+		})
+		Convey("When update is called with a resource data that contains an ETag", func() {
+			resourceData.SetId("someID")
+			resourceData.Set(etagPropertyName, `"v1"`)
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{stringProperty.Name: "someValue"},
+				responseHeaders: http.Header{"Etag": []string{`"v2"`}},
+			}
+			err := r.update(resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the If-Match header should have been sent with the ETag stored in the state", func() {
+				So(client.headersReceived, ShouldResemble, map[string]string{"If-Match": `"v1"`})
+			})
+			Convey("And the new ETag returned by the API should be stored in the state", func() {
+				So(resourceData.Get(etagPropertyName), ShouldEqual, `"v2"`)
+			})
+		})
+		Convey("When create is called with a client that returns a long running operation and the final read returns a different ETag", func() {
+			r.openAPIResource.getResourceOperations().Post.responses = specResponses{
+				http.StatusAccepted: &specResponse{operationPolling: &specOperationPolling{header: "Operation-Location", statusField: "state", completedStatuses: []string{"succeeded"}}},
+			}
+			r.defaultPollDelay = time.Millisecond
+			client := &clientOpenAPIStub{
+				responsePayload:           map[string]interface{}{idProperty.Name: "someID"},
+				responseHeaders:           http.Header{"Etag": []string{`"v2"`}},
+				operationResponsePayloads: []map[string]interface{}{{"state": "succeeded"}},
+				funcPost: func() (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusAccepted, Header: http.Header{"Etag": []string{`"v1"`}, "Operation-Location": []string{"/v1/operations/op1"}, "Location": []string{"/v1/resource/someID"}}, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be nil and the ETag of the final read should be stored in the state", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Get(etagPropertyName), ShouldEqual, `"v2"`)
+			})
+		})
+		Convey("When update is called with a client that returns a long running operation and the final read returns a different ETag", func() {
+			r.openAPIResource.getResourceOperations().Put.responses = specResponses{
+				http.StatusAccepted: &specResponse{operationPolling: &specOperationPolling{header: "Operation-Location", statusField: "state", completedStatuses: []string{"succeeded"}}},
+			}
+			r.defaultPollDelay = time.Millisecond
+			resourceData.SetId("someID")
+			resourceData.Set(etagPropertyName, `"v1"`)
+			client := &clientOpenAPIStub{
+				responsePayload:           map[string]interface{}{stringProperty.Name: "someValue"},
+				responseHeaders:           http.Header{"Etag": []string{`"v3"`}},
+				operationResponsePayloads: []map[string]interface{}{{"state": "succeeded"}},
+				funcPut: func() (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusAccepted, Header: http.Header{"Etag": []string{`"v2"`}, "Operation-Location": []string{"/v1/operations/op1"}}, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			}
+			err := r.update(resourceData, client)
+			Convey("Then the error returned should be nil and the ETag of the final read should be stored in the state", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Get(etagPropertyName), ShouldEqual, `"v3"`)
+			})
+		})
+		Convey("When update is called and the API returns 412 Precondition Failed", func() {
+			resourceData.SetId("someID")
+			resourceData.Set(etagPropertyName, `"v1"`)
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{stringProperty.Name: "someValue"},
+				funcPut: func() (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusPreconditionFailed, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			}
+			err := r.update(resourceData, client)
+			Convey("Then the error returned should ask the user to refresh the state and retry", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] UPDATE /v1/resource/someID failed: [resource='resourceName'] HTTP Response Status Code 412 - Precondition Failed: the resource changed outside Terraform since it was last read (ETag mismatch), refresh the state and retry")
+			})
+		})
+		Convey("When delete is called with a resource data that contains an ETag", func() {
+			resourceData.SetId("someID")
+			resourceData.Set(etagPropertyName, `"v1"`)
+			client := &clientOpenAPIStub{}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should be nil and the If-Match header should have been sent", func() {
+				So(err, ShouldBeNil)
+				So(client.headersReceived, ShouldResemble, map[string]string{"If-Match": `"v1"`})
+			})
+		})
+		Convey("When delete is called with a resource data that does not contain an ETag", func() {
+			resourceData.SetId("someID")
+			client := &clientOpenAPIStub{}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should be nil and the If-Match header should not have been sent", func() {
+				So(err, ShouldBeNil)
+				So(client.headersReceived, ShouldBeNil)
+			})
+		})
+		Convey("When delete is called and the API returns 412 Precondition Failed", func() {
+			resourceData.SetId("someID")
+			resourceData.Set(etagPropertyName, `"v1"`)
+			client := &clientOpenAPIStub{returnHTTPCode: http.StatusPreconditionFailed}
+			err := r.delete(resourceData, client)
+			Convey("Then the error returned should ask the user to refresh the state and retry", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] DELETE /v1/resource/someID failed: [resource='resourceName'] HTTP Response Status Code 412 - Precondition Failed: the resource changed outside Terraform since it was last read (ETag mismatch), refresh the state and retry")
+			})
+		})
+	})
+}
+
 func TestImporter(t *testing.T) {
 	Convey("Given a resource factory configured with a root resource (and the already populated id property value provided by the user)", t, func() {
 		var telemetryHandlerResourceNameReceived []string
@@ -941,6 +1294,38 @@ func TestImporter(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a resource factory configured with a resource with a composite identifier (and the composite ID provided by the user)", t, func() {
+		compositeSchemaDefinition := newCompositeIdentifierSchemaDefinition("")
+		resourceData := newTestSchema(compositeSchemaDefinition.Properties...).getResourceData(t)
+		resourceData.SetId("example.com:www:10")
+		r := newResourceFactory(newSpecStubResourceWithOperations("record", "/v1/records", false, compositeSchemaDefinition, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}))
+		Convey("When the resourceImporter State method is invoked with data resource and the provider client", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{},
+			}
+			data, err := r.importer().State(resourceData, client)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the data returned should contain the composite ID", func() {
+				So(data[0].Id(), ShouldEqual, "example.com:www:10")
+				So(client.idReceived, ShouldEqual, "example.com:www:10")
+			})
+			Convey("And the data returned should contain the identifier properties populated from the ID", func() {
+				So(data[0].Get("zone"), ShouldEqual, "example.com")
+				So(data[0].Get("record_name"), ShouldEqual, "www")
+				So(data[0].Get("priority"), ShouldEqual, 10)
+			})
+		})
+		Convey("When the resourceImporter State method is invoked with an ID that does not match the composite identifier format", func() {
+			resourceData.SetId("example.com:www")
+			_, err := r.importer().State(resourceData, &clientOpenAPIStub{})
+			Convey("Then the err returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "can not import resource 'record': resource ID 'example.com:www' does not match the composite identifier format 'zone:record_name:priority'")
+			})
+		})
+	})
 }
 
 func TestHandlePollingIfConfigured(t *testing.T) {
@@ -954,7 +1339,8 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 					stringProperty.Name: stringProperty.Default,
 					statusProperty.Name: targetState,
 				},
-				returnHTTPCode: http.StatusOK,
+				returnHTTPCode:  http.StatusOK,
+				responseHeaders: http.Header{"Etag": []string{`"v2"`}},
 			}
 
 			responsePayload := map[string]interface{}{}
@@ -969,10 +1355,13 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 					},
 				},
 			}
-			err := r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			pollResponse, err := r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, &http.Response{StatusCode: responseStatusCode}, schema.TimeoutCreate)
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
+			Convey("And the response returned should be the one of the final read", func() {
+				So(pollResponse.Header.Get(etagHeader), ShouldEqual, `"v2"`)
+			})
 			Convey("And the remote data should be the payload returned by the API", func() {
 				So(responsePayload[idProperty.Name], ShouldEqual, client.responsePayload[idProperty.Name])
 				So(responsePayload[stringProperty.Name], ShouldEqual, client.responsePayload[stringProperty.Name])
@@ -997,7 +1386,7 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 					},
 				},
 			}
-			err := r.handlePollingIfConfigured(nil, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			_, err := r.handlePollingIfConfigured(nil, resourceData, client, operation, &http.Response{StatusCode: responseStatusCode}, schema.TimeoutCreate)
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -1014,7 +1403,7 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 			operation := &specResourceOperation{
 				responses: map[int]*specResponse{},
 			}
-			err := r.handlePollingIfConfigured(nil, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			_, err := r.handlePollingIfConfigured(nil, resourceData, client, operation, &http.Response{StatusCode: responseStatusCode}, schema.TimeoutCreate)
 			Convey("Then the err  should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -1032,7 +1421,7 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 					},
 				},
 			}
-			err := r.handlePollingIfConfigured(nil, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			_, err := r.handlePollingIfConfigured(nil, resourceData, client, operation, &http.Response{StatusCode: responseStatusCode}, schema.TimeoutCreate)
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -1060,7 +1449,7 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 				},
 				error: fmt.Errorf("some error"),
 			}
-			err := r.handlePollingIfConfigured(nil, resourceData, client, operation, expectedReturnCode, schema.TimeoutCreate)
+			_, err := r.handlePollingIfConfigured(nil, resourceData, client, operation, &http.Response{StatusCode: expectedReturnCode}, schema.TimeoutCreate)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, "error waiting for resource to reach a completion status ([destroyed]) [valid pending statuses ([pending])]: error on retrieving resource 'resourceName' (id) when waiting: some error")
 			})
@@ -1069,6 +1458,110 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 
 }
 
+func TestGetPollPolicy(t *testing.T) {
+	Convey("Given a resource factory with a plugin configuration poll policy override", t, func() {
+		r := newResourceFactory(&specStubResource{name: "resourceName"})
+		r.pollPolicyOverride = &specPollPolicy{interval: 2 * time.Minute}
+		Convey("When getPollPolicy is called with a response that defines its own poll cadence", func() {
+			pollPolicy := r.getPollPolicy(&specResponse{pollPolicy: &specPollPolicy{delay: 30 * time.Second, interval: time.Minute, backoffMultiplier: 1.5}})
+			Convey("Then the response values should override the defaults and the plugin configuration should override the response values", func() {
+				So(pollPolicy, ShouldResemble, &specPollPolicy{delay: 30 * time.Second, interval: 2 * time.Minute, backoffMultiplier: 1.5})
+			})
+		})
+	})
+	Convey("Given a resource factory without poll policy override", t, func() {
+		r := newResourceFactory(&specStubResource{name: "resourceName"})
+		Convey("When getPollPolicy is called with a response that does not define a poll cadence", func() {
+			pollPolicy := r.getPollPolicy(&specResponse{})
+			Convey("Then the default poll cadence should be returned", func() {
+				So(pollPolicy, ShouldResemble, &specPollPolicy{delay: defaultPollDelay, interval: defaultPollInterval})
+			})
+		})
+	})
+}
+
+func TestHandleOperationPolling(t *testing.T) {
+	Convey("Given a resource factory and an operation whose 202 response returns a long running operation", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty)
+		r.defaultPollInterval = time.Millisecond
+		r.defaultPollDelay = time.Millisecond
+		operation := &specResourceOperation{
+			responses: specResponses{
+				http.StatusAccepted: {
+					operationPolling: &specOperationPolling{
+						header:            "Operation-Location",
+						statusField:       "state",
+						errorField:        "error",
+						completedStatuses: []string{"succeeded"},
+						failedStatuses:    []string{"failed"},
+					},
+				},
+			},
+		}
+		requestURL, _ := url.Parse("https://api.host.com/v1/resource")
+		res := &http.Response{
+			StatusCode: http.StatusAccepted,
+			Header:     http.Header{"Operation-Location": []string{"/v1/operations/op1"}},
+			Request:    &http.Request{URL: requestURL},
+		}
+		Convey("When handlePollingIfConfigured is called and the operation succeeds", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     idProperty.Default,
+					stringProperty.Name: "finalValue",
+				},
+				operationResponsePayloads: []map[string]interface{}{
+					{"state": "running"},
+					{"state": "Succeeded"},
+				},
+			}
+			responsePayload := map[string]interface{}{}
+			pollResponse, err := r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, res, schema.TimeoutCreate)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the response returned should be the one of the final read", func() {
+				So(pollResponse.StatusCode, ShouldEqual, http.StatusOK)
+			})
+			Convey("And the operation URL should have been resolved against the request URL", func() {
+				So(client.operationURLReceived, ShouldEqual, "https://api.host.com/v1/operations/op1")
+			})
+			Convey("And the response payload should be the final resource returned by the API", func() {
+				So(responsePayload[stringProperty.Name], ShouldEqual, "finalValue")
+			})
+		})
+		Convey("When handlePollingIfConfigured is called and the operation fails", func() {
+			client := &clientOpenAPIStub{
+				operationResponsePayloads: []map[string]interface{}{
+					{"state": "running"},
+					{"state": "failed", "error": map[string]interface{}{"code": "QuotaExceeded", "message": "quota exceeded"}},
+				},
+			}
+			responsePayload := map[string]interface{}{}
+			_, err := r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, res, schema.TimeoutCreate)
+			Convey("Then the err returned should contain the operation's error message", func() {
+				So(err.Error(), ShouldEqual, "error waiting for operation 'https://api.host.com/v1/operations/op1' to reach a completion status ([succeeded]): operation 'https://api.host.com/v1/operations/op1' finished with status 'failed': quota exceeded")
+			})
+		})
+		Convey("When handlePollingIfConfigured is called with a nil response payload (DELETE operations) and the operation succeeds", func() {
+			client := &clientOpenAPIStub{
+				operationResponsePayloads: []map[string]interface{}{{"state": "succeeded"}},
+			}
+			_, err := r.handlePollingIfConfigured(nil, resourceData, client, operation, res, schema.TimeoutDelete)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When handlePollingIfConfigured is called with a response that does not contain the operation header", func() {
+			client := &clientOpenAPIStub{}
+			_, err := r.handlePollingIfConfigured(nil, resourceData, client, operation, &http.Response{StatusCode: http.StatusAccepted, Header: http.Header{}}, schema.TimeoutDelete)
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "response with status code (202) is missing the 'Operation-Location' header containing the operation URL")
+			})
+		})
+	})
+}
+
 func TestResourceStateRefreshFunc(t *testing.T) {
 	Convey("Given a resource factory configured with a resource which has a schema definition containing a status property", t, func() {
 		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty, statusProperty)
@@ -1080,7 +1573,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					statusProperty.Name: statusProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil, nil)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
@@ -1099,7 +1592,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 			client := &clientOpenAPIStub{
 				returnHTTPCode: http.StatusNotFound,
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil, nil)
 			_, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
@@ -1114,7 +1607,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 			client := &clientOpenAPIStub{
 				error: errors.New(expectedError),
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil, nil)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
@@ -1140,7 +1633,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					stringProperty.Name: stringProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil, nil)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
@@ -1166,7 +1659,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					stringProperty.Name: stringProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client, nil, nil)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
@@ -1182,6 +1675,59 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a resource factory configured with a resource which has a schema definition containing a status property and a response with failure statuses", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty, statusProperty)
+		response := &specResponse{
+			isPollingEnabled:    true,
+			pollPendingStatuses: []string{"pending"},
+			pollTargetStatuses:  []string{"deployed"},
+			pollFailureStatuses: []string{"failed", "error"},
+			pollErrorField:      "status_reason",
+		}
+		Convey("When the returned function (stateRefreshFunc) is invoked and the API returns a failure status with error details", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     idProperty.Default,
+					statusProperty.Name: "failed",
+					"status_reason":     "not enough capacity in the region",
+				},
+			}
+			remoteData, newStatus, err := r.resourceStateRefreshFunc(resourceData, client, response, nil)()
+			Convey("Then the error returned should contain the failure status and the reason returned by the API", func() {
+				So(err.Error(), ShouldEqual, "resource 'resourceName' (id) reached the failure status 'failed': not enough capacity in the region")
+			})
+			Convey("And the remoteData and new status should be empty", func() {
+				So(remoteData, ShouldBeNil)
+				So(newStatus, ShouldBeEmpty)
+			})
+		})
+		Convey("When the returned function (stateRefreshFunc) is invoked and the API returns a failure status without error details", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     idProperty.Default,
+					statusProperty.Name: "error",
+				},
+			}
+			_, _, err := r.resourceStateRefreshFunc(resourceData, client, response, nil)()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource 'resourceName' (id) reached the failure status 'error': no error details provided by the API")
+			})
+		})
+		Convey("When the returned function (stateRefreshFunc) is invoked and the API returns a pending status", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     idProperty.Default,
+					statusProperty.Name: "pending",
+				},
+			}
+			_, newStatus, err := r.resourceStateRefreshFunc(resourceData, client, response, nil)()
+			Convey("Then the error returned should be nil and the status should be the one returned by the API", func() {
+				So(err, ShouldBeNil)
+				So(newStatus, ShouldEqual, "pending")
+			})
+		})
+	})
 }
 
 func TestCheckImmutableFields(t *testing.T) {
@@ -2375,6 +2921,19 @@ func testCreateResourceFactoryWithID(t *testing.T, idSchemaDefinitionProperty *s
 	return resourceFactory, resourceData
 }
 
+// testCreateResourceFactoryWithETag configures a resource factory whose PUT and DELETE operations declare the If-Match
+// header and a resourceData built from the resource schema so it contains the computed etag property
+func testCreateResourceFactoryWithETag(t *testing.T) (resourceFactory, *schema.ResourceData) {
+	testSchema := newTestSchema(idProperty, stringProperty)
+	specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{ifMatchEnabled: true}, &specResourceOperation{}, &specResourceOperation{ifMatchEnabled: true})
+	r := newResourceFactory(specResource)
+	resourceSchema, err := r.createTerraformResourceSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+}
+
 // testCreateResourceFactory configures the resourceData with some properties.
 func testCreateResourceFactory(t *testing.T, schemaDefinitionProperties ...*specSchemaDefinitionProperty) (resourceFactory, *schema.ResourceData) {
 	testSchema := newTestSchema(schemaDefinitionProperties...)